@@ -18,6 +18,7 @@ import (
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/mime"
 	"github.com/vmware/octant/internal/module"
+	"github.com/vmware/octant/internal/preferences"
 	"github.com/vmware/octant/pkg/navigation"
 )
 
@@ -89,6 +90,7 @@ type API struct {
 	clusterClient    ClusterClient
 	moduleManager    module.ManagerInterface
 	actionDispatcher ActionDispatcher
+	preferences      preferences.Interface
 	prefix           string
 	logger           log.Logger
 
@@ -99,14 +101,15 @@ type API struct {
 
 var _ Service = (*API)(nil)
 
-// New creates an instance of API.
-func New(ctx context.Context, prefix string, clusterClient ClusterClient, moduleManager module.ManagerInterface, actionDispatcher ActionDispatcher, logger log.Logger) *API {
+// New creates an instance of API. Preferences are optional.
+func New(ctx context.Context, prefix string, clusterClient ClusterClient, moduleManager module.ManagerInterface, actionDispatcher ActionDispatcher, prefs preferences.Interface, logger log.Logger) *API {
 	return &API{
 		ctx:              ctx,
 		prefix:           prefix,
 		clusterClient:    clusterClient,
 		moduleManager:    moduleManager,
 		actionDispatcher: actionDispatcher,
+		preferences:      prefs,
 		modulePaths:      make(map[string]module.Module),
 		logger:           logger,
 		forceUpdateCh:    make(chan bool, 1),
@@ -122,6 +125,7 @@ func (a *API) ForceUpdate() error {
 func (a *API) Handler(ctx context.Context) (*mux.Router, error) {
 	router := mux.NewRouter()
 	router.Use(rebindHandler(acceptedHosts))
+	router.Use(sameOriginHandler())
 
 	s := router.PathPrefix(a.prefix).Subrouter()
 
@@ -138,7 +142,7 @@ func (a *API) Handler(ctx context.Context) (*mux.Router, error) {
 	namespacesService := newNamespaces(nsClient, a.logger)
 	s.Handle("/namespaces", namespacesService).Methods(http.MethodGet)
 
-	ans := newAPINavSections(a.modules)
+	ans := newAPINavSections(a.modules, a.preferences)
 
 	navigationService := newNavigationHandler(ans, a.logger)
 	// Support no namespace (default) or specifying namespace in path
@@ -155,9 +159,19 @@ func (a *API) Handler(ctx context.Context) (*mux.Router, error) {
 	actionService := newAction(a.logger, a.actionDispatcher)
 	s.Handle("/action", actionService)
 
+	filterService := newFilterValidator(a.logger)
+	s.Handle("/filter", filterService).Methods(http.MethodGet)
+
+	if a.preferences != nil {
+		preferencesService := newPreferencesHandler(a.preferences, a.logger)
+		s.Handle("/preferences", preferencesService).Methods(http.MethodGet, http.MethodPut)
+	}
+
 	// Register content routes
 	contentService := &contentHandler{
 		nsClient:      nsClient,
+		moduleManager: a.moduleManager,
+		preferences:   a.preferences,
 		modulePaths:   a.modulePaths,
 		modules:       a.modules,
 		logger:        a.logger,
@@ -188,12 +202,14 @@ func (a *API) RegisterModule(m module.Module) error {
 }
 
 type apiNavSections struct {
-	modules []module.Module
+	modules     []module.Module
+	preferences preferences.Interface
 }
 
-func newAPINavSections(modules []module.Module) *apiNavSections {
+func newAPINavSections(modules []module.Module, prefs preferences.Interface) *apiNavSections {
 	return &apiNavSections{
-		modules: modules,
+		modules:     modules,
+		preferences: prefs,
 	}
 }
 
@@ -210,5 +226,9 @@ func (ans *apiNavSections) Sections(ctx context.Context, namespace string) ([]na
 		sections = append(sections, navList...)
 	}
 
+	if ans.preferences != nil {
+		sections = ans.preferences.Preferences().FilterNavigation(sections)
+	}
+
 	return sections, nil
 }
@@ -173,7 +173,7 @@ func TestAPI_routes(t *testing.T) {
 			actionDispatcher := apiFake.NewMockActionDispatcher(controller)
 
 			ctx := context.Background()
-			srv := New(ctx, "/", clusterClient, manager, actionDispatcher, log.NopLogger())
+			srv := New(ctx, "/", clusterClient, manager, actionDispatcher, nil, log.NopLogger())
 
 			err := srv.RegisterModule(m)
 			require.NoError(t, err)
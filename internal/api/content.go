@@ -19,9 +19,11 @@ import (
 
 	"github.com/vmware/octant/internal/cluster"
 	"github.com/vmware/octant/internal/event"
+	"github.com/vmware/octant/internal/filter"
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/module"
 	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/preferences"
 )
 
 type contentHandler struct {
@@ -31,6 +33,9 @@ type contentHandler struct {
 	prefix      string
 	nsClient    cluster.NamespaceInterface
 
+	moduleManager module.ManagerInterface
+	preferences   preferences.Interface
+
 	previousNamespace string
 	forceUpdateCh     <-chan bool
 }
@@ -82,6 +87,7 @@ func (h *contentHandler) handlerForModule(m module.Module) http.HandlerFunc {
 		poll := q.Get("poll")
 
 		filters := q["filter"]
+		where := q.Get("where")
 
 		h.logger.With(
 			"module", m.Name(),
@@ -90,6 +96,7 @@ func (h *contentHandler) handlerForModule(m module.Module) http.HandlerFunc {
 			"contentPath", contentPath,
 			"poll", poll,
 			"filters", fmt.Sprintf("%v", filters),
+			"where", where,
 		).Debugf("content")
 
 		set, err := selectorFromFilters(filters)
@@ -103,12 +110,18 @@ func (h *contentHandler) handlerForModule(m module.Module) http.HandlerFunc {
 			h.logger.Debugf("Label Set: %s", set)
 		}
 
+		expression, err := parseWhere(where)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error(), h.logger)
+			return
+		}
+
 		if poll != "" {
-			h.handlePoll(ctx, poll, r.URL.Path, namespace, &set, contentPath, w, r, m)
+			h.handlePoll(ctx, poll, r.URL.Path, namespace, &set, expression, contentPath, w, r, m)
 			return
 		}
 
-		resp, err := m.Content(ctx, contentPath, h.prefix, namespace, module.ContentOptions{LabelSet: &set})
+		resp, err := m.Content(ctx, contentPath, h.prefix, namespace, module.ContentOptions{LabelSet: &set, Filter: expression})
 		if err != nil {
 			RespondWithError(w, http.StatusInternalServerError, err.Error(), h.logger)
 			return
@@ -118,7 +131,7 @@ func (h *contentHandler) handlerForModule(m module.Module) http.HandlerFunc {
 	}
 }
 
-func (h *contentHandler) handlePoll(ctx context.Context, poll, requestPath, namespace string, labelSet *labels.Set, contentPath string, w http.ResponseWriter, r *http.Request, m module.Module) {
+func (h *contentHandler) handlePoll(ctx context.Context, poll, requestPath, namespace string, labelSet *labels.Set, expression *filter.Expression, contentPath string, w http.ResponseWriter, r *http.Request, m module.Module) {
 	if namespace != "" {
 		h.previousNamespace = namespace
 	} else {
@@ -142,17 +155,28 @@ func (h *contentHandler) handlePoll(ctx context.Context, poll, requestPath, name
 			Prefix:          h.prefix,
 			Namespace:       namespace,
 			LabelSet:        labelSet,
+			Filter:          expression,
 			RunEvery:        eventTimeout,
+			Preferences:     h.preferences,
 		},
 		&event.NavigationGenerator{
-			Modules:   h.modules,
-			Namespace: namespace,
+			Modules:     h.modules,
+			Namespace:   namespace,
+			Preferences: h.preferences,
 		},
 		&event.NamespacesGenerator{
 			NamespaceClient: h.nsClient,
+			Preferences:     h.preferences,
+			ContextName:     h.contextName,
 		},
 	}
 
+	if h.preferences != nil {
+		eventGenerators = append(eventGenerators, &event.PreferencesGenerator{
+			Preferences: h.preferences,
+		})
+	}
+
 	for _, m := range h.modules {
 		eventGenerators = append(eventGenerators, m.Generators()...)
 	}
@@ -167,6 +191,15 @@ func (h *contentHandler) handlePoll(ctx context.Context, poll, requestPath, name
 
 }
 
+// contextName returns the current context name.
+func (h *contentHandler) contextName() string {
+	if h.moduleManager == nil {
+		return ""
+	}
+
+	return h.moduleManager.GetContext()
+}
+
 // selectorFromFilters builds a labels.Selector from a list of
 // "key:value" formatted strings
 func selectorFromFilters(filters []string) (labels.Set, error) {
@@ -184,3 +217,12 @@ func selectorFromFilters(filters []string) (labels.Set, error) {
 
 	return set, nil
 }
+
+// parseWhere parses a filter expression. A blank expression doesn't filter.
+func parseWhere(where string) (*filter.Expression, error) {
+	if strings.TrimSpace(where) == "" {
+		return nil, nil
+	}
+
+	return filter.Parse(where)
+}
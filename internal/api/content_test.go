@@ -51,3 +51,16 @@ func Test_SelectorFromFilters(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseWhere(t *testing.T) {
+	expression, err := parseWhere("  ")
+	assert.NoError(t, err)
+	assert.Nil(t, expression)
+
+	expression, err = parseWhere("labels.app in (web, api)")
+	assert.NoError(t, err)
+	assert.Equal(t, "labels.app in (web, api)", expression.String())
+
+	_, err = parseWhere("labels.app in web")
+	assert.Error(t, err)
+}
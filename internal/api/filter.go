@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/vmware/octant/internal/filter"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/mime"
+)
+
+type filterValidator struct {
+	logger log.Logger
+}
+
+type filterResponse struct {
+	Expression string `json:"expression"`
+}
+
+func newFilterValidator(logger log.Logger) filterValidator {
+	return filterValidator{
+		logger: logger,
+	}
+}
+
+// ServeHTTP implements http.Handler and validates the filter expression
+// given by where. Invalid expressions are a bad request.
+func (fv filterValidator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	expression, err := filter.Parse(r.URL.Query().Get("where"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error(), fv.logger)
+		return
+	}
+
+	w.Header().Set("Content-Type", mime.JSONContentType)
+	resp := filterResponse{
+		Expression: expression.String(),
+	}
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		fv.logger.Errorf("encoding response: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
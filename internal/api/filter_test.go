@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+func Test_filterValidator(t *testing.T) {
+	tests := []struct {
+		name         string
+		where        string
+		expectedCode int
+		expected     filterResponse
+	}{
+		{
+			name:         "valid",
+			where:        " status.phase!=Running and age>1h ",
+			expectedCode: http.StatusOK,
+			expected: filterResponse{
+				Expression: "status.phase!=Running and age>1h",
+			},
+		},
+		{
+			name:         "invalid",
+			where:        "age=1h",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "blank",
+			where:        "",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/filter?where="+url.QueryEscape(tc.where), nil)
+
+			handler := newFilterValidator(log.NopLogger())
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			require.Equal(t, tc.expectedCode, resp.Code)
+			if tc.expectedCode != http.StatusOK {
+				return
+			}
+
+			var got filterResponse
+			err := json.NewDecoder(resp.Body).Decode(&got)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -8,12 +8,61 @@ package api
 import (
 	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
 
 	"github.com/gorilla/mux"
 	dashstrings "github.com/vmware/octant/internal/util/strings"
 )
 
+// AcceptedOriginsEnv lists extra origins, separated by commas, which may
+// make requests that change state, such as the frontend's development server.
+const AcceptedOriginsEnv = "OCTANT_ACCEPTED_ORIGINS"
+
+// IsSameOrigin returns true if a request was made by a page served by
+// Octant. Browsers always send Origin for cross site requests and WebSockets,
+// so requests without one are not from another site.
+func IsSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, accepted := range strings.Split(os.Getenv(AcceptedOriginsEnv), ",") {
+		if accepted = strings.TrimSpace(accepted); accepted != "" && strings.EqualFold(origin, accepted) {
+			return true
+		}
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+// sameOriginHandler is a middleware that rejects requests which change state
+// unless they were made by a page served by Octant, so other sites can't
+// make them from the user's browser.
+func sameOriginHandler() mux.MiddlewareFunc {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+			default:
+				if !IsSameOrigin(r) {
+					http.Error(w, "forbidden", http.StatusForbidden)
+					return
+				}
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
 // rebindHandler is a middleware that will only accept the supplied hosts
 func rebindHandler(acceptedHosts []string) mux.MiddlewareFunc {
 	return func(h http.Handler) http.Handler {
@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -62,3 +64,113 @@ func Test_rebindHandler(t *testing.T) {
 		})
 	}
 }
+
+func Test_sameOriginHandler(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		origin       string
+		expectedCode int
+	}{
+		{
+			name:         "same origin",
+			method:       http.MethodPost,
+			origin:       "http://localhost:7777",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "no origin",
+			method:       http.MethodDelete,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "cross site read",
+			method:       http.MethodGet,
+			origin:       "http://hacker.com",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "cross site post",
+			method:       http.MethodPost,
+			origin:       "http://hacker.com",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "cross site put",
+			method:       http.MethodPut,
+			origin:       "http://hacker.com",
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "response")
+			})
+
+			r := httptest.NewRequest(tc.method, "http://localhost:7777/api/v1/action", nil)
+			if tc.origin != "" {
+				r.Header.Set("Origin", tc.origin)
+			}
+
+			w := httptest.NewRecorder()
+			sameOriginHandler()(fake).ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestIsSameOrigin(t *testing.T) {
+	cases := []struct {
+		name     string
+		origin   string
+		accepted string
+		expected bool
+	}{
+		{
+			name:     "no origin",
+			expected: true,
+		},
+		{
+			name:     "same origin",
+			origin:   "http://localhost:7777",
+			expected: true,
+		},
+		{
+			name:   "other site",
+			origin: "http://hacker.com",
+		},
+		{
+			name:   "other port",
+			origin: "http://localhost:4200",
+		},
+		{
+			name:     "accepted origin",
+			origin:   "http://localhost:4200",
+			accepted: "http://example.com, http://localhost:4200",
+			expected: true,
+		},
+		{
+			name:   "invalid origin",
+			origin: "%",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.accepted != "" {
+				require.NoError(t, os.Setenv(AcceptedOriginsEnv, tc.accepted))
+				defer os.Unsetenv(AcceptedOriginsEnv)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:7777/api/v1/action", nil)
+			if tc.origin != "" {
+				r.Header.Set("Origin", tc.origin)
+			}
+
+			assert.Equal(t, tc.expected, IsSameOrigin(r))
+		})
+	}
+}
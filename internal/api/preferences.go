@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/preferences"
+)
+
+// preferencesHandler gets and updates the user's preferences. Updates are
+// saved to the configuration file.
+type preferencesHandler struct {
+	preferences preferences.Interface
+	logger      log.Logger
+}
+
+var _ http.Handler = (*preferencesHandler)(nil)
+
+func newPreferencesHandler(prefs preferences.Interface, logger log.Logger) *preferencesHandler {
+	return &preferencesHandler{
+		preferences: prefs,
+		logger:      logger,
+	}
+}
+
+func (h *preferencesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		serveAsJSON(w, h.preferences.Preferences(), h.logger)
+	case http.MethodPut:
+		h.update(w, r)
+	default:
+		RespondWithError(w, http.StatusNotFound, fmt.Sprintf("unhandled HTTP method %s for preferences", r.Method), h.logger)
+	}
+}
+
+func (h *preferencesHandler) update(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		if cErr := r.Body.Close(); cErr != nil {
+			h.logger.WithErr(cErr).Errorf("unable to close request body")
+		}
+	}()
+
+	var p preferences.Preferences
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error(), h.logger)
+		return
+	}
+
+	// plugins run with the user's privileges, so where they are loaded from
+	// can only be changed in the configuration file.
+	if !equalStrings(p.PluginDirs, h.preferences.Preferences().PluginDirs) {
+		RespondWithError(w, http.StatusBadRequest, "pluginDirs can only be changed in the configuration file", h.logger)
+		return
+	}
+
+	if err := h.preferences.Update(p); err != nil {
+		code := http.StatusInternalServerError
+		if _, ok := err.(*preferences.ValidationError); ok {
+			code = http.StatusBadRequest
+		}
+		RespondWithError(w, code, err.Error(), h.logger)
+		return
+	}
+
+	serveAsJSON(w, h.preferences.Preferences(), h.logger)
+}
+
+// equalStrings returns true if a and b contain the same strings in the same
+// order. A nil slice is equal to an empty one.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
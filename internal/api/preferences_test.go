@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/preferences"
+)
+
+func Test_preferencesHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		expectedCode int
+		expected     func() preferences.Preferences
+	}{
+		{
+			name:         "get",
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			expected:     preferences.Default,
+		},
+		{
+			name:         "update",
+			method:       http.MethodPut,
+			body:         `{"version":1,"theme":"dark","hiddenNavigation":["Custom Resources"]}`,
+			expectedCode: http.StatusOK,
+			expected: func() preferences.Preferences {
+				return preferences.Preferences{
+					Version:          1,
+					Theme:            preferences.ThemeDark,
+					HiddenNavigation: []string{"Custom Resources"},
+				}
+			},
+		},
+		{
+			name:         "invalid preferences",
+			method:       http.MethodPut,
+			body:         `{"version":1,"theme":"blue"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "plugin dirs can't be changed",
+			method:       http.MethodPut,
+			body:         `{"version":1,"pluginDirs":["/tmp/plugins"]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid body",
+			method:       http.MethodPut,
+			body:         `{`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unsupported method",
+			method:       http.MethodPost,
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			manager, err := preferences.NewManager(afero.NewMemMapFs(), "/octant", log.NopLogger())
+			require.NoError(t, err)
+
+			handler := newPreferencesHandler(manager, log.NopLogger())
+
+			req := httptest.NewRequest(tc.method, "/api/v1/preferences", strings.NewReader(tc.body))
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			require.Equal(t, tc.expectedCode, resp.Code)
+			if tc.expected == nil {
+				return
+			}
+
+			var got preferences.Preferences
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+			assert.Equal(t, tc.expected(), got)
+			assert.Equal(t, tc.expected(), manager.Preferences())
+		})
+	}
+}
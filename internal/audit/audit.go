@@ -0,0 +1,105 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package audit records sensitive operations performed through the dashboard.
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+// Entry is an audited operation.
+type Entry struct {
+	Time       time.Time
+	Action     string
+	Context    string
+	Namespace  string
+	APIVersion string
+	Kind       string
+	Name       string
+	Key        string
+	Allowed    bool
+	Reason     string
+}
+
+// Trail records audit entries.
+type Trail interface {
+	// Record records an entry.
+	Record(ctx context.Context, entry Entry)
+	// Entries returns the recorded entries, oldest first.
+	Entries() []Entry
+}
+
+// DefaultSize is the number of entries kept by a trail.
+const DefaultSize = 500
+
+// MemoryTrail is a trail which logs entries and keeps the most recent
+// entries in memory.
+type MemoryTrail struct {
+	logger  log.Logger
+	size    int
+	now     func() time.Time
+	mu      sync.Mutex
+	entries []Entry
+}
+
+var _ Trail = (*MemoryTrail)(nil)
+
+// NewMemoryTrail creates an instance of MemoryTrail which keeps up to size
+// entries.
+func NewMemoryTrail(logger log.Logger, size int) *MemoryTrail {
+	if size < 1 {
+		size = DefaultSize
+	}
+
+	return &MemoryTrail{
+		logger: logger,
+		size:   size,
+		now:    time.Now,
+	}
+}
+
+// Record records an entry. The entry time is set if it is zero.
+func (t *MemoryTrail) Record(ctx context.Context, entry Entry) {
+	if entry.Time.IsZero() {
+		entry.Time = t.now()
+	}
+
+	t.mu.Lock()
+	t.entries = append(t.entries, entry)
+	if len(t.entries) > t.size {
+		t.entries = t.entries[len(t.entries)-t.size:]
+	}
+	t.mu.Unlock()
+
+	if t.logger != nil {
+		t.logger.With(
+			"action", entry.Action,
+			"context", entry.Context,
+			"namespace", entry.Namespace,
+			"apiVersion", entry.APIVersion,
+			"kind", entry.Kind,
+			"name", entry.Name,
+			"key", entry.Key,
+			"allowed", entry.Allowed,
+			"reason", entry.Reason,
+		).Infof("audit")
+	}
+}
+
+// Entries returns the recorded entries, oldest first.
+func (t *MemoryTrail) Entries() []Entry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries := make([]Entry, len(t.entries))
+	copy(entries, t.entries)
+
+	return entries
+}
@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+func TestMemoryTrail(t *testing.T) {
+	now := time.Unix(1547211430, 0)
+
+	trail := NewMemoryTrail(log.NopLogger(), 2)
+	trail.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	trail.Record(ctx, Entry{Action: "reveal", Name: "first"})
+	trail.Record(ctx, Entry{Action: "reveal", Name: "second"})
+	trail.Record(ctx, Entry{Action: "reveal", Name: "third", Time: now.Add(time.Minute)})
+
+	expected := []Entry{
+		{Time: now, Action: "reveal", Name: "second"},
+		{Time: now.Add(time.Minute), Action: "reveal", Name: "third"},
+	}
+	assert.Equal(t, expected, trail.Entries())
+}
+
+func TestNewMemoryTrail_default_size(t *testing.T) {
+	trail := NewMemoryTrail(nil, 0)
+	assert.Equal(t, DefaultSize, trail.size)
+
+	trail.Record(context.Background(), Entry{Action: "reveal"})
+	assert.Len(t, trail.Entries(), 1)
+}
@@ -25,7 +25,9 @@ import (
 	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 
+	"github.com/vmware/octant/internal/kubeconfig"
 	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/metrics"
 
 	// auth plugins
 	_ "k8s.io/client-go/plugin/pkg/client/auth/azure"
@@ -51,6 +53,7 @@ type ClientInterface interface {
 	DiscoveryClient() (discovery.DiscoveryInterface, error)
 	NamespaceClient() (NamespaceInterface, error)
 	InfoClient() (InfoInterface, error)
+	MetricsClient() (metrics.Interface, error)
 	Close()
 	RESTInterface
 }
@@ -69,6 +72,7 @@ type Cluster struct {
 	kubernetesClient kubernetes.Interface
 	dynamicClient    dynamic.Interface
 	discoveryClient  discovery.DiscoveryInterface
+	metricsClient    *metrics.Client
 
 	restMapper *restmapper.DeferredDiscoveryRESTMapper
 
@@ -95,6 +99,11 @@ func newCluster(ctx context.Context, clientConfig clientcmd.ClientConfig, restCl
 		return nil, errors.Wrap(err, "create discovery client")
 	}
 
+	metricsRESTClient, err := rest.RESTClientFor(withConfigDefaults(restClient))
+	if err != nil {
+		return nil, errors.Wrap(err, "create metrics REST client")
+	}
+
 	dir, err := ioutil.TempDir("", "octant")
 	if err != nil {
 		return nil, errors.Wrap(err, "create temp directory")
@@ -120,6 +129,7 @@ func newCluster(ctx context.Context, clientConfig clientcmd.ClientConfig, restCl
 		kubernetesClient: kubernetesClient,
 		dynamicClient:    dynamicClient,
 		discoveryClient:  discoveryClient,
+		metricsClient:    metrics.NewClient(discoveryClient, metricsRESTClient),
 		restMapper:       restMapper,
 		logger:           log.From(ctx),
 	}
@@ -223,6 +233,12 @@ func (c *Cluster) InfoClient() (InfoInterface, error) {
 	return newClusterInfo(c.clientConfig), nil
 }
 
+// MetricsClient returns a client for the metrics API. The client keeps a
+// short history of resource usage for the lifetime of the cluster client.
+func (c *Cluster) MetricsClient() (metrics.Interface, error) {
+	return c.metricsClient, nil
+}
+
 // RESTClient returns a RESTClient for the cluster.
 func (c *Cluster) RESTClient() (rest.Interface, error) {
 	config := withConfigDefaults(c.restConfig)
@@ -247,12 +263,10 @@ func (c *Cluster) Version() (string, error) {
 	return fmt.Sprint(serverVersion), nil
 }
 
-// FromKubeConfig creates a Cluster from a kubeconfig.
-func FromKubeConfig(ctx context.Context, kubeconfig, contextName string) (*Cluster, error) {
-	rules := clientcmd.NewDefaultClientConfigLoadingRules()
-	if kubeconfig != "" {
-		rules.ExplicitPath = kubeconfig
-	}
+// FromKubeConfig creates a Cluster from a kubeconfig. kubeconfigPaths can be
+// a list of files, like KUBECONFIG, which are merged like kubectl merges them.
+func FromKubeConfig(ctx context.Context, kubeconfigPaths, contextName string) (*Cluster, error) {
+	rules := kubeconfig.NewLoadingRules(kubeconfigPaths)
 
 	overrides := &clientcmd.ConfigOverrides{}
 	if contextName != "" {
@@ -12,14 +12,15 @@ import (
 	golog "log"
 	"os"
 	"os/signal"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog"
 
 	"github.com/vmware/octant/internal/dash"
+	"github.com/vmware/octant/internal/kubeconfig"
 	"github.com/vmware/octant/internal/log"
 )
 
@@ -108,9 +109,9 @@ func newOctantCmd() *cobra.Command {
 	octantCmd.Flags().StringVarP(&initialContext, "context", "", "", "initial context")
 	octantCmd.Flags().IntVarP(&klogVerbosity, "klog-verbosity", "", 0, "initial context")
 
-	kubeConfig = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+	kubeConfig = kubeconfig.DefaultPaths()
 
-	octantCmd.Flags().StringVar(&kubeConfig, "kubeConfig", kubeConfig, "absolute path to kubeConfig file")
+	octantCmd.Flags().StringVar(&kubeConfig, "kubeConfig", kubeConfig, "absolute path to kubeConfig file, or a list of files separated by "+string(filepath.ListSeparator)+" which are merged like KUBECONFIG")
 
 	return octantCmd
 }
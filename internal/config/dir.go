@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+)
+
+// dirName is the name of Octant's configuration directory.
+const dirName = "octant"
+
+// Dir returns Octant's configuration directory. It is the same directory
+// plugins are loaded from: ~/.config/octant, or octant in the configuration
+// home on Windows or when XDG_CONFIG_HOME is set.
+func Dir() (string, error) {
+	home := configHome()
+	if home == "" {
+		return "", errors.New("unable to find configuration home")
+	}
+
+	if runtime.GOOS == "windows" || os.Getenv("XDG_CONFIG_HOME") != "" {
+		return filepath.Join(home, dirName), nil
+	}
+
+	return filepath.Join(home, ".config", dirName), nil
+}
+
+func configHome() string {
+	switch runtime.GOOS {
+	case "windows":
+		return os.Getenv("LOCALAPPDATA")
+	case "darwin":
+		return os.Getenv("HOME")
+	default: // Unix
+		if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+			return dir
+		}
+	}
+
+	return os.Getenv("HOME")
+}
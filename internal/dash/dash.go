@@ -20,14 +20,17 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/skratchdot/open-golang/open"
+	"github.com/spf13/afero"
 	"go.opencensus.io/exporter/jaeger"
 	"go.opencensus.io/trace"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"github.com/vmware/octant/internal/api"
 	"github.com/vmware/octant/internal/cluster"
 	"github.com/vmware/octant/internal/componentcache"
 	"github.com/vmware/octant/internal/config"
 	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/kubeconfig"
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/module"
 	"github.com/vmware/octant/internal/modules/clusteroverview"
@@ -36,6 +39,7 @@ import (
 	"github.com/vmware/octant/internal/modules/overview"
 	"github.com/vmware/octant/internal/objectstore"
 	"github.com/vmware/octant/internal/portforward"
+	"github.com/vmware/octant/internal/preferences"
 	"github.com/vmware/octant/pkg/action"
 	"github.com/vmware/octant/pkg/plugin"
 	pluginAPI "github.com/vmware/octant/pkg/plugin/api"
@@ -60,6 +64,26 @@ type Options struct {
 func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options Options) error {
 	ctx = log.WithLoggerContext(ctx, logger)
 
+	prefsManager, err := initPreferences(ctx, logger)
+	if err != nil {
+		return errors.Wrap(err, "loading configuration")
+	}
+
+	prefs := preferences.Default()
+	if prefsManager != nil {
+		prefs = prefsManager.Preferences()
+		go prefsManager.Watch(ctx, preferences.DefaultWatchInterval)
+	}
+
+	// Flags take precedence over the configuration file
+	if options.Context == "" {
+		options.Context = prefs.DefaultContext
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = prefs.DefaultNamespace
+	}
+
 	if options.Context != "" {
 		logger.With("initial-context", options.Context).Infof("Setting initial context from user flags")
 	}
@@ -104,7 +128,9 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 		return errors.Wrap(err, "initializing CRD watcher")
 	}
 
-	portForwarder, err := initPortForwarder(ctx, clusterClient, appObjectStore)
+	contextName := currentContextName(clusterClient, options.Context)
+
+	portForwarder, err := initPortForwarder(ctx, clusterClient, appObjectStore, contextName)
 	if err != nil {
 		return errors.Wrap(err, "initializing port forwarder")
 	}
@@ -122,6 +148,10 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 		return errors.Wrap(err, "init module manager")
 	}
 
+	if err := moduleManager.UpdateContext(ctx, contextName); err != nil {
+		return errors.Wrap(err, "set initial context")
+	}
+
 	frontendProxy := pluginAPI.FrontendProxy{}
 
 	pluginDashboardService := &pluginAPI.GRPCService{
@@ -130,7 +160,7 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 		FrontendProxy: frontendProxy,
 	}
 
-	pluginManager, err := initPlugin(moduleManager, actionManger, pluginDashboardService)
+	pluginManager, err := initPlugin(moduleManager, actionManger, pluginDashboardService, prefs.PluginDirs)
 	if err != nil {
 		return errors.Wrap(err, "initializing plugin manager")
 	}
@@ -147,7 +177,7 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 		portForwarder,
 		options.Context)
 
-	moduleList, err := initModules(ctx, dashConfig, options.Namespace)
+	moduleList, err := initModules(ctx, dashConfig, options.Namespace, prefs)
 	if err != nil {
 		return errors.Wrap(err, "initializing modules")
 	}
@@ -158,6 +188,8 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 		}
 	}
 
+	watchKubeConfig(ctx, options.KubeConfig, dashConfig, moduleManager, logger)
+
 	if err := pluginManager.Start(ctx); err != nil {
 		return errors.Wrapf(err, "start plugin manager")
 	}
@@ -168,7 +200,12 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 	}
 
 	// Initialize the API
-	apiService := api.New(ctx, apiPathPrefix, clusterClient, moduleManager, actionManger, logger)
+	var apiPreferences preferences.Interface
+	if prefsManager != nil {
+		apiPreferences = prefsManager
+	}
+
+	apiService := api.New(ctx, apiPathPrefix, clusterClient, moduleManager, actionManger, apiPreferences, logger)
 	for _, m := range moduleManager.Modules() {
 		if err := apiService.RegisterModule(m); err != nil {
 			return errors.Wrapf(err, "registering module: %v", m.Name())
@@ -186,6 +223,8 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 		d.willOpenBrowser = false
 	}
 
+	d.portForwardProxy = portforward.NewProxy(portForwarder, logger)
+
 	go func() {
 		if err := d.Run(ctx); err != nil {
 			logger.Debugf("running dashboard service: %v", err)
@@ -204,6 +243,66 @@ func Run(ctx context.Context, logger log.Logger, shutdownCh chan bool, options O
 	return nil
 }
 
+// initPreferences loads the configuration file. Preferences aren't
+// available if there is no configuration directory.
+func initPreferences(ctx context.Context, logger log.Logger) (*preferences.Manager, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		logger.Warnf("configuration file is not available: %v", err)
+		return nil, nil
+	}
+
+	return preferences.NewManager(afero.NewOsFs(), dir, logger)
+}
+
+// watchKubeConfig reloads the kube config when its files change. The
+// cluster client is rebuilt if the current context, or its cluster or user,
+// changed, e.g. when a token is rotated.
+func watchKubeConfig(ctx context.Context, kubeConfig string, dashConfig config.Dash, moduleManager module.ManagerInterface, logger log.Logger) {
+	if kubeConfig == "" {
+		kubeConfig = kubeconfig.DefaultPaths()
+	}
+
+	watcher, err := kubeconfig.NewWatcher(afero.NewOsFs(), kubeConfig, logger)
+	if err != nil {
+		logger.Warnf("kube config changes will not be reloaded: %v", err)
+		return
+	}
+
+	watcher.RegisterOnChange(func(previous, current *clientcmdapi.Config) {
+		contextName := moduleManager.GetContext()
+		if _, ok := current.Contexts[contextName]; !ok {
+			logger.With("context", contextName).Warnf("current context was removed from kube config")
+			return
+		}
+
+		if !kubeconfig.ContextChanged(previous, current, contextName) {
+			return
+		}
+
+		if err := dashConfig.UseContext(ctx, contextName); err != nil {
+			logger.WithErr(err).With("context", contextName).Errorf("rebuild cluster client after kube config changed")
+		}
+	})
+
+	go watcher.Watch(ctx, kubeconfig.DefaultWatchInterval)
+}
+
+// currentContextName returns the context the cluster client was created
+// for.
+func currentContextName(client cluster.ClientInterface, contextName string) string {
+	if contextName != "" {
+		return contextName
+	}
+
+	infoClient, err := client.InfoClient()
+	if err != nil {
+		return ""
+	}
+
+	return infoClient.Context()
+}
+
 // initObjectStore initializes the cluster object store interface
 func initObjectStore(ctx context.Context, client cluster.ClientInterface) (store.Store, error) {
 	if client == nil {
@@ -219,8 +318,17 @@ func initObjectStore(ctx context.Context, client cluster.ClientInterface) (store
 	return appObjectStore, nil
 }
 
-func initPortForwarder(ctx context.Context, client cluster.ClientInterface, appObjectStore store.Store) (portforward.PortForwarder, error) {
-	return portforward.Default(ctx, client, appObjectStore)
+func initPortForwarder(ctx context.Context, client cluster.ClientInterface, appObjectStore store.Store, contextName string) (portforward.PortForwarder, error) {
+	var profiles portforward.ProfileStore
+
+	dir, err := config.Dir()
+	if err != nil {
+		log.From(ctx).Warnf("port forward profiles are not available: %v", err)
+	} else {
+		profiles = portforward.NewFileProfileStore(afero.NewOsFs(), dir)
+	}
+
+	return portforward.Default(ctx, client, appObjectStore, profiles, contextName)
 }
 
 type moduleOptions struct {
@@ -237,40 +345,47 @@ type moduleOptions struct {
 	actionManager  *action.Manager
 }
 
-func initModules(ctx context.Context, dashConfig config.Dash, namespace string) ([]module.Module, error) {
+func initModules(ctx context.Context, dashConfig config.Dash, namespace string, prefs preferences.Preferences) ([]module.Module, error) {
 	var list []module.Module
 
-	overviewOptions := overview.Options{
-		Namespace:  namespace,
-		DashConfig: dashConfig,
-	}
-	overviewModule, err := overview.New(ctx, overviewOptions)
-	if err != nil {
-		return nil, errors.Wrap(err, "create overview module")
+	// Modules which aren't enabled in the configuration file aren't created.
+	if prefs.ModuleEnabled("overview") {
+		overviewOptions := overview.Options{
+			Namespace:  namespace,
+			DashConfig: dashConfig,
+		}
+		overviewModule, err := overview.New(ctx, overviewOptions)
+		if err != nil {
+			return nil, errors.Wrap(err, "create overview module")
+		}
+
+		list = append(list, overviewModule)
 	}
 
-	list = append(list, overviewModule)
+	if prefs.ModuleEnabled("cluster-overview") {
+		clusterOverviewOptions := clusteroverview.Options{
+			DashConfig: dashConfig,
+		}
+		clusterOverviewModule, err := clusteroverview.New(ctx, clusterOverviewOptions)
+		if err != nil {
+			return nil, errors.Wrap(err, "create cluster overview module")
+		}
 
-	clusterOverviewOptions := clusteroverview.Options{
-		DashConfig: dashConfig,
-	}
-	clusterOverviewModule, err := clusteroverview.New(ctx, clusterOverviewOptions)
-	if err != nil {
-		return nil, errors.Wrap(err, "create cluster overview module")
+		list = append(list, clusterOverviewModule)
 	}
 
-	list = append(list, clusterOverviewModule)
+	if prefs.ModuleEnabled("configuration") {
+		configurationOptions := configuration.Options{
+			DashConfig:     dashConfig,
+			KubeConfigPath: dashConfig.KubeConfigPath(),
+		}
+		configurationModule := configuration.New(ctx, configurationOptions)
 
-	configurationOptions := configuration.Options{
-		DashConfig:     dashConfig,
-		KubeConfigPath: dashConfig.KubeConfigPath(),
+		list = append(list, configurationModule)
 	}
-	configurationModule := configuration.New(ctx, configurationOptions)
-
-	list = append(list, configurationModule)
 
 	localContentPath := os.Getenv("OCTANT_LOCAL_CONTENT")
-	if localContentPath != "" {
+	if localContentPath != "" && prefs.ModuleEnabled("local") {
 		localContentModule := localcontent.New(localContentPath)
 		list = append(list, localContentModule)
 	}
@@ -305,6 +420,8 @@ type dash struct {
 	apiHandler      api.Service
 	willOpenBrowser bool
 	logger          log.Logger
+	// portForwardProxy proxies to forwarded HTTP ports. It is optional.
+	portForwardProxy http.Handler
 }
 
 func newDash(listener net.Listener, namespace, uiURL string, apiHandler api.Service, logger log.Logger) (*dash, error) {
@@ -365,6 +482,9 @@ func (d *dash) handler(ctx context.Context) (http.Handler, error) {
 	}
 
 	router.PathPrefix(apiPathPrefix).Handler(apiHandler)
+	if d.portForwardProxy != nil {
+		router.PathPrefix(portforward.ProxyPrefix).Handler(d.portForwardProxy)
+	}
 	router.PathPrefix("/").Handler(handler)
 
 	allowedOrigins := handlers.AllowedOrigins([]string{"*"})
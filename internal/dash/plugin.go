@@ -14,7 +14,23 @@ import (
 	"github.com/vmware/octant/pkg/plugin/api"
 )
 
-func initPlugin(moduleManager module.ManagerInterface, actionManager *action.Manager, service api.Service) (*plugin.Manager, error) {
+// pluginConfig adds plugin directories from the configuration file to the
+// default plugin directories.
+type pluginConfig struct {
+	plugin.Config
+	dirs []string
+}
+
+func (c *pluginConfig) PluginDirs() ([]string, error) {
+	dirs, err := c.Config.PluginDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]string{}, c.dirs...), dirs...), nil
+}
+
+func initPlugin(moduleManager module.ManagerInterface, actionManager *action.Manager, service api.Service, pluginDirs []string) (*plugin.Manager, error) {
 	apiService, err := api.New(service)
 	if err != nil {
 		return nil, errors.Wrap(err, "create dashboard api")
@@ -22,7 +38,7 @@ func initPlugin(moduleManager module.ManagerInterface, actionManager *action.Man
 
 	m := plugin.NewManager(apiService, moduleManager, actionManager)
 
-	pluginList, err := plugin.AvailablePlugins(plugin.DefaultConfig)
+	pluginList, err := plugin.AvailablePlugins(&pluginConfig{Config: plugin.DefaultConfig, dirs: pluginDirs})
 	if err != nil {
 		return nil, errors.Wrap(err, "finding available plugins")
 	}
@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dash
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPluginConfig struct {
+	dirs []string
+}
+
+func (c *stubPluginConfig) PluginDirs() ([]string, error) {
+	return c.dirs, nil
+}
+
+func (c *stubPluginConfig) Home() string {
+	return "/home/user"
+}
+
+func (c *stubPluginConfig) Fs() afero.Fs {
+	return afero.NewMemMapFs()
+}
+
+func Test_pluginConfig(t *testing.T) {
+	c := &pluginConfig{
+		Config: &stubPluginConfig{dirs: []string{"/home/user/.config/octant/plugins"}},
+		dirs:   []string{"/opt/octant/plugins"},
+	}
+
+	dirs, err := c.PluginDirs()
+	require.NoError(t, err)
+
+	expected := []string{"/opt/octant/plugins", "/home/user/.config/octant/plugins"}
+	assert.Equal(t, expected, dirs)
+	assert.Equal(t, []string{"/opt/octant/plugins"}, c.dirs)
+}
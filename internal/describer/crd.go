@@ -27,7 +27,7 @@ import (
 
 type crdPrinter func(ctx context.Context, crd *apiextv1beta1.CustomResourceDefinition, object *unstructured.Unstructured, options printer.Options) (component.Component, error)
 type resourceViewerPrinter func(ctx context.Context, object *unstructured.Unstructured, dashConfig config.Dash, q queryer.Queryer) (component.Component, error)
-type yamlPrinter func(runtime.Object) (*component.YAML, error)
+type yamlPrinter func(runtime.Object, ...yamlviewer.Option) (*component.YAML, error)
 
 type crdOption func(*crd)
 
@@ -125,7 +125,7 @@ func (c *crd) Describe(ctx context.Context, prefix, namespace string, options Op
 	resourceViewerComponent.SetAccessor("resourceViewer")
 	cr.Add(resourceViewerComponent)
 
-	yvComponent, err := c.yamlPrinter(object)
+	yvComponent, err := c.yamlPrinter(object, schemaOptions(ctx, object, options)...)
 	if err != nil {
 		return EmptyContentResponse, err
 	}
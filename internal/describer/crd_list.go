@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/vmware/octant/internal/link"
+	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/modules/overview/printer"
 	"github.com/vmware/octant/pkg/icon"
 	"github.com/vmware/octant/pkg/store"
@@ -28,21 +29,31 @@ type crdListPrinter func(
 	objects []*unstructured.Unstructured,
 	linkGenerator link.Interface) (component.Component, error)
 
+// crdTablePrinter prints custom resources using a table generated by the
+// API server.
+type crdTablePrinter func(
+	ctx context.Context,
+	key store.Key,
+	objects []*unstructured.Unstructured,
+	options printer.Options) (*component.Table, error)
+
 type crdListDescriptionOption func(*crdList)
 
 type crdList struct {
-	name    string
-	path    string
-	printer crdListPrinter
+	name         string
+	path         string
+	printer      crdListPrinter
+	tablePrinter crdTablePrinter
 }
 
 var _ Describer = (*crdList)(nil)
 
 func newCRDList(name, path string, options ...crdListDescriptionOption) *crdList {
 	d := &crdList{
-		name:    name,
-		path:    path,
-		printer: printer.CustomResourceListHandler,
+		name:         name,
+		path:         path,
+		printer:      printer.CustomResourceListHandler,
+		tablePrinter: printer.ServerSideTable,
 	}
 
 	for _, option := range options {
@@ -64,7 +75,12 @@ func (cld *crdList) Describe(ctx context.Context, prefix, namespace string, opti
 		return EmptyContentResponse, err
 	}
 
-	table, err := cld.printer(cld.name, crd, objects, options.Link)
+	objects, err = filterObjects(ctx, objects, options)
+	if err != nil {
+		return EmptyContentResponse, err
+	}
+
+	table, err := cld.printTable(ctx, crd, namespace, objects, options)
 	if err != nil {
 		return EmptyContentResponse, err
 	}
@@ -81,6 +97,49 @@ func (cld *crdList) Describe(ctx context.Context, prefix, namespace string, opti
 	}, nil
 }
 
+// printTable prints custom resources with the columns the API server
+// generates for them, which match `kubectl get`. The CRD's printer columns
+// are used if the API server can't generate a table.
+func (cld *crdList) printTable(
+	ctx context.Context,
+	crd *apiextv1beta1.CustomResourceDefinition,
+	namespace string,
+	objects []*unstructured.Unstructured,
+	options Options) (component.Component, error) {
+	if cld.tablePrinter != nil && len(objects) > 0 {
+		apiVersion, kind := schema.GroupVersionKind{
+			Group:   crd.Spec.Group,
+			Version: crd.Spec.Version,
+			Kind:    crd.Spec.Names.Kind,
+		}.ToAPIVersionAndKind()
+
+		key := store.Key{
+			Namespace:  namespace,
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Selector:   options.LabelSet,
+		}
+		if crd.Spec.Scope == apiextv1beta1.ClusterScoped {
+			key.Namespace = ""
+		}
+
+		printOptions := printer.Options{
+			DashConfig: options.Dash,
+			Link:       options.Link,
+		}
+
+		table, err := cld.tablePrinter(ctx, key, objects, printOptions)
+		if err == nil {
+			table.Metadata.SetTitleText(cld.name)
+			return table, nil
+		}
+
+		log.From(ctx).WithErr(err).Debugf("unable to print %s using a server side table", key)
+	}
+
+	return cld.printer(cld.name, crd, objects, options.Link)
+}
+
 func ListCustomResources(
 	ctx context.Context,
 	crd *apiextv1beta1.CustomResourceDefinition,
@@ -10,12 +10,15 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	configFake "github.com/vmware/octant/internal/config/fake"
 	"github.com/vmware/octant/internal/link"
+	"github.com/vmware/octant/internal/modules/overview/printer"
 	"github.com/vmware/octant/internal/testutil"
 	"github.com/vmware/octant/pkg/icon"
 	"github.com/vmware/octant/pkg/store"
@@ -81,3 +84,87 @@ func Test_crdListDescriber(t *testing.T) {
 
 	testutil.AssertJSONEqual(t, expected, got)
 }
+
+func Test_crdListDescriber_server_side_table(t *testing.T) {
+	tests := []struct {
+		name     string
+		tableErr error
+		expected component.Component
+	}{
+		{
+			name:     "server side table",
+			expected: component.NewTable("crd1", component.NewTableCols("Name", "Ready")),
+		},
+		{
+			name:     "server side table error",
+			tableErr: errors.New("error"),
+			expected: component.NewText("crd list"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			o := storefake.NewMockStore(controller)
+
+			crd := testutil.CreateCRD("crd1")
+			crd.Spec.Group = "foo.example.com"
+			crd.Spec.Version = "v1"
+			crd.Spec.Names.Kind = "Name"
+
+			crdKey := store.Key{
+				APIVersion: "apiextensions.k8s.io/v1beta1",
+				Kind:       "CustomResourceDefinition",
+				Name:       crd.Name,
+			}
+
+			o.EXPECT().HasAccess(gomock.Any(), gomock.Any(), "list").Return(nil)
+			o.EXPECT().Get(gomock.Any(), gomock.Eq(crdKey)).Return(testutil.ToUnstructured(t, crd), nil)
+
+			crKey := store.Key{
+				Namespace:  "default",
+				APIVersion: "foo.example.com/v1",
+				Kind:       "Name",
+			}
+
+			cr := testutil.CreateCustomResource("cr1")
+			objects := []*unstructured.Unstructured{cr}
+			o.EXPECT().List(gomock.Any(), gomock.Eq(crKey)).Return(objects, nil)
+
+			tablePrinter := func(cld *crdList) {
+				cld.printer = func(name string, crd *apiextv1beta1.CustomResourceDefinition, objects []*unstructured.Unstructured, linkGenerator link.Interface) (component.Component, error) {
+					return component.NewText("crd list"), nil
+				}
+				cld.tablePrinter = func(ctx context.Context, key store.Key, got []*unstructured.Unstructured, options printer.Options) (*component.Table, error) {
+					assert.Equal(t, crKey, key)
+					assert.Equal(t, objects, got)
+					if tc.tableErr != nil {
+						return nil, tc.tableErr
+					}
+					return component.NewTable("foo.example.com/v1, Kind=Name", component.NewTableCols("Name", "Ready")), nil
+				}
+			}
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().ObjectStore().Return(o).AnyTimes()
+
+			options := Options{
+				Dash: dashConfig,
+			}
+			cld := newCRDList(crd.Name, "path", tablePrinter)
+
+			got, err := cld.Describe(context.Background(), "prefix", "default", options)
+			require.NoError(t, err)
+
+			expected := *component.NewContentResponse(nil)
+			list := component.NewList("Custom Resources / crd1", []component.Component{tc.expected})
+			iconName, iconSource := loadIcon(icon.CustomResourceDefinition)
+			list.SetIcon(iconName, iconSource)
+			expected.Add(list)
+
+			testutil.AssertJSONEqual(t, expected, got)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	configFake "github.com/vmware/octant/internal/config/fake"
 	linkFake "github.com/vmware/octant/internal/link/fake"
 	"github.com/vmware/octant/internal/modules/overview/printer"
+	"github.com/vmware/octant/internal/modules/overview/yamlviewer"
 	"github.com/vmware/octant/internal/queryer"
 	"github.com/vmware/octant/internal/testutil"
 	"github.com/vmware/octant/pkg/icon"
@@ -76,7 +77,7 @@ func Test_crd(t *testing.T) {
 			return component.NewText("rv"), nil
 		}
 
-		cd.yamlPrinter = func(runtime.Object) (*component.YAML, error) {
+		cd.yamlPrinter = func(runtime.Object, ...yamlviewer.Option) (*component.YAML, error) {
 			return component.NewYAML(component.TitleFromString("yaml"), "data"), nil
 		}
 	}
@@ -17,8 +17,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/filter"
 	"github.com/vmware/octant/internal/link"
 	"github.com/vmware/octant/internal/modules/overview/printer"
+	"github.com/vmware/octant/internal/openapi"
 	"github.com/vmware/octant/internal/queryer"
 	"github.com/vmware/octant/pkg/store"
 	"github.com/vmware/octant/pkg/view/component"
@@ -99,7 +101,9 @@ type Options struct {
 	Fields   map[string]string
 	Printer  printer.Printer
 	LabelSet *kLabels.Set
+	Filter   *filter.Expression
 	Link     link.Interface
+	Schemas  openapi.Interface
 
 	LoadObjects func(ctx context.Context, namespace string, fields map[string]string, objectStoreKeys []store.Key) ([]*unstructured.Unstructured, error)
 	LoadObject  func(ctx context.Context, namespace string, fields map[string]string, objectStoreKey store.Key) (*unstructured.Unstructured, error)
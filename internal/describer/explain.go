@@ -0,0 +1,96 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package describer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/openapi"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// Explain describes the schema for a group version kind. The path is
+// expected to end with the API version and kind, e.g. `/explain/apps/v1/Deployment`.
+type Explain struct {
+	path string
+}
+
+var _ Describer = (*Explain)(nil)
+
+// NewExplain creates an instance of Explain.
+func NewExplain(path string) *Explain {
+	return &Explain{
+		path: path,
+	}
+}
+
+// Describe describes the schema tree for a group version kind.
+func (e *Explain) Describe(ctx context.Context, prefix, namespace string, options Options) (component.ContentResponse, error) {
+	if options.Schemas == nil {
+		return EmptyContentResponse, errors.New("OpenAPI schemas are not available")
+	}
+
+	apiVersion, kind := options.Fields["apiVersion"], options.Fields["kind"]
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return EmptyContentResponse, errors.Wrapf(err, "parse api version %q", apiVersion)
+	}
+
+	gvk := gv.WithKind(kind)
+
+	root, err := options.Schemas.Lookup(ctx, gvk)
+	if err != nil {
+		return EmptyContentResponse, errors.Wrapf(err, "look up schema for %s", gvk)
+	}
+
+	cr := component.NewContentResponse(component.TitleFromString(fmt.Sprintf("Explain / %s", kind)))
+
+	summary := component.NewSummary("Kind", []component.SummarySection{
+		{Header: "API Version", Content: component.NewText(apiVersion)},
+		{Header: "Kind", Content: component.NewText(kind)},
+		{Header: "Description", Content: component.NewText(root.Description)},
+	}...)
+	cr.Add(summary)
+
+	cols := component.NewTableCols("Field", "Type", "Required", "Deprecated", "Description")
+	table := component.NewTable("Fields", cols)
+
+	root.Walk(func(field *openapi.Field, depth int) {
+		if depth == 0 {
+			return
+		}
+
+		table.Add(component.TableRow{
+			"Field":       component.NewText(field.Path),
+			"Type":        component.NewText(field.Type),
+			"Required":    component.NewText(yesOrEmpty(field.Required)),
+			"Deprecated":  component.NewText(yesOrEmpty(field.Deprecated)),
+			"Description": component.NewText(field.Description),
+		})
+	})
+
+	cr.Add(table)
+
+	return *cr, nil
+}
+
+// PathFilters returns the path filters for Explain.
+func (e *Explain) PathFilters() []PathFilter {
+	return []PathFilter{
+		*NewPathFilter(fmt.Sprintf("%s/(?P<apiVersion>.+)/(?P<kind>[^/]+)", e.path), e),
+	}
+}
+
+func yesOrEmpty(b bool) string {
+	if b {
+		return "yes"
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package describer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/openapi"
+	openapiFake "github.com/vmware/octant/internal/openapi/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func TestExplain(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	root := &openapi.Field{
+		Name:        "Deployment",
+		Type:        "Object",
+		Description: "Deployment enables declarative updates for Pods and ReplicaSets.",
+		Fields: []openapi.Field{
+			{
+				Name: "spec",
+				Path: "spec",
+				Type: "Object",
+				Fields: []openapi.Field{
+					{
+						Name:        "selector",
+						Path:        "spec.selector",
+						Type:        "Object",
+						Description: "Label selector for pods.",
+						Required:    true,
+					},
+				},
+			},
+		},
+	}
+
+	schemas := openapiFake.NewMockInterface(controller)
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	schemas.EXPECT().Lookup(gomock.Any(), gvk).Return(root, nil)
+
+	e := NewExplain("/explain")
+
+	filters := e.PathFilters()
+	require.Len(t, filters, 1)
+
+	path := "/explain/apps/v1/Deployment"
+	require.True(t, filters[0].Match(path))
+
+	options := Options{
+		Fields:  filters[0].Fields(path),
+		Schemas: schemas,
+	}
+
+	got, err := e.Describe(context.Background(), "/prefix", "default", options)
+	require.NoError(t, err)
+
+	summary := component.NewSummary("Kind", []component.SummarySection{
+		{Header: "API Version", Content: component.NewText("apps/v1")},
+		{Header: "Kind", Content: component.NewText("Deployment")},
+		{Header: "Description", Content: component.NewText(root.Description)},
+	}...)
+
+	table := component.NewTable("Fields", component.NewTableCols("Field", "Type", "Required", "Deprecated", "Description"))
+	table.Add(
+		component.TableRow{
+			"Field":       component.NewText("spec"),
+			"Type":        component.NewText("Object"),
+			"Required":    component.NewText(""),
+			"Deprecated":  component.NewText(""),
+			"Description": component.NewText(""),
+		},
+		component.TableRow{
+			"Field":       component.NewText("spec.selector"),
+			"Type":        component.NewText("Object"),
+			"Required":    component.NewText("yes"),
+			"Deprecated":  component.NewText(""),
+			"Description": component.NewText("Label selector for pods."),
+		},
+	)
+
+	expected := component.ContentResponse{
+		Title:      component.TitleFromString("Explain / Deployment"),
+		Components: []component.Component{summary, table},
+	}
+
+	assert.Equal(t, expected, got)
+}
+
+func TestExplain_core_group(t *testing.T) {
+	e := NewExplain("/explain")
+	filters := e.PathFilters()
+
+	fields := filters[0].Fields("/explain/v1/Pod")
+	assert.Equal(t, map[string]string{"apiVersion": "v1", "kind": "Pod"}, fields)
+}
@@ -0,0 +1,35 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package describer
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware/octant/internal/filter"
+	"github.com/vmware/octant/internal/modules/overview/objectstatus"
+)
+
+// filterObjects returns the objects which match the filter expression in
+// options. Objects aren't filtered if there is no expression.
+func filterObjects(ctx context.Context, objects []*unstructured.Unstructured, options Options) ([]*unstructured.Unstructured, error) {
+	if options.Filter == nil {
+		return objects, nil
+	}
+
+	env := filter.Env{
+		Status: func(ctx context.Context, object *unstructured.Unstructured) (string, error) {
+			status, err := objectstatus.Status(ctx, object, options.ObjectStore())
+			if err != nil {
+				return "", err
+			}
+			return string(status.Status()), nil
+		},
+	}
+
+	return options.Filter.Filter(ctx, objects, env)
+}
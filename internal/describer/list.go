@@ -76,6 +76,11 @@ func (d *List) Describe(ctx context.Context, prefix, namespace string, options O
 		return EmptyContentResponse, err
 	}
 
+	objects, err = filterObjects(ctx, objects, options)
+	if err != nil {
+		return EmptyContentResponse, err
+	}
+
 	list := component.NewList(d.title, nil)
 	list.SetIcon(d.iconName, d.iconSource)
 
@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/filter"
 	printerFake "github.com/vmware/octant/internal/modules/overview/printer/fake"
 	"github.com/vmware/octant/internal/testutil"
 	"github.com/vmware/octant/pkg/plugin"
@@ -87,3 +88,60 @@ func TestListDescriber(t *testing.T) {
 
 	assert.Equal(t, expected, cResponse)
 }
+
+func TestListDescriber_filter(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	pod := testutil.CreatePod("pod")
+	pod.CreationTimestamp = metav1.Time{
+		Time: time.Unix(1547472896, 0),
+	}
+	other := testutil.CreatePod("other")
+
+	key, err := store.KeyFromObject(pod)
+	require.NoError(t, err)
+
+	dashConfig := configFake.NewMockDash(controller)
+	moduleRegistrar := pluginFake.NewMockModuleRegistrar(controller)
+	actionRegistrar := pluginFake.NewMockActionRegistrar(controller)
+
+	pluginManager := plugin.NewManager(nil, moduleRegistrar, actionRegistrar)
+	dashConfig.EXPECT().PluginManager().Return(pluginManager)
+
+	podListTable := createPodTable(*pod)
+
+	objectPrinter := printerFake.NewMockPrinter(controller)
+	podList := &corev1.PodList{Items: []corev1.Pod{*pod}}
+	objectPrinter.EXPECT().Print(gomock.Any(), podList, pluginManager).Return(podListTable, nil)
+
+	expression, err := filter.Parse("name=pod and age>1h")
+	require.NoError(t, err)
+
+	options := Options{
+		Dash:    dashConfig,
+		Printer: objectPrinter,
+		Filter:  expression,
+		LoadObjects: func(ctx context.Context, namespace string, fields map[string]string, objectStoreKeys []store.Key) ([]*unstructured.Unstructured, error) {
+			return testutil.ToUnstructuredList(t, pod, other), nil
+		},
+	}
+
+	d := NewList(ListConfig{
+		Path:       "/",
+		Title:      "list",
+		StoreKey:   key,
+		ListType:   podListType,
+		ObjectType: podObjectType,
+	})
+	cResponse, err := d.Describe(context.Background(), "/path", "default", options)
+	require.NoError(t, err)
+
+	list := component.NewList("list", nil)
+	list.Add(podListTable)
+	expected := component.ContentResponse{
+		Components: []component.Component{list},
+	}
+
+	assert.Equal(t, expected, cResponse)
+}
@@ -14,8 +14,13 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 
 	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/modules/overview/lifecycle"
 	"github.com/vmware/octant/internal/modules/overview/logviewer"
 	"github.com/vmware/octant/internal/modules/overview/resourceviewer"
+	"github.com/vmware/octant/internal/modules/overview/rollouthistory"
+	"github.com/vmware/octant/internal/modules/overview/terminalviewer"
+	"github.com/vmware/octant/internal/modules/overview/timelineviewer"
+	"github.com/vmware/octant/internal/modules/overview/yamleditor"
 	"github.com/vmware/octant/internal/modules/overview/yamlviewer"
 	"github.com/vmware/octant/pkg/store"
 	"github.com/vmware/octant/pkg/view/component"
@@ -61,8 +66,12 @@ func NewObject(c ObjectConfig) *Object {
 	o.tabFuncDescriptors = []tabFuncDescriptor{
 		{name: "summary", tabFunc: o.addSummaryTab},
 		{name: "resource viewer", tabFunc: o.addResourceViewerTab},
+		{name: "timeline", tabFunc: o.addTimelineTab},
+		{name: "rollout history", tabFunc: o.addRolloutHistoryTab},
 		{name: "yaml", tabFunc: o.addYAMLViewerTab},
+		{name: "yaml editor", tabFunc: o.addYAMLEditorTab},
 		{name: "logs", tabFunc: o.addLogsTab},
+		{name: "terminal", tabFunc: o.addTerminalTab},
 	}
 
 	return o
@@ -164,12 +173,39 @@ func (d *Object) addSummaryTab(ctx context.Context, object runtime.Object, cr *c
 		return nil
 	}
 
+	if err := addLifecycleActions(ctx, object, vc, options); err != nil {
+		logger := log.From(ctx)
+		logger.Errorf("adding lifecycle actions: %s", err)
+	}
+
 	vc.SetAccessor("summary")
 	cr.Add(vc)
 
 	return nil
 }
 
+// addLifecycleActions adds the lifecycle actions the current user can
+// perform on an object to its summary.
+func addLifecycleActions(ctx context.Context, object runtime.Object, vc component.Component, options Options) error {
+	fl, ok := vc.(*component.FlexLayout)
+	if !ok || options.Dash == nil {
+		return nil
+	}
+
+	summary, err := lifecycle.ToComponent(ctx, options.Dash.ObjectStore(), lifecycle.Actions(), object)
+	if err != nil {
+		return err
+	}
+
+	if summary != nil {
+		fl.AddSections(component.FlexLayoutSection{
+			{Width: component.WidthHalf, View: summary},
+		})
+	}
+
+	return nil
+}
+
 func (d *Object) addResourceViewerTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
 	if !d.disableResourceViewer {
 		cacheFn := resourceviewer.CachedResourceViewer(object, options.Dash, options.Queryer)
@@ -185,9 +221,54 @@ func (d *Object) addResourceViewerTab(ctx context.Context, object runtime.Object
 	return nil
 }
 
-func (d *Object) addYAMLViewerTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
-	yvComponent, err := yamlviewer.ToComponent(object)
+func (d *Object) addTimelineTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
+	if !d.disableResourceViewer {
+		cacheFn := timelineviewer.CachedTimeline(object, options.Dash, options.Queryer)
+		timelineComponent, err := options.Dash.ComponentCache().Update(ctx, cacheFn)
+		if err != nil {
+			return errors.Wrap(err, "retrieve timeline from component cache")
+		}
+
+		timelineComponent.SetAccessor("timeline")
+		cr.Add(timelineComponent)
+	}
+
+	return nil
+}
 
+func (d *Object) addRolloutHistoryTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
+	if !rollouthistory.Supports(object) {
+		return nil
+	}
+
+	historyComponent, err := rolloutHistory(ctx, object, options)
+	if err != nil {
+		errComponent := component.NewError(component.TitleFromString("Rollout History"), err)
+		cr.Add(errComponent)
+
+		logger := log.From(ctx)
+		logger.Errorf("creating rollout history: %s", err)
+
+		return nil
+	}
+
+	historyComponent.SetAccessor("rolloutHistory")
+	cr.Add(historyComponent)
+
+	return nil
+}
+
+func rolloutHistory(ctx context.Context, object runtime.Object, options Options) (component.Component, error) {
+	revisions, err := rollouthistory.Load(ctx, options.Queryer, object)
+	if err != nil {
+		return nil, errors.Wrap(err, "load revisions")
+	}
+
+	return rollouthistory.ToComponent(object, revisions)
+}
+
+func (d *Object) addYAMLViewerTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
+	yvComponent, err := yamlviewer.ToComponent(object, schemaOptions(ctx, object, options)...)
 	if err != nil {
 		errComponent := component.NewError(component.TitleFromString("YAML"), err)
 		cr.Add(errComponent)
@@ -204,15 +285,54 @@ func (d *Object) addYAMLViewerTab(ctx context.Context, object runtime.Object, cr
 
 }
 
+func (d *Object) addYAMLEditorTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
+	yeComponent, err := yamleditor.ToComponent(object)
+	if err != nil {
+		errComponent := component.NewError(component.TitleFromString("YAML Editor"), err)
+		cr.Add(errComponent)
+
+		logger := log.From(ctx)
+		logger.Errorf("creating YAML editor: %s", err)
+
+		return nil
+	}
+
+	if yeComponent == nil {
+		return nil
+	}
+
+	yeComponent.SetAccessor("yamlEditor")
+	cr.Add(yeComponent)
+	return nil
+}
+
+// schemaOptions returns YAML viewer options which annotate an object's
+// YAML with field documentation if a schema is available.
+func schemaOptions(ctx context.Context, object runtime.Object, options Options) []yamlviewer.Option {
+	if options.Schemas == nil {
+		return nil
+	}
+
+	gvk := object.GetObjectKind().GroupVersionKind()
+	schema, err := options.Schemas.Lookup(ctx, gvk)
+	if err != nil {
+		logger := log.From(ctx)
+		logger.WithErr(err).Debugf("unable to find schema for %s", gvk)
+		return nil
+	}
+
+	return []yamlviewer.Option{yamlviewer.WithSchema(schema)}
+}
+
 func (d *Object) addLogsTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
-	if isPod(object) {
+	if logviewer.HasLogs(object) {
 		logsComponent, err := logviewer.ToComponent(object)
 		if err != nil {
 			errComponent := component.NewError(component.TitleFromString("Logs"), err)
 			cr.Add(errComponent)
 
 			logger := log.From(ctx)
-			logger.Errorf("retrieving logs for pod: %s", err)
+			logger.Errorf("retrieving logs: %s", err)
 
 			return nil
 		}
@@ -223,3 +343,34 @@ func (d *Object) addLogsTab(ctx context.Context, object runtime.Object, cr *comp
 
 	return nil
 }
+
+func (d *Object) addTerminalTab(ctx context.Context, object runtime.Object, cr *component.ContentResponse, options Options) error {
+	if !isPod(object) {
+		return nil
+	}
+
+	accessor := meta.NewAccessor()
+	namespace, _ := accessor.Namespace(object)
+
+	if err := terminalviewer.HasAccess(ctx, options.ObjectStore(), namespace); err != nil {
+		logger := log.From(ctx)
+		logger.WithErr(err).Debugf("terminal is not available")
+		return nil
+	}
+
+	terminalComponent, err := terminalviewer.ToComponent(object)
+	if err != nil {
+		errComponent := component.NewError(component.TitleFromString("Terminal"), err)
+		cr.Add(errComponent)
+
+		logger := log.From(ctx)
+		logger.Errorf("creating terminal for pod: %s", err)
+
+		return nil
+	}
+
+	terminalComponent.SetAccessor("terminal")
+	cr.Add(terminalComponent)
+
+	return nil
+}
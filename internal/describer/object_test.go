@@ -11,17 +11,21 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	componentCacheFake "github.com/vmware/octant/internal/componentcache/fake"
 	configFake "github.com/vmware/octant/internal/config/fake"
 	printerFake "github.com/vmware/octant/internal/modules/overview/printer/fake"
+	"github.com/vmware/octant/internal/modules/overview/terminalviewer"
 	"github.com/vmware/octant/internal/testutil"
 	"github.com/vmware/octant/pkg/plugin"
 	pluginFake "github.com/vmware/octant/pkg/plugin/fake"
 	"github.com/vmware/octant/pkg/store"
+	storefake "github.com/vmware/octant/pkg/store/fake"
 	"github.com/vmware/octant/pkg/view/component"
 )
 
@@ -92,3 +96,97 @@ func TestObjectDescriber(t *testing.T) {
 	assert.Equal(t, expected, cResponse)
 
 }
+
+func TestObjectDescriber_terminal(t *testing.T) {
+	cases := []struct {
+		name      string
+		accessErr error
+		expected  []component.Component
+	}{
+		{
+			name: "with access",
+			expected: func() []component.Component {
+				terminal := component.NewTerminal("namespace", "pod", []string{}, terminalviewer.Shells)
+				terminal.SetAccessor("terminal")
+				return []component.Component{terminal}
+			}(),
+		},
+		{
+			name:      "without access",
+			accessErr: errors.New("denied"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			pod := testutil.CreatePod("pod")
+
+			key := store.Key{
+				Namespace:   pod.Namespace,
+				APIVersion:  "v1",
+				Kind:        "Pod",
+				Subresource: "exec",
+			}
+
+			objectStore := storefake.NewMockStore(controller)
+			objectStore.EXPECT().HasAccess(gomock.Any(), key, "create").Return(tc.accessErr)
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().ObjectStore().Return(objectStore)
+
+			d := NewObject(ObjectConfig{})
+
+			cr := component.NewContentResponse(nil)
+			err := d.addTerminalTab(context.Background(), pod, cr, Options{Dash: dashConfig})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, cr.Components)
+		})
+	}
+}
+
+func TestObjectDescriber_timeline(t *testing.T) {
+	cases := []struct {
+		name                  string
+		disableResourceViewer bool
+	}{
+		{name: "enabled"},
+		{name: "disabled", disableResourceViewer: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			pod := testutil.CreatePod("pod")
+
+			dashConfig := configFake.NewMockDash(controller)
+
+			var expected []component.Component
+			if !tc.disableResourceViewer {
+				timeline := component.NewTimeline("Timeline", nil)
+
+				componentCache := componentCacheFake.NewMockComponentCache(controller)
+				componentCache.EXPECT().Update(gomock.Any(), gomock.Any()).Return(timeline, nil)
+				dashConfig.EXPECT().ComponentCache().Return(componentCache)
+
+				expected = []component.Component{timeline}
+			}
+
+			d := NewObject(ObjectConfig{DisableResourceViewer: tc.disableResourceViewer})
+
+			cr := component.NewContentResponse(nil)
+			err := d.addTimelineTab(context.Background(), pod, cr, Options{Dash: dashConfig})
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, cr.Components)
+			for _, c := range cr.Components {
+				assert.Equal(t, "timeline", c.GetMetadata().Accessor)
+			}
+		})
+	}
+}
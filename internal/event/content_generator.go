@@ -13,8 +13,10 @@ import (
 
 	"k8s.io/apimachinery/pkg/labels"
 
+	"github.com/vmware/octant/internal/filter"
 	"github.com/vmware/octant/internal/module"
 	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/preferences"
 	"github.com/vmware/octant/pkg/view/component"
 )
 
@@ -36,9 +38,16 @@ type ContentGenerator struct {
 	// LabelSet is a label set to filter any content.
 	LabelSet *labels.Set
 
+	// Filter is a filter expression for lists.
+	Filter *filter.Expression
+
 	// RunEvery is how often the event generator should be run.
 	RunEvery time.Duration
 
+	// Preferences are the user's preferences. A configured content refresh
+	// interval takes precedence over RunEvery. It is optional.
+	Preferences preferences.Interface
+
 	isRunning bool
 	mu        sync.Mutex
 }
@@ -72,7 +81,7 @@ func (g *ContentGenerator) Event(ctx context.Context) (octant.Event, error) {
 }
 
 func (g *ContentGenerator) generateContent(ctx context.Context) (octant.Event, error) {
-	resp, err := g.ResponseFactory(ctx, g.Path, g.Prefix, g.Namespace, module.ContentOptions{LabelSet: g.LabelSet})
+	resp, err := g.ResponseFactory(ctx, g.Path, g.Prefix, g.Namespace, module.ContentOptions{LabelSet: g.LabelSet, Filter: g.Filter})
 	if err != nil {
 		return octant.Event{}, err
 	}
@@ -91,6 +100,12 @@ func (g *ContentGenerator) generateContent(ctx context.Context) (octant.Event, e
 
 // ScheduleDelay returns how long to delay before running this generator again.
 func (g *ContentGenerator) ScheduleDelay() time.Duration {
+	if g.Preferences != nil {
+		if d := g.Preferences.Preferences().Refresh.Content.Duration; d != 0 {
+			return d
+		}
+	}
+
 	return g.RunEvery
 }
 
@@ -14,6 +14,7 @@ import (
 
 	"github.com/vmware/octant/internal/cluster"
 	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/preferences"
 )
 
 type namespacesResponse struct {
@@ -24,6 +25,13 @@ type namespacesResponse struct {
 type NamespacesGenerator struct {
 	// NamespaceClient is a namespaces client.
 	NamespaceClient cluster.NamespaceInterface
+
+	// Preferences are the user's preferences. Namespaces configured for the
+	// current context are listed instead of the cluster's. It is optional.
+	Preferences preferences.Interface
+
+	// ContextName returns the current context name.
+	ContextName func() string
 }
 
 var _ octant.Generator = (*NamespacesGenerator)(nil)
@@ -34,12 +42,30 @@ func (g *NamespacesGenerator) Event(ctx context.Context) (octant.Event, error) {
 		return octant.Event{}, errors.New("unable to query namespaces, client is nil")
 	}
 
+	if names := g.contextNamespaces(); len(names) > 0 {
+		return namespacesEvent(names)
+	}
+
 	names, err := g.NamespaceClient.Names()
 	if err != nil {
 		initialNamespace := g.NamespaceClient.InitialNamespace()
 		names = []string{initialNamespace}
 	}
 
+	return namespacesEvent(names)
+}
+
+// contextNamespaces returns the namespaces configured for the current
+// context.
+func (g *NamespacesGenerator) contextNamespaces() []string {
+	if g.Preferences == nil || g.ContextName == nil {
+		return nil
+	}
+
+	return g.Preferences.Preferences().ContextNamespaces(g.ContextName())
+}
+
+func namespacesEvent(names []string) (octant.Event, error) {
 	nr := &namespacesResponse{Namespaces: names}
 	data, err := json.Marshal(nr)
 	if err != nil {
@@ -53,7 +79,11 @@ func (g *NamespacesGenerator) Event(ctx context.Context) (octant.Event, error) {
 }
 
 // ScheduleDelay returns how long to delay before running this generator again.
-func (NamespacesGenerator) ScheduleDelay() time.Duration {
+func (g NamespacesGenerator) ScheduleDelay() time.Duration {
+	if g.Preferences != nil {
+		return g.Preferences.Preferences().Refresh.NamespacesInterval()
+	}
+
 	return DefaultScheduleDelay
 }
 
@@ -16,6 +16,7 @@ import (
 
 	"github.com/vmware/octant/internal/cluster/fake"
 	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/preferences"
 )
 
 func TestNamespacesGenerator_Event(t *testing.T) {
@@ -46,6 +47,34 @@ func TestNamespacesGenerator_Event(t *testing.T) {
 	assert.Equal(t, expectedData, event.Data)
 }
 
+func TestNamespacesGenerator_Event_contextNamespaces(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	namespaceClient := fake.NewMockNamespaceInterface(controller)
+
+	p := preferences.Default()
+	p.Contexts = map[string]preferences.ContextPreferences{
+		"dev": {Namespaces: []string{"web", "api"}},
+	}
+
+	g := NamespacesGenerator{
+		NamespaceClient: namespaceClient,
+		Preferences:     &stubPreferences{preferences: p},
+		ContextName:     func() string { return "dev" },
+	}
+
+	ctx := context.Background()
+	event, err := g.Event(ctx)
+	require.NoError(t, err)
+
+	expectedData, err := json.Marshal(&namespacesResponse{Namespaces: []string{"web", "api"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedData, event.Data)
+	assert.Equal(t, preferences.DefaultRefresh, g.ScheduleDelay())
+}
+
 func TestNamespacesGenerator_ScheduleDelay(t *testing.T) {
 	g := NamespacesGenerator{
 	}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/vmware/octant/internal/module"
 	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/preferences"
 	"github.com/vmware/octant/pkg/navigation"
 )
 
@@ -33,6 +34,10 @@ type NavigationGenerator struct {
 
 	// RunEvery is how often the event generator should be run.
 	RunEvery time.Duration
+
+	// Preferences are the user's preferences. They hide navigation sections
+	// and set the refresh interval. It is optional.
+	Preferences preferences.Interface
 }
 
 var _ octant.Generator = (*NavigationGenerator)(nil)
@@ -46,6 +51,10 @@ func (g *NavigationGenerator) Event(ctx context.Context) (octant.Event, error) {
 		return octant.Event{}, err
 	}
 
+	if g.Preferences != nil {
+		ns = g.Preferences.Preferences().FilterNavigation(ns)
+	}
+
 	nr := navigationResponse{
 		Sections: ns,
 	}
@@ -63,6 +72,10 @@ func (g *NavigationGenerator) Event(ctx context.Context) (octant.Event, error) {
 
 // ScheduleDelay returns how long to delay before running this generator again.
 func (g *NavigationGenerator) ScheduleDelay() time.Duration {
+	if g.Preferences != nil {
+		return g.Preferences.Preferences().Refresh.NavigationInterval()
+	}
+
 	return DefaultScheduleDelay
 }
 
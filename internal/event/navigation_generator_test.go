@@ -17,6 +17,7 @@ import (
 	"github.com/vmware/octant/internal/module"
 	"github.com/vmware/octant/internal/module/fake"
 	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/preferences"
 	"github.com/vmware/octant/pkg/navigation"
 )
 
@@ -64,6 +65,39 @@ func TestNavigationGenerator_Event(t *testing.T) {
 	assert.Equal(t, expectedData, event.Data)
 }
 
+func TestNavigationGenerator_Event_hidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	mod := fake.NewMockModule(controller)
+	mod.EXPECT().Name().Return("module").AnyTimes()
+	mod.EXPECT().ContentPath().Return("/module").AnyTimes()
+	mod.EXPECT().
+		Navigation(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]navigation.Navigation{
+			{Path: "/content/module", Title: "module"},
+			{Path: "/content/hidden", Title: "Hidden"},
+		}, nil)
+
+	p := preferences.Default()
+	p.HiddenNavigation = []string{"hidden"}
+
+	g := NavigationGenerator{
+		Modules:     []module.Module{mod},
+		Preferences: &stubPreferences{preferences: p},
+	}
+
+	event, err := g.Event(context.Background())
+	require.NoError(t, err)
+
+	expectedData, err := json.Marshal(&navigationResponse{
+		Sections: []navigation.Navigation{{Path: "/content/module", Title: "module"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedData, event.Data)
+}
+
 func TestNavigationGenerator_ScheduleDelay(t *testing.T) {
 	g := NavigationGenerator{
 		RunEvery: DefaultScheduleDelay,
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/preferences"
+)
+
+// PreferencesGenerator generates preferences events. Unchanged preferences
+// aren't streamed again, so the frontend sees edits to the configuration
+// file shortly after they are made.
+type PreferencesGenerator struct {
+	// Preferences are the user's preferences.
+	Preferences preferences.Interface
+}
+
+var _ octant.Generator = (*PreferencesGenerator)(nil)
+
+// Event generates a preferences event.
+func (g *PreferencesGenerator) Event(ctx context.Context) (octant.Event, error) {
+	if g.Preferences == nil {
+		return octant.Event{}, errors.New("unable to generate preferences, preferences is nil")
+	}
+
+	data, err := json.Marshal(g.Preferences.Preferences())
+	if err != nil {
+		return octant.Event{}, errors.Wrap(err, "encoding preferences")
+	}
+
+	return octant.Event{
+		Type: octant.EventTypePreferences,
+		Data: data,
+	}, nil
+}
+
+// ScheduleDelay returns how long to delay before running this generator again.
+func (PreferencesGenerator) ScheduleDelay() time.Duration {
+	return DefaultScheduleDelay
+}
+
+// Name returns the generator's name.
+func (PreferencesGenerator) Name() string {
+	return "preferences"
+}
@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/preferences"
+)
+
+type stubPreferences struct {
+	preferences preferences.Preferences
+}
+
+var _ preferences.Interface = (*stubPreferences)(nil)
+
+func (s *stubPreferences) Preferences() preferences.Preferences {
+	return s.preferences
+}
+
+func (s *stubPreferences) Update(p preferences.Preferences) error {
+	s.preferences = p
+	return nil
+}
+
+func TestPreferencesGenerator_Event(t *testing.T) {
+	p := preferences.Default()
+	p.Theme = preferences.ThemeDark
+
+	g := PreferencesGenerator{
+		Preferences: &stubPreferences{preferences: p},
+	}
+
+	event, err := g.Event(context.Background())
+	require.NoError(t, err)
+
+	expectedData, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	assert.Equal(t, octant.EventTypePreferences, event.Type)
+	assert.Equal(t, expectedData, event.Data)
+	assert.Equal(t, "preferences", g.Name())
+}
+
+func TestPreferencesGenerator_Event_nil(t *testing.T) {
+	g := PreferencesGenerator{}
+
+	_, err := g.Event(context.Background())
+	require.Error(t, err)
+}
+
+func TestContentGenerator_ScheduleDelay_preferences(t *testing.T) {
+	prefs := &stubPreferences{preferences: preferences.Default()}
+	g := ContentGenerator{
+		RunEvery:    3 * time.Second,
+		Preferences: prefs,
+	}
+
+	assert.Equal(t, 3*time.Second, g.ScheduleDelay())
+
+	prefs.preferences.Refresh.Content = metav1.Duration{Duration: time.Minute}
+	assert.Equal(t, time.Minute, g.ScheduleDelay())
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package filter implements filter expressions for lists of objects, e.g.
+// `status.phase!=Running and age>1h`.
+//
+// An expression is one or more predicates joined by "and". A predicate
+// compares an operand with a value:
+//
+//	labels.app=web                  label equality (also !=)
+//	labels.tier in (web, api)       set based labels (also notin)
+//	labels.canary, !labels.canary   label exists, label doesn't exist
+//	annotations.<key>               annotations, like labels
+//	status.phase!=Running           field paths
+//	spec.replicas>=3                numbers and quantities, e.g. 512Mi
+//	{.spec.containers[*].image}=nginx  JSONPath, any result can match
+//	age>1h, age<=2d                 time since the object was created
+//	status=error                    Octant's object status: ok, warning or error
+//	name=web, namespace=default     shorthand for metadata fields, as is kind
+package filter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// StatusFunc returns the status of an object. It is one of ok, warning or
+// error.
+type StatusFunc func(ctx context.Context, object *unstructured.Unstructured) (string, error)
+
+// Env is the environment an expression is evaluated in.
+type Env struct {
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+	// Status returns the status of an object. Expressions using status
+	// can't be evaluated without it.
+	Status StatusFunc
+}
+
+func (env Env) now() time.Time {
+	if env.Now == nil {
+		return time.Now()
+	}
+	return env.Now()
+}
+
+// Expression is a parsed filter expression.
+type Expression struct {
+	source     string
+	predicates []predicate
+}
+
+// Parse parses a filter expression. Errors are returned as *SyntaxError.
+func Parse(s string) (*Expression, error) {
+	source := strings.TrimSpace(s)
+	if source == "" {
+		return nil, &SyntaxError{Expression: s, Message: "expression is blank"}
+	}
+
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{source: source, tokens: tokens}
+	predicates, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Expression{
+		source:     source,
+		predicates: predicates,
+	}, nil
+}
+
+// String returns the expression's source.
+func (e *Expression) String() string {
+	if e == nil {
+		return ""
+	}
+	return e.source
+}
+
+// Match returns true if the object matches every predicate.
+func (e *Expression) Match(ctx context.Context, object *unstructured.Unstructured, env Env) (bool, error) {
+	for _, pr := range e.predicates {
+		ok, err := pr.match(ctx, object, env)
+		if err != nil {
+			return false, errors.Wrapf(err, "evaluate %s", pr.operand)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Filter returns the objects which match the expression. A nil expression
+// matches every object.
+func (e *Expression) Filter(ctx context.Context, objects []*unstructured.Unstructured, env Env) ([]*unstructured.Unstructured, error) {
+	if e == nil {
+		return objects, nil
+	}
+
+	var matched []*unstructured.Unstructured
+	for _, object := range objects {
+		ok, err := e.Match(ctx, object, env)
+		if err != nil {
+			return nil, errors.Wrapf(err, "filter %s %s", object.GetKind(), object.GetName())
+		}
+		if ok {
+			matched = append(matched, object)
+		}
+	}
+
+	return matched, nil
+}
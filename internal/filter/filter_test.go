@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware/octant/internal/testutil"
+)
+
+var now = time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+
+func testPod(t *testing.T) *unstructured.Unstructured {
+	pod := testutil.CreatePod("web-1")
+	pod.CreationTimestamp = metav1.NewTime(now.Add(-2 * time.Hour))
+	pod.Labels = map[string]string{"app": "web", "app.kubernetes.io/tier": "frontend"}
+	pod.Annotations = map[string]string{"owner": "payments"}
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name:  "web",
+			Image: "nginx",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+			},
+		},
+		{Name: "sidecar", Image: "envoy"},
+	}
+	pod.Status.Phase = corev1.PodPending
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "web", RestartCount: 4}}
+
+	return testutil.ToUnstructured(t, pod)
+}
+
+func testEnv(status string) Env {
+	return Env{
+		Now: func() time.Time { return now },
+		Status: func(context.Context, *unstructured.Unstructured) (string, error) {
+			return status, nil
+		},
+	}
+}
+
+func TestExpression_Match(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{expression: "status.phase!=Running and age>1h", expected: true},
+		{expression: "status.phase=Running", expected: false},
+		{expression: "status.phase == Pending", expected: true},
+		{expression: "labels.app=web", expected: true},
+		{expression: "labels.app!=web", expected: false},
+		{expression: "labels.app in (web, api)", expected: true},
+		{expression: "labels.app notin (web, api)", expected: false},
+		{expression: "labels.app.kubernetes.io/tier=frontend", expected: true},
+		{expression: "metadata.labels.app=web", expected: true},
+		{expression: "labels.canary", expected: false},
+		{expression: "!labels.canary", expected: true},
+		{expression: "labels.canary!=true", expected: true},
+		{expression: "annotations.owner='payments'", expected: true},
+		{expression: "name=web-1 and namespace=namespace and kind=Pod", expected: true},
+		{expression: "spec.containers", expected: true},
+		{expression: "spec.containers=nginx", expected: false},
+		{expression: "{.spec.containers[*].image}=envoy", expected: true},
+		{expression: "{.spec.containers[*].image}!=envoy", expected: false},
+		{expression: "{.status.containerStatuses[*].restartCount}>3", expected: true},
+		{expression: "{.spec.containers[0].resources.limits.memory}>=256Mi", expected: true},
+		{expression: "{.spec.containers[0].resources.limits.memory}<256Mi", expected: false},
+		{expression: "{.spec.nodeName}", expected: false},
+		{expression: "age>1h", expected: true},
+		{expression: "age<=1d", expected: true},
+		{expression: "age>1d12h", expected: false},
+		{expression: "status=warning", expected: true},
+		{expression: "status in (ok, ERROR)", expected: false},
+	}
+
+	pod := testPod(t)
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			e, err := Parse(test.expression)
+			require.NoError(t, err)
+
+			got, err := e.Match(context.Background(), pod, testEnv("warning"))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{expression: "", expected: "expression is blank"},
+		{expression: "status.phase!=", expected: `expected value after "!=", got end of expression at position 15: status.phase!= <--`},
+		{expression: "age>soon", expected: `invalid age "soon", expected a duration such as 30m, 12h or 7d at position 5: age>s <--`},
+		{expression: "age=1h", expected: "age can only be compared with"},
+		{expression: "status>ok", expected: "status can only be compared with =, !=, in or notin"},
+		{expression: "status=broken", expected: `unknown status "broken", expected one of ok, warning, error`},
+		{expression: "spec.replicas>three", expected: `"three" is not a number, > needs a number or quantity`},
+		{expression: "labels.app in web", expected: `expected "(" after "in"`},
+		{expression: "labels.app in (web", expected: `expected "," or ")" in set, got end of expression`},
+		{expression: "labels.app=web labels.tier=api", expected: `expected "and" or end of expression, got "labels.tier"`},
+		{expression: "labels.app=web or labels.app=api", expected: `"or" is not supported`},
+		{expression: "labels.=web", expected: `"labels." needs a key`},
+		{expression: "spec.containers[0].image=nginx", expected: "invalid field path"},
+		{expression: "{.spec.containers[}=x", expected: "invalid JSONPath"},
+		{expression: "{.spec", expected: "unterminated JSONPath"},
+		{expression: "name='web", expected: "unterminated string"},
+		{expression: "=web", expected: "expected field, label, annotation, JSONPath, age or status, got operator"},
+		{expression: "!age", expected: "age can only be compared with"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			_, err := Parse(test.expression)
+			require.Error(t, err)
+
+			_, ok := err.(*SyntaxError)
+			assert.True(t, ok, "error is a %T", err)
+			assert.Contains(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestExpression_Filter(t *testing.T) {
+	pod := testPod(t)
+	other := testutil.ToUnstructured(t, testutil.CreatePod("db-1"))
+
+	e, err := Parse("labels.app=web")
+	require.NoError(t, err)
+
+	got, err := e.Filter(context.Background(), []*unstructured.Unstructured{pod, other}, testEnv("ok"))
+	require.NoError(t, err)
+	assert.Equal(t, []*unstructured.Unstructured{pod}, got)
+
+	var nilExpression *Expression
+	got, err = nilExpression.Filter(context.Background(), []*unstructured.Unstructured{pod, other}, Env{})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+}
+
+func TestExpression_status_errors(t *testing.T) {
+	e, err := Parse("status=ok")
+	require.NoError(t, err)
+
+	_, err = e.Match(context.Background(), testPod(t), Env{})
+	assert.Error(t, err)
+
+	env := Env{
+		Status: func(context.Context, *unstructured.Unstructured) (string, error) {
+			return "", errors.New("failed")
+		},
+	}
+	_, err = e.Match(context.Background(), testPod(t), env)
+	assert.Error(t, err)
+}
+
+func Test_parseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"90s":   90 * time.Second,
+		"7d":    7 * 24 * time.Hour,
+		"1d12h": 36 * time.Hour,
+	}
+
+	for s, expected := range tests {
+		got, err := parseDuration(s)
+		require.NoError(t, err)
+		assert.Equal(t, expected, got, s)
+	}
+
+	_, err := parseDuration("1w")
+	assert.Error(t, err)
+}
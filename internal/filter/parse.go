@@ -0,0 +1,312 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SyntaxError is an error in a filter expression.
+type SyntaxError struct {
+	// Expression is the expression which was parsed.
+	Expression string
+	// Pos is the byte offset of the error in the expression.
+	Pos int
+	// Message describes the error.
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d: %s", e.Message, e.Pos+1, e.context())
+}
+
+// context returns the expression up to and including the error position
+// so the user can see where parsing stopped.
+func (e *SyntaxError) context() string {
+	end := e.Pos + 1
+	if end > len(e.Expression) {
+		end = len(e.Expression)
+	}
+
+	return strings.TrimSpace(e.Expression[:end]) + " <--"
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenJSONPath
+	tokenOperator
+	tokenNot
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of expression"
+	case tokenWord, tokenString:
+		return "value"
+	case tokenJSONPath:
+		return "JSONPath"
+	case tokenOperator:
+		return "operator"
+	case tokenNot:
+		return `"!"`
+	case tokenLeftParen:
+		return `"("`
+	case tokenRightParen:
+		return `")"`
+	case tokenComma:
+		return `","`
+	default:
+		return "token"
+	}
+}
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// isKeyword returns true if the token is the keyword. Keywords are case
+// insensitive.
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.value, keyword)
+}
+
+// operators are ordered so two character operators are matched first.
+var operators = []string{"==", "!=", ">=", "<=", "=", ">", "<"}
+
+const specialChars = `=!<>(),"'{}`
+
+// lex splits an expression into tokens.
+func lex(s string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+			continue
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "(", pos: i})
+			i++
+			continue
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")", pos: i})
+			i++
+			continue
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, value: ",", pos: i})
+			i++
+			continue
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				return nil, &SyntaxError{Expression: s, Pos: i, Message: "unterminated string"}
+			}
+			tokens = append(tokens, token{kind: tokenString, value: s[i+1 : i+1+end], pos: i})
+			i += end + 2
+			continue
+		case c == '{':
+			end, err := matchBrace(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenJSONPath, value: s[i : end+1], pos: i})
+			i = end + 1
+			continue
+		case c == '}':
+			return nil, &SyntaxError{Expression: s, Pos: i, Message: `unexpected "}"`}
+		}
+
+		if op := matchOperator(s[i:]); op != "" {
+			tokens = append(tokens, token{kind: tokenOperator, value: op, pos: i})
+			i += len(op)
+			continue
+		}
+
+		if c == '!' {
+			tokens = append(tokens, token{kind: tokenNot, value: "!", pos: i})
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && !unicode.IsSpace(rune(s[i])) && !strings.ContainsRune(specialChars, rune(s[i])) {
+			i++
+		}
+		tokens = append(tokens, token{kind: tokenWord, value: s[start:i], pos: start})
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(s)})
+
+	return tokens, nil
+}
+
+func matchOperator(s string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+// matchBrace returns the position of the brace which closes the brace at
+// start. Braces in JSONPath filters can be nested.
+func matchBrace(s string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, &SyntaxError{Expression: s, Pos: start, Message: "unterminated JSONPath"}
+}
+
+// parser parses tokens into predicates:
+//
+//	expression := predicate { "and" predicate }
+//	predicate  := [ "!" ] operand
+//	            | operand operator value
+//	            | operand ( "in" | "notin" ) "(" value { "," value } ")"
+type parser struct {
+	source string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return &SyntaxError{Expression: p.source, Pos: t.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseExpression() ([]predicate, error) {
+	var predicates []predicate
+
+	for {
+		pr, err := p.parsePredicate()
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, pr)
+
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return predicates, nil
+		case t.isKeyword("and"):
+			continue
+		case t.isKeyword("or"):
+			return nil, p.errorf(t, `"or" is not supported, join predicates with "and"`)
+		default:
+			return nil, p.errorf(t, `expected "and" or end of expression, got %q`, t.value)
+		}
+	}
+}
+
+func (p *parser) parsePredicate() (predicate, error) {
+	t := p.next()
+
+	if t.kind == tokenNot {
+		operandToken := p.next()
+		o, err := p.parseOperand(operandToken)
+		if err != nil {
+			return predicate{}, err
+		}
+		return o.validate(p, predicate{operand: o, op: opNotExists}, t)
+	}
+
+	o, err := p.parseOperand(t)
+	if err != nil {
+		return predicate{}, err
+	}
+
+	t = p.peek()
+	switch {
+	case t.kind == tokenEOF, t.isKeyword("and"), t.isKeyword("or"):
+		return o.validate(p, predicate{operand: o, op: opExists}, t)
+	case t.isKeyword("in"), t.isKeyword("notin"):
+		p.next()
+		values, err := p.parseSet(t)
+		if err != nil {
+			return predicate{}, err
+		}
+		op := opIn
+		if t.isKeyword("notin") {
+			op = opNotIn
+		}
+		return o.validate(p, predicate{operand: o, op: op, values: values}, t)
+	case t.kind == tokenOperator:
+		p.next()
+		valueToken := p.next()
+		if valueToken.kind != tokenWord && valueToken.kind != tokenString {
+			return predicate{}, p.errorf(valueToken, "expected value after %q, got %s", t.value, valueToken.kind)
+		}
+		op := operator(t.value)
+		if op == "==" {
+			op = opEqual
+		}
+		return o.validate(p, predicate{operand: o, op: op, values: []string{valueToken.value}}, valueToken)
+	default:
+		return predicate{}, p.errorf(t, "expected operator, \"in\" or \"notin\" after %q, got %q", o.String(), t.value)
+	}
+}
+
+func (p *parser) parseSet(start token) ([]string, error) {
+	if t := p.next(); t.kind != tokenLeftParen {
+		return nil, p.errorf(t, "expected \"(\" after %q", start.value)
+	}
+
+	var values []string
+	for {
+		t := p.next()
+		if t.kind != tokenWord && t.kind != tokenString {
+			return nil, p.errorf(t, "expected value in set, got %s", t.kind)
+		}
+		values = append(values, t.value)
+
+		t = p.next()
+		switch t.kind {
+		case tokenComma:
+			continue
+		case tokenRightParen:
+			return values, nil
+		default:
+			return nil, p.errorf(t, `expected "," or ")" in set, got %s`, t.kind)
+		}
+	}
+}
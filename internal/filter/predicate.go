@@ -0,0 +1,394 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+type operator string
+
+const (
+	opEqual        operator = "="
+	opNotEqual     operator = "!="
+	opGreater      operator = ">"
+	opGreaterEqual operator = ">="
+	opLess         operator = "<"
+	opLessEqual    operator = "<="
+	opIn           operator = "in"
+	opNotIn        operator = "notin"
+	opExists       operator = "exists"
+	opNotExists    operator = "!"
+)
+
+func (op operator) isOrdering() bool {
+	switch op {
+	case opGreater, opGreaterEqual, opLess, opLessEqual:
+		return true
+	default:
+		return false
+	}
+}
+
+// compare returns true if cmp, the result of comparing a found value with
+// the predicate value, satisfies an ordering operator.
+func (op operator) compare(cmp int) bool {
+	switch op {
+	case opGreater:
+		return cmp > 0
+	case opGreaterEqual:
+		return cmp >= 0
+	case opLess:
+		return cmp < 0
+	case opLessEqual:
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+type operandKind int
+
+const (
+	operandField operandKind = iota
+	operandLabel
+	operandAnnotation
+	operandJSONPath
+	operandAge
+	operandStatus
+)
+
+// operand is what a predicate compares.
+type operand struct {
+	kind   operandKind
+	source string
+	key    string
+	path   []string
+
+	// jsonPath isn't safe for concurrent use.
+	jsonPath *jsonpath.JSONPath
+	mu       *sync.Mutex
+}
+
+func (o operand) String() string {
+	return o.source
+}
+
+var (
+	fieldSegmentRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	durationDaysRe = regexp.MustCompile(`^(\d+)d(.*)$`)
+)
+
+// statuses are the values of status predicates.
+var statuses = []string{"ok", "warning", "error"}
+
+// shorthands are operands for common fields.
+var shorthands = map[string][]string{
+	"name":      {"metadata", "name"},
+	"namespace": {"metadata", "namespace"},
+	"kind":      {"kind"},
+}
+
+func (p *parser) parseOperand(t token) (operand, error) {
+	switch t.kind {
+	case tokenJSONPath:
+		j := jsonpath.New("filter").AllowMissingKeys(true)
+		if err := j.Parse(t.value); err != nil {
+			return operand{}, p.errorf(t, "invalid JSONPath %s: %v", t.value, err)
+		}
+		return operand{kind: operandJSONPath, source: t.value, jsonPath: j, mu: &sync.Mutex{}}, nil
+	case tokenWord:
+	default:
+		return operand{}, p.errorf(t, "expected field, label, annotation, JSONPath, age or status, got %s", t.kind)
+	}
+
+	o := operand{source: t.value}
+
+	switch {
+	case t.value == "age":
+		o.kind = operandAge
+		return o, nil
+	case t.value == "status":
+		o.kind = operandStatus
+		return o, nil
+	case shorthands[t.value] != nil:
+		o.kind = operandField
+		o.path = shorthands[t.value]
+		return o, nil
+	}
+
+	for _, prefix := range []string{"labels.", "metadata.labels."} {
+		if strings.HasPrefix(t.value, prefix) {
+			o.kind = operandLabel
+			o.key = strings.TrimPrefix(t.value, prefix)
+		}
+	}
+	for _, prefix := range []string{"annotations.", "metadata.annotations."} {
+		if strings.HasPrefix(t.value, prefix) {
+			o.kind = operandAnnotation
+			o.key = strings.TrimPrefix(t.value, prefix)
+		}
+	}
+	if o.kind == operandLabel || o.kind == operandAnnotation {
+		if o.key == "" {
+			return operand{}, p.errorf(t, "%q needs a key", t.value)
+		}
+		return o, nil
+	}
+
+	o.kind = operandField
+	o.path = strings.Split(t.value, ".")
+	for _, segment := range o.path {
+		if !fieldSegmentRe.MatchString(segment) {
+			return operand{}, p.errorf(t, "invalid field path %q, use a JSONPath such as {.spec.containers[0].image} to select list items", t.value)
+		}
+	}
+
+	return o, nil
+}
+
+// predicate is a comparison of an operand with values.
+type predicate struct {
+	operand operand
+	op      operator
+	values  []string
+
+	durations  []time.Duration
+	quantities []resource.Quantity
+}
+
+// validate checks the values of a predicate can be compared with its
+// operand, and parses them. t is the token errors are reported at.
+func (o operand) validate(p *parser, pr predicate, t token) (predicate, error) {
+	switch o.kind {
+	case operandAge:
+		if !pr.op.isOrdering() {
+			return predicate{}, p.errorf(t, "age can only be compared with >, >=, < or <=, e.g. age>1h")
+		}
+		for _, value := range pr.values {
+			d, err := parseDuration(value)
+			if err != nil {
+				return predicate{}, p.errorf(t, "invalid age %q, expected a duration such as 30m, 12h or 7d", value)
+			}
+			pr.durations = append(pr.durations, d)
+		}
+		return pr, nil
+	case operandStatus:
+		if pr.op.isOrdering() || pr.op == opExists || pr.op == opNotExists {
+			return predicate{}, p.errorf(t, "status can only be compared with =, !=, in or notin")
+		}
+		for i, value := range pr.values {
+			value = strings.ToLower(value)
+			if !containsString(statuses, value) {
+				return predicate{}, p.errorf(t, "unknown status %q, expected one of %s", value, strings.Join(statuses, ", "))
+			}
+			pr.values[i] = value
+		}
+		return pr, nil
+	}
+
+	if pr.op.isOrdering() {
+		for _, value := range pr.values {
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				return predicate{}, p.errorf(t, "%q is not a number, %s needs a number or quantity", value, pr.op)
+			}
+			pr.quantities = append(pr.quantities, q)
+		}
+	}
+
+	return pr, nil
+}
+
+func (pr predicate) match(ctx context.Context, object *unstructured.Unstructured, env Env) (bool, error) {
+	switch pr.operand.kind {
+	case operandAge:
+		return pr.matchAge(object, env), nil
+	case operandStatus:
+		if env.Status == nil {
+			return false, errors.New("object status is not available")
+		}
+		status, err := env.Status(ctx, object)
+		if err != nil {
+			return false, err
+		}
+		return pr.matchValues([]string{status}, true), nil
+	}
+
+	values, exists, err := pr.operand.find(object)
+	if err != nil {
+		return false, err
+	}
+
+	return pr.matchValues(values, exists), nil
+}
+
+func (pr predicate) matchAge(object *unstructured.Unstructured, env Env) bool {
+	created := object.GetCreationTimestamp()
+	if created.IsZero() {
+		return false
+	}
+
+	age := env.now().Sub(created.Time)
+	want := pr.durations[0]
+
+	switch {
+	case age > want:
+		return pr.op.compare(1)
+	case age < want:
+		return pr.op.compare(-1)
+	default:
+		return pr.op.compare(0)
+	}
+}
+
+// matchValues matches the values found for the operand. Positive
+// operators match if any value matches, negative operators if none do.
+func (pr predicate) matchValues(found []string, exists bool) bool {
+	switch pr.op {
+	case opExists:
+		return exists
+	case opNotExists:
+		return !exists
+	case opEqual, opIn:
+		return anyIn(found, pr.values)
+	case opNotEqual, opNotIn:
+		return !anyIn(found, pr.values)
+	}
+
+	want := pr.quantities[0]
+	for _, value := range found {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			continue
+		}
+		if pr.op.compare(q.Cmp(want)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// find returns the scalar values of the operand in an object. exists is
+// true if the operand is set, even if it isn't a scalar.
+func (o operand) find(object *unstructured.Unstructured) (values []string, exists bool, err error) {
+	switch o.kind {
+	case operandLabel:
+		value, ok := object.GetLabels()[o.key]
+		if !ok {
+			return nil, false, nil
+		}
+		return []string{value}, true, nil
+	case operandAnnotation:
+		value, ok := object.GetAnnotations()[o.key]
+		if !ok {
+			return nil, false, nil
+		}
+		return []string{value}, true, nil
+	case operandField:
+		value, ok, err := unstructured.NestedFieldNoCopy(object.Object, o.path...)
+		if err != nil || !ok || value == nil {
+			// a path through a scalar isn't set.
+			return nil, false, nil
+		}
+		if s, ok := scalarString(value); ok {
+			return []string{s}, true, nil
+		}
+		return nil, true, nil
+	case operandJSONPath:
+		o.mu.Lock()
+		results, err := o.jsonPath.FindResults(object.Object)
+		o.mu.Unlock()
+		if err != nil {
+			return nil, false, err
+		}
+		for _, result := range results {
+			for _, v := range result {
+				if !v.IsValid() || !v.CanInterface() || v.Interface() == nil {
+					continue
+				}
+				exists = true
+				if s, ok := scalarString(v.Interface()); ok {
+					values = append(values, s)
+				}
+			}
+		}
+		return values, exists, nil
+	default:
+		return nil, false, errors.Errorf("unknown operand %s", o.source)
+	}
+}
+
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case int:
+		return strconv.Itoa(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return fmt.Sprint(v), false
+	}
+}
+
+// parseDuration parses a Go duration which can start with a number of
+// days, e.g. 7d or 1d12h.
+func parseDuration(s string) (time.Duration, error) {
+	var days time.Duration
+	if match := durationDaysRe.FindStringSubmatch(s); match != nil {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, err
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		if match[2] == "" {
+			return days, nil
+		}
+		s = match[2]
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	return days + d, nil
+}
+
+func anyIn(found, values []string) bool {
+	for _, value := range found {
+		if containsString(values, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
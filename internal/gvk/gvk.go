@@ -20,8 +20,11 @@ var (
 	DeploymentGVK               = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
 	ExtReplicaSet               = schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "ReplicaSet"}
 	Event                       = schema.GroupVersionKind{Version: "v1", Kind: "Event"}
+	HorizontalPodAutoscalerGVK  = schema.GroupVersionKind{Group: "autoscaling", Version: "v1", Kind: "HorizontalPodAutoscaler"}
 	IngressGVK                  = schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}
 	JobGVK                      = schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+	NetworkPolicyGVK            = schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"}
+	NodeGVK                     = schema.GroupVersionKind{Version: "v1", Kind: "Node"}
 	ServiceAccountGVK           = schema.GroupVersionKind{Version: "v1", Kind: "ServiceAccount"}
 	SecretGVK                   = schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
 	ServiceGVK                  = schema.GroupVersionKind{Version: "v1", Kind: "Service"}
@@ -31,4 +34,8 @@ var (
 	StatefulSetGVK              = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}
 	RoleBindingGVK              = schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"}
 	RoleGVK                     = schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"}
+
+	// HelmReleaseGVK identifies Helm releases. Releases are not Kubernetes objects; they
+	// are decoded from release secrets.
+	HelmReleaseGVK = schema.GroupVersionKind{Group: "helm.sh", Version: "v3", Kind: "Release"}
 )
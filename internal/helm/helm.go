@@ -0,0 +1,357 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	// OwnerLabel is the label Helm v3 sets on release secrets.
+	OwnerLabel = "owner"
+	// OwnerValue is the value of the owner label on release secrets.
+	OwnerValue = "helm"
+	// ReleaseNameAnnotation is set on objects created by Helm 3.2 and later.
+	ReleaseNameAnnotation = "meta.helm.sh/release-name"
+	// ReleaseNamespaceAnnotation is set on objects created by Helm 3.2 and later.
+	ReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+
+	releaseKey = "release"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// Release is a Helm release.
+type Release struct {
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Version   int                    `json:"version"`
+	Info      Info                   `json:"info"`
+	Chart     Chart                  `json:"chart"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+	Manifest  string                 `json:"manifest,omitempty"`
+}
+
+// Info describes a release deployment.
+type Info struct {
+	FirstDeployed Time   `json:"first_deployed,omitempty"`
+	LastDeployed  Time   `json:"last_deployed,omitempty"`
+	Deleted       Time   `json:"deleted,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Status        string `json:"status,omitempty"`
+	Notes         string `json:"notes,omitempty"`
+}
+
+// Chart is the chart a release was installed from.
+type Chart struct {
+	Metadata ChartMetadata          `json:"metadata"`
+	Values   map[string]interface{} `json:"values,omitempty"`
+}
+
+// ChartMetadata is a chart's metadata.
+type ChartMetadata struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion,omitempty"`
+	Description string `json:"description,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+}
+
+// Time is a timestamp in a release. Helm encodes zero times as empty strings.
+type Time struct {
+	time.Time
+}
+
+// UnmarshalJSON unmarshals a Helm timestamp.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if string(data) == `""` || string(data) == "null" {
+		*t = Time{}
+		return nil
+	}
+
+	return json.Unmarshal(data, &t.Time)
+}
+
+// ChartName returns the chart name and version in the same format as `helm list`.
+func (r *Release) ChartName() string {
+	return r.Chart.Metadata.Name + "-" + r.Chart.Metadata.Version
+}
+
+// ComputedValues returns the values the release was rendered with: the
+// user-supplied values merged over the chart's default values. Like Helm, a
+// null user-supplied value removes the default.
+func (r *Release) ComputedValues() map[string]interface{} {
+	return coalesceValues(r.Config, r.Chart.Values)
+}
+
+// coalesceValues merges values over defaults. Nested maps are merged, and
+// other values replace the default. Neither map is modified.
+func coalesceValues(values, defaults map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults)+len(values))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+
+	for key, value := range values {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+
+		valueMap, isMap := value.(map[string]interface{})
+		defaultMap, defaultIsMap := merged[key].(map[string]interface{})
+		if isMap && defaultIsMap {
+			merged[key] = coalesceValues(valueMap, defaultMap)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// History is the revisions of a release ordered from newest to oldest.
+type History struct {
+	Name      string
+	Namespace string
+	Revisions []Release
+}
+
+// Latest returns the newest revision of a release.
+func (h *History) Latest() *Release {
+	if len(h.Revisions) == 0 {
+		return nil
+	}
+	return &h.Revisions[0]
+}
+
+// DecodeRelease decodes the contents of a release secret's data. The data is
+// base64 encoded and optionally gzipped JSON.
+func DecodeRelease(data []byte) (*Release, error) {
+	b, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode base64 release")
+	}
+
+	if bytes.HasPrefix(b, gzipMagic) {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return nil, errors.Wrap(err, "create gzip reader")
+		}
+		defer r.Close()
+
+		b, err = ioutil.ReadAll(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "decompress release")
+		}
+	}
+
+	var release Release
+	if err := json.Unmarshal(b, &release); err != nil {
+		return nil, errors.Wrap(err, "decode release JSON")
+	}
+
+	return &release, nil
+}
+
+// ReleaseFromSecret decodes a release from a release secret.
+func ReleaseFromSecret(secret *corev1.Secret) (*Release, error) {
+	if secret == nil {
+		return nil, errors.New("secret is nil")
+	}
+
+	data, ok := secret.Data[releaseKey]
+	if !ok {
+		return nil, errors.Errorf("secret %s does not contain a release", secret.Name)
+	}
+
+	return DecodeRelease(data)
+}
+
+// Histories loads the releases in a namespace and groups them by release name.
+// Secrets which can't be decoded are skipped.
+func Histories(ctx context.Context, objectStore store.Store, namespace string) ([]History, error) {
+	if objectStore == nil {
+		return nil, errors.New("object store is nil")
+	}
+
+	selector := labels.Set{OwnerLabel: OwnerValue}
+	key := store.Key{
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Selector:   &selector,
+	}
+
+	objects, err := objectStore.List(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "list release secrets")
+	}
+
+	logger := log.From(ctx)
+
+	var releases []Release
+	for _, object := range objects {
+		secret := &corev1.Secret{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, secret); err != nil {
+			return nil, errors.Wrap(err, "convert object to secret")
+		}
+
+		release, err := ReleaseFromSecret(secret)
+		if err != nil {
+			logger.WithErr(err).With("secret", secret.Name).Debugf("unable to decode Helm release")
+			continue
+		}
+
+		if release.Namespace == "" {
+			release.Namespace = secret.Namespace
+		}
+
+		releases = append(releases, *release)
+	}
+
+	return GroupReleases(releases), nil
+}
+
+// ReleaseHistory loads the history for a single release.
+func ReleaseHistory(ctx context.Context, objectStore store.Store, namespace, name string) (*History, error) {
+	histories, err := Histories(ctx, objectStore, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range histories {
+		if histories[i].Name == name {
+			return &histories[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("release %q not found", name)
+}
+
+// GroupReleases groups releases by name. Histories are sorted by name and
+// revisions are sorted from newest to oldest.
+func GroupReleases(releases []Release) []History {
+	m := make(map[string]*History)
+	var names []string
+
+	for _, release := range releases {
+		h, ok := m[release.Name]
+		if !ok {
+			h = &History{Name: release.Name, Namespace: release.Namespace}
+			m[release.Name] = h
+			names = append(names, release.Name)
+		}
+		h.Revisions = append(h.Revisions, release)
+	}
+
+	sort.Strings(names)
+
+	var histories []History
+	for _, name := range names {
+		h := m[name]
+		sort.Slice(h.Revisions, func(i, j int) bool {
+			return h.Revisions[i].Version > h.Revisions[j].Version
+		})
+		histories = append(histories, *h)
+	}
+
+	return histories
+}
+
+// ManifestObject identifies an object rendered in a release manifest.
+type ManifestObject struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// ManifestObjects parses a release manifest and returns the objects it contains.
+// Objects without a namespace are assigned the release namespace.
+func ManifestObjects(release *Release) ([]ManifestObject, error) {
+	if release == nil {
+		return nil, errors.New("release is nil")
+	}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(release.Manifest), 4096)
+
+	var objects []ManifestObject
+	for {
+		var m map[string]interface{}
+		if err := decoder.Decode(&m); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "decode release manifest")
+		}
+
+		if len(m) == 0 {
+			continue
+		}
+
+		u := &unstructured.Unstructured{Object: m}
+		namespace := u.GetNamespace()
+		if namespace == "" {
+			namespace = release.Namespace
+		}
+
+		objects = append(objects, ManifestObject{
+			APIVersion: u.GetAPIVersion(),
+			Kind:       u.GetKind(),
+			Namespace:  namespace,
+			Name:       u.GetName(),
+		})
+	}
+
+	return objects, nil
+}
+
+// ReleaseName returns the name of the release which manages an object. Objects
+// are matched using the annotations set by Helm 3.2 and later, falling back to
+// the `app.kubernetes.io/instance` label for objects labeled as managed by Helm.
+func ReleaseName(object runtime.Object) (string, bool) {
+	u, ok := object.(interface {
+		GetAnnotations() map[string]string
+		GetLabels() map[string]string
+	})
+	if !ok {
+		return "", false
+	}
+
+	if name := u.GetAnnotations()[ReleaseNameAnnotation]; name != "" {
+		return name, true
+	}
+
+	objectLabels := u.GetLabels()
+	if strings.EqualFold(objectLabels["app.kubernetes.io/managed-by"], "helm") {
+		if name := objectLabels["app.kubernetes.io/instance"]; name != "" {
+			return name, true
+		}
+	}
+
+	return "", false
+}
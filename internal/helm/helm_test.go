@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+)
+
+const manifest = `---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: other
+`
+
+func encodeRelease(t *testing.T, release map[string]interface{}) []byte {
+	data, err := json.Marshal(release)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return []byte(base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+func releaseData(name string, version int) map[string]interface{} {
+	return map[string]interface{}{
+		"name":      name,
+		"namespace": "default",
+		"version":   version,
+		"info": map[string]interface{}{
+			"first_deployed": "2019-07-01T10:00:00Z",
+			"last_deployed":  "2019-07-02T10:00:00Z",
+			"deleted":        "",
+			"status":         "deployed",
+			"notes":          "notes",
+		},
+		"chart": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":       "app",
+				"version":    "1.0.0",
+				"appVersion": "2.0.0",
+			},
+		},
+		"config": map[string]interface{}{
+			"replicas": 2,
+		},
+		"manifest": manifest,
+	}
+}
+
+func releaseSecret(t *testing.T, name string, version int) *unstructured.Unstructured {
+	secret := testutil.CreateSecret(name)
+	secret.Labels = map[string]string{OwnerLabel: OwnerValue}
+	secret.Data = map[string][]byte{
+		releaseKey: encodeRelease(t, releaseData(name, version)),
+	}
+	return testutil.ToUnstructured(t, secret)
+}
+
+func TestDecodeRelease(t *testing.T) {
+	got, err := DecodeRelease(encodeRelease(t, releaseData("app", 1)))
+	require.NoError(t, err)
+
+	assert.Equal(t, "app", got.Name)
+	assert.Equal(t, 1, got.Version)
+	assert.Equal(t, "deployed", got.Info.Status)
+	assert.Equal(t, time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC), got.Info.LastDeployed.Time.UTC())
+	assert.True(t, got.Info.Deleted.IsZero())
+	assert.Equal(t, "app-1.0.0", got.ChartName())
+	assert.Equal(t, "2.0.0", got.Chart.Metadata.AppVersion)
+	assert.Equal(t, map[string]interface{}{"replicas": float64(2)}, got.Config)
+}
+
+func TestDecodeRelease_uncompressed(t *testing.T) {
+	data, err := json.Marshal(releaseData("app", 1))
+	require.NoError(t, err)
+
+	got, err := DecodeRelease([]byte(base64.StdEncoding.EncodeToString(data)))
+	require.NoError(t, err)
+	assert.Equal(t, "app", got.Name)
+}
+
+func TestDecodeRelease_invalid(t *testing.T) {
+	_, err := DecodeRelease([]byte("not base64"))
+	require.Error(t, err)
+}
+
+func TestRelease_ComputedValues(t *testing.T) {
+	release := Release{
+		Chart: Chart{
+			Values: map[string]interface{}{
+				"replicas": 1,
+				"image": map[string]interface{}{
+					"repository": "nginx",
+					"tag":        "1.15",
+				},
+				"ingress": map[string]interface{}{"enabled": false},
+			},
+		},
+		Config: map[string]interface{}{
+			"replicas": 2,
+			"image":    map[string]interface{}{"tag": "1.17"},
+			"ingress":  nil,
+		},
+	}
+
+	expected := map[string]interface{}{
+		"replicas": 2,
+		"image": map[string]interface{}{
+			"repository": "nginx",
+			"tag":        "1.17",
+		},
+	}
+	assert.Equal(t, expected, release.ComputedValues())
+	assert.Equal(t, "1.15", release.Chart.Values["image"].(map[string]interface{})["tag"])
+}
+
+func TestHistories(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := storeFake.NewMockStore(controller)
+
+	selector := labels.Set{OwnerLabel: OwnerValue}
+	key := store.Key{Namespace: "default", APIVersion: "v1", Kind: "Secret", Selector: &selector}
+
+	invalid := testutil.CreateSecret("invalid")
+	invalid.Labels = map[string]string{OwnerLabel: OwnerValue}
+	invalid.Data = map[string][]byte{releaseKey: []byte("invalid")}
+
+	objects := []*unstructured.Unstructured{
+		releaseSecret(t, "b", 1),
+		releaseSecret(t, "a", 1),
+		releaseSecret(t, "a", 2),
+		testutil.ToUnstructured(t, invalid),
+	}
+	objectStore.EXPECT().List(gomock.Any(), key).Return(objects, nil)
+
+	got, err := Histories(context.Background(), objectStore, "default")
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "a", got[0].Name)
+	require.Len(t, got[0].Revisions, 2)
+	assert.Equal(t, 2, got[0].Latest().Version)
+	assert.Equal(t, 1, got[0].Revisions[1].Version)
+	assert.Equal(t, "b", got[1].Name)
+}
+
+func TestManifestObjects(t *testing.T) {
+	release := &Release{Namespace: "default", Manifest: manifest}
+
+	got, err := ManifestObjects(release)
+	require.NoError(t, err)
+
+	expected := []ManifestObject{
+		{APIVersion: "v1", Kind: "Service", Namespace: "default", Name: "app"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "other", Name: "app"},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestReleaseName(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectMeta metav1.ObjectMeta
+		expected   string
+		isFound    bool
+	}{
+		{
+			name: "annotation",
+			objectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{ReleaseNameAnnotation: "app"},
+			},
+			expected: "app",
+			isFound:  true,
+		},
+		{
+			name: "managed by label",
+			objectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "Helm",
+					"app.kubernetes.io/instance":   "app",
+				},
+			},
+			expected: "app",
+			isFound:  true,
+		},
+		{
+			name: "not managed by helm",
+			objectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app.kubernetes.io/instance": "app"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			object := &corev1.Service{ObjectMeta: test.objectMeta}
+
+			got, ok := ReleaseName(object)
+			assert.Equal(t, test.isFound, ok)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
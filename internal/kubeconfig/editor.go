@@ -0,0 +1,739 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubeconfig
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// BackupSuffix is appended to a kube config's path to name the copy
+	// made before Octant changes it.
+	BackupSuffix = ".bak"
+
+	// DefaultLockTimeout is how long the editor waits for a locked kube
+	// config.
+	DefaultLockTimeout = 5 * time.Second
+)
+
+// Keys of the kube config lists the editor changes.
+const (
+	clustersKey = "clusters"
+	usersKey    = "users"
+	contextsKey = "contexts"
+)
+
+// ContextInfo describes a context and the file it is defined in.
+type ContextInfo struct {
+	Name      string
+	Cluster   string
+	User      string
+	Namespace string
+	Path      string
+}
+
+// Editor changes contexts in a list of kube configs. Changes are written
+// to the file which defines the entry, like kubectl writes them, and new
+// entries are written to the first file which exists.
+//
+// Files are locked while they are changed, the previous version is copied
+// to a backup and the new version replaces the file atomically. Key order
+// and comments are kept.
+type Editor struct {
+	fs          afero.Fs
+	list        string
+	lockTimeout time.Duration
+}
+
+// NewEditor creates an instance of Editor for a list of kube config paths.
+func NewEditor(fs afero.Fs, list string) *Editor {
+	return &Editor{
+		fs:          fs,
+		list:        list,
+		lockTimeout: DefaultLockTimeout,
+	}
+}
+
+// Contexts returns the contexts in the merged kube config sorted by name.
+func (e *Editor) Contexts() ([]ContextInfo, error) {
+	config, err := LoadMerged(e.fs, e.list)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []ContextInfo
+	for name, kubeContext := range config.Contexts {
+		list = append(list, ContextInfo{
+			Name:      name,
+			Cluster:   kubeContext.Cluster,
+			User:      kubeContext.AuthInfo,
+			Namespace: kubeContext.Namespace,
+			Path:      kubeContext.LocationOfOrigin,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+// CurrentContext returns the current context of the merged kube config.
+func (e *Editor) CurrentContext() (string, error) {
+	config, err := LoadMerged(e.fs, e.list)
+	if err != nil {
+		return "", err
+	}
+
+	return config.CurrentContext, nil
+}
+
+// RenameContext renames a context. The current context is updated if it
+// is the renamed context.
+func (e *Editor) RenameContext(name, newName string) error {
+	if err := validateContextName(newName); err != nil {
+		return err
+	}
+
+	config, err := LoadMerged(e.fs, e.list)
+	if err != nil {
+		return err
+	}
+
+	kubeContext, ok := config.Contexts[name]
+	if !ok {
+		return errors.Errorf("context %q does not exist", name)
+	}
+	if _, ok := config.Contexts[newName]; ok {
+		return errors.Errorf("context %q already exists", newName)
+	}
+
+	updateCurrent := config.CurrentContext == name
+	currentPath := e.currentContextPath()
+	setCurrent := func(doc *document) {
+		setKey(doc.root(), "current-context", stringNode(newName))
+	}
+
+	err = e.modify(kubeContext.LocationOfOrigin, func(doc *document) error {
+		ok := doc.updateEntry(contextsKey, name, func(entry *yaml.Node) {
+			setKey(entry, "name", stringNode(newName))
+		})
+		if !ok {
+			return errors.Errorf("context %q is not in %s", name, doc.path)
+		}
+		if updateCurrent && currentPath == doc.path {
+			setCurrent(doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !updateCurrent || currentPath == kubeContext.LocationOfOrigin {
+		return nil
+	}
+
+	return e.modify(currentPath, func(doc *document) error {
+		setCurrent(doc)
+		return nil
+	})
+}
+
+// DeleteContext deletes a context. Its cluster and user are kept because
+// other contexts can use them. Files which use the context as their current
+// context stop setting one, unless another file still defines the context.
+func (e *Editor) DeleteContext(name string) error {
+	config, err := LoadMerged(e.fs, e.list)
+	if err != nil {
+		return err
+	}
+
+	kubeContext, ok := config.Contexts[name]
+	if !ok {
+		return errors.Errorf("context %q does not exist", name)
+	}
+
+	origin := kubeContext.LocationOfOrigin
+	defined, current := e.contextReferences(name)
+
+	// a context shadowed by the deleted one is still a valid current context.
+	var clearCurrent []string
+	if len(defined) == 1 {
+		clearCurrent = current
+	}
+
+	removeCurrent := func(doc *document) {
+		removeKey(doc.root(), "current-context")
+	}
+
+	err = e.modify(origin, func(doc *document) error {
+		if !doc.removeEntry(contextsKey, name) {
+			return errors.Errorf("context %q is not in %s", name, doc.path)
+		}
+		if containsString(clearCurrent, doc.path) {
+			removeCurrent(doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, path := range clearCurrent {
+		if path == origin {
+			continue
+		}
+
+		err := e.modify(path, func(doc *document) error {
+			removeCurrent(doc)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DuplicateContext copies a context to a new name in the same file.
+func (e *Editor) DuplicateContext(name, newName string) error {
+	if err := validateContextName(newName); err != nil {
+		return err
+	}
+
+	config, err := LoadMerged(e.fs, e.list)
+	if err != nil {
+		return err
+	}
+
+	kubeContext, ok := config.Contexts[name]
+	if !ok {
+		return errors.Errorf("context %q does not exist", name)
+	}
+	if _, ok := config.Contexts[newName]; ok {
+		return errors.Errorf("context %q already exists", newName)
+	}
+
+	return e.modify(kubeContext.LocationOfOrigin, func(doc *document) error {
+		var duplicate *yaml.Node
+		ok := doc.updateEntry(contextsKey, name, func(entry *yaml.Node) {
+			duplicate = copyNode(entry)
+		})
+		if !ok {
+			return errors.Errorf("context %q is not in %s", name, doc.path)
+		}
+
+		setKey(duplicate, "name", stringNode(newName))
+		doc.appendEntries(contextsKey, duplicate)
+		return nil
+	})
+}
+
+// SetContextNamespace sets the default namespace of a context. A blank
+// namespace removes it.
+func (e *Editor) SetContextNamespace(name, namespace string) error {
+	namespace = strings.TrimSpace(namespace)
+	if namespace != "" {
+		if msgs := validation.IsDNS1123Label(namespace); len(msgs) > 0 {
+			return errors.Errorf("%q is not a valid namespace: %s", namespace, strings.Join(msgs, ", "))
+		}
+	}
+
+	config, err := LoadMerged(e.fs, e.list)
+	if err != nil {
+		return err
+	}
+
+	kubeContext, ok := config.Contexts[name]
+	if !ok {
+		return errors.Errorf("context %q does not exist", name)
+	}
+
+	return e.modify(kubeContext.LocationOfOrigin, func(doc *document) error {
+		ok := doc.updateEntry(contextsKey, name, func(entry *yaml.Node) {
+			body := getKey(entry, "context")
+			if body == nil || body.Kind != yaml.MappingNode {
+				body = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				setKey(entry, "context", body)
+			}
+			if namespace == "" {
+				removeKey(body, "namespace")
+			} else {
+				setKey(body, "namespace", stringNode(namespace))
+			}
+		})
+		if !ok {
+			return errors.Errorf("context %q is not in %s", name, doc.path)
+		}
+		return nil
+	})
+}
+
+// Import adds the clusters, users and contexts in a kube config snippet.
+// Nothing is imported if any of their names are already used. Users which
+// run a command or use an auth provider to get credentials are only imported
+// if allowCredentialPlugins is true, since the command runs whenever the
+// user is used. It returns the names of the imported contexts.
+func (e *Editor) Import(data []byte, allowCredentialPlugins bool) ([]string, error) {
+	snippet, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "snippet is not a valid kube config")
+	}
+
+	if len(snippet.Clusters) == 0 && len(snippet.AuthInfos) == 0 && len(snippet.Contexts) == 0 {
+		return nil, errors.New("snippet does not contain clusters, users or contexts")
+	}
+
+	if users := credentialPluginUsers(snippet); len(users) > 0 && !allowCredentialPlugins {
+		return nil, errors.Errorf("users %s run a command or use an auth provider to get credentials; confirm they are trusted to import them",
+			strings.Join(users, ", "))
+	}
+
+	config, err := LoadMerged(e.fs, e.list)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for name := range snippet.Clusters {
+		if _, ok := config.Clusters[name]; ok {
+			problems = append(problems, fmt.Sprintf("cluster %q already exists", name))
+		}
+	}
+	for name := range snippet.AuthInfos {
+		if _, ok := config.AuthInfos[name]; ok {
+			problems = append(problems, fmt.Sprintf("user %q already exists", name))
+		}
+	}
+
+	var contextNames []string
+	for name, kubeContext := range snippet.Contexts {
+		if _, ok := config.Contexts[name]; ok {
+			problems = append(problems, fmt.Sprintf("context %q already exists", name))
+		}
+		if _, ok := snippet.Clusters[kubeContext.Cluster]; !ok && config.Clusters[kubeContext.Cluster] == nil {
+			problems = append(problems, fmt.Sprintf("context %q uses cluster %q which does not exist", name, kubeContext.Cluster))
+		}
+		if _, ok := snippet.AuthInfos[kubeContext.AuthInfo]; !ok && config.AuthInfos[kubeContext.AuthInfo] == nil {
+			problems = append(problems, fmt.Sprintf("context %q uses user %q which does not exist", name, kubeContext.AuthInfo))
+		}
+		contextNames = append(contextNames, name)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.Errorf("unable to import snippet: %s", strings.Join(problems, "; "))
+	}
+
+	// The snippet is decoded again to keep the order, fields and comments
+	// of its entries.
+	raw := document{path: "snippet"}
+	if err := yaml.Unmarshal(data, &raw.node); err != nil {
+		return nil, errors.Wrap(err, "parse snippet")
+	}
+	if len(raw.node.Content) != 1 || raw.root().Kind != yaml.MappingNode {
+		return nil, errors.New("snippet is not a valid kube config")
+	}
+
+	err = e.modify(e.defaultPath(), func(doc *document) error {
+		for _, key := range []string{clustersKey, usersKey, contextsKey} {
+			for _, entry := range raw.entries(key) {
+				if entry.Kind == yaml.MappingNode {
+					doc.appendEntries(key, entry)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(contextNames)
+	return contextNames, nil
+}
+
+// credentialPluginUsers returns the names of the users in a kube config
+// which run a command or use an auth provider to get credentials, sorted by
+// name and quoted.
+func credentialPluginUsers(config *clientcmdapi.Config) []string {
+	var users []string
+	for name, authInfo := range config.AuthInfos {
+		if authInfo.Exec != nil || authInfo.AuthProvider != nil {
+			users = append(users, fmt.Sprintf("%q", name))
+		}
+	}
+
+	sort.Strings(users)
+	return users
+}
+
+// defaultPath returns the file new entries are written to: the first file
+// which exists, or the first file if none do.
+func (e *Editor) defaultPath() string {
+	paths := Paths(e.list)
+	for _, path := range paths {
+		if _, err := e.fs.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	if len(paths) == 0 {
+		return ""
+	}
+
+	return paths[0]
+}
+
+// currentContextPath returns the file which sets the current context.
+func (e *Editor) currentContextPath() string {
+	for _, path := range Paths(e.list) {
+		data, err := afero.ReadFile(e.fs, path)
+		if err != nil {
+			continue
+		}
+
+		config, err := clientcmd.Load(data)
+		if err == nil && config.CurrentContext != "" {
+			return path
+		}
+	}
+
+	return e.defaultPath()
+}
+
+// contextReferences returns the files which define a context and the files
+// which set it as their current context.
+func (e *Editor) contextReferences(name string) (defined, current []string) {
+	for _, path := range Paths(e.list) {
+		data, err := afero.ReadFile(e.fs, path)
+		if err != nil {
+			continue
+		}
+
+		config, err := clientcmd.Load(data)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := config.Contexts[name]; ok {
+			defined = append(defined, path)
+		}
+		if config.CurrentContext == name {
+			current = append(current, path)
+		}
+	}
+
+	return defined, current
+}
+
+// modify locks a file, applies fn to it, backs up the previous version and
+// writes the new one.
+func (e *Editor) modify(path string, fn func(doc *document) error) error {
+	if path == "" {
+		return errors.New("no kube config to write to")
+	}
+
+	unlock, err := lockFile(e.fs, path, e.lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = unlock()
+	}()
+
+	// the file is read again with the lock held so changes made since it was
+	// last read aren't lost.
+	doc, err := readDocument(e.fs, path)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(doc); err != nil {
+		return err
+	}
+
+	data, err := doc.encode()
+	if err != nil {
+		return err
+	}
+
+	if _, err := clientcmd.Load(data); err != nil {
+		return errors.Wrapf(err, "changes to %s are not a valid kube config", path)
+	}
+
+	if doc.original != nil {
+		if err := afero.WriteFile(e.fs, path+BackupSuffix, doc.original, 0600); err != nil {
+			return errors.Wrapf(err, "back up %s", path)
+		}
+	}
+
+	return writeFileAtomic(e.fs, path, data, doc.mode)
+}
+
+// writeFileAtomic writes a temporary file next to path and renames it, so
+// a failed write doesn't leave a truncated kube config. If path is a
+// symlink, the file it links to is replaced and the link is kept.
+func writeFileAtomic(fs afero.Fs, path string, data []byte, mode os.FileMode) error {
+	path, err := resolveSymlinks(fs, path)
+	if err != nil {
+		return err
+	}
+
+	tmp := fmt.Sprintf("%s.%d.tmp", path, time.Now().UnixNano())
+
+	if err := afero.WriteFile(fs, tmp, data, mode); err != nil {
+		return errors.Wrapf(err, "write %s", tmp)
+	}
+
+	if err := fs.Rename(tmp, path); err != nil {
+		_ = fs.Remove(tmp)
+		return errors.Wrapf(err, "replace %s", path)
+	}
+
+	return nil
+}
+
+// resolveSymlinks returns the file path refers to. Only the OS file system
+// has symlinks, so other file systems return path.
+func resolveSymlinks(fs afero.Fs, path string) (string, error) {
+	if _, ok := fs.(*afero.OsFs); !ok {
+		return path, nil
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return path, nil
+		}
+		return "", errors.Wrapf(err, "resolve %s", path)
+	}
+
+	return resolved, nil
+}
+
+// document is a kube config file decoded so it can be changed without
+// reordering keys, dropping fields Octant doesn't know about or losing
+// comments.
+type document struct {
+	path     string
+	mode     os.FileMode
+	original []byte
+	node     yaml.Node
+}
+
+func readDocument(fs afero.Fs, path string) (*document, error) {
+	doc := &document{
+		path: path,
+		mode: 0600,
+	}
+
+	data, err := afero.ReadFile(fs, path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "read %s", path)
+	}
+
+	if err == nil {
+		doc.original = data
+
+		if fi, err := fs.Stat(path); err == nil {
+			doc.mode = fi.Mode().Perm()
+		}
+
+		if err := yaml.Unmarshal(data, &doc.node); err != nil {
+			return nil, errors.Wrapf(err, "parse %s", path)
+		}
+	}
+
+	// an empty file has no document.
+	if doc.node.Kind == 0 {
+		doc.node = yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode}},
+		}
+	}
+
+	if len(doc.node.Content) != 1 || doc.root().Kind != yaml.MappingNode {
+		return nil, errors.Errorf("%s is not a kube config", path)
+	}
+
+	if getKey(doc.root(), "apiVersion") == nil {
+		setKey(doc.root(), "apiVersion", stringNode("v1"))
+	}
+	if getKey(doc.root(), "kind") == nil {
+		setKey(doc.root(), "kind", stringNode("Config"))
+	}
+
+	return doc, nil
+}
+
+// root returns the top level mapping of the document.
+func (d *document) root() *yaml.Node {
+	return d.node.Content[0]
+}
+
+func (d *document) encode() ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&d.node); err != nil {
+		return nil, errors.Wrapf(err, "encode %s", d.path)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, errors.Wrapf(err, "encode %s", d.path)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// entries returns the entries in a list, e.g. the contexts.
+func (d *document) entries(key string) []*yaml.Node {
+	list := getKey(d.root(), key)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return list.Content
+}
+
+// updateEntry calls fn with the entry with a name in a list, e.g. a
+// context. It returns false if there is no such entry.
+func (d *document) updateEntry(key, name string, fn func(entry *yaml.Node)) bool {
+	for _, entry := range d.entries(key) {
+		if entry.Kind == yaml.MappingNode && stringValue(getKey(entry, "name")) == name {
+			fn(entry)
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeEntry removes the entry with a name from a list.
+func (d *document) removeEntry(key, name string) bool {
+	list := getKey(d.root(), key)
+	for i, entry := range d.entries(key) {
+		if entry.Kind == yaml.MappingNode && stringValue(getKey(entry, "name")) == name {
+			list.Content = append(list.Content[:i], list.Content[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendEntries appends entries to a list.
+func (d *document) appendEntries(key string, entries ...*yaml.Node) {
+	list := getKey(d.root(), key)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		setKey(d.root(), key, list)
+	}
+
+	list.Content = append(list.Content, entries...)
+}
+
+func stringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// stringValue returns the value of a scalar node, or a blank string for
+// other nodes.
+func stringValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
+
+// getKey returns the value of a key in a mapping.
+func getKey(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setKey sets a key's value, keeping its position and comments if it
+// exists.
+func setKey(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			current := mapping.Content[i+1]
+			value.HeadComment = current.HeadComment
+			value.LineComment = current.LineComment
+			value.FootComment = current.FootComment
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+
+	mapping.Content = append(mapping.Content, stringNode(key), value)
+}
+
+func removeKey(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// copyNode deep copies a node.
+func copyNode(node *yaml.Node) *yaml.Node {
+	out := *node
+	out.Content = make([]*yaml.Node, len(node.Content))
+	for i := range node.Content {
+		out.Content[i] = copyNode(node.Content[i])
+	}
+
+	return &out
+}
+
+func validateContextName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("context name is blank")
+	}
+	if strings.TrimSpace(name) != name {
+		return errors.Errorf("context name %q has leading or trailing spaces", name)
+	}
+
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
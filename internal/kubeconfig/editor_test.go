@@ -0,0 +1,299 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubeconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const header = "# managed by hand\n\n"
+
+func newTestEditor(t *testing.T) (afero.Fs, *Editor) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/first", []byte(header+firstConfig), 0640))
+	require.NoError(t, afero.WriteFile(fs, "/second", []byte(secondConfig), 0600))
+
+	return fs, NewEditor(fs, joinPaths("/first", "/second"))
+}
+
+func readString(t *testing.T, fs afero.Fs, path string) string {
+	data, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestEditor_Contexts(t *testing.T) {
+	_, e := newTestEditor(t)
+
+	got, err := e.Contexts()
+	require.NoError(t, err)
+
+	expected := []ContextInfo{
+		{Name: "dev", Cluster: "development", User: "developer", Path: "/first"},
+		{Name: "prod", Cluster: "production", User: "admin", Path: "/second"},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestEditor_RenameContext(t *testing.T) {
+	fs, e := newTestEditor(t)
+
+	require.NoError(t, e.RenameContext("dev", "development"))
+
+	config, err := LoadMerged(fs, e.list)
+	require.NoError(t, err)
+	assert.Contains(t, config.Contexts, "development")
+	assert.Equal(t, "development", config.CurrentContext)
+
+	// the shadowed context in the second file is unchanged
+	assert.Contains(t, readString(t, fs, "/second"), "name: dev\n")
+
+	first := readString(t, fs, "/first")
+	assert.True(t, strings.HasPrefix(first, header), "header comments are kept")
+	assert.True(t, strings.Index(first, "clusters:") < strings.Index(first, "contexts:"), "key order is kept")
+	assert.Equal(t, header+firstConfig, readString(t, fs, "/first"+BackupSuffix))
+
+	fi, err := fs.Stat("/first")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), fi.Mode().Perm())
+
+	_, err = fs.Stat("/first" + lockSuffix)
+	assert.True(t, os.IsNotExist(err), "lock is removed")
+
+	assert.Error(t, e.RenameContext("missing", "other"))
+	assert.Error(t, e.RenameContext("development", "prod"))
+	assert.Error(t, e.RenameContext("development", " "))
+}
+
+func TestEditor_keeps_comments(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	commented := strings.Replace(firstConfig, "contexts:\n", "contexts:\n# the team's cluster\n", 1)
+	commented = strings.Replace(commented, "token: first\n", "token: first # rotated weekly\n", 1)
+	require.NoError(t, afero.WriteFile(fs, "/config", []byte(header+commented), 0600))
+
+	e := NewEditor(fs, "/config")
+	require.NoError(t, e.RenameContext("dev", "development"))
+
+	got := readString(t, fs, "/config")
+	assert.True(t, strings.HasPrefix(got, header), "header comments are kept")
+	assert.Contains(t, got, "# the team's cluster\n")
+	assert.Contains(t, got, "token: first # rotated weekly\n")
+	assert.Contains(t, got, "name: development\n")
+}
+
+func TestEditor_DeleteContext(t *testing.T) {
+	fs, e := newTestEditor(t)
+
+	require.NoError(t, e.DeleteContext("prod"))
+
+	config, err := LoadMerged(fs, e.list)
+	require.NoError(t, err)
+	assert.NotContains(t, config.Contexts, "prod")
+	assert.Contains(t, config.Clusters, "production", "clusters are kept")
+	assert.Equal(t, "dev", config.CurrentContext)
+	assert.NotContains(t, readString(t, fs, "/second"), "current-context", "deleted current context is cleared")
+
+	assert.Error(t, e.DeleteContext("prod"))
+}
+
+func TestEditor_DeleteContext_current(t *testing.T) {
+	fs, e := newTestEditor(t)
+
+	// dev in the second file is used once dev in the first file is deleted.
+	require.NoError(t, e.DeleteContext("dev"))
+
+	config, err := LoadMerged(fs, e.list)
+	require.NoError(t, err)
+	assert.Contains(t, config.Contexts, "dev")
+	assert.Equal(t, "dev", config.CurrentContext)
+
+	require.NoError(t, e.DeleteContext("dev"))
+
+	config, err = LoadMerged(fs, e.list)
+	require.NoError(t, err)
+	assert.NotContains(t, config.Contexts, "dev")
+	assert.Equal(t, "prod", config.CurrentContext)
+	assert.NotContains(t, readString(t, fs, "/first"), "current-context")
+}
+
+func TestEditor_DuplicateContext(t *testing.T) {
+	fs, e := newTestEditor(t)
+
+	require.NoError(t, e.DuplicateContext("prod", "prod-copy"))
+
+	config, err := LoadMerged(fs, e.list)
+	require.NoError(t, err)
+	require.Contains(t, config.Contexts, "prod-copy")
+	assert.Equal(t, "/second", config.Contexts["prod-copy"].LocationOfOrigin)
+	assert.Equal(t, "production", config.Contexts["prod-copy"].Cluster)
+	assert.Equal(t, "admin", config.Contexts["prod-copy"].AuthInfo)
+
+	assert.Error(t, e.DuplicateContext("prod", "dev"))
+}
+
+func TestEditor_SetContextNamespace(t *testing.T) {
+	fs, e := newTestEditor(t)
+
+	require.NoError(t, e.SetContextNamespace("dev", "web"))
+
+	config, err := LoadMerged(fs, e.list)
+	require.NoError(t, err)
+	assert.Equal(t, "web", config.Contexts["dev"].Namespace)
+
+	require.NoError(t, e.SetContextNamespace("dev", ""))
+
+	config, err = LoadMerged(fs, e.list)
+	require.NoError(t, err)
+	assert.Equal(t, "", config.Contexts["dev"].Namespace)
+
+	assert.Error(t, e.SetContextNamespace("dev", "Not_Valid"))
+}
+
+func TestEditor_Import(t *testing.T) {
+	snippet := `apiVersion: v1
+kind: Config
+clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com
+users:
+- name: stager
+  user:
+    token: staging
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: stager
+- name: staging-admin
+  context:
+    cluster: staging
+    user: admin
+`
+
+	execSnippet := strings.Replace(snippet, "    token: staging\n",
+		"    exec:\n      apiVersion: client.authentication.k8s.io/v1beta1\n      command: staging-login\n", 1)
+
+	tests := []struct {
+		name                   string
+		snippet                string
+		allowCredentialPlugins bool
+		expected               []string
+		expectErr              string
+	}{
+		{
+			name:     "new entries",
+			snippet:  snippet,
+			expected: []string{"staging", "staging-admin"},
+		},
+		{
+			name:      "credential plugin",
+			snippet:   execSnippet,
+			expectErr: `users "stager" run a command`,
+		},
+		{
+			name:                   "confirmed credential plugin",
+			snippet:                execSnippet,
+			allowCredentialPlugins: true,
+			expected:               []string{"staging", "staging-admin"},
+		},
+		{
+			name:      "existing names",
+			snippet:   secondConfig,
+			expectErr: `cluster "development" already exists`,
+		},
+		{
+			name:      "missing reference",
+			snippet:   "contexts:\n- name: other\n  context:\n    cluster: missing\n    user: admin\n",
+			expectErr: `uses cluster "missing" which does not exist`,
+		},
+		{
+			name:      "empty",
+			snippet:   "apiVersion: v1\nkind: Config\n",
+			expectErr: "does not contain",
+		},
+		{
+			name:      "invalid",
+			snippet:   "clusters: [",
+			expectErr: "not a valid kube config",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fs, e := newTestEditor(t)
+
+			got, err := e.Import([]byte(tc.snippet), tc.allowCredentialPlugins)
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+				assert.Equal(t, header+firstConfig, readString(t, fs, "/first"))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+
+			config, err := LoadMerged(fs, e.list)
+			require.NoError(t, err)
+			assert.Equal(t, "/first", config.Contexts["staging"].LocationOfOrigin)
+			assert.Equal(t, "https://staging.example.com", config.Clusters["staging"].Server)
+			assert.Equal(t, "dev", config.CurrentContext, "current context is unchanged")
+		})
+	}
+}
+
+func Test_lockFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	fs := afero.NewOsFs()
+	path := filepath.Join(dir, "config")
+
+	unlock, err := lockFile(fs, path, time.Second)
+	require.NoError(t, err)
+
+	_, err = lockFile(fs, path, 0)
+	assert.Error(t, err)
+
+	require.NoError(t, unlock())
+
+	unlock, err = lockFile(fs, path, 0)
+	require.NoError(t, err)
+	require.NoError(t, unlock())
+}
+
+func Test_writeFileAtomic_symlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	fs := afero.NewOsFs()
+	target := filepath.Join(dir, "target")
+	link := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(target, []byte("old"), 0600))
+	require.NoError(t, os.Symlink(target, link))
+
+	require.NoError(t, writeFileAtomic(fs, link, []byte("new"), 0600))
+
+	fi, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.True(t, fi.Mode()&os.ModeSymlink != 0, "link is kept")
+	assert.Equal(t, "new", readString(t, fs, target))
+}
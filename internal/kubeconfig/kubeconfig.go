@@ -6,9 +6,9 @@ SPDX-License-Identifier: Apache-2.0
 package kubeconfig
 
 import (
+	"sort"
+
 	"github.com/spf13/afero"
-	"k8s.io/apimachinery/pkg/util/yaml"
-	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 )
 
 //go:generate mockgen -destination=./fake/mock_loader.go -package=fake github.com/vmware/octant/internal/kubeconfig Loader
@@ -52,33 +52,25 @@ func NewFSLoader(options ...FSLoaderOpt) *FSLoader {
 	return l
 }
 
-// Load loads a kube config contexts from a file.
+// Load loads kube config contexts. filename can be a list of files
+// separated by the OS path list separator, like KUBECONFIG. They are merged
+// like kubectl merges them.
 func (l *FSLoader) Load(filename string) (*KubeConfig, error) {
-	var rawConfig *clientcmdapiv1.Config
-
-	f, err := l.AppFS.Open(filename)
+	config, err := LoadMerged(l.AppFS, filename)
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		if cErr := f.Close(); cErr != nil && err == nil {
-			err = cErr
-		}
-	}()
-
-	if err := yaml.NewYAMLToJSONDecoder(f).Decode(&rawConfig); err != nil {
-		return nil, err
-	}
-
 	var list []Context
-
-	for _, kubeContext := range rawConfig.Contexts {
-		list = append(list, Context{Name: kubeContext.Name})
+	for name := range config.Contexts {
+		list = append(list, Context{Name: name})
 	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
 
 	return &KubeConfig{
 		Contexts:       list,
-		CurrentContext: rawConfig.CurrentContext,
+		CurrentContext: config.CurrentContext,
 	}, nil
 }
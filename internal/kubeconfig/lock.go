@@ -0,0 +1,52 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubeconfig
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// lockSuffix is appended to a kube config's path to lock it. kubectl uses
+// the same lock file, so Octant and kubectl don't write a file at the same
+// time.
+const lockSuffix = ".lock"
+
+// lockRetryInterval is how often a locked file is retried.
+const lockRetryInterval = 50 * time.Millisecond
+
+// lockFile locks path by creating its lock file. It retries until timeout
+// if the file is already locked. The returned function unlocks the file.
+func lockFile(fs afero.Fs, path string, timeout time.Duration) (func() error, error) {
+	lockPath := path + lockSuffix
+	deadline := time.Now().Add(timeout)
+
+	for {
+		f, err := fs.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			if err := f.Close(); err != nil {
+				return nil, errors.Wrapf(err, "close %s", lockPath)
+			}
+
+			return func() error {
+				return fs.Remove(lockPath)
+			}, nil
+		}
+
+		if !os.IsExist(err) {
+			return nil, errors.Wrapf(err, "lock %s", path)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("%s is locked by another process, remove %s if it isn't", path, lockPath)
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
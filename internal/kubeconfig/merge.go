@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubeconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// DefaultPaths returns the kube config paths used when none are given:
+// KUBECONFIG if it is set, or ~/.kube/config.
+func DefaultPaths() string {
+	if env := os.Getenv(clientcmd.RecommendedConfigPathEnvVar); env != "" {
+		return env
+	}
+
+	return clientcmd.RecommendedHomeFile
+}
+
+// Paths splits a list of kube config paths separated by the OS path list
+// separator, like KUBECONFIG. Blank and repeated paths are removed.
+func Paths(list string) []string {
+	var paths []string
+	seen := make(map[string]bool)
+
+	for _, path := range filepath.SplitList(list) {
+		path = strings.TrimSpace(path)
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		paths = append(paths, path)
+	}
+
+	return paths
+}
+
+// NewLoadingRules creates loading rules for a list of kube config paths. A
+// single path must exist, like kubectl's --kubeconfig flag. Missing files in
+// a longer list are ignored and the rest are merged, like KUBECONFIG.
+func NewLoadingRules(list string) *clientcmd.ClientConfigLoadingRules {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+
+	paths := Paths(list)
+	switch len(paths) {
+	case 0:
+	case 1:
+		rules.ExplicitPath = paths[0]
+	default:
+		rules.Precedence = paths
+	}
+
+	return rules
+}
+
+// LoadMerged loads and merges a list of kube configs with kubectl's rules:
+// the first file to set a cluster, user or context wins, and so does the
+// first file to set the current context. A single path must exist. The
+// LocationOfOrigin of each entry is the file it was loaded from.
+func LoadMerged(fs afero.Fs, list string) (*clientcmdapi.Config, error) {
+	paths := Paths(list)
+	if len(paths) == 0 {
+		return nil, errors.New("no kube config paths")
+	}
+
+	merged := clientcmdapi.NewConfig()
+	found := false
+
+	for _, path := range paths {
+		data, err := afero.ReadFile(fs, path)
+		if err != nil {
+			if os.IsNotExist(err) && len(paths) > 1 {
+				continue
+			}
+			return nil, errors.Wrapf(err, "read kube config %s", path)
+		}
+
+		config, err := clientcmd.Load(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "load kube config %s", path)
+		}
+		found = true
+
+		for name, cluster := range config.Clusters {
+			cluster.LocationOfOrigin = path
+			if _, ok := merged.Clusters[name]; !ok {
+				merged.Clusters[name] = cluster
+			}
+		}
+		for name, authInfo := range config.AuthInfos {
+			authInfo.LocationOfOrigin = path
+			if _, ok := merged.AuthInfos[name]; !ok {
+				merged.AuthInfos[name] = authInfo
+			}
+		}
+		for name, kubeContext := range config.Contexts {
+			kubeContext.LocationOfOrigin = path
+			if _, ok := merged.Contexts[name]; !ok {
+				merged.Contexts[name] = kubeContext
+			}
+		}
+		if merged.CurrentContext == "" {
+			merged.CurrentContext = config.CurrentContext
+		}
+	}
+
+	if !found {
+		return nil, errors.Errorf("no kube config found in %s", strings.Join(paths, string(filepath.ListSeparator)))
+	}
+
+	return merged, nil
+}
+
+// ContextChanged returns true if the context, or the cluster or user it
+// refers to, differs between two kube configs.
+func ContextChanged(previous, current *clientcmdapi.Config, contextName string) bool {
+	if previous == nil || current == nil {
+		return previous != current
+	}
+
+	p, c := previous.Contexts[contextName], current.Contexts[contextName]
+	if p == nil || c == nil {
+		return p != c
+	}
+
+	return !reflect.DeepEqual(p, c) ||
+		!reflect.DeepEqual(previous.Clusters[p.Cluster], current.Clusters[c.Cluster]) ||
+		!reflect.DeepEqual(previous.AuthInfos[p.AuthInfo], current.AuthInfos[c.AuthInfo])
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubeconfig
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	firstConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: development
+  cluster:
+    server: https://dev.example.com
+users:
+- name: developer
+  user:
+    token: first
+contexts:
+- name: dev
+  context:
+    cluster: development
+    user: developer
+current-context: dev
+`
+
+	secondConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: development
+  cluster:
+    server: https://other.example.com
+- name: production
+  cluster:
+    server: https://prod.example.com
+users:
+- name: developer
+  user:
+    token: second
+- name: admin
+  user:
+    token: admin
+contexts:
+- name: dev
+  context:
+    cluster: production
+    user: admin
+- name: prod
+  context:
+    cluster: production
+    user: admin
+current-context: prod
+`
+)
+
+func joinPaths(paths ...string) string {
+	return strings.Join(paths, string(filepath.ListSeparator))
+}
+
+func TestPaths(t *testing.T) {
+	assert.Equal(t, []string{"/a", "/b"}, Paths(joinPaths("/a", "", " ", "/b", "/a")))
+	assert.Nil(t, Paths(""))
+}
+
+func TestNewLoadingRules(t *testing.T) {
+	rules := NewLoadingRules("/a")
+	assert.Equal(t, "/a", rules.ExplicitPath)
+
+	rules = NewLoadingRules(joinPaths("/a", "/b"))
+	assert.Equal(t, "", rules.ExplicitPath)
+	assert.Equal(t, []string{"/a", "/b"}, rules.Precedence)
+}
+
+func TestLoadMerged(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/first", []byte(firstConfig), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/second", []byte(secondConfig), 0600))
+
+	config, err := LoadMerged(fs, joinPaths("/first", "/missing", "/second"))
+	require.NoError(t, err)
+
+	// the first file to set a name wins
+	assert.Equal(t, "dev", config.CurrentContext)
+	assert.Equal(t, "development", config.Contexts["dev"].Cluster)
+	assert.Equal(t, "https://dev.example.com", config.Clusters["development"].Server)
+	assert.Equal(t, "first", config.AuthInfos["developer"].Token)
+
+	// names which are only in later files are added
+	assert.Equal(t, "production", config.Contexts["prod"].Cluster)
+	assert.Equal(t, "admin", config.AuthInfos["admin"].Token)
+}
+
+func TestLoadMerged_missing(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	_, err := LoadMerged(fs, "/missing")
+	assert.Error(t, err)
+
+	_, err = LoadMerged(fs, joinPaths("/missing", "/other"))
+	assert.Error(t, err)
+
+	_, err = LoadMerged(fs, "")
+	assert.Error(t, err)
+}
+
+func TestFSLoader_Load_list(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/first", []byte(firstConfig), 0600))
+	require.NoError(t, afero.WriteFile(fs, "/second", []byte(secondConfig), 0600))
+
+	l := NewFSLoader(func(l *FSLoader) {
+		l.AppFS = fs
+	})
+
+	kc, err := l.Load(joinPaths("/second", "/first"))
+	require.NoError(t, err)
+
+	expected := &KubeConfig{
+		Contexts:       []Context{{Name: "dev"}, {Name: "prod"}},
+		CurrentContext: "prod",
+	}
+	assert.Equal(t, expected, kc)
+}
+
+func TestContextChanged(t *testing.T) {
+	previous, err := loadData(t, firstConfig)
+	require.NoError(t, err)
+
+	current := previous.DeepCopy()
+	assert.False(t, ContextChanged(previous, current, "dev"))
+
+	current.AuthInfos["developer"].Token = "rotated"
+	assert.True(t, ContextChanged(previous, current, "dev"))
+	assert.False(t, ContextChanged(previous, current, "other"))
+
+	current = previous.DeepCopy()
+	current.Clusters["development"].Server = "https://new.example.com"
+	assert.True(t, ContextChanged(previous, current, "dev"))
+
+	current = previous.DeepCopy()
+	delete(current.Contexts, "dev")
+	assert.True(t, ContextChanged(previous, current, "dev"))
+}
+
+func loadData(t *testing.T, data string) (*clientcmdapi.Config, error) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/config", []byte(data), 0600))
+	return LoadMerged(fs, "/config")
+}
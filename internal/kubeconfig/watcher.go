@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubeconfig
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+// DefaultWatchInterval is how often kube config files are checked for
+// changes.
+const DefaultWatchInterval = 2 * time.Second
+
+// ChangeFunc is called with the previous and current merged kube config
+// after a kube config file changes.
+type ChangeFunc func(previous, current *clientcmdapi.Config)
+
+// Watcher watches a list of kube config files and reloads the merged kube
+// config when any of them change, are created or are removed.
+type Watcher struct {
+	fs     afero.Fs
+	list   string
+	logger log.Logger
+
+	mu       sync.RWMutex
+	current  *clientcmdapi.Config
+	modTimes map[string]time.Time
+	onChange []ChangeFunc
+}
+
+// NewWatcher creates an instance of Watcher for a list of kube config paths
+// and loads them.
+func NewWatcher(fs afero.Fs, list string, logger log.Logger) (*Watcher, error) {
+	w := &Watcher{
+		fs:     fs,
+		list:   list,
+		logger: logger.With("component", "kubeconfig-watcher"),
+	}
+
+	modTimes := w.statModTimes()
+	config, err := LoadMerged(fs, list)
+	if err != nil {
+		return nil, err
+	}
+
+	w.current = config
+	w.modTimes = modTimes
+
+	return w, nil
+}
+
+// Config returns the current merged kube config.
+func (w *Watcher) Config() *clientcmdapi.Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.current.DeepCopy()
+}
+
+// RegisterOnChange registers a function which is called when the merged
+// kube config changes.
+func (w *Watcher) RegisterOnChange(fn ChangeFunc) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.onChange = append(w.onChange, fn)
+}
+
+// Watch checks the files for changes every interval until the context is
+// cancelled. An invalid file is logged and the current kube config is kept.
+func (w *Watcher) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.Reload(); err != nil {
+				w.logger.WithErr(err).Errorf("reload kube config, keeping previous kube config")
+			}
+		}
+	}
+}
+
+// Reload reloads the kube config if any of its files changed since they
+// were last loaded.
+func (w *Watcher) Reload() error {
+	modTimes := w.statModTimes()
+
+	w.mu.RLock()
+	unchanged := sameModTimes(w.modTimes, modTimes)
+	w.mu.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	config, err := LoadMerged(w.fs, w.list)
+
+	w.mu.Lock()
+	// don't report the same invalid file again.
+	w.modTimes = modTimes
+	if err != nil {
+		w.mu.Unlock()
+		return err
+	}
+	previous := w.current
+	w.current = config
+	listeners := make([]ChangeFunc, len(w.onChange))
+	copy(listeners, w.onChange)
+	w.mu.Unlock()
+
+	w.logger.With("paths", w.list).Infof("reloaded kube config")
+
+	for _, fn := range listeners {
+		fn(previous.DeepCopy(), config.DeepCopy())
+	}
+
+	return nil
+}
+
+// statModTimes returns the modification time of each file. Missing files
+// have the zero time, so creating one is noticed.
+func (w *Watcher) statModTimes() map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+	for _, path := range Paths(w.list) {
+		fi, err := w.fs.Stat(path)
+		if err != nil {
+			modTimes[path] = time.Time{}
+			continue
+		}
+		modTimes[path] = fi.ModTime()
+	}
+
+	return modTimes
+}
+
+func sameModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for path, modTime := range a {
+		if other, ok := b[path]; !ok || !other.Equal(modTime) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubeconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+func writeKubeConfig(t *testing.T, fs afero.Fs, path, data string, modTime time.Time) {
+	require.NoError(t, afero.WriteFile(fs, path, []byte(data), 0600))
+	require.NoError(t, fs.Chtimes(path, modTime, modTime))
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	start := time.Unix(1000, 0)
+	writeKubeConfig(t, fs, "/first", firstConfig, start)
+
+	w, err := NewWatcher(fs, joinPaths("/first", "/second"), log.NopLogger())
+	require.NoError(t, err)
+	require.Len(t, w.Config().Contexts, 1)
+
+	var changes int
+	var current *clientcmdapi.Config
+	w.RegisterOnChange(func(previous, c *clientcmdapi.Config) {
+		changes++
+		current = c
+	})
+
+	// nothing changed
+	require.NoError(t, w.Reload())
+	assert.Equal(t, 0, changes)
+
+	// a new file adds contexts
+	writeKubeConfig(t, fs, "/second", secondConfig, start)
+	require.NoError(t, w.Reload())
+	assert.Equal(t, 1, changes)
+	assert.Contains(t, current.Contexts, "prod")
+	assert.Contains(t, w.Config().Contexts, "prod")
+
+	// an invalid file keeps the previous config and is reported once
+	writeKubeConfig(t, fs, "/first", "clusters: [", start.Add(time.Second))
+	assert.Error(t, w.Reload())
+	assert.NoError(t, w.Reload())
+	assert.Equal(t, 1, changes)
+	assert.Contains(t, w.Config().Contexts, "prod")
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"path"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxSamples is the number of samples kept for each object. metrics-server
+// scrapes every minute by default, so this is roughly the last half hour.
+const maxSamples = 30
+
+// Sample is the usage of an object at a point in time.
+type Sample struct {
+	Timestamp time.Time
+	// CPU is the CPU usage in millicores.
+	CPU int64
+	// Memory is the memory usage in bytes.
+	Memory int64
+}
+
+// NewSample creates a sample from a resource list.
+func NewSample(timestamp time.Time, usage corev1.ResourceList) Sample {
+	return Sample{
+		Timestamp: timestamp,
+		CPU:       usage.Cpu().MilliValue(),
+		Memory:    usage.Memory().Value(),
+	}
+}
+
+// PodKey is the history key for a pod.
+func PodKey(namespace, name string) string {
+	return path.Join("pod", namespace, name)
+}
+
+// ContainerKey is the history key for a container in a pod.
+func ContainerKey(namespace, pod, container string) string {
+	return path.Join("pod", namespace, pod, container)
+}
+
+// NodeKey is the history key for a node.
+func NodeKey(name string) string {
+	return path.Join("node", name)
+}
+
+// history stores a fixed number of recent samples for each key.
+type history struct {
+	samples map[string][]Sample
+	mu      sync.Mutex
+}
+
+func newHistory() *history {
+	return &history{
+		samples: make(map[string][]Sample),
+	}
+}
+
+// Add adds a sample for a key. Samples which are not newer than the last
+// sample are ignored since metrics-server returns the same sample until
+// its next scrape.
+func (h *history) Add(key string, sample Sample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := h.samples[key]
+	if n := len(samples); n > 0 && !sample.Timestamp.After(samples[n-1].Timestamp) {
+		return
+	}
+
+	samples = append(samples, sample)
+	if len(samples) > maxSamples {
+		samples = samples[len(samples)-maxSamples:]
+	}
+
+	h.samples[key] = samples
+}
+
+// Get returns a copy of the samples for a key ordered from oldest to newest.
+func (h *history) Get(key string) []Sample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := h.samples[key]
+	if len(samples) == 0 {
+		return nil
+	}
+
+	out := make([]Sample, len(samples))
+	copy(out, samples)
+	return out
+}
+
+func (h *history) addPod(pm *PodMetrics) {
+	timestamp := pm.Timestamp.Time
+	h.Add(PodKey(pm.Namespace, pm.Name), NewSample(timestamp, pm.Usage()))
+
+	for _, c := range pm.Containers {
+		h.Add(ContainerKey(pm.Namespace, pm.Name, c.Name), NewSample(timestamp, c.Usage))
+	}
+}
+
+func (h *history) addNode(nm *NodeMetrics) {
+	h.Add(NodeKey(nm.Name), NewSample(nm.Timestamp.Time, nm.Usage))
+}
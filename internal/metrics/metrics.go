@@ -0,0 +1,213 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// GroupVersion is the metrics API group version.
+	GroupVersion = "metrics.k8s.io/v1beta1"
+
+	// availabilityInterval is how long the result of checking if the
+	// metrics API is served is cached. metrics-server can be installed
+	// or removed while Octant is running.
+	availabilityInterval = time.Minute
+)
+
+// ErrUnavailable is returned when the cluster doesn't serve the metrics API.
+var ErrUnavailable = errors.New("metrics API is not available")
+
+//go:generate mockgen -destination=./fake/mock_interface.go -package=fake github.com/vmware/octant/internal/metrics Interface
+
+// Interface queries resource usage from the metrics API.
+type Interface interface {
+	// Available returns true if the cluster serves the metrics API.
+	Available(ctx context.Context) bool
+	// PodMetrics returns the usage for a pod.
+	PodMetrics(ctx context.Context, namespace, name string) (*PodMetrics, error)
+	// PodMetricsList returns the usage for pods in a namespace matching a selector.
+	PodMetricsList(ctx context.Context, namespace string, selector labels.Selector) ([]PodMetrics, error)
+	// NodeMetrics returns the usage for a node.
+	NodeMetrics(ctx context.Context, name string) (*NodeMetrics, error)
+	// NodeMetricsList returns the usage for all nodes.
+	NodeMetricsList(ctx context.Context) ([]NodeMetrics, error)
+	// History returns recent samples for a history key.
+	History(key string) []Sample
+}
+
+// Client is a metrics API client. Every response is recorded in a short
+// in-memory history.
+type Client struct {
+	discoveryClient discovery.ServerResourcesInterface
+	restClient      rest.Interface
+	nowFunc         func() time.Time
+	history         *history
+
+	available bool
+	checkedAt time.Time
+
+	// checks shares one availability check between concurrent callers.
+	// Checks run without mu held so cached results aren't blocked by the
+	// network.
+	checks singleflight.Group
+
+	mu sync.Mutex
+}
+
+var _ Interface = (*Client)(nil)
+
+// NewClient creates an instance of Client.
+func NewClient(discoveryClient discovery.ServerResourcesInterface, restClient rest.Interface) *Client {
+	return &Client{
+		discoveryClient: discoveryClient,
+		restClient:      restClient,
+		nowFunc:         time.Now,
+		history:         newHistory(),
+	}
+}
+
+// Available returns true if the cluster serves the metrics API.
+func (c *Client) Available(ctx context.Context) bool {
+	c.mu.Lock()
+	now := c.nowFunc()
+	if !c.checkedAt.IsZero() && now.Sub(c.checkedAt) < availabilityInterval {
+		available := c.available
+		c.mu.Unlock()
+		return available
+	}
+	c.mu.Unlock()
+
+	v, _, _ := c.checks.Do(GroupVersion, func() (interface{}, error) {
+		return c.checkAvailable(), nil
+	})
+	available := v.(bool)
+
+	c.mu.Lock()
+	c.available = available
+	c.checkedAt = now
+	c.mu.Unlock()
+
+	return available
+}
+
+// checkAvailable asks the cluster if it serves the metrics API.
+func (c *Client) checkAvailable() bool {
+	if c.discoveryClient == nil || c.restClient == nil {
+		return false
+	}
+
+	resources, err := c.discoveryClient.ServerResourcesForGroupVersion(GroupVersion)
+	if err != nil || resources == nil {
+		return false
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Name == "pods" || resource.Name == "nodes" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PodMetrics returns the usage for a pod.
+func (c *Client) PodMetrics(ctx context.Context, namespace, name string) (*PodMetrics, error) {
+	pm := &PodMetrics{}
+	if err := c.get(ctx, nil, pm, "namespaces", namespace, "pods", name); err != nil {
+		return nil, err
+	}
+
+	c.history.addPod(pm)
+
+	return pm, nil
+}
+
+// PodMetricsList returns the usage for pods in a namespace matching a
+// selector. metrics-server copies pod labels to pod metrics.
+func (c *Client) PodMetricsList(ctx context.Context, namespace string, selector labels.Selector) ([]PodMetrics, error) {
+	var segments []string
+	if namespace != "" {
+		segments = append(segments, "namespaces", namespace)
+	}
+	segments = append(segments, "pods")
+
+	list := &PodMetricsList{}
+	if err := c.get(ctx, selector, list, segments...); err != nil {
+		return nil, err
+	}
+
+	for i := range list.Items {
+		c.history.addPod(&list.Items[i])
+	}
+
+	return list.Items, nil
+}
+
+// NodeMetrics returns the usage for a node.
+func (c *Client) NodeMetrics(ctx context.Context, name string) (*NodeMetrics, error) {
+	nm := &NodeMetrics{}
+	if err := c.get(ctx, nil, nm, "nodes", name); err != nil {
+		return nil, err
+	}
+
+	c.history.addNode(nm)
+
+	return nm, nil
+}
+
+// NodeMetricsList returns the usage for all nodes.
+func (c *Client) NodeMetricsList(ctx context.Context) ([]NodeMetrics, error) {
+	list := &NodeMetricsList{}
+	if err := c.get(ctx, nil, list, "nodes"); err != nil {
+		return nil, err
+	}
+
+	for i := range list.Items {
+		c.history.addNode(&list.Items[i])
+	}
+
+	return list.Items, nil
+}
+
+// History returns recent samples for a history key.
+func (c *Client) History(key string) []Sample {
+	return c.history.Get(key)
+}
+
+func (c *Client) get(ctx context.Context, selector labels.Selector, into interface{}, segments ...string) error {
+	if !c.Available(ctx) {
+		return ErrUnavailable
+	}
+
+	path := append([]string{"apis", "metrics.k8s.io", "v1beta1"}, segments...)
+
+	req := c.restClient.Get().Context(ctx).AbsPath(path...)
+	if selector != nil && !selector.Empty() {
+		req = req.Param("labelSelector", selector.String())
+	}
+
+	data, err := req.DoRaw()
+	if err != nil {
+		return errors.Wrapf(err, "fetch metrics %v", segments)
+	}
+
+	if err := json.Unmarshal(data, into); err != nil {
+		return errors.Wrap(err, "decode metrics")
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+const (
+	podMetrics = `{
+  "kind": "PodMetrics",
+  "apiVersion": "metrics.k8s.io/v1beta1",
+  "metadata": {"name": "pod", "namespace": "default"},
+  "timestamp": "2019-07-02T10:00:00Z",
+  "window": "30s",
+  "containers": [
+    {"name": "a", "usage": {"cpu": "100m", "memory": "64Mi"}},
+    {"name": "b", "usage": {"cpu": "50m", "memory": "32Mi"}}
+  ]
+}`
+
+	podMetricsList = `{
+  "kind": "PodMetricsList",
+  "apiVersion": "metrics.k8s.io/v1beta1",
+  "metadata": {},
+  "items": [` + podMetrics + `]
+}`
+
+	nodeMetricsList = `{
+  "kind": "NodeMetricsList",
+  "apiVersion": "metrics.k8s.io/v1beta1",
+  "metadata": {},
+  "items": [
+    {
+      "metadata": {"name": "node"},
+      "timestamp": "2019-07-02T10:00:00Z",
+      "window": "30s",
+      "usage": {"cpu": "1500m", "memory": "2Gi"}
+    }
+  ]
+}`
+)
+
+func fakeDiscovery(available bool) *fakediscovery.FakeDiscovery {
+	fake := &clienttesting.Fake{}
+	if available {
+		fake.Resources = []*metav1.APIResourceList{
+			{
+				GroupVersion: GroupVersion,
+				APIResources: []metav1.APIResource{
+					{Name: "nodes", Kind: "NodeMetrics"},
+					{Name: "pods", Kind: "PodMetrics", Namespaced: true},
+				},
+			},
+		}
+	}
+
+	return &fakediscovery.FakeDiscovery{Fake: fake}
+}
+
+type request struct {
+	path     string
+	selector string
+}
+
+func fakeMetricsServer(t *testing.T, responses map[string]string) (rest.Interface, *[]request, func()) {
+	var requests []request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, request{path: r.URL.Path, selector: r.URL.Query().Get("labelSelector")})
+
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, body)
+	}))
+
+	restClient, err := rest.RESTClientFor(&rest.Config{
+		Host: server.URL,
+		ContentConfig: rest.ContentConfig{
+			GroupVersion:         &schema.GroupVersion{Version: "v1"},
+			NegotiatedSerializer: scheme.Codecs,
+		},
+	})
+	require.NoError(t, err)
+
+	return restClient, &requests, server.Close
+}
+
+func TestClient_PodMetrics(t *testing.T) {
+	restClient, requests, closeFn := fakeMetricsServer(t, map[string]string{
+		"/apis/metrics.k8s.io/v1beta1/namespaces/default/pods/pod": podMetrics,
+	})
+	defer closeFn()
+
+	client := NewClient(fakeDiscovery(true), restClient)
+
+	got, err := client.PodMetrics(context.Background(), "default", "pod")
+	require.NoError(t, err)
+
+	assert.Equal(t, []request{{path: "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods/pod"}}, *requests)
+	assert.Equal(t, "pod", got.Name)
+	require.Len(t, got.Containers, 2)
+
+	usage := got.Usage()
+	assert.Equal(t, int64(150), usage.Cpu().MilliValue())
+	assert.Equal(t, int64(96*1024*1024), usage.Memory().Value())
+
+	c, ok := got.Container("b")
+	require.True(t, ok)
+	assert.Equal(t, int64(50), c.Usage.Cpu().MilliValue())
+
+	ts := time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC).Local()
+	assert.Equal(t, []Sample{{Timestamp: ts, CPU: 150, Memory: 96 * 1024 * 1024}},
+		client.History(PodKey("default", "pod")))
+	assert.Equal(t, []Sample{{Timestamp: ts, CPU: 100, Memory: 64 * 1024 * 1024}},
+		client.History(ContainerKey("default", "pod", "a")))
+}
+
+func TestClient_PodMetricsList(t *testing.T) {
+	restClient, requests, closeFn := fakeMetricsServer(t, map[string]string{
+		"/apis/metrics.k8s.io/v1beta1/namespaces/default/pods": podMetricsList,
+	})
+	defer closeFn()
+
+	client := NewClient(fakeDiscovery(true), restClient)
+
+	selector := labels.SelectorFromSet(labels.Set{"app": "app"})
+	got, err := client.PodMetricsList(context.Background(), "default", selector)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	assert.Equal(t, []request{{path: "/apis/metrics.k8s.io/v1beta1/namespaces/default/pods", selector: "app=app"}}, *requests)
+
+	usage := SumPodUsage(got)
+	assert.Equal(t, int64(150), usage.Cpu().MilliValue())
+}
+
+func TestClient_NodeMetricsList(t *testing.T) {
+	restClient, _, closeFn := fakeMetricsServer(t, map[string]string{
+		"/apis/metrics.k8s.io/v1beta1/nodes": nodeMetricsList,
+	})
+	defer closeFn()
+
+	client := NewClient(fakeDiscovery(true), restClient)
+
+	got, err := client.NodeMetricsList(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	assert.Equal(t, int64(1500), got[0].Usage.Cpu().MilliValue())
+	assert.Len(t, client.History(NodeKey("node")), 1)
+}
+
+func TestClient_unavailable(t *testing.T) {
+	restClient, requests, closeFn := fakeMetricsServer(t, nil)
+	defer closeFn()
+
+	now := time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC)
+
+	discoveryClient := fakeDiscovery(false)
+	client := NewClient(discoveryClient, restClient)
+	client.nowFunc = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	_, err := client.PodMetrics(ctx, "default", "pod")
+	assert.Equal(t, ErrUnavailable, err)
+
+	_, err = client.NodeMetrics(ctx, "node")
+	assert.Equal(t, ErrUnavailable, err)
+
+	assert.Empty(t, *requests)
+	assert.Len(t, discoveryClient.Actions(), 1, "availability should be cached")
+
+	now = now.Add(availabilityInterval + time.Second)
+	assert.False(t, client.Available(ctx))
+	assert.Len(t, discoveryClient.Actions(), 2, "availability should be checked again")
+}
+
+func TestClient_Available_unlocked(t *testing.T) {
+	restClient, _, closeFn := fakeMetricsServer(t, nil)
+	defer closeFn()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	discoveryClient := fakeDiscovery(true)
+	discoveryClient.PrependReactor("get", "resource", func(clienttesting.Action) (bool, runtime.Object, error) {
+		close(started)
+		<-release
+		return false, nil, nil
+	})
+
+	client := NewClient(discoveryClient, restClient)
+
+	done := make(chan bool)
+	go func() {
+		done <- client.Available(context.Background())
+	}()
+
+	<-started
+
+	// the cache can be read while the cluster is being checked.
+	unlocked := make(chan struct{})
+	go func() {
+		client.mu.Lock()
+		client.mu.Unlock()
+		close(unlocked)
+	}()
+
+	select {
+	case <-unlocked:
+	case <-time.After(time.Second):
+		t.Error("lock was held while checking availability")
+	}
+
+	close(release)
+	assert.True(t, <-done)
+}
+
+func Test_history(t *testing.T) {
+	h := newHistory()
+
+	start := time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < maxSamples+5; i++ {
+		h.Add("key", Sample{Timestamp: start.Add(time.Duration(i) * time.Minute), CPU: int64(i)})
+	}
+
+	// samples which aren't newer are ignored
+	h.Add("key", Sample{Timestamp: start, CPU: 1000})
+
+	got := h.Get("key")
+	require.Len(t, got, maxSamples)
+	assert.Equal(t, int64(5), got[0].CPU)
+	assert.Equal(t, int64(maxSamples+4), got[len(got)-1].CPU)
+
+	assert.Nil(t, h.Get("missing"))
+}
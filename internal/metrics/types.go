@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The types in this file mirror the metrics.k8s.io/v1beta1 API. They are
+// declared here so Octant doesn't depend on the metrics client library.
+
+// PodMetrics is the usage of a pod's containers.
+type PodMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Timestamp  metav1.Time        `json:"timestamp"`
+	Window     metav1.Duration    `json:"window"`
+	Containers []ContainerMetrics `json:"containers"`
+}
+
+// Usage returns the total usage of the pod's containers.
+func (pm *PodMetrics) Usage() corev1.ResourceList {
+	usage := corev1.ResourceList{}
+	for _, c := range pm.Containers {
+		addResourceList(usage, c.Usage)
+	}
+	return usage
+}
+
+// Container returns the metrics for a container.
+func (pm *PodMetrics) Container(name string) (ContainerMetrics, bool) {
+	for _, c := range pm.Containers {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return ContainerMetrics{}, false
+}
+
+// PodMetricsList is a list of PodMetrics.
+type PodMetricsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PodMetrics `json:"items"`
+}
+
+// ContainerMetrics is the usage of a container.
+type ContainerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// NodeMetrics is the usage of a node.
+type NodeMetrics struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Timestamp metav1.Time         `json:"timestamp"`
+	Window    metav1.Duration     `json:"window"`
+	Usage     corev1.ResourceList `json:"usage"`
+}
+
+// NodeMetricsList is a list of NodeMetrics.
+type NodeMetricsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NodeMetrics `json:"items"`
+}
+
+// SumPodUsage returns the total usage of a set of pods.
+func SumPodUsage(pods []PodMetrics) corev1.ResourceList {
+	usage := corev1.ResourceList{}
+	for i := range pods {
+		addResourceList(usage, pods[i].Usage())
+	}
+	return usage
+}
+
+func addResourceList(list, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -34,6 +34,7 @@ type ManagerInterface interface {
 	SetNamespace(namespace string)
 	GetNamespace() string
 	UpdateContext(ctx context.Context, contextName string) error
+	GetContext() string
 
 	ObjectPath(namespace, apiVersion, kind, name string) (string, error)
 	RegisterObjectPath(Module, schema.GroupVersionKind)
@@ -44,6 +45,7 @@ type ManagerInterface interface {
 type Manager struct {
 	clusterClient   cluster.ClientInterface
 	namespace       string
+	contextName     string
 	actionRegistrar ActionRegistrar
 	logger          log.Logger
 
@@ -123,9 +125,16 @@ func (m *Manager) UpdateContext(ctx context.Context, contextName string) error {
 		}
 	}
 
+	m.contextName = contextName
+
 	return nil
 }
 
+// GetContext gets the current context name.
+func (m *Manager) GetContext() string {
+	return m.contextName
+}
+
 func (m *Manager) ObjectPath(namespace, apiVersion, kind, name string) (string, error) {
 	gv, err := schema.ParseGroupVersion(apiVersion)
 	if err != nil {
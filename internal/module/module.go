@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/vmware/octant/internal/filter"
 	"github.com/vmware/octant/internal/octant"
 	"github.com/vmware/octant/pkg/navigation"
 	"github.com/vmware/octant/pkg/view/component"
@@ -23,6 +24,9 @@ import (
 // ContentOptions are additional options for content generation
 type ContentOptions struct {
 	LabelSet *labels.Set
+	// Filter is a filter expression for lists. It is nil if lists aren't
+	// filtered.
+	Filter *filter.Expression
 }
 
 // Module is an octant plugin.
@@ -22,6 +22,8 @@ import (
 	"github.com/vmware/octant/internal/modules/overview/printer"
 	"github.com/vmware/octant/internal/octant"
 	"github.com/vmware/octant/internal/queryer"
+	"github.com/vmware/octant/internal/search"
+	"github.com/vmware/octant/pkg/action"
 	"github.com/vmware/octant/pkg/icon"
 	"github.com/vmware/octant/pkg/navigation"
 	"github.com/vmware/octant/pkg/store"
@@ -39,15 +41,36 @@ type ClusterOverview struct {
 	Options
 
 	pathMatcher *describer.PathMatcher
+	indexer     *search.Indexer
 }
 
 var _ module.Module = (*ClusterOverview)(nil)
+var _ module.ActionReceiver = (*ClusterOverview)(nil)
 
 func New(ctx context.Context, options Options) (*ClusterOverview, error) {
 	pathMatcher := describer.NewPathMatcher("cluster-overview")
 	for _, pf := range rootDescriber.PathFilters() {
 		pathMatcher.Register(ctx, pf)
 	}
+	for _, pf := range rbacWhoCanDescriber.PathFilters() {
+		pathMatcher.Register(ctx, pf)
+	}
+
+	indexer, err := search.NewIndexer(options.DashConfig.ObjectStore(), func() ([]string, error) {
+		nsClient, err := options.DashConfig.ClusterClient().NamespaceClient()
+		if err != nil {
+			return nil, err
+		}
+		return nsClient.Names()
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "create search indexer")
+	}
+	indexer.Start(ctx)
+
+	for _, pf := range NewSearchDescriber("/search", indexer).PathFilters() {
+		pathMatcher.Register(ctx, pf)
+	}
 
 	objectPathConfig := octant.ObjectPathConfig{
 		ModuleName:     "cluster-overview",
@@ -63,6 +86,7 @@ func New(ctx context.Context, options Options) (*ClusterOverview, error) {
 	co := &ClusterOverview{
 		ObjectPath:  objectPath,
 		pathMatcher: pathMatcher,
+		indexer:     indexer,
 		Options:     options,
 	}
 
@@ -115,8 +139,19 @@ func (co *ClusterOverview) Handlers(ctx context.Context) map[string]http.Handler
 		panic(fmt.Sprintf("unable to create port forwards handler: %v", err))
 	}
 
+	linkGenerator, err := link.NewFromDashConfig(co.DashConfig)
+	if err != nil {
+		panic(fmt.Sprintf("unable to create link generator: %v", err))
+	}
+
+	searchHandler, err := newSearchHandler(co.indexer, linkGenerator, logger)
+	if err != nil {
+		panic(fmt.Sprintf("unable to create search handler: %v", err))
+	}
+
 	return map[string]http.Handler{
 		"/port-forwards": pfHandler,
+		"/object-search": searchHandler,
 	}
 }
 
@@ -156,6 +191,7 @@ func (co *ClusterOverview) Content(ctx context.Context, contentPath string, pref
 		Fields:   pf.Fields(contentPath),
 		Printer:  p,
 		LabelSet: opts.LabelSet,
+		Filter:   opts.Filter,
 		Dash:     co.DashConfig,
 		Link:     linkGenerator,
 
@@ -180,14 +216,20 @@ func (co *ClusterOverview) Navigation(ctx context.Context, namespace string, roo
 		Lookup: map[string]string{
 			"Custom Resources": "custom-resources",
 			"RBAC":             "rbac",
+			"Nodes":            "nodes",
+			"Search":           "search",
 		},
 		EntriesFuncs: map[string]octant.EntriesFunc{
 			"Custom Resources": navigation.CRDEntries,
 			"RBAC":             rbacEntries,
+			"Nodes":            nil,
+			"Search":           nil,
 		},
 		Order: []string{
 			"Custom Resources",
 			"RBAC",
+			"Nodes",
+			"Search",
 		},
 	}
 
@@ -225,10 +267,22 @@ func rbacEntries(_ context.Context, prefix, _ string, _ store.Store) ([]navigati
 	neh := navigation.NavigationEntriesHelper{}
 	neh.Add("Cluster Roles", "cluster-roles", icon.ClusterOverviewClusterRole)
 	neh.Add("Cluster Role Bindings", "cluster-role-bindings", icon.ClusterOverviewClusterRoleBinding)
+	neh.Add("Subjects", "subjects", icon.OverviewServiceAccount)
+	neh.Add("Who Can", "who-can", icon.OverviewRole)
 
 	return neh.Generate(prefix)
 }
 
+// ActionPaths returns the actions handled by the cluster overview.
+func (co *ClusterOverview) ActionPaths() map[string]action.DispatcherFunc {
+	profiles := newPortForwardProfiles(co.DashConfig.PortForwarder)
+
+	return map[string]action.DispatcherFunc{
+		saveProfileActionName:   profiles.Save,
+		deleteProfileActionName: profiles.Delete,
+	}
+}
+
 func (co *ClusterOverview) SetContext(ctx context.Context, contextName string) error {
 	return nil
 }
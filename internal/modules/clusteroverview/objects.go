@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package clusteroverview
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
 	"github.com/vmware/octant/internal/describer"
@@ -39,13 +40,29 @@ var (
 		IconName:       icon.ClusterOverviewClusterRoleBinding,
 	})
 
+	rbacSubjects = NewRBACSubjectsDescriber("/rbac/subjects")
+
 	rbacDescriber = describer.NewSection(
 		"/rbac",
 		"RBAC",
 		rbacClusterRoles,
 		rbacClusterRoleBindings,
+		rbacSubjects,
 	)
 
+	// rbacWhoCanDescriber isn't part of the RBAC section since it doesn't
+	// describe a list of objects.
+	rbacWhoCanDescriber = NewRBACWhoCanDescriber("/rbac/who-can")
+
+	nodesDescriber = describer.NewResource(describer.ResourceOptions{
+		Path:           "/nodes",
+		ObjectStoreKey: store.Key{APIVersion: "v1", Kind: "Node"},
+		ListType:       &corev1.NodeList{},
+		ObjectType:     &corev1.Node{},
+		Titles:         describer.ResourceTitle{List: "Nodes", Object: "Node"},
+		ClusterWide:    true,
+	})
+
 	portForwardDescriber = NewPortForwardListDescriber()
 
 	rootDescriber = describer.NewSection(
@@ -53,6 +70,7 @@ var (
 		"Cluster Overview",
 		customResourcesDescriber,
 		rbacDescriber,
+		nodesDescriber,
 		portForwardDescriber,
 	)
 )
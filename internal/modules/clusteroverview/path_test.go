@@ -44,6 +44,13 @@ func Test_gvk_path(t *testing.T) {
 			objectName: "cluster-role-binding",
 			expected:   path.Join("/content", "cluster-overview", "rbac", "cluster-role-bindings", "cluster-role-binding"),
 		},
+		{
+			name:       "Node",
+			apiVersion: "v1",
+			kind:       "Node",
+			objectName: "node",
+			expected:   path.Join("/content", "cluster-overview", "nodes", "node"),
+		},
 		{
 			name:       "unknown",
 			apiVersion: "unknown",
@@ -8,6 +8,7 @@ package clusteroverview
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/vmware/octant/internal/describer"
 	"github.com/vmware/octant/internal/portforward"
@@ -28,26 +29,32 @@ func (d *PortForwardListDescriber) Describe(ctx context.Context, prefix, namespa
 
 	list := component.NewList("Port Forwards", nil)
 
-	tblCols := component.NewTableCols("Name", "Ports", "Age")
+	tblCols := component.NewTableCols("Name", "Ports", "Status", "Health", "Preview", "Profile", "Age")
 	tbl := component.NewTable("Port Forwards", tblCols)
 	list.Add(tbl)
 
 	for _, pf := range portForwarder.List() {
 		t := &pf.Target
 		apiVersion, kind := t.GVK.ToAPIVersionAndKind()
-		nameLink ,err := options.Link.ForGVK(t.Namespace, apiVersion, kind, t.Name, t.Name)
+		nameLink, err := options.Link.ForGVK(t.Namespace, apiVersion, kind, t.Name, t.Name)
 		if err != nil {
 			return describer.EmptyContentResponse, err
 		}
 
 		pfRow := component.TableRow{
-			"Name":  nameLink,
-			"Ports": describePortForwardPorts(pf),
-			"Age":   component.NewTimestamp(pf.CreatedAt),
+			"Name":    nameLink,
+			"Ports":   describePortForwardPorts(pf),
+			"Status":  describePortForwardStatus(pf),
+			"Health":  describePortForwardHealth(pf),
+			"Preview": describePortForwardPreview(pf),
+			"Profile": component.NewText(pf.Profile),
+			"Age":     component.NewTimestamp(pf.CreatedAt),
 		}
 		tbl.Add(pfRow)
 	}
 
+	list.Add(describePortForwardProfiles(portForwarder.Profiles()))
+
 	return component.ContentResponse{
 		Components: []component.Component{list},
 	}, nil
@@ -58,6 +65,44 @@ func (d *PortForwardListDescriber) PathFilters() []describer.PathFilter {
 	return []describer.PathFilter{*filter}
 }
 
+func describePortForwardStatus(pf portforward.State) component.Component {
+	status := string(pf.Status)
+	if pf.Message != "" {
+		status = fmt.Sprintf("%s: %s", status, pf.Message)
+	}
+
+	return component.NewText(status)
+}
+
+// describePortForwardHealth describes the last probe of each port. Ports are
+// probed in the background by the port forwarder.
+func describePortForwardHealth(pf portforward.State) component.Component {
+	if len(pf.Health) == 0 {
+		return component.NewText("-")
+	}
+
+	var health []string
+	for i, probe := range pf.Health {
+		if i >= len(pf.Ports) {
+			break
+		}
+
+		health = append(health, fmt.Sprintf("%d: %s", pf.Ports[i].Remote, probe))
+	}
+
+	return component.NewText(strings.Join(health, ", "))
+}
+
+func describePortForwardPreview(pf portforward.State) component.Component {
+	lst := component.NewList("", nil)
+
+	for _, p := range pf.Ports {
+		lst.Add(component.NewLink("", fmt.Sprintf("Open %d", p.Remote), portforward.ProxyURL(pf.ID, p.Remote)))
+	}
+
+	return lst
+}
+
 func describePortForwardPorts(pf portforward.State) component.Component {
 	lst := component.NewList("", nil)
 
@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware/octant/internal/portforward"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func Test_describePortForwardPreview(t *testing.T) {
+	pf := portforward.State{
+		ID:    "id",
+		Ports: []portforward.ForwardedPort{{Local: 8080, Remote: 80}},
+	}
+
+	expected := component.NewList("", nil)
+	expected.Add(component.NewLink("", "Open 80", "/proxy/port-forwards/id/80/"))
+
+	assert.Equal(t, expected, describePortForwardPreview(pf))
+}
+
+func Test_describePortForwardHealth(t *testing.T) {
+	pf := portforward.State{
+		Ports: []portforward.ForwardedPort{
+			{Local: 8080, Remote: 80},
+			{Local: 8443, Remote: 443},
+		},
+	}
+
+	assert.Equal(t, component.NewText("-"), describePortForwardHealth(pf))
+
+	pf.Health = []portforward.ProbeResult{
+		{StatusCode: 200},
+		{Err: errors.New("connection refused")},
+	}
+
+	assert.Equal(t,
+		component.NewText("80: 200 OK (0s), 443: unreachable: connection refused"),
+		describePortForwardHealth(pf))
+}
@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware/octant/internal/portforward"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const (
+	saveProfileActionName   = "portForward/saveProfile"
+	deleteProfileActionName = "portForward/deleteProfile"
+)
+
+// profileAPIVersions are the API versions of the kinds profiles can target.
+var profileAPIVersions = map[string]string{
+	"Service":     "v1",
+	"Deployment":  "apps/v1",
+	"StatefulSet": "apps/v1",
+	"DaemonSet":   "apps/v1",
+	"ReplicaSet":  "apps/v1",
+}
+
+var profileKinds = []string{"Service", "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet"}
+
+// portForwardProfiles handles actions which save and delete port forward
+// profiles.
+type portForwardProfiles struct {
+	portForwarder func() portforward.PortForwarder
+}
+
+func newPortForwardProfiles(portForwarder func() portforward.PortForwarder) *portForwardProfiles {
+	return &portForwardProfiles{
+		portForwarder: portForwarder,
+	}
+}
+
+// Save saves the profile in the payload.
+func (p *portForwardProfiles) Save(ctx context.Context, payload action.Payload) error {
+	name, err := payload.String("profile")
+	if err != nil {
+		return err
+	}
+
+	namespace, err := payload.String("namespace")
+	if err != nil {
+		return err
+	}
+
+	kind, err := payload.String("kind")
+	if err != nil {
+		return err
+	}
+
+	apiVersion, ok := profileAPIVersions[kind]
+	if !ok {
+		return errors.Errorf("profiles can't target %q", kind)
+	}
+
+	target, err := payload.String("target")
+	if err != nil {
+		return err
+	}
+
+	portsValue, err := payload.String("ports")
+	if err != nil {
+		return err
+	}
+
+	ports, err := parseProfilePorts(portsValue)
+	if err != nil {
+		return err
+	}
+
+	profile := portforward.Profile{
+		Name:       strings.TrimSpace(name),
+		Namespace:  strings.TrimSpace(namespace),
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Target:     strings.TrimSpace(target),
+		Ports:      ports,
+	}
+
+	return p.portForwarder().SaveProfile(profile)
+}
+
+// Delete deletes the profile in the payload.
+func (p *portForwardProfiles) Delete(ctx context.Context, payload action.Payload) error {
+	name, err := payload.String("profile")
+	if err != nil {
+		return err
+	}
+
+	return p.portForwarder().DeleteProfile(name)
+}
+
+// parseProfilePorts parses a comma separated list of local:remote ports.
+func parseProfilePorts(value string) ([]portforward.PortForwardPortSpec, error) {
+	var ports []portforward.PortForwardPortSpec
+
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		parts := strings.Split(field, ":")
+		if len(parts) != 2 {
+			return nil, errors.Errorf("port %q is not in local:remote form", field)
+		}
+
+		local, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 16)
+		if err != nil {
+			return nil, errors.Errorf("local port %q is invalid", parts[0])
+		}
+
+		remote, err := strconv.ParseUint(strings.TrimSpace(parts[1]), 10, 16)
+		if err != nil {
+			return nil, errors.Errorf("remote port %q is invalid", parts[1])
+		}
+
+		ports = append(ports, portforward.PortForwardPortSpec{
+			Local:  uint16(local),
+			Remote: uint16(remote),
+		})
+	}
+
+	return ports, nil
+}
+
+func describePortForwardProfiles(profiles []portforward.Profile) component.Component {
+	tbl := component.NewTable("Profiles", component.NewTableCols("Name", "Context", "Target", "Ports"))
+	tbl.SetAccessor("profiles")
+
+	var choices []component.InputChoice
+	for i, profile := range profiles {
+		var ports []string
+		for _, port := range profile.Ports {
+			ports = append(ports, fmt.Sprintf("%d -> %d", port.Local, port.Remote))
+		}
+
+		tbl.Add(component.TableRow{
+			"Name":    component.NewText(profile.Name),
+			"Context": component.NewText(profile.Context),
+			"Target":  component.NewText(fmt.Sprintf("%s %s/%s", profile.Kind, profile.Namespace, profile.Target)),
+			"Ports":   component.NewText(strings.Join(ports, ", ")),
+		})
+
+		choices = append(choices, component.InputChoice{
+			Label:   profile.Name,
+			Value:   profile.Name,
+			Checked: i == 0,
+		})
+	}
+
+	summary := component.NewSummary("Port Forward Profiles", component.SummarySection{
+		Header:  "Profiles",
+		Content: tbl,
+	})
+
+	var kindChoices []component.InputChoice
+	for i, kind := range profileKinds {
+		kindChoices = append(kindChoices, component.InputChoice{
+			Label:   kind,
+			Value:   kind,
+			Checked: i == 0,
+		})
+	}
+
+	summary.AddAction(component.Action{
+		Name:  "Save Profile",
+		Title: "Save port forward profile",
+		Form: component.Form{
+			Fields: []component.FormField{
+				component.NewFormFieldText("Name", "profile", ""),
+				component.NewFormFieldText("Namespace", "namespace", ""),
+				component.NewFormFieldRadio("Kind", "kind", kindChoices),
+				component.NewFormFieldText("Target", "target", ""),
+				component.NewFormFieldText("Ports (local:remote, ...)", "ports", ""),
+				component.NewFormFieldHidden("action", saveProfileActionName),
+			},
+		},
+	})
+
+	if len(choices) > 0 {
+		summary.AddAction(component.Action{
+			Name:  "Delete Profile",
+			Title: "Delete port forward profile",
+			Form: component.Form{
+				Fields: []component.FormField{
+					component.NewFormFieldRadio("Profile", "profile", choices),
+					component.NewFormFieldHidden("action", deleteProfileActionName),
+				},
+			},
+		})
+	}
+
+	return summary
+}
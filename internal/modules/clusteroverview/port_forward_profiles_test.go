@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/portforward"
+	portForwardFake "github.com/vmware/octant/internal/portforward/fake"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func Test_parseProfilePorts(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []portforward.PortForwardPortSpec
+		isErr    bool
+	}{
+		{
+			name:  "ports",
+			value: "8080:80, 8443:443",
+			expected: []portforward.PortForwardPortSpec{
+				{Local: 8080, Remote: 80},
+				{Local: 8443, Remote: 443},
+			},
+		},
+		{
+			name:  "missing local port",
+			value: "80",
+			isErr: true,
+		},
+		{
+			name:  "invalid port",
+			value: "8080:http",
+			isErr: true,
+		},
+		{
+			name:  "port out of range",
+			value: "80800:80",
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseProfilePorts(test.value)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func Test_portForwardProfiles_Save(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	pf := portForwardFake.NewMockPortForwarder(controller)
+	pf.EXPECT().
+		SaveProfile(portforward.Profile{
+			Name:       "web",
+			Namespace:  "default",
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Target:     "web",
+			Ports:      []portforward.PortForwardPortSpec{{Local: 8080, Remote: 80}},
+		}).
+		Return(nil)
+
+	profiles := newPortForwardProfiles(func() portforward.PortForwarder { return pf })
+
+	payload := action.Payload{
+		"profile":   "web",
+		"namespace": "default",
+		"kind":      "Deployment",
+		"target":    "web",
+		"ports":     "8080:80",
+	}
+
+	require.NoError(t, profiles.Save(context.Background(), payload))
+
+	payload["kind"] = "ConfigMap"
+	require.Error(t, profiles.Save(context.Background(), payload))
+}
+
+func Test_portForwardProfiles_Delete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	pf := portForwardFake.NewMockPortForwarder(controller)
+	pf.EXPECT().DeleteProfile("web").Return(nil)
+
+	profiles := newPortForwardProfiles(func() portforward.PortForwarder { return pf })
+
+	require.NoError(t, profiles.Delete(context.Background(), action.Payload{"profile": "web"}))
+}
+
+func Test_describePortForwardProfiles(t *testing.T) {
+	profiles := []portforward.Profile{
+		{
+			Name:       "web",
+			Namespace:  "default",
+			APIVersion: "v1",
+			Kind:       "Service",
+			Target:     "web",
+			Ports:      []portforward.PortForwardPortSpec{{Local: 8080, Remote: 80}},
+		},
+	}
+
+	got := describePortForwardProfiles(profiles)
+
+	summary, ok := got.(*component.Summary)
+	require.True(t, ok)
+	require.Len(t, summary.Config.Actions, 2)
+	assert.Equal(t, "Save Profile", summary.Config.Actions[0].Name)
+	assert.Equal(t, "Delete Profile", summary.Config.Actions[1].Name)
+
+	empty := describePortForwardProfiles(nil).(*component.Summary)
+	require.Len(t, empty.Config.Actions, 1)
+}
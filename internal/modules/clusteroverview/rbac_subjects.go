@@ -0,0 +1,299 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/link"
+	"github.com/vmware/octant/internal/rbac"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const (
+	rbacSubjectsPath = "/content/cluster-overview/rbac/subjects"
+	rbacWhoCanPath   = "/content/cluster-overview/rbac/who-can"
+)
+
+// RBACSubjectsDescriber describes the users, groups and service accounts
+// which appear in role bindings and cluster role bindings.
+type RBACSubjectsDescriber struct {
+	path string
+}
+
+var _ describer.Describer = (*RBACSubjectsDescriber)(nil)
+
+// NewRBACSubjectsDescriber creates an instance of RBACSubjectsDescriber.
+func NewRBACSubjectsDescriber(p string) *RBACSubjectsDescriber {
+	return &RBACSubjectsDescriber{path: p}
+}
+
+// PathFilters returns path filters for the subject list and subjects.
+// Service accounts are namespaced, so their path includes the namespace.
+func (d *RBACSubjectsDescriber) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter(d.path, d),
+		*describer.NewPathFilter(fmt.Sprintf("%s/(?P<kind>User|Group)/(?P<name>[^/]+)", d.path), d),
+		*describer.NewPathFilter(fmt.Sprintf("%s/(?P<kind>ServiceAccount)/(?P<namespace>[^/]+)/(?P<name>[^/]+)", d.path), d),
+	}
+}
+
+// Describe describes the subject list, or a subject if the kind and name
+// fields are set.
+func (d *RBACSubjectsDescriber) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	analysis, err := rbac.Load(ctx, options.ObjectStore())
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	if options.Fields["kind"] == "" {
+		return describeSubjects(analysis), nil
+	}
+
+	subject := rbac.Subject{
+		Kind:      options.Fields["kind"],
+		Name:      options.Fields["name"],
+		Namespace: options.Fields["namespace"],
+	}
+
+	return describeSubject(analysis, subject, options.Link)
+}
+
+func describeSubjects(analysis *rbac.Analysis) component.ContentResponse {
+	list := component.NewList("RBAC / Subjects", nil)
+
+	cols := component.NewTableCols("Name", "Kind", "Namespace", "Bindings")
+	table := component.NewTable("Subjects", cols)
+	list.Add(table)
+
+	for _, subject := range analysis.Subjects() {
+		table.Add(component.TableRow{
+			"Name":      component.NewLink("", subject.Name, subjectPath(subject)),
+			"Kind":      component.NewText(subject.Kind),
+			"Namespace": component.NewText(subject.Namespace),
+			"Bindings":  component.NewText(fmt.Sprintf("%d", len(analysis.Grants(subject)))),
+		})
+	}
+
+	return component.ContentResponse{
+		Components: []component.Component{list},
+	}
+}
+
+func describeSubject(analysis *rbac.Analysis, subject rbac.Subject, l link.Interface) (component.ContentResponse, error) {
+	title := component.Title(
+		component.NewLink("", "RBAC", "/content/cluster-overview/rbac"),
+		component.NewLink("", "Subjects", rbacSubjectsPath),
+		component.NewText(subject.Name))
+	cr := component.NewContentResponse(title)
+
+	var sections []component.SummarySection
+	sections = append(sections, component.SummarySection{Header: "Kind", Content: component.NewText(subject.Kind)})
+
+	if subject.Kind == rbacv1.ServiceAccountKind {
+		serviceAccountLink, err := l.ForGVK(subject.Namespace, "v1", "ServiceAccount", subject.Name, subject.Name)
+		if err != nil {
+			return describer.EmptyContentResponse, err
+		}
+		sections = append(sections,
+			component.SummarySection{Header: "Namespace", Content: component.NewText(subject.Namespace)},
+			component.SummarySection{Header: "Service Account", Content: serviceAccountLink})
+	}
+
+	grants := analysis.Grants(subject)
+	bindings, err := grantLinks(l, grants)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+	sections = append(sections, component.SummarySection{Header: "Bindings", Content: bindings})
+
+	summary := component.NewSummary("Subject", sections...)
+	summary.SetAccessor("summary")
+	cr.Add(summary)
+
+	cols := component.NewTableCols("Namespace", "API Group", "Resource", "Resource Names", "Verbs", "Granted By")
+	table := component.NewTable("Effective Rules", cols)
+
+	for _, rule := range analysis.Rules(subject) {
+		row, err := ruleRow(l, rule)
+		if err != nil {
+			return describer.EmptyContentResponse, err
+		}
+		table.Add(row)
+	}
+
+	table.SetAccessor("rules")
+	cr.Add(table)
+
+	return *cr, nil
+}
+
+// RBACWhoCanDescriber describes the subjects which can perform a verb on a
+// resource.
+type RBACWhoCanDescriber struct {
+	path string
+}
+
+var _ describer.Describer = (*RBACWhoCanDescriber)(nil)
+
+// NewRBACWhoCanDescriber creates an instance of RBACWhoCanDescriber.
+func NewRBACWhoCanDescriber(p string) *RBACWhoCanDescriber {
+	return &RBACWhoCanDescriber{path: p}
+}
+
+// PathFilters returns path filters for who can queries. A query without a
+// namespace only matches cluster wide permissions.
+func (d *RBACWhoCanDescriber) PathFilters() []describer.PathFilter {
+	query := fmt.Sprintf("%s/(?P<verb>[^/]+)/(?P<resource>[^/]+)", d.path)
+
+	return []describer.PathFilter{
+		*describer.NewPathFilter(d.path, d),
+		*describer.NewPathFilter(query, d),
+		*describer.NewPathFilter(query+"/(?P<namespace>[^/]+)", d),
+	}
+}
+
+// whoCanExamples are the queries shown when no query is given.
+var whoCanExamples = []string{
+	"get/secrets",
+	"create/pods",
+	"delete/deployments.apps",
+	"*/*",
+}
+
+// Describe describes the subjects which match the verb, resource and
+// namespace fields. Without a verb and resource, example queries are shown.
+func (d *RBACWhoCanDescriber) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	verb, resource := options.Fields["verb"], options.Fields["resource"]
+
+	if verb == "" || resource == "" {
+		title := component.Title(component.NewText("RBAC"), component.NewText("Who Can"))
+		cr := component.NewContentResponse(title)
+
+		var examples []component.Component
+		for _, example := range whoCanExamples {
+			examples = append(examples, component.NewLink("", "who can "+strings.Replace(example, "/", " ", -1), path.Join(rbacWhoCanPath, example)))
+		}
+
+		summary := component.NewSummary("Who Can",
+			component.SummarySection{
+				Header:  "Usage",
+				Content: component.NewText(fmt.Sprintf("%s/<verb>/<resource>[.<group>][/<namespace>]", rbacWhoCanPath)),
+			},
+			component.SummarySection{
+				Header:  "Examples",
+				Content: component.NewList("", examples),
+			},
+		)
+		cr.Add(summary)
+
+		return *cr, nil
+	}
+
+	analysis, err := rbac.Load(ctx, options.ObjectStore())
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	queryNamespace := options.Fields["namespace"]
+	scope := "cluster wide"
+	if queryNamespace != "" {
+		scope = "in " + queryNamespace
+	}
+
+	title := component.Title(
+		component.NewLink("", "RBAC", "/content/cluster-overview/rbac"),
+		component.NewLink("", "Who Can", rbacWhoCanPath),
+		component.NewText(fmt.Sprintf("%s %s %s", verb, resource, scope)))
+	cr := component.NewContentResponse(title)
+
+	cols := component.NewTableCols("Subject", "Kind", "Namespace", "API Group", "Resource", "Resource Names", "Verbs", "Granted By")
+	table := component.NewTable("Subjects", cols)
+
+	for _, access := range analysis.WhoCan(verb, resource, queryNamespace) {
+		row, err := ruleRow(options.Link, access.Rule)
+		if err != nil {
+			return describer.EmptyContentResponse, err
+		}
+		row["Subject"] = component.NewLink("", access.Subject.Name, subjectPath(access.Subject))
+		row["Kind"] = component.NewText(access.Subject.Kind)
+		table.Add(row)
+	}
+
+	table.SetAccessor("subjects")
+	cr.Add(table)
+
+	return *cr, nil
+}
+
+// subjectPath returns the content path for a subject.
+func subjectPath(subject rbac.Subject) string {
+	if subject.Namespace != "" {
+		return path.Join(rbacSubjectsPath, subject.Kind, subject.Namespace, subject.Name)
+	}
+	return path.Join(rbacSubjectsPath, subject.Kind, subject.Name)
+}
+
+// ruleRow creates a table row for an effective rule. Non resource URLs are
+// shown in the resource column.
+func ruleRow(l link.Interface, rule rbac.Rule) (component.TableRow, error) {
+	namespace := rule.Namespace
+	if namespace == "" {
+		namespace = "*"
+	}
+
+	resource := rule.Resource
+	if rule.NonResourceURL != "" {
+		resource = rule.NonResourceURL
+	}
+
+	apiGroup := rule.APIGroup
+	if apiGroup == "" && rule.NonResourceURL == "" {
+		apiGroup = "core"
+	}
+
+	grantedBy, err := grantLinks(l, rule.Grants)
+	if err != nil {
+		return nil, err
+	}
+
+	return component.TableRow{
+		"Namespace":      component.NewText(namespace),
+		"API Group":      component.NewText(apiGroup),
+		"Resource":       component.NewText(resource),
+		"Resource Names": component.NewText(strings.Join(rule.ResourceNames, ", ")),
+		"Verbs":          component.NewText(strings.Join(rule.Verbs, ", ")),
+		"Granted By":     grantedBy,
+	}, nil
+}
+
+// grantLinks creates links to the bindings and roles in grants.
+func grantLinks(l link.Interface, grants []rbac.Grant) (*component.List, error) {
+	var items []component.Component
+	for _, grant := range grants {
+		bindingLink, err := l.ForGVK(grant.BindingNamespace, rbacAPIVersion, grant.BindingKind, grant.BindingName,
+			fmt.Sprintf("%s %s", grant.BindingKind, grant.BindingName))
+		if err != nil {
+			return nil, err
+		}
+
+		roleLink, err := l.ForGVK(grant.RoleNamespace(), rbacAPIVersion, grant.RoleKind, grant.RoleName,
+			fmt.Sprintf("%s %s", grant.RoleKind, grant.RoleName))
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, bindingLink, roleLink)
+	}
+
+	return component.NewList("", items), nil
+}
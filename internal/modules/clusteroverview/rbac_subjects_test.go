@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/describer"
+	linkFake "github.com/vmware/octant/internal/link/fake"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func rbacOptions(t *testing.T, controller *gomock.Controller, fields map[string]string) (describer.Options, *linkFake.MockInterface) {
+	role := testutil.CreateRole("pod-reader")
+	rb := testutil.CreateRoleBinding("pod-readers", "pod-reader", []rbacv1.Subject{
+		*testutil.CreateRoleBindingSubject("User", "alice", ""),
+		*testutil.CreateRoleBindingSubject("ServiceAccount", "default", "namespace"),
+	})
+
+	lists := map[string][]*unstructured.Unstructured{
+		"ClusterRole":        nil,
+		"Role":               testutil.ToUnstructuredList(t, role),
+		"ClusterRoleBinding": nil,
+		"RoleBinding":        testutil.ToUnstructuredList(t, rb),
+	}
+
+	objectStore := storeFake.NewMockStore(controller)
+	for kind, list := range lists {
+		objectStore.EXPECT().
+			List(gomock.Any(), store.Key{APIVersion: rbacAPIVersion, Kind: kind}).
+			Return(list, nil)
+	}
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+
+	l := linkFake.NewMockInterface(controller)
+
+	return describer.Options{
+		Dash:   dashConfig,
+		Link:   l,
+		Fields: fields,
+	}, l
+}
+
+func TestRBACSubjectsDescriber_list(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	options, _ := rbacOptions(t, controller, map[string]string{})
+
+	d := NewRBACSubjectsDescriber("/rbac/subjects")
+	got, err := d.Describe(context.Background(), "/prefix", "", options)
+	require.NoError(t, err)
+
+	list := component.NewList("RBAC / Subjects", nil)
+	table := component.NewTable("Subjects", component.NewTableCols("Name", "Kind", "Namespace", "Bindings"))
+	table.Add(
+		component.TableRow{
+			"Name":      component.NewLink("", "default", "/content/cluster-overview/rbac/subjects/ServiceAccount/namespace/default"),
+			"Kind":      component.NewText("ServiceAccount"),
+			"Namespace": component.NewText("namespace"),
+			"Bindings":  component.NewText("1"),
+		},
+		component.TableRow{
+			"Name":      component.NewLink("", "alice", "/content/cluster-overview/rbac/subjects/User/alice"),
+			"Kind":      component.NewText("User"),
+			"Namespace": component.NewText(""),
+			"Bindings":  component.NewText("1"),
+		},
+	)
+	list.Add(table)
+
+	expected := component.ContentResponse{
+		Components: []component.Component{list},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestRBACSubjectsDescriber_subject(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	options, l := rbacOptions(t, controller, map[string]string{
+		"kind":      "ServiceAccount",
+		"namespace": "namespace",
+		"name":      "default",
+	})
+
+	serviceAccountLink := component.NewLink("", "default", "/service-account")
+	bindingLink := component.NewLink("", "RoleBinding pod-readers", "/role-binding")
+	roleLink := component.NewLink("", "Role pod-reader", "/role")
+
+	l.EXPECT().ForGVK("namespace", "v1", "ServiceAccount", "default", "default").Return(serviceAccountLink, nil)
+	l.EXPECT().ForGVK("namespace", rbacAPIVersion, "RoleBinding", "pod-readers", "RoleBinding pod-readers").
+		Return(bindingLink, nil).Times(2)
+	l.EXPECT().ForGVK("namespace", rbacAPIVersion, "Role", "pod-reader", "Role pod-reader").
+		Return(roleLink, nil).Times(2)
+
+	d := NewRBACSubjectsDescriber("/rbac/subjects")
+	got, err := d.Describe(context.Background(), "/prefix", "", options)
+	require.NoError(t, err)
+
+	grantedBy := component.NewList("", []component.Component{bindingLink, roleLink})
+
+	summary := component.NewSummary("Subject",
+		component.SummarySection{Header: "Kind", Content: component.NewText("ServiceAccount")},
+		component.SummarySection{Header: "Namespace", Content: component.NewText("namespace")},
+		component.SummarySection{Header: "Service Account", Content: serviceAccountLink},
+		component.SummarySection{Header: "Bindings", Content: grantedBy},
+	)
+	summary.SetAccessor("summary")
+
+	table := component.NewTable("Effective Rules",
+		component.NewTableCols("Namespace", "API Group", "Resource", "Resource Names", "Verbs", "Granted By"))
+	table.Add(component.TableRow{
+		"Namespace":      component.NewText("namespace"),
+		"API Group":      component.NewText("core"),
+		"Resource":       component.NewText("pods"),
+		"Resource Names": component.NewText(""),
+		"Verbs":          component.NewText("get, list, watch"),
+		"Granted By":     grantedBy,
+	})
+	table.SetAccessor("rules")
+
+	require.Len(t, got.Components, 2)
+	assert.Equal(t, summary, got.Components[0])
+	assert.Equal(t, table, got.Components[1])
+}
+
+func TestRBACWhoCanDescriber(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	options, l := rbacOptions(t, controller, map[string]string{
+		"verb":      "list",
+		"resource":  "pods",
+		"namespace": "namespace",
+	})
+
+	bindingLink := component.NewLink("", "RoleBinding pod-readers", "/role-binding")
+	roleLink := component.NewLink("", "Role pod-reader", "/role")
+	l.EXPECT().ForGVK("namespace", rbacAPIVersion, "RoleBinding", "pod-readers", "RoleBinding pod-readers").
+		Return(bindingLink, nil).AnyTimes()
+	l.EXPECT().ForGVK("namespace", rbacAPIVersion, "Role", "pod-reader", "Role pod-reader").
+		Return(roleLink, nil).AnyTimes()
+
+	d := NewRBACWhoCanDescriber("/rbac/who-can")
+	got, err := d.Describe(context.Background(), "/prefix", "", options)
+	require.NoError(t, err)
+
+	require.Len(t, got.Components, 1)
+	table, ok := got.Components[0].(*component.Table)
+	require.True(t, ok)
+
+	var subjects []component.Component
+	for _, row := range table.Rows() {
+		subjects = append(subjects, row["Subject"])
+	}
+
+	expected := []component.Component{
+		component.NewLink("", "default", "/content/cluster-overview/rbac/subjects/ServiceAccount/namespace/default"),
+		component.NewLink("", "alice", "/content/cluster-overview/rbac/subjects/User/alice"),
+	}
+	assert.Equal(t, expected, subjects)
+}
+
+func TestRBACWhoCanDescriber_examples(t *testing.T) {
+	d := NewRBACWhoCanDescriber("/rbac/who-can")
+	got, err := d.Describe(context.Background(), "/prefix", "", describer.Options{Fields: map[string]string{}})
+	require.NoError(t, err)
+
+	require.Len(t, got.Components, 1)
+	summary, ok := got.Components[0].(*component.Summary)
+	require.True(t, ok)
+	assert.Len(t, summary.Sections(), 2)
+}
@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware/octant/internal/api"
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/link"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/mime"
+	"github.com/vmware/octant/internal/search"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const searchPath = "/content/cluster-overview/search"
+
+// searchExamples are the queries shown when no query is given.
+var searchExamples = []string{
+	"nginx",
+	"label:app=web",
+	"image:redis kind:Pod",
+	"owner:Deployment/web",
+	"node:worker-1 ns:default",
+}
+
+// SearchDescriber describes objects which match a search query.
+type SearchDescriber struct {
+	path     string
+	searcher search.Searcher
+}
+
+var _ describer.Describer = (*SearchDescriber)(nil)
+
+// NewSearchDescriber creates an instance of SearchDescriber.
+func NewSearchDescriber(p string, searcher search.Searcher) *SearchDescriber {
+	return &SearchDescriber{
+		path:     p,
+		searcher: searcher,
+	}
+}
+
+// PathFilters returns path filters for search queries. The query is the
+// rest of the path, so it can contain owner names with a slash.
+func (d *SearchDescriber) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter(d.path, d),
+		*describer.NewPathFilter(d.path+"/(?P<query>.+)", d),
+	}
+}
+
+// Describe describes the objects which match the query field. Without a
+// query, example queries are shown.
+func (d *SearchDescriber) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	queryString := options.Fields["query"]
+
+	if strings.TrimSpace(queryString) == "" {
+		cr := component.NewContentResponse(component.TitleFromString("Search"))
+
+		var examples []component.Component
+		for _, example := range searchExamples {
+			examples = append(examples, component.NewLink("", example, path.Join(searchPath, example)))
+		}
+
+		summary := component.NewSummary("Search",
+			component.SummarySection{
+				Header:  "Usage",
+				Content: component.NewText(fmt.Sprintf("%s/<query>", searchPath)),
+			},
+			component.SummarySection{
+				Header:  "Qualifiers",
+				Content: component.NewText("label:<key>[=<value>] annotation:<key>[=<value>] owner:[<kind>/]<name> image:<name> node:<name> kind:<kind> ns:<namespace>"),
+			},
+			component.SummarySection{
+				Header:  "Examples",
+				Content: component.NewList("", examples),
+			},
+		)
+		cr.Add(summary)
+
+		return *cr, nil
+	}
+
+	title := component.Title(
+		component.NewLink("", "Search", searchPath),
+		component.NewText(queryString))
+	cr := component.NewContentResponse(title)
+
+	query, err := search.ParseQuery(queryString)
+	if err != nil {
+		cr.Add(component.NewText(fmt.Sprintf("Invalid query: %v", err)))
+		return *cr, nil
+	}
+
+	cr.Add(searchResultsTable(options.Link, d.searcher.Search(query, search.DefaultLimit)))
+
+	return *cr, nil
+}
+
+// searchResultsTable creates a table of search results in ranked order.
+// Objects Octant can't link to are shown by name.
+func searchResultsTable(l link.Interface, results []search.Result) *component.Table {
+	cols := component.NewTableCols("Name", "Kind", "Namespace", "Score")
+	table := component.NewTable("Results", cols)
+
+	for _, result := range results {
+		var name component.Component = component.NewText(result.Name)
+		if nameLink, err := l.ForGVK(result.Namespace, result.APIVersion, result.Kind, result.Name, result.Name); err == nil {
+			name = nameLink
+		}
+
+		table.Add(component.TableRow{
+			"Name":      name,
+			"Kind":      component.NewText(result.Kind),
+			"Namespace": component.NewText(result.Namespace),
+			"Score":     component.NewText(strconv.Itoa(result.Score)),
+		})
+	}
+
+	table.SetAccessor("results")
+
+	return table
+}
+
+// searchResult is a search result in a search response. Only what is
+// needed to show and link to the object is returned.
+type searchResult struct {
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Score     int    `json:"score"`
+	Path      string `json:"path,omitempty"`
+}
+
+// searchResponse is the response to a search request.
+type searchResponse struct {
+	Query   string         `json:"query"`
+	Results []searchResult `json:"results"`
+}
+
+// searchHandler is a JSON API for searching. The query is given by q and
+// the number of results by limit.
+type searchHandler struct {
+	searcher search.Searcher
+	link     link.Interface
+	logger   log.Logger
+}
+
+var _ http.Handler = (*searchHandler)(nil)
+
+func newSearchHandler(searcher search.Searcher, l link.Interface, logger log.Logger) (*searchHandler, error) {
+	if searcher == nil {
+		return nil, errors.New("searcher is nil")
+	}
+
+	if l == nil {
+		return nil, errors.New("link is nil")
+	}
+
+	return &searchHandler{
+		searcher: searcher,
+		link:     l,
+		logger:   logger,
+	}, nil
+}
+
+func (h *searchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		api.RespondWithError(w, http.StatusNotFound, fmt.Sprintf("unhandled HTTP method %s for search", r.Method), h.logger)
+		return
+	}
+
+	q := r.URL.Query()
+	queryString := q.Get("q")
+
+	query, err := search.ParseQuery(queryString)
+	if err != nil {
+		api.RespondWithError(w, http.StatusBadRequest, err.Error(), h.logger)
+		return
+	}
+
+	limit := search.DefaultLimit
+	if s := q.Get("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 1 {
+			api.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid limit %q", s), h.logger)
+			return
+		}
+	}
+
+	resp := searchResponse{
+		Query:   queryString,
+		Results: []searchResult{},
+	}
+
+	for _, result := range h.searcher.Search(query, limit) {
+		sr := searchResult{
+			Name:      result.Name,
+			Kind:      result.Kind,
+			Namespace: result.Namespace,
+			Score:     result.Score,
+		}
+		// objects Octant can't link to are still returned without a path.
+		if l, err := h.link.ForGVK(result.Namespace, result.APIVersion, result.Kind, result.Name, result.Name); err == nil {
+			sr.Path = l.Ref()
+		}
+		resp.Results = append(resp.Results, sr)
+	}
+
+	w.Header().Set("Content-Type", mime.JSONContentType)
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.With("err", err.Error()).Errorf("encoding JSON response")
+	}
+}
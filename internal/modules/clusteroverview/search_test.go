@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/describer"
+	linkFake "github.com/vmware/octant/internal/link/fake"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/search"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// stubSearcher returns results and records the last query.
+type stubSearcher struct {
+	results []search.Result
+	query   search.Query
+	limit   int
+}
+
+func (s *stubSearcher) Search(query search.Query, limit int) []search.Result {
+	s.query = query
+	s.limit = limit
+	return s.results
+}
+
+func searchResults() []search.Result {
+	return []search.Result{
+		{Namespace: "default", APIVersion: "v1", Kind: "Pod", Name: "web", Score: 100},
+		{APIVersion: "v1", Kind: "Namespace", Name: "web", Score: 100},
+	}
+}
+
+func TestSearchDescriber(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().
+		ForGVK("default", "v1", "Pod", "web", "web").
+		Return(component.NewLink("", "web", "/pod"), nil)
+	l.EXPECT().
+		ForGVK("", "v1", "Namespace", "web", "web").
+		Return(nil, errors.New("no path"))
+
+	searcher := &stubSearcher{results: searchResults()}
+	d := NewSearchDescriber("/search", searcher)
+
+	options := describer.Options{
+		Link:   l,
+		Fields: map[string]string{"query": "web kind:Pod"},
+	}
+
+	got, err := d.Describe(context.Background(), "/prefix", "", options)
+	require.NoError(t, err)
+
+	assert.Len(t, searcher.query.Terms, 2)
+	assert.Equal(t, search.DefaultLimit, searcher.limit)
+
+	expected := component.NewTable("Results", component.NewTableCols("Name", "Kind", "Namespace", "Score"))
+	expected.Add(
+		component.TableRow{
+			"Name":      component.NewLink("", "web", "/pod"),
+			"Kind":      component.NewText("Pod"),
+			"Namespace": component.NewText("default"),
+			"Score":     component.NewText("100"),
+		},
+		component.TableRow{
+			"Name":      component.NewText("web"),
+			"Kind":      component.NewText("Namespace"),
+			"Namespace": component.NewText(""),
+			"Score":     component.NewText("100"),
+		},
+	)
+	expected.SetAccessor("results")
+
+	require.Len(t, got.Components, 1)
+	assert.Equal(t, expected, got.Components[0])
+}
+
+func TestSearchDescriber_examples(t *testing.T) {
+	d := NewSearchDescriber("/search", &stubSearcher{})
+	got, err := d.Describe(context.Background(), "/prefix", "", describer.Options{Fields: map[string]string{}})
+	require.NoError(t, err)
+
+	require.Len(t, got.Components, 1)
+	summary, ok := got.Components[0].(*component.Summary)
+	require.True(t, ok)
+	assert.Len(t, summary.Sections(), 3)
+}
+
+func TestSearchDescriber_invalid_query(t *testing.T) {
+	d := NewSearchDescriber("/search", &stubSearcher{})
+	options := describer.Options{Fields: map[string]string{"query": "label:"}}
+
+	got, err := d.Describe(context.Background(), "/prefix", "", options)
+	require.NoError(t, err)
+
+	require.Len(t, got.Components, 1)
+	assert.Equal(t, component.NewText("Invalid query: label:: value is blank"), got.Components[0])
+}
+
+func Test_searchHandler(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().
+		ForGVK("default", "v1", "Pod", "web", "web").
+		Return(component.NewLink("", "web", "/pod"), nil).AnyTimes()
+	l.EXPECT().
+		ForGVK("", "v1", "Namespace", "web", "web").
+		Return(nil, errors.New("no path")).AnyTimes()
+
+	searcher := &stubSearcher{results: searchResults()}
+	handler, err := newSearchHandler(searcher, l, log.NopLogger())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		expectedCode int
+	}{
+		{name: "search", method: http.MethodGet, url: "/?q=web&limit=10", expectedCode: http.StatusOK},
+		{name: "invalid query", method: http.MethodGet, url: "/?q=label:", expectedCode: http.StatusBadRequest},
+		{name: "invalid limit", method: http.MethodGet, url: "/?q=web&limit=none", expectedCode: http.StatusBadRequest},
+		{name: "unsupported method", method: http.MethodPost, url: "/?q=web", expectedCode: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(test.method, test.url, nil)
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, test.expectedCode, w.Code)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?q=web&limit=10", nil))
+	assert.Equal(t, 10, searcher.limit)
+
+	var resp searchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "web", resp.Query)
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, "/pod", resp.Results[0].Path)
+	assert.Equal(t, "Pod", resp.Results[0].Kind)
+	assert.Equal(t, "", resp.Results[1].Path)
+}
+
+func Test_searchHandler_omits_annotations(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().
+		ForGVK(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(component.NewLink("", "web", "/object"), nil).AnyTimes()
+
+	pod := testutil.CreatePod("web")
+	pod.Annotations = map[string]string{"team": "payments"}
+	secret := testutil.CreateSecret("web")
+	secret.UID = "secret"
+	secret.Annotations = map[string]string{"token": "s3cr3t"}
+
+	index := search.NewIndex()
+	for _, object := range testutil.ToUnstructuredList(t, pod, secret) {
+		index.Add(object)
+	}
+
+	handler, err := newSearchHandler(index, l, log.NopLogger())
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?q=web", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.NotContains(t, w.Body.String(), "payments")
+	assert.NotContains(t, w.Body.String(), "s3cr3t")
+
+	var resp struct {
+		Results []map[string]interface{} `json:"results"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Results, 2)
+
+	for _, result := range resp.Results {
+		var keys []string
+		for key := range result {
+			keys = append(keys, key)
+		}
+		assert.ElementsMatch(t, []string{"name", "kind", "namespace", "score", "path"}, keys)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"path"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/module"
 	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/pkg/action"
 	"github.com/vmware/octant/pkg/icon"
 	"github.com/vmware/octant/pkg/navigation"
 	"github.com/vmware/octant/pkg/view/component"
@@ -71,6 +73,19 @@ func (c *Configuration) Handlers(ctx context.Context) map[string]http.Handler {
 	}
 }
 
+// ActionPaths returns the actions handled by the configuration module.
+func (c *Configuration) ActionPaths() map[string]action.DispatcherFunc {
+	actions := newKubeConfigActions(afero.NewOsFs(), c.DashConfig)
+
+	return map[string]action.DispatcherFunc{
+		renameContextActionName:    actions.Rename,
+		deleteContextActionName:    actions.Delete,
+		duplicateContextActionName: actions.Duplicate,
+		setNamespaceActionName:     actions.SetNamespace,
+		importKubeConfigActionName: actions.Import,
+	}
+}
+
 func (c *Configuration) SetContext(ctx context.Context, contextName string) error {
 	return nil
 }
@@ -87,6 +102,7 @@ func (c *Configuration) Content(ctx context.Context, contentPath, prefix, namesp
 	options := describer.Options{
 		Fields:   pf.Fields(contentPath),
 		LabelSet: opts.LabelSet,
+		Filter:   opts.Filter,
 		Dash:     c.DashConfig,
 	}
 
@@ -114,6 +130,11 @@ func (c *Configuration) Navigation(ctx context.Context, namespace, root string)
 					Path:     path.Join("/content", c.ContentPath(), "plugins"),
 					IconName: icon.ConfigurationPlugin,
 				},
+				{
+					Title:    "Kube Config",
+					Path:     path.Join("/content", c.ContentPath(), "kube-config"),
+					IconName: icon.Configuration,
+				},
 			},
 		},
 	}, nil
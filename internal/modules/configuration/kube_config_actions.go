@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configuration
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/kubeconfig"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/pkg/action"
+)
+
+const (
+	renameContextActionName    = "kubeConfig/renameContext"
+	deleteContextActionName    = "kubeConfig/deleteContext"
+	duplicateContextActionName = "kubeConfig/duplicateContext"
+	setNamespaceActionName     = "kubeConfig/setContextNamespace"
+	importKubeConfigActionName = "kubeConfig/import"
+)
+
+// trustCredentialPluginsValue is the value of the check box which confirms
+// the users in an imported snippet may run commands to get credentials.
+const trustCredentialPluginsValue = "trust"
+
+// kubeConfigActions handles actions which change the kube config. They are
+// dispatched by the API's action endpoint, which rejects requests from other
+// sites.
+type kubeConfigActions struct {
+	fs         afero.Fs
+	dashConfig config.Dash
+}
+
+func newKubeConfigActions(fs afero.Fs, dashConfig config.Dash) *kubeConfigActions {
+	return &kubeConfigActions{
+		fs:         fs,
+		dashConfig: dashConfig,
+	}
+}
+
+func (a *kubeConfigActions) editor() *kubeconfig.Editor {
+	return kubeconfig.NewEditor(a.fs, a.dashConfig.KubeConfigPath())
+}
+
+// Rename renames a context. Octant switches to the new name if it is using
+// the renamed context.
+func (a *kubeConfigActions) Rename(ctx context.Context, payload action.Payload) error {
+	name, err := payload.String("contextName")
+	if err != nil {
+		return err
+	}
+
+	newName, err := payload.String("newName")
+	if err != nil {
+		return err
+	}
+	newName = strings.TrimSpace(newName)
+
+	editor := a.editor()
+
+	inUse, err := inUseContext(a.dashConfig, editor)
+	if err != nil {
+		return err
+	}
+
+	if err := editor.RenameContext(name, newName); err != nil {
+		return err
+	}
+
+	log.From(ctx).With("context", name, "new-name", newName).Infof("renamed kube config context")
+
+	if name != inUse {
+		return nil
+	}
+
+	return a.dashConfig.UseContext(ctx, newName)
+}
+
+// Delete deletes a context. The context Octant is using can't be deleted.
+func (a *kubeConfigActions) Delete(ctx context.Context, payload action.Payload) error {
+	name, err := payload.String("contextName")
+	if err != nil {
+		return err
+	}
+
+	editor := a.editor()
+
+	inUse, err := inUseContext(a.dashConfig, editor)
+	if err != nil {
+		return err
+	}
+
+	if name == inUse {
+		return errors.Errorf("context %q is in use, switch to another context before deleting it", name)
+	}
+
+	if err := editor.DeleteContext(name); err != nil {
+		return err
+	}
+
+	log.From(ctx).With("context", name).Infof("deleted kube config context")
+	return nil
+}
+
+// Duplicate copies a context to a new name.
+func (a *kubeConfigActions) Duplicate(ctx context.Context, payload action.Payload) error {
+	name, err := payload.String("contextName")
+	if err != nil {
+		return err
+	}
+
+	newName, err := payload.String("newName")
+	if err != nil {
+		return err
+	}
+	newName = strings.TrimSpace(newName)
+
+	if err := a.editor().DuplicateContext(name, newName); err != nil {
+		return err
+	}
+
+	log.From(ctx).With("context", name, "new-name", newName).Infof("duplicated kube config context")
+	return nil
+}
+
+// SetNamespace sets a context's default namespace.
+func (a *kubeConfigActions) SetNamespace(ctx context.Context, payload action.Payload) error {
+	name, err := payload.String("contextName")
+	if err != nil {
+		return err
+	}
+
+	namespace, err := payload.String("namespace")
+	if err != nil {
+		return err
+	}
+
+	if err := a.editor().SetContextNamespace(name, namespace); err != nil {
+		return err
+	}
+
+	log.From(ctx).With("context", name, "namespace", namespace).Infof("set kube config context namespace")
+	return nil
+}
+
+// Import imports a kube config snippet. Users which run a command to get
+// credentials are only imported if the user trusts them.
+func (a *kubeConfigActions) Import(ctx context.Context, payload action.Payload) error {
+	snippet, err := payload.String("snippet")
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(snippet) == "" {
+		return errors.New("snippet is blank")
+	}
+
+	// the check box isn't sent if it isn't checked.
+	trusted := false
+	if options, err := payload.StringSlice("trustCredentialPlugins"); err == nil {
+		trusted = len(options) > 0
+	}
+
+	contexts, err := a.editor().Import([]byte(snippet), trusted)
+	if err != nil {
+		return err
+	}
+
+	log.From(ctx).With("contexts", contexts).Infof("imported kube config snippet")
+	return nil
+}
+
+// inUseContext returns the context Octant is using.
+func inUseContext(dashConfig config.Dash, editor *kubeconfig.Editor) (string, error) {
+	if name := dashConfig.ContextName(); name != "" {
+		return name, nil
+	}
+
+	return editor.CurrentContext()
+}
@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/kubeconfig"
+	"github.com/vmware/octant/pkg/action"
+)
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: development
+  cluster:
+    server: https://dev.example.com
+users:
+- name: developer
+  user:
+    token: secret
+contexts:
+- name: dev
+  context:
+    cluster: development
+    user: developer
+- name: scratch
+  context:
+    cluster: development
+    user: developer
+current-context: dev
+`
+
+func newTestKubeConfigFs(t *testing.T) afero.Fs {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/kubeconfig", []byte(testKubeConfig), 0600))
+	return fs
+}
+
+func contextNames(t *testing.T, fs afero.Fs) []string {
+	contexts, err := kubeconfig.NewEditor(fs, "/kubeconfig").Contexts()
+	require.NoError(t, err)
+
+	var names []string
+	for _, c := range contexts {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func Test_kubeConfigActions_Rename(t *testing.T) {
+	tests := []struct {
+		name       string
+		rename     string
+		expectUse  bool
+		expectList []string
+	}{
+		{
+			name:       "context not in use",
+			rename:     "scratch",
+			expectList: []string{"dev", "renamed"},
+		},
+		{
+			name:       "context in use",
+			rename:     "dev",
+			expectUse:  true,
+			expectList: []string{"renamed", "scratch"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			fs := newTestKubeConfigFs(t)
+			ctx := context.Background()
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().KubeConfigPath().Return("/kubeconfig").AnyTimes()
+			dashConfig.EXPECT().ContextName().Return("")
+			if tc.expectUse {
+				dashConfig.EXPECT().UseContext(ctx, "renamed").Return(nil)
+			}
+
+			actions := newKubeConfigActions(fs, dashConfig)
+
+			payload := action.Payload{
+				"contextName": tc.rename,
+				"newName":     " renamed ",
+			}
+			require.NoError(t, actions.Rename(ctx, payload))
+
+			assert.Equal(t, tc.expectList, contextNames(t, fs))
+		})
+	}
+}
+
+func Test_kubeConfigActions_Delete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	fs := newTestKubeConfigFs(t)
+	ctx := context.Background()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().KubeConfigPath().Return("/kubeconfig").AnyTimes()
+	dashConfig.EXPECT().ContextName().Return("dev").AnyTimes()
+
+	actions := newKubeConfigActions(fs, dashConfig)
+
+	err := actions.Delete(ctx, action.Payload{"contextName": "dev"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is in use")
+
+	require.NoError(t, actions.Delete(ctx, action.Payload{"contextName": "scratch"}))
+	assert.Equal(t, []string{"dev"}, contextNames(t, fs))
+}
+
+func Test_kubeConfigActions_Duplicate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	fs := newTestKubeConfigFs(t)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().KubeConfigPath().Return("/kubeconfig").AnyTimes()
+
+	actions := newKubeConfigActions(fs, dashConfig)
+
+	payload := action.Payload{"contextName": "dev", "newName": "dev-copy"}
+	require.NoError(t, actions.Duplicate(context.Background(), payload))
+
+	assert.Equal(t, []string{"dev", "dev-copy", "scratch"}, contextNames(t, fs))
+}
+
+func Test_kubeConfigActions_SetNamespace(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	fs := newTestKubeConfigFs(t)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().KubeConfigPath().Return("/kubeconfig").AnyTimes()
+
+	actions := newKubeConfigActions(fs, dashConfig)
+
+	payload := action.Payload{"contextName": "dev", "namespace": "web"}
+	require.NoError(t, actions.SetNamespace(context.Background(), payload))
+
+	contexts, err := kubeconfig.NewEditor(fs, "/kubeconfig").Contexts()
+	require.NoError(t, err)
+	assert.Equal(t, "web", contexts[0].Namespace)
+}
+
+func Test_kubeConfigActions_Import(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	fs := newTestKubeConfigFs(t)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().KubeConfigPath().Return("/kubeconfig").AnyTimes()
+
+	actions := newKubeConfigActions(fs, dashConfig)
+
+	snippet := "contexts:\n- name: imported\n  context:\n    cluster: development\n    user: developer\n"
+	require.NoError(t, actions.Import(context.Background(), action.Payload{"snippet": snippet}))
+	assert.Equal(t, []string{"dev", "imported", "scratch"}, contextNames(t, fs))
+
+	assert.Error(t, actions.Import(context.Background(), action.Payload{"snippet": " "}))
+
+	execSnippet := "users:\n- name: plugin\n  user:\n    exec:\n      apiVersion: client.authentication.k8s.io/v1beta1\n      command: login\n"
+	assert.Error(t, actions.Import(context.Background(), action.Payload{"snippet": execSnippet}))
+
+	payload := action.Payload{
+		"snippet":                execSnippet,
+		"trustCredentialPlugins": []interface{}{trustCredentialPluginsValue},
+	}
+	require.NoError(t, actions.Import(context.Background(), payload))
+}
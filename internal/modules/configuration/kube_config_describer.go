@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configuration
+
+import (
+	"context"
+
+	"github.com/spf13/afero"
+
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/kubeconfig"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// KubeConfigDescriber describes the contexts in the kube config and the
+// actions which change them.
+type KubeConfigDescriber struct {
+	fs afero.Fs
+}
+
+// NewKubeConfigDescriber creates an instance of KubeConfigDescriber.
+func NewKubeConfigDescriber(fs afero.Fs) *KubeConfigDescriber {
+	return &KubeConfigDescriber{
+		fs: fs,
+	}
+}
+
+// Describe describes the kube config's contexts.
+func (d *KubeConfigDescriber) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	editor := kubeconfig.NewEditor(d.fs, options.KubeConfigPath())
+
+	contexts, err := editor.Contexts()
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	inUse, err := inUseContext(options.Dash, editor)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	list := component.NewList("Kube Config", nil)
+
+	tbl := component.NewTable("Contexts", component.NewTableCols("Name", "Cluster", "User", "Namespace", "File", "In Use"))
+	for _, c := range contexts {
+		used := ""
+		if c.Name == inUse {
+			used = "Yes"
+		}
+
+		tbl.Add(component.TableRow{
+			"Name":      component.NewText(c.Name),
+			"Cluster":   component.NewText(c.Cluster),
+			"User":      component.NewText(c.User),
+			"Namespace": component.NewText(c.Namespace),
+			"File":      component.NewText(c.Path),
+			"In Use":    component.NewText(used),
+		})
+	}
+
+	summary := component.NewSummary("Contexts", component.SummarySection{
+		Header:  "Contexts",
+		Content: tbl,
+	})
+	addKubeConfigActions(summary, contexts, inUse)
+
+	list.Add(summary)
+
+	return component.ContentResponse{
+		Components: []component.Component{list},
+	}, nil
+}
+
+// PathFilters returns the paths the describer handles.
+func (d *KubeConfigDescriber) PathFilters() []describer.PathFilter {
+	filter := describer.NewPathFilter("/kube-config", d)
+	return []describer.PathFilter{*filter}
+}
+
+func addKubeConfigActions(summary *component.Summary, contexts []kubeconfig.ContextInfo, inUse string) {
+	snippet := component.NewFormFieldTextarea("Kube config snippet", "snippet", "")
+	snippet.SetUpload(true)
+
+	summary.AddAction(component.Action{
+		Name:  "Import",
+		Title: "Import kube config snippet",
+		Form: component.Form{
+			Fields: []component.FormField{
+				snippet,
+				component.NewFormFieldCheckBox("Credential plugins", "trustCredentialPlugins", []component.InputChoice{
+					{Label: "Allow users which run a command or auth provider to get credentials", Value: trustCredentialPluginsValue},
+				}),
+				component.NewFormFieldHidden("action", importKubeConfigActionName),
+			},
+		},
+	})
+
+	if len(contexts) == 0 {
+		return
+	}
+
+	summary.AddAction(component.Action{
+		Name:  "Rename",
+		Title: "Rename context",
+		Form: component.Form{
+			Fields: []component.FormField{
+				component.NewFormFieldRadio("Context", "contextName", contextChoices(contexts, "")),
+				component.NewFormFieldText("New name", "newName", ""),
+				component.NewFormFieldHidden("action", renameContextActionName),
+			},
+		},
+	})
+
+	summary.AddAction(component.Action{
+		Name:  "Duplicate",
+		Title: "Duplicate context",
+		Form: component.Form{
+			Fields: []component.FormField{
+				component.NewFormFieldRadio("Context", "contextName", contextChoices(contexts, "")),
+				component.NewFormFieldText("New name", "newName", ""),
+				component.NewFormFieldHidden("action", duplicateContextActionName),
+			},
+		},
+	})
+
+	summary.AddAction(component.Action{
+		Name:  "Set Namespace",
+		Title: "Set context default namespace",
+		Form: component.Form{
+			Fields: []component.FormField{
+				component.NewFormFieldRadio("Context", "contextName", contextChoices(contexts, "")),
+				component.NewFormFieldText("Namespace (blank to remove)", "namespace", ""),
+				component.NewFormFieldHidden("action", setNamespaceActionName),
+			},
+		},
+	})
+
+	// the context in use can't be deleted.
+	if deletable := contextChoices(contexts, inUse); len(deletable) > 0 {
+		summary.AddAction(component.Action{
+			Name:  "Delete",
+			Title: "Delete context",
+			Form: component.Form{
+				Fields: []component.FormField{
+					component.NewFormFieldRadio("Context", "contextName", deletable),
+					component.NewFormFieldHidden("action", deleteContextActionName),
+				},
+			},
+		})
+	}
+}
+
+// contextChoices creates choices for selecting a context, leaving out
+// exclude.
+func contextChoices(contexts []kubeconfig.ContextInfo, exclude string) []component.InputChoice {
+	var choices []component.InputChoice
+	for _, c := range contexts {
+		if c.Name == exclude {
+			continue
+		}
+
+		choices = append(choices, component.InputChoice{
+			Label:   c.Name,
+			Value:   c.Name,
+			Checked: len(choices) == 0,
+		})
+	}
+
+	return choices
+}
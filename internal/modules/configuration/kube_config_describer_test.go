@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configuration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func TestKubeConfigDescriber(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().KubeConfigPath().Return("/kubeconfig")
+	dashConfig.EXPECT().ContextName().Return("dev")
+
+	d := NewKubeConfigDescriber(newTestKubeConfigFs(t))
+
+	cResponse, err := d.Describe(context.Background(), "/kube-config", "default", describer.Options{Dash: dashConfig})
+	require.NoError(t, err)
+
+	require.Len(t, cResponse.Components, 1)
+	list, ok := cResponse.Components[0].(*component.List)
+	require.True(t, ok)
+
+	require.Len(t, list.Config.Items, 1)
+	summary, ok := list.Config.Items[0].(*component.Summary)
+	require.True(t, ok)
+
+	var actionNames []string
+	for _, a := range summary.Config.Actions {
+		actionNames = append(actionNames, a.Name)
+	}
+	assert.Equal(t, []string{"Import", "Rename", "Duplicate", "Set Namespace", "Delete"}, actionNames)
+
+	// the context in use can't be deleted
+	deleteField := summary.Config.Actions[4].Form.Fields[0]
+	assert.Equal(t, "scratch", deleteField.Value())
+
+	tbl, ok := summary.Sections()[0].Content.(*component.Table)
+	require.True(t, ok)
+
+	expected := component.NewTable("Contexts", component.NewTableCols("Name", "Cluster", "User", "Namespace", "File", "In Use"))
+	expected.Add(
+		component.TableRow{
+			"Name":      component.NewText("dev"),
+			"Cluster":   component.NewText("development"),
+			"User":      component.NewText("developer"),
+			"Namespace": component.NewText(""),
+			"File":      component.NewText("/kubeconfig"),
+			"In Use":    component.NewText("Yes"),
+		},
+		component.TableRow{
+			"Name":      component.NewText("scratch"),
+			"Cluster":   component.NewText("development"),
+			"User":      component.NewText("developer"),
+			"Namespace": component.NewText(""),
+			"File":      component.NewText("/kubeconfig"),
+			"In Use":    component.NewText(""),
+		},
+	)
+	assert.Equal(t, expected, tbl)
+}
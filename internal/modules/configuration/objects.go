@@ -5,14 +5,20 @@ SPDX-License-Identifier: Apache-2.0
 
 package configuration
 
-import "github.com/vmware/octant/internal/describer"
+import (
+	"github.com/spf13/afero"
+
+	"github.com/vmware/octant/internal/describer"
+)
 
 var (
-	pluginDescriber = &PluginListDescriber{}
+	pluginDescriber     = &PluginListDescriber{}
+	kubeConfigDescriber = NewKubeConfigDescriber(afero.NewOsFs())
 
 	rootDescriber = describer.NewSection(
 		"/",
 		"Configuration",
 		pluginDescriber,
+		kubeConfigDescriber,
 	)
 )
@@ -0,0 +1,423 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/modules/overview/objectstatus"
+	"github.com/vmware/octant/internal/modules/overview/resourceviewer"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const (
+	appNameLabel      = "app.kubernetes.io/name"
+	appInstanceLabel  = "app.kubernetes.io/instance"
+	appPartOfLabel    = "app.kubernetes.io/part-of"
+	appComponentLabel = "app.kubernetes.io/component"
+)
+
+type appObjectCategory int
+
+const (
+	appWorkload appObjectCategory = iota
+	appService
+	appIngress
+	appOther
+)
+
+type appObjectKey struct {
+	key      store.Key
+	category appObjectCategory
+}
+
+// applicationObjectKeys are the kinds which are searched for application
+// labels. Objects owned by a controller are skipped, so only the top level
+// object of a workload is listed.
+var applicationObjectKeys = []appObjectKey{
+	{key: store.Key{APIVersion: "apps/v1", Kind: "Deployment"}, category: appWorkload},
+	{key: store.Key{APIVersion: "apps/v1", Kind: "StatefulSet"}, category: appWorkload},
+	{key: store.Key{APIVersion: "apps/v1", Kind: "DaemonSet"}, category: appWorkload},
+	{key: store.Key{APIVersion: "apps/v1", Kind: "ReplicaSet"}, category: appWorkload},
+	{key: store.Key{APIVersion: "batch/v1beta1", Kind: "CronJob"}, category: appWorkload},
+	{key: store.Key{APIVersion: "batch/v1", Kind: "Job"}, category: appWorkload},
+	{key: store.Key{APIVersion: "v1", Kind: "ReplicationController"}, category: appWorkload},
+	{key: store.Key{APIVersion: "v1", Kind: "Pod"}, category: appWorkload},
+	{key: store.Key{APIVersion: "v1", Kind: "Service"}, category: appService},
+	{key: store.Key{APIVersion: "extensions/v1beta1", Kind: "Ingress"}, category: appIngress},
+	{key: store.Key{APIVersion: "v1", Kind: "ConfigMap"}, category: appOther},
+	{key: store.Key{APIVersion: "v1", Kind: "Secret"}, category: appOther},
+	{key: store.Key{APIVersion: "v1", Kind: "PersistentVolumeClaim"}, category: appOther},
+}
+
+// application is a set of objects which share the recommended
+// `app.kubernetes.io/name` and `app.kubernetes.io/instance` labels.
+type application struct {
+	Name      string
+	Instance  string
+	PartOf    string
+	Workloads []*unstructured.Unstructured
+	Services  []*unstructured.Unstructured
+	Ingresses []*unstructured.Unstructured
+	Others    []*unstructured.Unstructured
+}
+
+// Components returns the sorted component names used by the application's objects.
+func (a *application) Components() []string {
+	set := make(map[string]bool)
+	for _, object := range a.objects() {
+		if c := object.GetLabels()[appComponentLabel]; c != "" {
+			set[c] = true
+		}
+	}
+
+	var components []string
+	for c := range set {
+		components = append(components, c)
+	}
+	sort.Strings(components)
+
+	return components
+}
+
+// Title returns the display name for the application.
+func (a *application) Title() string {
+	if a.Instance == "" || a.Instance == a.Name {
+		return a.Name
+	}
+	return fmt.Sprintf("%s (%s)", a.Name, a.Instance)
+}
+
+func (a *application) objects() []*unstructured.Unstructured {
+	var objects []*unstructured.Unstructured
+	objects = append(objects, a.Workloads...)
+	objects = append(objects, a.Services...)
+	objects = append(objects, a.Ingresses...)
+	objects = append(objects, a.Others...)
+	return objects
+}
+
+func (a *application) add(object *unstructured.Unstructured, category appObjectCategory) {
+	if a.PartOf == "" {
+		a.PartOf = object.GetLabels()[appPartOfLabel]
+	}
+
+	switch category {
+	case appWorkload:
+		a.Workloads = append(a.Workloads, object)
+	case appService:
+		a.Services = append(a.Services, object)
+	case appIngress:
+		a.Ingresses = append(a.Ingresses, object)
+	default:
+		a.Others = append(a.Others, object)
+	}
+}
+
+// listApplications groups the labeled objects in a namespace into
+// applications. Applications are sorted by name and instance.
+func listApplications(ctx context.Context, objectStore store.Store, namespace string) ([]*application, error) {
+	if objectStore == nil {
+		return nil, errors.New("object store is nil")
+	}
+
+	type appKey struct {
+		name     string
+		instance string
+	}
+
+	apps := make(map[appKey]*application)
+
+	for _, ak := range applicationObjectKeys {
+		key := ak.key
+		key.Namespace = namespace
+
+		objects, err := objectStore.List(ctx, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "list %s", key)
+		}
+
+		for _, object := range objects {
+			objectLabels := object.GetLabels()
+			name := objectLabels[appNameLabel]
+			if name == "" || metav1.GetControllerOf(object) != nil {
+				continue
+			}
+
+			k := appKey{name: name, instance: objectLabels[appInstanceLabel]}
+			app, ok := apps[k]
+			if !ok {
+				app = &application{Name: k.name, Instance: k.instance}
+				apps[k] = app
+			}
+
+			app.add(object, ak.category)
+		}
+	}
+
+	var list []*application
+	for _, app := range apps {
+		list = append(list, app)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Instance < list[j].Instance
+	})
+
+	return list, nil
+}
+
+// findApplication finds an application by name and instance.
+func findApplication(ctx context.Context, objectStore store.Store, namespace, name, instance string) (*application, error) {
+	apps, err := listApplications(ctx, objectStore, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, app := range apps {
+		if app.Name == name && app.Instance == instance {
+			return app, nil
+		}
+	}
+
+	return nil, errors.Errorf("application %q not found", name)
+}
+
+// appResourceViewerFunc creates a resource graph for a set of objects.
+type appResourceViewerFunc func(ctx context.Context, objects []runtime.Object, options describer.Options) (component.Component, error)
+
+// applications describes applications. Applications are groups of objects
+// which share the recommended application labels.
+type applications struct {
+	path           string
+	resourceViewer appResourceViewerFunc
+}
+
+var _ describer.Describer = (*applications)(nil)
+
+func newApplications(path string) *applications {
+	return &applications{
+		path:           path,
+		resourceViewer: createApplicationResourceViewer,
+	}
+}
+
+// Describe describes a list of applications or a single application if the name field is set.
+func (a *applications) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	if name := options.Fields["name"]; name != "" {
+		return a.describeApplication(ctx, namespace, name, options.Fields["instance"], options)
+	}
+
+	return a.describeList(ctx, namespace, options)
+}
+
+// PathFilters returns the path filters for applications.
+func (a *applications) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter(a.path, a),
+		*describer.NewPathFilter(fmt.Sprintf("%s/(?P<name>[^/]+)", a.path), a),
+		*describer.NewPathFilter(fmt.Sprintf("%s/(?P<name>[^/]+)/(?P<instance>[^/]+)", a.path), a),
+	}
+}
+
+func (a *applications) describeList(ctx context.Context, namespace string, options describer.Options) (component.ContentResponse, error) {
+	objectStore := options.ObjectStore()
+
+	apps, err := listApplications(ctx, objectStore, namespace)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	list := component.NewList("Applications", nil)
+
+	cols := component.NewTableCols("Name", "Instance", "Part Of", "Components", "Status")
+	table := component.NewTable("Applications", cols)
+
+	for _, app := range apps {
+		status, err := applicationStatus(ctx, app, objectStore)
+		if err != nil {
+			return describer.EmptyContentResponse, err
+		}
+
+		table.Add(component.TableRow{
+			"Name":       applicationLink(namespace, app),
+			"Instance":   component.NewText(app.Instance),
+			"Part Of":    component.NewText(app.PartOf),
+			"Components": component.NewText(strings.Join(app.Components(), ", ")),
+			"Status":     component.NewText(string(status.Status())),
+		})
+	}
+
+	list.Add(table)
+
+	return component.ContentResponse{
+		Components: []component.Component{list},
+	}, nil
+}
+
+func (a *applications) describeApplication(ctx context.Context, namespace, name, instance string, options describer.Options) (component.ContentResponse, error) {
+	objectStore := options.ObjectStore()
+
+	app, err := findApplication(ctx, objectStore, namespace, name, instance)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	title := component.Title(component.NewText("Applications"), component.NewText(app.Title()))
+	cr := component.NewContentResponse(title)
+
+	status, err := applicationStatus(ctx, app, objectStore)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	sections := []component.SummarySection{
+		{Header: "Name", Content: component.NewText(app.Name)},
+		{Header: "Instance", Content: component.NewText(app.Instance)},
+		{Header: "Part Of", Content: component.NewText(app.PartOf)},
+		{Header: "Components", Content: component.NewText(strings.Join(app.Components(), ", "))},
+		{Header: "Status", Content: component.NewText(string(status.Status()))},
+	}
+	if len(status.Details) > 0 {
+		sections = append(sections, component.SummarySection{
+			Header:  "Status Details",
+			Content: component.NewList("", status.Details),
+		})
+	}
+
+	summary := component.NewSummary("Application", sections...)
+	summary.SetAccessor("summary")
+	cr.Add(summary)
+
+	workloads, err := applicationObjectTable(ctx, "Workloads", app.Workloads, objectStore, options)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+	workloads.SetAccessor("workloads")
+	cr.Add(workloads)
+
+	services, err := applicationObjectTable(ctx, "Services", app.Services, objectStore, options)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+	services.SetAccessor("services")
+	cr.Add(services)
+
+	ingresses, err := applicationObjectTable(ctx, "Ingresses", app.Ingresses, objectStore, options)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+	ingresses.SetAccessor("ingresses")
+	cr.Add(ingresses)
+
+	var objects []runtime.Object
+	for _, object := range app.objects() {
+		objects = append(objects, object)
+	}
+
+	rv, err := a.resourceViewer(ctx, objects, options)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+	rv.SetAccessor("resourceViewer")
+	cr.Add(rv)
+
+	return *cr, nil
+}
+
+// applicationStatus aggregates the status of an application's workloads,
+// services and ingresses. Details are only included for objects which
+// are not healthy.
+func applicationStatus(ctx context.Context, app *application, objectStore store.Store) (objectstatus.ObjectStatus, error) {
+	appStatus := objectstatus.ObjectStatus{}
+
+	var objects []*unstructured.Unstructured
+	objects = append(objects, app.Workloads...)
+	objects = append(objects, app.Services...)
+	objects = append(objects, app.Ingresses...)
+
+	for _, object := range objects {
+		status, err := objectstatus.Status(ctx, object, objectStore)
+		if err != nil {
+			return objectstatus.ObjectStatus{}, errors.Wrapf(err, "get status for %s %s", object.GetKind(), object.GetName())
+		}
+
+		switch status.Status() {
+		case component.NodeStatusError:
+			appStatus.SetError()
+		case component.NodeStatusWarning:
+			appStatus.SetWarning()
+		default:
+			continue
+		}
+
+		for _, detail := range status.Details {
+			text, ok := detail.(*component.Text)
+			if !ok {
+				continue
+			}
+			appStatus.AddDetailf("%s %s: %s", object.GetKind(), object.GetName(), text.Config.Text)
+		}
+	}
+
+	return appStatus, nil
+}
+
+// applicationObjectTable lists objects in an application with their component and status.
+func applicationObjectTable(ctx context.Context, title string, objects []*unstructured.Unstructured, objectStore store.Store, options describer.Options) (*component.Table, error) {
+	cols := component.NewTableCols("Name", "Kind", "Component", "Status")
+	table := component.NewTable(title, cols)
+
+	for _, object := range objects {
+		name, err := options.Link.ForObject(object, object.GetName())
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := objectstatus.Status(ctx, object, objectStore)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get status for %s %s", object.GetKind(), object.GetName())
+		}
+
+		table.Add(component.TableRow{
+			"Name":      name,
+			"Kind":      component.NewText(object.GetKind()),
+			"Component": component.NewText(object.GetLabels()[appComponentLabel]),
+			"Status":    component.NewText(string(status.Status())),
+		})
+	}
+
+	return table, nil
+}
+
+// applicationLink creates a link to an application's summary page.
+func applicationLink(namespace string, app *application) *component.Link {
+	p := path.Join("/content/overview/namespace", namespace, "applications", app.Name, app.Instance)
+	return component.NewLink("", app.Name, p)
+}
+
+func createApplicationResourceViewer(ctx context.Context, objects []runtime.Object, options describer.Options) (component.Component, error) {
+	rv, err := resourceviewer.New(options.Dash, resourceviewer.WithDefaultQueryer(options.Dash, options.Queryer))
+	if err != nil {
+		return nil, err
+	}
+
+	return rv.VisitObjects(ctx, objects)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/describer"
+	linkFake "github.com/vmware/octant/internal/link/fake"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func applicationLabels(name, instance, component string) map[string]string {
+	return map[string]string{
+		appNameLabel:      name,
+		appInstanceLabel:  instance,
+		appPartOfLabel:    "shop",
+		appComponentLabel: component,
+	}
+}
+
+func applicationObjects(t *testing.T) map[string][]*unstructured.Unstructured {
+	deployment := testutil.CreateDeployment("web")
+	deployment.Labels = applicationLabels("web", "web-1", "frontend")
+	deployment.Status = appsv1.DeploymentStatus{Replicas: 1, AvailableReplicas: 1}
+
+	replicaSet := testutil.CreateAppReplicaSet("web-123")
+	replicaSet.Labels = applicationLabels("web", "web-1", "frontend")
+	replicaSet.OwnerReferences = testutil.ToOwnerReferences(t, deployment)
+	replicaSet.OwnerReferences[0].Controller = pointer.BoolPtr(true)
+
+	service := testutil.CreateService("web")
+	service.Labels = applicationLabels("web", "web-1", "frontend")
+
+	statefulSet := testutil.CreateStatefulSet("db")
+	statefulSet.Labels = applicationLabels("web", "web-1", "database")
+
+	otherDeployment := testutil.CreateDeployment("web-2")
+	otherDeployment.Labels = applicationLabels("web", "web-2", "frontend")
+	otherDeployment.Status = appsv1.DeploymentStatus{Replicas: 1, AvailableReplicas: 1}
+
+	unlabeled := testutil.CreateDeployment("unlabeled")
+
+	return map[string][]*unstructured.Unstructured{
+		"Deployment":  testutil.ToUnstructuredList(t, deployment, otherDeployment, unlabeled),
+		"ReplicaSet":  testutil.ToUnstructuredList(t, replicaSet),
+		"Service":     testutil.ToUnstructuredList(t, service),
+		"StatefulSet": testutil.ToUnstructuredList(t, statefulSet),
+	}
+}
+
+func mockApplicationStore(t *testing.T, controller *gomock.Controller) *storeFake.MockStore {
+	objects := applicationObjects(t)
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key store.Key) ([]*unstructured.Unstructured, error) {
+			return objects[key.Kind], nil
+		}).AnyTimes()
+
+	endpoints := &corev1.Endpoints{}
+	objectStore.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(testutil.ToUnstructured(t, endpoints), nil).AnyTimes()
+
+	return objectStore
+}
+
+func Test_listApplications(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := mockApplicationStore(t, controller)
+
+	got, err := listApplications(context.Background(), objectStore, "default")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "web", got[0].Name)
+	assert.Equal(t, "web-1", got[0].Instance)
+	assert.Equal(t, "shop", got[0].PartOf)
+	assert.Equal(t, []string{"database", "frontend"}, got[0].Components())
+	assert.Equal(t, "web (web-1)", got[0].Title())
+
+	var workloads []string
+	for _, object := range got[0].Workloads {
+		workloads = append(workloads, object.GetKind()+"/"+object.GetName())
+	}
+	assert.Equal(t, []string{"Deployment/web", "StatefulSet/db"}, workloads)
+	require.Len(t, got[0].Services, 1)
+	assert.Empty(t, got[0].Ingresses)
+
+	assert.Equal(t, "web-2", got[1].Instance)
+}
+
+func Test_applications_list(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(mockApplicationStore(t, controller)).AnyTimes()
+
+	options := describer.Options{
+		Dash:   dashConfig,
+		Fields: map[string]string{},
+	}
+
+	d := newApplications("/applications")
+	got, err := d.Describe(context.Background(), "/prefix", "default", options)
+	require.NoError(t, err)
+
+	list := component.NewList("Applications", nil)
+	table := component.NewTable("Applications",
+		component.NewTableCols("Name", "Instance", "Part Of", "Components", "Status"))
+	table.Add(
+		component.TableRow{
+			"Name":       component.NewLink("", "web", "/content/overview/namespace/default/applications/web/web-1"),
+			"Instance":   component.NewText("web-1"),
+			"Part Of":    component.NewText("shop"),
+			"Components": component.NewText("database, frontend"),
+			"Status":     component.NewText("warning"),
+		},
+		component.TableRow{
+			"Name":       component.NewLink("", "web", "/content/overview/namespace/default/applications/web/web-2"),
+			"Instance":   component.NewText("web-2"),
+			"Part Of":    component.NewText("shop"),
+			"Components": component.NewText("frontend"),
+			"Status":     component.NewText("ok"),
+		},
+	)
+	list.Add(table)
+
+	expected := component.ContentResponse{
+		Components: []component.Component{list},
+	}
+
+	assert.Equal(t, expected, got)
+}
+
+func Test_applications_application(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(mockApplicationStore(t, controller)).AnyTimes()
+
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().
+		ForObject(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(object runtime.Object, text string) (*component.Link, error) {
+			return component.NewLink("", text, "/"+text), nil
+		}).AnyTimes()
+
+	options := describer.Options{
+		Dash:   dashConfig,
+		Link:   l,
+		Fields: map[string]string{"name": "web", "instance": "web-1"},
+	}
+
+	var visited []runtime.Object
+
+	d := newApplications("/applications")
+	d.resourceViewer = func(ctx context.Context, objects []runtime.Object, options describer.Options) (component.Component, error) {
+		visited = objects
+		return component.NewResourceViewer("Resource Viewer"), nil
+	}
+
+	got, err := d.Describe(context.Background(), "/prefix", "default", options)
+	require.NoError(t, err)
+
+	assert.Equal(t, component.Title(component.NewText("Applications"), component.NewText("web (web-1)")), got.Title)
+	assert.Len(t, visited, 3)
+
+	var accessors []string
+	for _, c := range got.Components {
+		accessors = append(accessors, c.GetMetadata().Accessor)
+	}
+	assert.Equal(t, []string{"summary", "workloads", "services", "ingresses", "resourceViewer"}, accessors)
+
+	summary, ok := got.Components[0].(*component.Summary)
+	require.True(t, ok)
+	assert.Equal(t, "Status", summary.Config.Sections[4].Header)
+	assert.Equal(t, component.NewText("warning"), summary.Config.Sections[4].Content)
+	assert.Equal(t, "Status Details", summary.Config.Sections[5].Header)
+
+	workloads, ok := got.Components[1].(*component.Table)
+	require.True(t, ok)
+	assert.Len(t, workloads.Rows(), 2)
+}
+
+func Test_applications_application_not_found(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(mockApplicationStore(t, controller)).AnyTimes()
+
+	options := describer.Options{
+		Dash:   dashConfig,
+		Fields: map[string]string{"name": "missing"},
+	}
+
+	d := newApplications("/applications")
+	_, err := d.Describe(context.Background(), "/prefix", "default", options)
+	require.Error(t, err)
+}
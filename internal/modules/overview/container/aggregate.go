@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"context"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+var (
+	// podRefreshInterval is how often pods are listed to find new pods while following.
+	podRefreshInterval = 5 * time.Second
+	// mergeWindow is how long lines are held so lines from different
+	// containers can be sent in time order.
+	mergeWindow = 500 * time.Millisecond
+)
+
+// PodLister lists the pods whose logs are aggregated.
+type PodLister func(ctx context.Context) ([]*corev1.Pod, error)
+
+// TaggedLogLine is a log line tagged with its pod and container.
+type TaggedLogLine struct {
+	LogLine
+	Pod       string
+	Container string
+}
+
+// AggregateLogs streams the logs of every container in a set of pods to a
+// channel, which is closed when streaming finishes. Lines are sent in time
+// order. When following, pods are listed periodically so pods which are
+// created later, e.g. during a rollout, are streamed as well. If container
+// is set, only containers with that name are streamed.
+func AggregateLogs(ctx context.Context, client kubernetes.Interface, listPods PodLister, container string, options LogOptions, ch chan<- TaggedLogLine) error {
+	defer close(ch)
+
+	a := &aggregator{
+		listPods:  listPods,
+		container: container,
+		options:   options,
+		streamFunc: func(ctx context.Context, pod *corev1.Pod, container string, options LogOptions, out chan<- LogLine) error {
+			return StreamLogs(ctx, client, pod.Namespace, pod.Name, container, options, out)
+		},
+		seen:   make(map[string]bool),
+		merged: make(chan TaggedLogLine),
+	}
+
+	return a.run(ctx, ch)
+}
+
+type streamFunc func(ctx context.Context, pod *corev1.Pod, container string, options LogOptions, out chan<- LogLine) error
+
+type aggregator struct {
+	listPods   PodLister
+	container  string
+	options    LogOptions
+	streamFunc streamFunc
+
+	// seen are the containers which have been streamed.
+	seen   map[string]bool
+	merged chan TaggedLogLine
+	wg     sync.WaitGroup
+}
+
+func (a *aggregator) run(ctx context.Context, ch chan<- TaggedLogLine) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := a.refresh(ctx, a.options); err != nil {
+		return err
+	}
+
+	// pods found later are streamed from their start
+	laterOptions := LogOptions{Follow: true}
+
+	streamsDone := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(streamsDone)
+	}()
+
+	var refreshC <-chan time.Time
+	if a.options.Follow {
+		refreshTicker := time.NewTicker(podRefreshInterval)
+		defer refreshTicker.Stop()
+		refreshC = refreshTicker.C
+	}
+
+	mergeTicker := time.NewTicker(mergeWindow)
+	defer mergeTicker.Stop()
+
+	var pending []TaggedLogLine
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line := <-a.merged:
+			pending = append(pending, line)
+		case <-mergeTicker.C:
+			// without follow, every line is sorted once all streams are finished
+			if a.options.Follow {
+				if !a.send(ctx, &pending, ch) {
+					return nil
+				}
+			}
+		case <-refreshC:
+			if err := a.refresh(ctx, laterOptions); err != nil {
+				log.From(ctx).WithErr(err).Debugf("unable to list pods for logs")
+			}
+		case <-streamsDone:
+			if a.options.Follow {
+				// wait for pods which are created later
+				streamsDone = nil
+				continue
+			}
+
+			a.send(ctx, &pending, ch)
+			return nil
+		}
+	}
+}
+
+// send sends pending lines in time order.
+func (a *aggregator) send(ctx context.Context, pending *[]TaggedLogLine, ch chan<- TaggedLogLine) bool {
+	lines := *pending
+	*pending = nil
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Timestamp.Before(lines[j].Timestamp)
+	})
+
+	for _, line := range lines {
+		select {
+		case <-ctx.Done():
+			return false
+		case ch <- line:
+		}
+	}
+
+	return true
+}
+
+// refresh starts streaming containers which haven't been streamed.
+func (a *aggregator) refresh(ctx context.Context, options LogOptions) error {
+	pods, err := a.listPods(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if a.container != "" && c.Name != a.container {
+				continue
+			}
+
+			key := path.Join(pod.Namespace, pod.Name, string(pod.UID), c.Name)
+			if a.seen[key] {
+				continue
+			}
+			a.seen[key] = true
+
+			a.wg.Add(1)
+			go a.stream(ctx, pod, c.Name, options)
+		}
+	}
+
+	return nil
+}
+
+func (a *aggregator) stream(ctx context.Context, pod *corev1.Pod, container string, options LogOptions) {
+	defer a.wg.Done()
+
+	lines := make(chan LogLine)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for line := range lines {
+			select {
+			case <-ctx.Done():
+			case a.merged <- TaggedLogLine{LogLine: line, Pod: pod.Name, Container: container}:
+			}
+		}
+	}()
+
+	if err := a.streamFunc(ctx, pod, container, options, lines); err != nil {
+		log.From(ctx).WithErr(err).With("pod", pod.Name, "container", container).
+			Debugf("streaming container logs")
+	}
+
+	<-done
+}
@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func createAggregatePod(name string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+	}
+
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+	}
+
+	return pod
+}
+
+func at(second int) time.Time {
+	return time.Date(2019, 7, 2, 10, 0, second, 0, time.UTC)
+}
+
+func newTestAggregator(listPods PodLister, container string, options LogOptions, logs map[string][]LogLine) *aggregator {
+	return &aggregator{
+		listPods:  listPods,
+		container: container,
+		options:   options,
+		streamFunc: func(ctx context.Context, pod *corev1.Pod, container string, options LogOptions, out chan<- LogLine) error {
+			defer close(out)
+			for _, line := range logs[pod.Name+"/"+container] {
+				out <- line
+			}
+			return nil
+		},
+		seen:   make(map[string]bool),
+		merged: make(chan TaggedLogLine),
+	}
+}
+
+func Test_aggregator(t *testing.T) {
+	pods := []*corev1.Pod{
+		createAggregatePod("pod-a", "app", "sidecar"),
+		createAggregatePod("pod-b", "app"),
+	}
+
+	logs := map[string][]LogLine{
+		"pod-a/app":     {{Timestamp: at(1), Message: "a1"}, {Timestamp: at(4), Message: "a4"}},
+		"pod-a/sidecar": {{Timestamp: at(3), Message: "s3"}},
+		"pod-b/app":     {{Timestamp: at(2), Message: "b2"}},
+	}
+
+	listPods := func(context.Context) ([]*corev1.Pod, error) {
+		return pods, nil
+	}
+
+	cases := []struct {
+		name      string
+		container string
+		expected  []TaggedLogLine
+	}{
+		{
+			name: "all containers",
+			expected: []TaggedLogLine{
+				{LogLine: LogLine{Timestamp: at(1), Message: "a1"}, Pod: "pod-a", Container: "app"},
+				{LogLine: LogLine{Timestamp: at(2), Message: "b2"}, Pod: "pod-b", Container: "app"},
+				{LogLine: LogLine{Timestamp: at(3), Message: "s3"}, Pod: "pod-a", Container: "sidecar"},
+				{LogLine: LogLine{Timestamp: at(4), Message: "a4"}, Pod: "pod-a", Container: "app"},
+			},
+		},
+		{
+			name:      "single container",
+			container: "sidecar",
+			expected: []TaggedLogLine{
+				{LogLine: LogLine{Timestamp: at(3), Message: "s3"}, Pod: "pod-a", Container: "sidecar"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := newTestAggregator(listPods, tc.container, LogOptions{}, logs)
+
+			ch := make(chan TaggedLogLine, 10)
+			require.NoError(t, a.run(context.Background(), ch))
+			close(ch)
+
+			var got []TaggedLogLine
+			for line := range ch {
+				got = append(got, line)
+			}
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_aggregator_follow_finds_new_pods(t *testing.T) {
+	defer func(d time.Duration) { podRefreshInterval = d }(podRefreshInterval)
+	podRefreshInterval = 10 * time.Millisecond
+	defer func(d time.Duration) { mergeWindow = d }(mergeWindow)
+	mergeWindow = 10 * time.Millisecond
+
+	var mu sync.Mutex
+	pods := []*corev1.Pod{createAggregatePod("old", "app")}
+
+	listPods := func(context.Context) ([]*corev1.Pod, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return pods, nil
+	}
+
+	logs := map[string][]LogLine{
+		"old/app": {{Timestamp: at(1), Message: "old"}},
+		"new/app": {{Timestamp: at(2), Message: "new"}},
+	}
+
+	var streamedOptions []LogOptions
+	tail := int64(5)
+
+	a := newTestAggregator(listPods, "", LogOptions{Follow: true, TailLines: &tail}, logs)
+	streamFunc := a.streamFunc
+	a.streamFunc = func(ctx context.Context, pod *corev1.Pod, container string, options LogOptions, out chan<- LogLine) error {
+		mu.Lock()
+		streamedOptions = append(streamedOptions, options)
+		mu.Unlock()
+		return streamFunc(ctx, pod, container, options, out)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan TaggedLogLine)
+	done := make(chan error, 1)
+	go func() {
+		done <- a.run(ctx, ch)
+	}()
+
+	first := <-ch
+	assert.Equal(t, "old", first.Message)
+
+	// a pod is created during a rollout
+	mu.Lock()
+	pods = append(pods, createAggregatePod("new", "app"))
+	mu.Unlock()
+
+	second := <-ch
+	assert.Equal(t, "new", second.Message)
+	assert.Equal(t, "new", second.Pod)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, streamedOptions, 2)
+	assert.Equal(t, &tail, streamedOptions[0].TailLines)
+	assert.Equal(t, LogOptions{Follow: true}, streamedOptions[1], "new pods are streamed from their start")
+}
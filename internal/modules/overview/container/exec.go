@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/vmware/octant/internal/cluster"
+)
+
+// ExecOptions are options for running a command in a container.
+type ExecOptions struct {
+	Namespace string
+	PodName   string
+	Container string
+	Command   []string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+
+	// SizeQueue is the source of terminal resize events.
+	SizeQueue remotecommand.TerminalSizeQueue
+}
+
+// Exec runs a command in a container with a TTY. It blocks until the command exits.
+// Since a TTY is allocated, stderr is merged into stdout.
+func Exec(client cluster.RESTInterface, options ExecOptions) error {
+	if client == nil {
+		return errors.New("cluster client is nil")
+	}
+
+	restClient, err := client.RESTClient()
+	if err != nil {
+		return errors.Wrap(err, "fetching RESTClient")
+	}
+
+	req := restClient.Post().
+		Resource("pods").
+		Namespace(options.Namespace).
+		Name(options.PodName).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: options.Container,
+			Command:   options.Command,
+			Stdin:     true,
+			Stdout:    true,
+			TTY:       true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(client.RESTConfig(), http.MethodPost, req.URL())
+	if err != nil {
+		return errors.Wrap(err, "create executor")
+	}
+
+	err = executor.Stream(remotecommand.StreamOptions{
+		Stdin:             options.Stdin,
+		Stdout:            options.Stdout,
+		Tty:               true,
+		TerminalSizeQueue: options.SizeQueue,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "exec in container %s", options.Container)
+	}
+
+	return nil
+}
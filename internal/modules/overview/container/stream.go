@@ -0,0 +1,261 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxLogLineSize is the longest log line which can be streamed.
+const maxLogLineSize = 1024 * 1024
+
+var (
+	// durContainerUpWait is the time to wait before checking if a container has started
+	durContainerUpWait = 1 * time.Second
+)
+
+// LogOptions are options for streaming a container's log.
+type LogOptions struct {
+	// Follow keeps streaming new lines. The log is reopened if the
+	// container restarts or the connection is interrupted.
+	Follow bool
+	// Previous streams the log of the previous instance of the container.
+	Previous bool
+	// SinceTime only streams lines after a time.
+	SinceTime *time.Time
+	// SinceSeconds only streams lines from the last number of seconds.
+	SinceSeconds *int64
+	// TailLines only streams the last number of lines.
+	TailLines *int64
+	// LimitBytes limits the number of bytes read from the log.
+	LimitBytes *int64
+}
+
+// LogLine is a line from a container's log.
+type LogLine struct {
+	Timestamp time.Time
+	Message   string
+}
+
+// StreamLogs streams a container's log to a channel, which is closed when
+// streaming finishes. Sends block until the receiver is ready, so a slow
+// receiver slows down reading the log rather than buffering it.
+func StreamLogs(ctx context.Context, client kubernetes.Interface, namespace, podName, container string, options LogOptions, ch chan<- LogLine) error {
+	if ch == nil {
+		return errors.New("channel is nil")
+	}
+
+	defer close(ch)
+
+	ls := &logStreamer{
+		client:    client,
+		namespace: namespace,
+		podName:   podName,
+		container: container,
+		options:   options,
+	}
+	ls.openStream = ls.open
+
+	return ls.run(ctx, ch)
+}
+
+type logStreamer struct {
+	client kubernetes.Interface
+
+	namespace string
+	podName   string
+	container string
+	options   LogOptions
+
+	openStream func(options *corev1.PodLogOptions) (io.ReadCloser, error)
+}
+
+func (ls *logStreamer) run(ctx context.Context, ch chan<- LogLine) error {
+	if ls.options.Previous {
+		_, err := ls.stream(ctx, ls.logOptions(), time.Time{}, ch)
+		return err
+	}
+
+	var containerID string
+	var lastTimestamp time.Time
+
+	for attempt := 0; ; attempt++ {
+		id, done, err := ls.waitForContainer(ctx, containerID, attempt > 0)
+		if err != nil {
+			return err
+		}
+
+		if done || ctx.Err() != nil {
+			return nil
+		}
+
+		logOptions := ls.logOptions()
+		var after time.Time
+
+		if attempt > 0 {
+			// later attempts ignore the initial range options
+			logOptions = &corev1.PodLogOptions{
+				Container:  ls.container,
+				Follow:     true,
+				Timestamps: true,
+			}
+
+			if id == containerID && !lastTimestamp.IsZero() {
+				// the stream was interrupted, so resume from the last line.
+				// SinceTime is truncated to seconds, so skip lines which
+				// were already sent.
+				logOptions.SinceTime = &metav1.Time{Time: lastTimestamp}
+				after = lastTimestamp
+			}
+		}
+
+		containerID = id
+
+		last, err := ls.stream(ctx, logOptions, after, ch)
+		if err != nil {
+			return err
+		}
+
+		if !last.IsZero() {
+			lastTimestamp = last
+		}
+
+		if !ls.options.Follow {
+			return nil
+		}
+	}
+}
+
+func (ls *logStreamer) logOptions() *corev1.PodLogOptions {
+	logOptions := &corev1.PodLogOptions{
+		Container:    ls.container,
+		Follow:       ls.options.Follow && !ls.options.Previous,
+		Previous:     ls.options.Previous,
+		SinceSeconds: ls.options.SinceSeconds,
+		TailLines:    ls.options.TailLines,
+		LimitBytes:   ls.options.LimitBytes,
+		Timestamps:   true,
+	}
+
+	if ls.options.SinceTime != nil {
+		logOptions.SinceTime = &metav1.Time{Time: *ls.options.SinceTime}
+	}
+
+	return logOptions
+}
+
+// waitForContainer waits until the container has started. After the first attempt,
+// it waits for the container to be running again or for a new instance of the
+// container. done is true if the pod has finished and the container won't restart.
+func (ls *logStreamer) waitForContainer(ctx context.Context, previousID string, reconnect bool) (string, bool, error) {
+	for {
+		if reconnect {
+			// give the container time to restart before checking
+			select {
+			case <-ctx.Done():
+				return "", true, nil
+			case <-time.After(durContainerUpWait):
+			}
+		}
+
+		pod, err := ls.client.CoreV1().Pods(ls.namespace).Get(ls.podName, metav1.GetOptions{})
+		if err != nil {
+			return "", false, errors.Wrapf(err, "get pod %s in %s", ls.podName, ls.namespace)
+		}
+
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != ls.container || status.State.Waiting != nil {
+				continue
+			}
+
+			if !reconnect || status.ContainerID != previousID || status.State.Running != nil {
+				return status.ContainerID, false, nil
+			}
+		}
+
+		if reconnect && (pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed) {
+			return "", true, nil
+		}
+
+		reconnect = true
+	}
+}
+
+func (ls *logStreamer) open(options *corev1.PodLogOptions) (io.ReadCloser, error) {
+	return ls.client.CoreV1().Pods(ls.namespace).GetLogs(ls.podName, options).Stream()
+}
+
+// stream sends lines with timestamps after a time to a channel. It returns the
+// timestamp of the last line.
+func (ls *logStreamer) stream(ctx context.Context, options *corev1.PodLogOptions, after time.Time, ch chan<- LogLine) (time.Time, error) {
+	var last time.Time
+
+	rc, err := ls.openStream(options)
+	if err != nil {
+		return last, errors.Wrap(err, "stream container logs")
+	}
+
+	// closing the stream unblocks the scanner when the context is cancelled
+	streamDone := make(chan struct{})
+	defer close(streamDone)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-streamDone:
+		}
+		_ = rc.Close()
+	}()
+
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+
+	for scanner.Scan() {
+		line := ParseLogLine(scanner.Text())
+		if !after.IsZero() && !line.Timestamp.After(after) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, nil
+		case ch <- line:
+		}
+
+		if !line.Timestamp.IsZero() {
+			last = line.Timestamp
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return last, errors.Wrap(err, "read container logs")
+	}
+
+	return last, nil
+}
+
+// ParseLogLine parses a log line which is prefixed with a RFC3339 timestamp.
+func ParseLogLine(text string) LogLine {
+	parts := strings.SplitN(text, " ", 2)
+	if len(parts) != 2 {
+		return LogLine{Message: text}
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return LogLine{Message: text}
+	}
+
+	return LogLine{Timestamp: timestamp, Message: parts[1]}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func createLogPod(containerID string, phase corev1.PodPhase, state corev1.ContainerState) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", ContainerID: containerID, State: state},
+			},
+		},
+	}
+}
+
+func running() corev1.ContainerState {
+	return corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+}
+
+func collectLines(ch <-chan LogLine) []LogLine {
+	var lines []LogLine
+	for line := range ch {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func Test_logStreamer_options(t *testing.T) {
+	client := fake.NewSimpleClientset(createLogPod("docker://1", corev1.PodRunning, running()))
+
+	since := time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC)
+	tail := int64(10)
+	limit := int64(1024)
+
+	var got []*corev1.PodLogOptions
+
+	ls := &logStreamer{
+		client:    client,
+		namespace: "default",
+		podName:   "pod",
+		container: "app",
+		options: LogOptions{
+			SinceTime:  &since,
+			TailLines:  &tail,
+			LimitBytes: &limit,
+		},
+		openStream: func(options *corev1.PodLogOptions) (io.ReadCloser, error) {
+			got = append(got, options)
+			return ioutil.NopCloser(strings.NewReader(
+				"2019-07-02T10:00:01.5Z line 1\n2019-07-02T10:00:02Z line 2\n")), nil
+		},
+	}
+
+	ch := make(chan LogLine, 10)
+	require.NoError(t, ls.run(context.Background(), ch))
+	close(ch)
+
+	expected := []LogLine{
+		{Timestamp: time.Date(2019, 7, 2, 10, 0, 1, 500000000, time.UTC), Message: "line 1"},
+		{Timestamp: time.Date(2019, 7, 2, 10, 0, 2, 0, time.UTC), Message: "line 2"},
+	}
+	assert.Equal(t, expected, collectLines(ch))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, &corev1.PodLogOptions{
+		Container:  "app",
+		SinceTime:  &metav1.Time{Time: since},
+		TailLines:  &tail,
+		LimitBytes: &limit,
+		Timestamps: true,
+	}, got[0])
+}
+
+func Test_logStreamer_previous(t *testing.T) {
+	ls := &logStreamer{
+		client:    fake.NewSimpleClientset(),
+		namespace: "default",
+		podName:   "pod",
+		container: "app",
+		options:   LogOptions{Previous: true, Follow: true},
+		openStream: func(options *corev1.PodLogOptions) (io.ReadCloser, error) {
+			assert.True(t, options.Previous)
+			assert.False(t, options.Follow)
+			return ioutil.NopCloser(strings.NewReader("2019-07-02T10:00:00Z crashed\n")), nil
+		},
+	}
+
+	ch := make(chan LogLine, 10)
+	require.NoError(t, ls.run(context.Background(), ch))
+	close(ch)
+
+	assert.Equal(t, []LogLine{
+		{Timestamp: time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC), Message: "crashed"},
+	}, collectLines(ch))
+}
+
+func Test_logStreamer_follow_reconnects(t *testing.T) {
+	defer func(d time.Duration) { durContainerUpWait = d }(durContainerUpWait)
+	durContainerUpWait = time.Millisecond
+
+	pod := createLogPod("docker://1", corev1.PodRunning, running())
+	client := fake.NewSimpleClientset(pod)
+
+	var got []*corev1.PodLogOptions
+
+	streams := []string{
+		// first stream is interrupted
+		"2019-07-02T10:00:01Z one\n2019-07-02T10:00:02Z two\n",
+		// resumed stream repeats lines from the same second
+		"2019-07-02T10:00:02Z two\n2019-07-02T10:00:03Z three\n",
+		// the container restarted
+		"2019-07-02T10:01:00Z restarted\n",
+	}
+
+	ls := &logStreamer{
+		client:    client,
+		namespace: "default",
+		podName:   "pod",
+		container: "app",
+		options:   LogOptions{Follow: true},
+	}
+	ls.openStream = func(options *corev1.PodLogOptions) (io.ReadCloser, error) {
+		got = append(got, options)
+		body := streams[len(got)-1]
+
+		switch len(got) {
+		case 2:
+			// restart the container after the second stream
+			updated := createLogPod("docker://2", corev1.PodRunning, running())
+			_, err := client.CoreV1().Pods("default").UpdateStatus(updated)
+			require.NoError(t, err)
+		case 3:
+			// the pod completes after the third stream
+			updated := createLogPod("docker://2", corev1.PodSucceeded, corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{},
+			})
+			_, err := client.CoreV1().Pods("default").UpdateStatus(updated)
+			require.NoError(t, err)
+		}
+
+		return ioutil.NopCloser(strings.NewReader(body)), nil
+	}
+
+	ch := make(chan LogLine, 10)
+	require.NoError(t, ls.run(context.Background(), ch))
+	close(ch)
+
+	var messages []string
+	for _, line := range collectLines(ch) {
+		messages = append(messages, line.Message)
+	}
+	assert.Equal(t, []string{"one", "two", "three", "restarted"}, messages)
+
+	require.Len(t, got, 3)
+	assert.True(t, got[0].Follow)
+	assert.Equal(t, &metav1.Time{Time: time.Date(2019, 7, 2, 10, 0, 2, 0, time.UTC)}, got[1].SinceTime)
+	assert.Nil(t, got[2].SinceTime, "a new container is streamed from the start")
+}
+
+func Test_logStreamer_cancel(t *testing.T) {
+	client := fake.NewSimpleClientset(createLogPod("docker://1", corev1.PodRunning, running()))
+
+	r, w := io.Pipe()
+	defer w.Close()
+
+	ls := &logStreamer{
+		client:    client,
+		namespace: "default",
+		podName:   "pod",
+		container: "app",
+		options:   LogOptions{Follow: true},
+		openStream: func(options *corev1.PodLogOptions) (io.ReadCloser, error) {
+			return r, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// an unbuffered channel nobody reads from applies backpressure
+	ch := make(chan LogLine)
+	done := make(chan error, 1)
+	go func() {
+		done <- ls.run(ctx, ch)
+	}()
+
+	go func() {
+		_, _ = w.Write([]byte("2019-07-02T10:00:01Z blocked\n"))
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("streaming did not stop")
+	}
+}
+
+func Test_ParseLogLine(t *testing.T) {
+	assert.Equal(t,
+		LogLine{Timestamp: time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC), Message: "message with spaces"},
+		ParseLogLine("2019-07-02T10:00:00Z message with spaces"))
+	assert.Equal(t, LogLine{Message: "no timestamp"}, ParseLogLine("no timestamp"))
+	assert.Equal(t, LogLine{Message: "single"}, ParseLogLine("single"))
+}
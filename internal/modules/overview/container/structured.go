@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Log levels detected in structured logs.
+const (
+	LevelTrace   = "trace"
+	LevelDebug   = "debug"
+	LevelInfo    = "info"
+	LevelWarning = "warning"
+	LevelError   = "error"
+	LevelFatal   = "fatal"
+)
+
+var (
+	// levelKeys are keys commonly used for the level in JSON logs.
+	levelKeys = []string{"level", "lvl", "severity", "log.level", "loglevel"}
+	// timeKeys are keys commonly used for the time in JSON logs. They aren't
+	// extracted as fields since each line already has a timestamp.
+	timeKeys = []string{"time", "ts", "timestamp", "@timestamp"}
+)
+
+// StructuredLog is a log message parsed from JSON.
+type StructuredLog struct {
+	// Level is the normalized level, or empty if there wasn't one.
+	Level string
+	// Fields are the remaining fields in the message, including the message
+	// itself.
+	Fields map[string]string
+}
+
+// ParseStructured parses a JSON log message. It returns false if the
+// message isn't a JSON object.
+func ParseStructured(message string) (StructuredLog, bool) {
+	trimmed := strings.TrimSpace(message)
+	if !strings.HasPrefix(trimmed, "{") {
+		return StructuredLog{}, false
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(trimmed), &m); err != nil {
+		return StructuredLog{}, false
+	}
+
+	sl := StructuredLog{
+		Fields: make(map[string]string),
+	}
+
+	if key, ok := findKey(m, levelKeys); ok {
+		sl.Level = NormalizeLevel(m[key])
+		delete(m, key)
+	}
+
+	for _, key := range timeKeys {
+		delete(m, key)
+	}
+
+	for k, v := range m {
+		sl.Fields[k] = fieldString(v)
+	}
+
+	return sl, true
+}
+
+// NormalizeLevel converts a level to one of the levels above. Numeric levels
+// use the bunyan and pino convention. It returns an empty string if the level
+// isn't recognized.
+func NormalizeLevel(level interface{}) string {
+	switch t := level.(type) {
+	case float64:
+		switch {
+		case t >= 60:
+			return LevelFatal
+		case t >= 50:
+			return LevelError
+		case t >= 40:
+			return LevelWarning
+		case t >= 30:
+			return LevelInfo
+		case t >= 20:
+			return LevelDebug
+		default:
+			return LevelTrace
+		}
+	case string:
+		switch strings.ToLower(strings.TrimSpace(t)) {
+		case "trace":
+			return LevelTrace
+		case "debug", "dbug":
+			return LevelDebug
+		case "info", "information", "notice":
+			return LevelInfo
+		case "warn", "warning":
+			return LevelWarning
+		case "error", "err", "eror":
+			return LevelError
+		case "fatal", "panic", "critical", "crit", "dpanic", "emergency", "alert":
+			return LevelFatal
+		}
+	}
+
+	return ""
+}
+
+func findKey(m map[string]interface{}, keys []string) (string, bool) {
+	for _, key := range keys {
+		if _, ok := m[key]; ok {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// fieldString formats a field value. Nested values are formatted as JSON.
+func fieldString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(t)
+		if err != nil {
+			return fmt.Sprint(t)
+		}
+		return string(data)
+	default:
+		return fmt.Sprint(t)
+	}
+}
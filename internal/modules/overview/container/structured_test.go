@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseStructured(t *testing.T) {
+	cases := []struct {
+		name     string
+		message  string
+		expected StructuredLog
+		isJSON   bool
+	}{
+		{
+			name:    "zap",
+			message: `{"level":"warn","ts":1562061600.5,"msg":"slow request","duration":1.5,"path":"/"}`,
+			expected: StructuredLog{
+				Level:  LevelWarning,
+				Fields: map[string]string{"msg": "slow request", "duration": "1.5", "path": "/"},
+			},
+			isJSON: true,
+		},
+		{
+			name:    "bunyan",
+			message: `{"level":50,"time":"2019-07-02T10:00:00Z","message":"failed","err":{"code":1}}`,
+			expected: StructuredLog{
+				Level:  LevelError,
+				Fields: map[string]string{"message": "failed", "err": `{"code":1}`},
+			},
+			isJSON: true,
+		},
+		{
+			name:    "no level",
+			message: ` {"msg":"hello"}`,
+			expected: StructuredLog{
+				Fields: map[string]string{"msg": "hello"},
+			},
+			isJSON: true,
+		},
+		{
+			name:    "text",
+			message: "level=info msg=hello",
+		},
+		{
+			name:    "invalid JSON",
+			message: "{not json",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ParseStructured(tc.message)
+			assert.Equal(t, tc.isJSON, ok)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_NormalizeLevel(t *testing.T) {
+	assert.Equal(t, LevelWarning, NormalizeLevel("WARNING"))
+	assert.Equal(t, LevelFatal, NormalizeLevel("panic"))
+	assert.Equal(t, LevelDebug, NormalizeLevel(float64(20)))
+	assert.Equal(t, LevelTrace, NormalizeLevel(float64(10)))
+	assert.Equal(t, "", NormalizeLevel("verbose"))
+	assert.Equal(t, "", NormalizeLevel(true))
+}
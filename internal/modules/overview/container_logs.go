@@ -6,20 +6,38 @@ SPDX-License-Identifier: Apache-2.0
 package overview
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
-	"strings"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 
-	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/config"
 	"github.com/vmware/octant/internal/log"
 	container2 "github.com/vmware/octant/internal/modules/overview/container"
+	"github.com/vmware/octant/internal/queryer"
+	"github.com/vmware/octant/pkg/store"
 )
 
-func containerLogsHandler(ctx context.Context, clusterClient cluster.ClientInterface) http.HandlerFunc {
+// defaultLogTailLines is how many lines of a container's log are returned as
+// JSON when the query doesn't select or filter lines, so requests for a
+// snapshot of a long log don't read all of it.
+const defaultLogTailLines = 100
+
+// containerLogsHandler returns a container's log entries which match the
+// query as JSON. Only the last defaultLogTailLines lines are read unless the
+// query sets tailLines, sinceTime or sinceSeconds or filters lines.
+func containerLogsHandler(ctx context.Context, dashConfig config.Dash) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -28,52 +46,512 @@ func containerLogsHandler(ctx context.Context, clusterClient cluster.ClientInter
 		podName := vars["pod"]
 		namespace := vars["namespace"]
 
-		kubeClient, err := clusterClient.KubernetesClient()
+		q, err := logQueryFromValues(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		q.options.Follow = false
+		q.defaultTail(defaultLogTailLines)
+
+		kubeClient, err := dashConfig.ClusterClient().KubernetesClient()
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		lines := make(chan string)
-		done := make(chan bool)
+		var lr logResponse
 
-		var entries []logEntry
+		err = readContainerLog(r.Context(), kubeClient, namespace, podName, containerName, q, func(entry logEntry) error {
+			lr.Entries = append(lr.Entries, entry)
+			return nil
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(&lr); err != nil {
+			logger := log.From(ctx)
+			logger.With("err", err.Error()).Errorf("unable to encode log entries")
+		}
+	}
+}
+
+// logDownloadFormats are the content types of log download formats.
+var logDownloadFormats = map[string]string{
+	"text":   "text/plain; charset=utf-8",
+	"ndjson": "application/x-ndjson",
+}
+
+// containerLogsDownloadHandler streams a container's log as a file. The
+// format query parameter selects plain text (the default) or newline
+// delimited JSON. If the query has filters, only matching entries are
+// included.
+func containerLogsDownloadHandler(ctx context.Context, dashConfig config.Dash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		containerName := vars["container"]
+		podName := vars["pod"]
+		namespace := vars["namespace"]
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "text"
+		}
+
+		contentType, ok := logDownloadFormats[format]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+			return
+		}
+
+		q, err := logQueryFromValues(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		q.options.Follow = false
+
+		kubeClient, err := dashConfig.ClusterClient().KubernetesClient()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		filename := fmt.Sprintf("%s-%s", podName, containerName)
+		if q.isFiltered() {
+			filename += "-filtered"
+		}
+		extension := ".log"
+		if format == "ndjson" {
+			extension = ".ndjson"
+		}
+
+		// headers are written with the first entry so errors opening the log
+		// can still be returned as errors.
+		bw := bufio.NewWriter(w)
+		wroteHeader := false
+		writeHeader := func() {
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+extension))
+			w.WriteHeader(http.StatusOK)
+			wroteHeader = true
+		}
+
+		encoder := json.NewEncoder(bw)
+
+		err = readContainerLog(r.Context(), kubeClient, namespace, podName, containerName, q, func(entry logEntry) error {
+			if !wroteHeader {
+				writeHeader()
+			}
+
+			if format == "ndjson" {
+				return encoder.Encode(&entry)
+			}
+
+			if entry.Timestamp != nil {
+				if _, err := bw.WriteString(entry.Timestamp.Format(time.RFC3339Nano) + " "); err != nil {
+					return err
+				}
+			}
+
+			_, err := bw.WriteString(entry.Message + "\n")
+			return err
+		})
+
+		if err != nil && !wroteHeader {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if !wroteHeader {
+			writeHeader()
+		}
+
+		if err == nil {
+			err = bw.Flush()
+		}
+
+		if err != nil {
+			logger := log.From(ctx).With("pod", podName, "container", containerName)
+			logger.WithErr(err).Errorf("unable to download log")
+		}
+	}
+}
+
+// readContainerLog reads a container's log and calls fn with each entry which
+// matches the query. Reading stops if fn returns an error.
+func readContainerLog(ctx context.Context, kubeClient kubernetes.Interface, namespace, podName, containerName string, q logQuery, fn func(logEntry) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan container2.LogLine, logStreamBuffer)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- container2.StreamLogs(ctx, kubeClient, namespace, podName, containerName, q.options, lines)
+	}()
+
+	var fnErr error
+	for line := range lines {
+		if fnErr != nil {
+			// drain lines until the stream stops
+			continue
+		}
+
+		entry, ok := q.entry(line)
+		if !ok {
+			continue
+		}
+
+		if fnErr = fn(entry); fnErr != nil {
+			cancel()
+		}
+	}
+
+	if err := <-errCh; err != nil {
+		return err
+	}
+
+	return fnErr
+}
+
+const (
+	// logStreamBuffer is the number of lines read ahead of a client. Once it
+	// is full, reading the log waits for the client.
+	logStreamBuffer = 1000
+	// logStreamMaxBatch is the maximum number of lines sent in one event.
+	logStreamMaxBatch = 500
+)
+
+// logStreamInterval is how often batches of lines are sent to a client.
+var logStreamInterval = 250 * time.Millisecond
+
+// containerLogsStreamHandler streams a container's log as server sent events.
+func containerLogsStreamHandler(ctx context.Context, dashConfig config.Dash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		containerName := vars["container"]
+		podName := vars["pod"]
+		namespace := vars["namespace"]
+
+		q, err := logQueryFromValues(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		kubeClient, err := dashConfig.ClusterClient().KubernetesClient()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		lines := make(chan container2.LogLine, logStreamBuffer)
+		entries := make(chan logEntry)
+		errCh := make(chan error, 1)
 
 		go func() {
+			errCh <- container2.StreamLogs(r.Context(), kubeClient, namespace, podName, containerName, q.options, lines)
+		}()
+
+		go func() {
+			defer close(entries)
 			for line := range lines {
-				parts := strings.SplitN(line, " ", 2)
-				logTime, err := time.Parse(time.RFC3339, parts[0])
-				if err == nil {
-					entries = append(entries, logEntry{
-						Timestamp: logTime,
-						Message:   parts[1],
-					})
+				entry, ok := q.entry(line)
+				if !ok {
+					continue
 				}
-			}
 
-			done <- true
+				select {
+				case <-r.Context().Done():
+				case entries <- entry:
+				}
+			}
 		}()
 
-		err = container2.Logs(r.Context(), kubeClient, namespace, podName, containerName, lines)
+		logger := log.From(ctx).With("pod", podName, "container", containerName)
+		streamLogEntries(w, entries, errCh, logger)
+	}
+}
+
+// aggregatedLogSources are the objects whose pods' logs can be aggregated by
+// the lowercase kind used in their log stream path.
+var aggregatedLogSources = map[string]store.Key{
+	"daemonset":   {APIVersion: "apps/v1", Kind: "DaemonSet"},
+	"deployment":  {APIVersion: "apps/v1", Kind: "Deployment"},
+	"job":         {APIVersion: "batch/v1", Kind: "Job"},
+	"service":     {APIVersion: "v1", Kind: "Service"},
+	"statefulset": {APIVersion: "apps/v1", Kind: "StatefulSet"},
+}
+
+// aggregatedLogsStreamHandler streams the logs of all containers in the pods
+// of a workload or service as server sent events. Entries are tagged with
+// their pod and container.
+func aggregatedLogsStreamHandler(ctx context.Context, dashConfig config.Dash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		key, ok := aggregatedLogSources[vars["kind"]]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unable to aggregate logs for %q", vars["kind"]), http.StatusNotFound)
+			return
+		}
+
+		key.Namespace = vars["namespace"]
+		key.Name = vars["name"]
+
+		q, err := logQueryFromValues(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		objectStore := dashConfig.ObjectStore()
+		clusterClient := dashConfig.ClusterClient()
+
+		object, err := loadLogSource(r.Context(), objectStore, key)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		<-done
+		if object == nil {
+			http.Error(w, fmt.Sprintf("%s %s was not found", key.Kind, key.Name), http.StatusNotFound)
+			return
+		}
 
-		var lr logResponse
+		discoveryClient, err := clusterClient.DiscoveryClient()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 
-		if len(entries) <= 100 {
-			lr.Entries = entries
-		} else {
-			// take last 100 entries from the slice
-			lr.Entries = entries[len(entries)-100:]
+		kubeClient, err := dashConfig.ClusterClient().KubernetesClient()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
 
-		if err := json.NewEncoder(w).Encode(&lr); err != nil {
-			logger := log.From(ctx)
-			logger.With("err", err.Error()).Errorf("unable to encode log entries")
+		oq := queryer.New(objectStore, discoveryClient)
+		listPods := func(ctx context.Context) ([]*corev1.Pod, error) {
+			return oq.PodsForObject(ctx, object)
 		}
+
+		lines := make(chan container2.TaggedLogLine, logStreamBuffer)
+		entries := make(chan logEntry)
+		errCh := make(chan error, 1)
+
+		containerName := r.URL.Query().Get("container")
+
+		go func() {
+			errCh <- container2.AggregateLogs(r.Context(), kubeClient, listPods, containerName, q.options, lines)
+		}()
+
+		go func() {
+			defer close(entries)
+			for line := range lines {
+				entry, ok := q.entry(line.LogLine)
+				if !ok {
+					continue
+				}
+				entry.Pod = line.Pod
+				entry.Container = line.Container
+
+				select {
+				case <-r.Context().Done():
+				case entries <- entry:
+				}
+			}
+		}()
+
+		logger := log.From(ctx).With("kind", key.Kind, "name", key.Name)
+		streamLogEntries(w, entries, errCh, logger)
+	}
+}
+
+// loadLogSource loads an object whose pods' logs are aggregated. It returns
+// nil if the object doesn't exist.
+func loadLogSource(ctx context.Context, objectStore store.Store, key store.Key) (runtime.Object, error) {
+	u, err := objectStore.Get(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get %s %s", key.Kind, key.Name)
+	}
+
+	if u == nil {
+		return nil, nil
 	}
+
+	object, err := scheme.Scheme.New(key.GroupVersionKind())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, object); err != nil {
+		return nil, errors.Wrapf(err, "convert %s %s", key.Kind, key.Name)
+	}
+
+	return object, nil
+}
+
+// newLogEntry creates a log entry from a line. The timestamp is omitted
+// unless timestamps is true. If the line is JSON, its level and fields are
+// extracted.
+func newLogEntry(line container2.LogLine, timestamps bool) logEntry {
+	entry := logEntry{Message: line.Message}
+	if timestamps && !line.Timestamp.IsZero() {
+		timestamp := line.Timestamp
+		entry.Timestamp = &timestamp
+	}
+
+	if sl, ok := container2.ParseStructured(line.Message); ok {
+		entry.Level = sl.Level
+		if len(sl.Fields) > 0 {
+			entry.Fields = sl.Fields
+		}
+	}
+
+	return entry
+}
+
+// streamLogEntries sends log entries as server sent events until entries is
+// closed. Entries are sent in batches as "logs" events. An "error" event is
+// sent if errCh returns an error and an "end" event is sent when the log is
+// finished. Writing blocks while the client is slow, which stops entries
+// from being read and in turn stops lines being read from the cluster.
+func streamLogEntries(w http.ResponseWriter, entries <-chan logEntry, errCh <-chan error, logger log.Logger) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "server sent events are unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	send := func(eventType string, v interface{}) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			logger.WithErr(err).Errorf("unable to encode log event")
+			return
+		}
+
+		_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, data)
+		flusher.Flush()
+	}
+
+	ticker := time.NewTicker(logStreamInterval)
+	defer ticker.Stop()
+
+	var batch []logEntry
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		send("logs", logResponse{Entries: batch})
+		batch = nil
+	}
+
+	for {
+		select {
+		case entry, ok := <-entries:
+			if !ok {
+				flush()
+
+				if err := <-errCh; err != nil {
+					logger.WithErr(err).Errorf("streaming logs")
+					send("error", map[string]string{"message": err.Error()})
+				}
+
+				send("end", map[string]string{})
+				return
+			}
+
+			batch = append(batch, entry)
+			if len(batch) >= logStreamMaxBatch {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// logOptionsFromQuery creates log options from query parameters. Timestamps
+// are included in entries unless timestamps is false.
+func logOptionsFromQuery(values url.Values) (container2.LogOptions, bool, error) {
+	var options container2.LogOptions
+	var err error
+
+	if options.Follow, err = queryBool(values, "follow", false); err != nil {
+		return options, false, err
+	}
+
+	if options.Previous, err = queryBool(values, "previous", false); err != nil {
+		return options, false, err
+	}
+
+	timestamps, err := queryBool(values, "timestamps", true)
+	if err != nil {
+		return options, false, err
+	}
+
+	if s := values.Get("sinceTime"); s != "" {
+		sinceTime, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return options, false, errors.Errorf("invalid sinceTime %q", s)
+		}
+		options.SinceTime = &sinceTime
+	}
+
+	if options.SinceSeconds, err = queryInt(values, "sinceSeconds"); err != nil {
+		return options, false, err
+	}
+
+	if options.SinceTime != nil && options.SinceSeconds != nil {
+		return options, false, errors.New("only one of sinceTime and sinceSeconds can be set")
+	}
+
+	if options.TailLines, err = queryInt(values, "tailLines"); err != nil {
+		return options, false, err
+	}
+
+	if options.LimitBytes, err = queryInt(values, "limitBytes"); err != nil {
+		return options, false, err
+	}
+
+	return options, timestamps, nil
+}
+
+func queryBool(values url.Values, name string, defaultValue bool) (bool, error) {
+	s := values.Get(name)
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errors.Errorf("invalid %s %q", name, s)
+	}
+
+	return b, nil
+}
+
+func queryInt(values url.Values, name string) (*int64, error) {
+	s := values.Get(name)
+	if s == "" {
+		return nil, nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || i < 0 {
+		return nil, errors.Errorf("invalid %s %q", name, s)
+	}
+
+	return &i, nil
 }
@@ -0,0 +1,286 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+
+	clusterfake "github.com/vmware/octant/internal/cluster/fake"
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/modules/overview/container"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storefake "github.com/vmware/octant/pkg/store/fake"
+)
+
+func Test_logOptionsFromQuery(t *testing.T) {
+	sinceTime := time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC)
+	ten := int64(10)
+
+	cases := []struct {
+		name       string
+		query      string
+		expected   container.LogOptions
+		timestamps bool
+		isErr      bool
+	}{
+		{
+			name:       "defaults",
+			expected:   container.LogOptions{},
+			timestamps: true,
+		},
+		{
+			name:  "all options",
+			query: "follow=true&previous=1&timestamps=false&sinceTime=2019-07-02T10:00:00Z&tailLines=10&limitBytes=10",
+			expected: container.LogOptions{
+				Follow:     true,
+				Previous:   true,
+				SinceTime:  &sinceTime,
+				TailLines:  &ten,
+				LimitBytes: &ten,
+			},
+		},
+		{
+			name:       "since seconds",
+			query:      "sinceSeconds=10",
+			expected:   container.LogOptions{SinceSeconds: &ten},
+			timestamps: true,
+		},
+		{
+			name:  "since time and since seconds",
+			query: "sinceTime=2019-07-02T10:00:00Z&sinceSeconds=10",
+			isErr: true,
+		},
+		{
+			name:  "invalid bool",
+			query: "follow=maybe",
+			isErr: true,
+		},
+		{
+			name:  "invalid time",
+			query: "sinceTime=yesterday",
+			isErr: true,
+		},
+		{
+			name:  "negative tail lines",
+			query: "tailLines=-1",
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			require.NoError(t, err)
+
+			got, timestamps, err := logOptionsFromQuery(values)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.timestamps, timestamps)
+		})
+	}
+}
+
+func Test_containerLogsStreamHandler(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	clusterClient := clusterfake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().KubernetesClient().Return(fake.NewSimpleClientset(), nil)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ClusterClient().Return(clusterClient)
+
+	router := mux.NewRouter()
+	router.Handle("/namespace/{namespace}/logs/pod/{pod}/container/{container}/stream",
+		containerLogsStreamHandler(context.Background(), dashConfig))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/namespace/default/logs/pod/missing/container/app/stream")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	body, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	expected := "event: error\n" +
+		`data: {"message":"get pod missing in default: pods \"missing\" not found"}` + "\n\n" +
+		"event: end\ndata: {}\n\n"
+	assert.Equal(t, expected, string(body))
+}
+
+func Test_containerLogsStreamHandler_invalid_options(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+
+	handler := containerLogsStreamHandler(context.Background(), dashConfig)
+
+	req := httptest.NewRequest(http.MethodGet, "/stream?tailLines=many", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func Test_aggregatedLogsStreamHandler_errors(t *testing.T) {
+	cases := []struct {
+		name         string
+		path         string
+		setup        func(dashConfig *configFake.MockDash, objectStore *storefake.MockStore)
+		expectedCode int
+	}{
+		{
+			name:         "unsupported kind",
+			path:         "/namespace/default/logs/configmap/name/stream",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "object not found",
+			path: "/namespace/default/logs/deployment/missing/stream",
+			setup: func(dashConfig *configFake.MockDash, objectStore *storefake.MockStore) {
+				key := store.Key{
+					Namespace:  "default",
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       "missing",
+				}
+				objectStore.EXPECT().Get(gomock.Any(), key).Return(nil, nil)
+				dashConfig.EXPECT().ObjectStore().Return(objectStore)
+				dashConfig.EXPECT().ClusterClient().Return(nil)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid options",
+			path:         "/namespace/default/logs/deployment/name/stream?follow=often",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dashConfig := configFake.NewMockDash(controller)
+			objectStore := storefake.NewMockStore(controller)
+
+			if tc.setup != nil {
+				tc.setup(dashConfig, objectStore)
+			}
+
+			router := mux.NewRouter()
+			router.Handle("/namespace/{namespace}/logs/{kind}/{name}/stream",
+				aggregatedLogsStreamHandler(context.Background(), dashConfig))
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+		})
+	}
+}
+
+func Test_loadLogSource(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.CreateDeployment("deployment")
+
+	key := store.Key{
+		Namespace:  deployment.Namespace,
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       deployment.Name,
+	}
+
+	objectStore := storefake.NewMockStore(controller)
+	objectStore.EXPECT().Get(gomock.Any(), key).Return(testutil.ToUnstructured(t, deployment), nil)
+
+	got, err := loadLogSource(context.Background(), objectStore, key)
+	require.NoError(t, err)
+
+	assert.Equal(t, deployment, got)
+}
+
+func Test_containerLogsDownloadHandler_errors(t *testing.T) {
+	cases := []struct {
+		name         string
+		query        string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "unsupported format",
+			query:        "format=xml",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "unsupported format \"xml\"\n",
+		},
+		{
+			name:         "invalid query",
+			query:        "regex=(",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "missing pod",
+			query:        "format=ndjson",
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "get pod missing in default: pods \"missing\" not found\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			clusterClient := clusterfake.NewMockClientInterface(controller)
+			clusterClient.EXPECT().KubernetesClient().Return(fake.NewSimpleClientset(), nil).AnyTimes()
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+
+			router := mux.NewRouter()
+			router.Handle("/namespace/{namespace}/logs/pod/{pod}/container/{container}/download",
+				containerLogsDownloadHandler(context.Background(), dashConfig))
+
+			req := httptest.NewRequest(http.MethodGet, "/namespace/default/logs/pod/missing/container/app/download?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Disposition"))
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -7,17 +7,21 @@ package overview
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 	kLabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/discovery"
 
 	"github.com/vmware/octant/internal/api"
 	"github.com/vmware/octant/internal/componentcache"
 	"github.com/vmware/octant/internal/config"
 	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/filter"
 	"github.com/vmware/octant/internal/link"
 	"github.com/vmware/octant/internal/modules/overview/printer"
+	"github.com/vmware/octant/internal/openapi"
 	"github.com/vmware/octant/internal/queryer"
 	"github.com/vmware/octant/pkg/view/component"
 )
@@ -27,11 +31,15 @@ type realGenerator struct {
 	componentCache componentcache.ComponentCache
 	printer        printer.Printer
 	dashConfig     config.Dash
+	schemas        *openapi.Schemas
+
+	mu sync.Mutex
 }
 
 // GeneratorOptions are additional options to pass a generator
 type GeneratorOptions struct {
 	LabelSet *kLabels.Set
+	Filter   *filter.Expression
 }
 
 // newGenerator creates a generator.
@@ -77,6 +85,8 @@ func (g *realGenerator) Generate(ctx context.Context, path, prefix, namespace st
 
 	q := queryer.New(g.dashConfig.ObjectStore(), discoveryInterface)
 
+	schemas := g.schemaLookup(discoveryInterface)
+
 	loaderFactory := describer.NewObjectLoaderFactory(g.dashConfig)
 
 	fields := pf.Fields(path)
@@ -85,8 +95,10 @@ func (g *realGenerator) Generate(ctx context.Context, path, prefix, namespace st
 		Fields:   fields,
 		Printer:  g.printer,
 		LabelSet: opts.LabelSet,
+		Filter:   opts.Filter,
 		Dash:     g.dashConfig,
 		Link:     linkGenerator,
+		Schemas:  schemas,
 
 		LoadObjects: loaderFactory.LoadObjects,
 		LoadObject:  loaderFactory.LoadObject,
@@ -99,3 +111,26 @@ func (g *realGenerator) Generate(ctx context.Context, path, prefix, namespace st
 
 	return cResponse, nil
 }
+
+// Schemas returns the schemas for the current cluster.
+func (g *realGenerator) Schemas() (openapi.Interface, error) {
+	discoveryInterface, err := g.dashConfig.ClusterClient().DiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return g.schemaLookup(discoveryInterface), nil
+}
+
+// schemaLookup lazily creates the schemas so they are cached between
+// requests.
+func (g *realGenerator) schemaLookup(client discovery.OpenAPISchemaInterface) *openapi.Schemas {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.schemas == nil {
+		g.schemas = openapi.NewSchemas(client, g.dashConfig.ObjectStore())
+	}
+
+	return g.schemas
+}
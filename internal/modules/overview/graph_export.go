@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	kLabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/api"
+	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/modules/overview/resourceviewer"
+	"github.com/vmware/octant/internal/queryer"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+type graphExportFormat struct {
+	contentType string
+	extension   string
+}
+
+// graphExportFormats are the formats a resource graph can be exported as.
+var graphExportFormats = map[string]graphExportFormat{
+	"dot":  {contentType: "text/vnd.graphviz; charset=utf-8", extension: ".dot"},
+	"svg":  {contentType: "image/svg+xml", extension: ".svg"},
+	"json": {contentType: "application/json", extension: ".json"},
+}
+
+// resourceGraphLoader creates the resource graph for an export request. It
+// returns the graph and the base name of the exported file.
+type resourceGraphLoader func(ctx context.Context, namespace string, values url.Values) (*component.ResourceViewer, string, error)
+
+// resourceGraphExportHandler exports a resource graph as DOT, SVG or JSON.
+// The graph is for a single object if the apiVersion, kind and name query
+// parameters are set. Otherwise it is the namespace graph, which can be
+// filtered with the kinds and labels query parameters.
+func resourceGraphExportHandler(load resourceGraphLoader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace := mux.Vars(r)["namespace"]
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "json"
+		}
+
+		exportFormat, ok := graphExportFormats[format]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+			return
+		}
+
+		rv, name, err := load(r.Context(), namespace, r.URL.Query())
+		if err != nil {
+			status := http.StatusInternalServerError
+			if isNotFound(err) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		graph, err := resourceviewer.NewGraph(rv)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var data []byte
+		switch format {
+		case "dot":
+			data = []byte(graph.DOT())
+		case "svg":
+			data = graph.SVG()
+		default:
+			data, err = json.MarshalIndent(graph, "", "  ")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", exportFormat.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+exportFormat.extension))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(data)
+	}
+}
+
+func isNotFound(err error) bool {
+	nf, ok := errors.Cause(err).(interface{ NotFound() bool })
+	return ok && nf.NotFound()
+}
+
+// newResourceGraphLoader creates a resource graph loader which visits
+// objects in the object store.
+func newResourceGraphLoader(dashConfig config.Dash) resourceGraphLoader {
+	return func(ctx context.Context, namespace string, values url.Values) (*component.ResourceViewer, string, error) {
+		objectStore := dashConfig.ObjectStore()
+
+		discoveryClient, err := dashConfig.ClusterClient().DiscoveryClient()
+		if err != nil {
+			return nil, "", err
+		}
+
+		q := queryer.New(objectStore, discoveryClient)
+
+		rv, err := resourceviewer.New(dashConfig, resourceviewer.WithDefaultQueryer(dashConfig, q))
+		if err != nil {
+			return nil, "", err
+		}
+
+		if kind := values.Get("kind"); kind != "" {
+			key := store.Key{
+				Namespace:  namespace,
+				APIVersion: values.Get("apiVersion"),
+				Kind:       kind,
+				Name:       values.Get("name"),
+			}
+
+			if key.APIVersion == "" || key.Name == "" {
+				return nil, "", errors.New("apiVersion, kind and name are required to export an object graph")
+			}
+
+			object, err := objectStore.Get(ctx, key)
+			if err != nil {
+				return nil, "", errors.Wrapf(err, "get %s %s", key.Kind, key.Name)
+			}
+
+			if object == nil {
+				return nil, "", api.NewNotFoundError(path.Join(namespace, key.Kind, key.Name))
+			}
+
+			c, err := rv.Visit(ctx, object)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return c, fmt.Sprintf("%s-%s-graph", strings.ToLower(key.Kind), key.Name), nil
+		}
+
+		var labelSet *kLabels.Set
+		if s := values.Get("labels"); s != "" {
+			set, err := kLabels.ConvertSelectorToLabelsMap(s)
+			if err != nil {
+				return nil, "", errors.Wrap(err, "invalid labels")
+			}
+			labelSet = &set
+		}
+
+		objects, _, err := listGraphObjects(ctx, objectStore, namespace, labelSet, parseGraphKinds(values.Get("kinds")))
+		if err != nil {
+			return nil, "", err
+		}
+
+		var runtimeObjects []runtime.Object
+		for _, object := range objects {
+			runtimeObjects = append(runtimeObjects, object)
+		}
+
+		c, err := rv.VisitObjects(ctx, runtimeObjects)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return c, fmt.Sprintf("%s-graph", namespace), nil
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/api"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func Test_resourceGraphExportHandler(t *testing.T) {
+	rv := component.NewResourceViewer("Resource Viewer")
+	rv.AddNode("deployment", component.Node{Name: "web", Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment", Status: component.NodeStatusOK})
+
+	cases := []struct {
+		name                string
+		query               string
+		loadErr             error
+		expectedCode        int
+		expectedType        string
+		expectedDisposition string
+		expectedBody        string
+	}{
+		{
+			name:                "json",
+			expectedCode:        http.StatusOK,
+			expectedType:        "application/json",
+			expectedDisposition: `attachment; filename="default-graph.json"`,
+			expectedBody: `{
+  "nodes": [
+    {
+      "id": "deployment",
+      "namespace": "default",
+      "apiVersion": "apps/v1",
+      "kind": "Deployment",
+      "name": "web",
+      "status": "ok"
+    }
+  ],
+  "edges": []
+}`,
+		},
+		{
+			name:                "dot",
+			query:               "format=dot",
+			expectedCode:        http.StatusOK,
+			expectedType:        "text/vnd.graphviz; charset=utf-8",
+			expectedDisposition: `attachment; filename="default-graph.dot"`,
+		},
+		{
+			name:                "svg",
+			query:               "format=svg",
+			expectedCode:        http.StatusOK,
+			expectedType:        "image/svg+xml",
+			expectedDisposition: `attachment; filename="default-graph.svg"`,
+		},
+		{
+			name:         "unsupported format",
+			query:        "format=png",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "unsupported format \"png\"\n",
+		},
+		{
+			name:         "not found",
+			loadErr:      api.NewNotFoundError("default/Deployment/missing"),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "load error",
+			loadErr:      errors.New("failed"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: "failed\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			load := func(ctx context.Context, namespace string, values url.Values) (*component.ResourceViewer, string, error) {
+				require.Equal(t, "default", namespace)
+				if tc.loadErr != nil {
+					return nil, "", tc.loadErr
+				}
+				return rv, "default-graph", nil
+			}
+
+			router := mux.NewRouter()
+			router.Handle("/namespace/{namespace}/resource-graph/export", resourceGraphExportHandler(load))
+
+			req := httptest.NewRequest(http.MethodGet, "/namespace/default/resource-graph/export?"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			if tc.expectedType != "" {
+				assert.Equal(t, tc.expectedType, w.Header().Get("Content-Type"))
+			}
+			if tc.expectedDisposition != "" {
+				assert.Equal(t, tc.expectedDisposition, w.Header().Get("Content-Disposition"))
+			}
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/gvk"
+	"github.com/vmware/octant/internal/helm"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// helmReleases describes Helm releases. Releases are decoded from the release
+// secrets Helm v3 stores in the release namespace.
+type helmReleases struct {
+	path string
+}
+
+var _ describer.Describer = (*helmReleases)(nil)
+
+func newHelmReleases(path string) *helmReleases {
+	return &helmReleases{
+		path: path,
+	}
+}
+
+// Describe describes a list of releases or a single release if the name field is set.
+func (h *helmReleases) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	if name := options.Fields["name"]; name != "" {
+		return h.describeRelease(ctx, namespace, name, options)
+	}
+
+	return h.describeList(ctx, namespace, options)
+}
+
+// PathFilters returns the path filters for Helm releases.
+func (h *helmReleases) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter(h.path, h),
+		*describer.NewPathFilter(fmt.Sprintf("%s/(?P<name>.*?)", h.path), h),
+	}
+}
+
+func (h *helmReleases) describeList(ctx context.Context, namespace string, options describer.Options) (component.ContentResponse, error) {
+	histories, err := helm.Histories(ctx, options.ObjectStore(), namespace)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	list := component.NewList("Helm Releases", nil)
+
+	cols := component.NewTableCols("Name", "Revision", "Status", "Chart", "App Version", "Updated")
+	table := component.NewTable("Helm Releases", cols)
+
+	for i := range histories {
+		release := histories[i].Latest()
+		if release == nil {
+			continue
+		}
+
+		nameLink, err := releaseLink(release, options)
+		if err != nil {
+			return describer.EmptyContentResponse, err
+		}
+
+		table.Add(component.TableRow{
+			"Name":        nameLink,
+			"Revision":    component.NewText(strconv.Itoa(release.Version)),
+			"Status":      component.NewText(release.Info.Status),
+			"Chart":       component.NewText(release.ChartName()),
+			"App Version": component.NewText(release.Chart.Metadata.AppVersion),
+			"Updated":     component.NewTimestamp(release.Info.LastDeployed.Time),
+		})
+	}
+
+	list.Add(table)
+
+	return component.ContentResponse{
+		Components: []component.Component{list},
+	}, nil
+}
+
+func (h *helmReleases) describeRelease(ctx context.Context, namespace, name string, options describer.Options) (component.ContentResponse, error) {
+	history, err := helm.ReleaseHistory(ctx, options.ObjectStore(), namespace, name)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	release := history.Latest()
+	if release == nil {
+		return describer.EmptyContentResponse, errors.Errorf("release %q has no revisions", name)
+	}
+
+	title := component.Title(component.NewText("Helm Releases"), component.NewText(name))
+	cr := component.NewContentResponse(title)
+
+	summary := component.NewSummary("Release", []component.SummarySection{
+		{Header: "Status", Content: component.NewText(release.Info.Status)},
+		{Header: "Revision", Content: component.NewText(strconv.Itoa(release.Version))},
+		{Header: "Chart", Content: component.NewText(release.Chart.Metadata.Name)},
+		{Header: "Chart Version", Content: component.NewText(release.Chart.Metadata.Version)},
+		{Header: "App Version", Content: component.NewText(release.Chart.Metadata.AppVersion)},
+		{Header: "First Deployed", Content: component.NewTimestamp(release.Info.FirstDeployed.Time)},
+		{Header: "Last Deployed", Content: component.NewTimestamp(release.Info.LastDeployed.Time)},
+		{Header: "Description", Content: component.NewText(release.Info.Description)},
+	}...)
+	summary.SetAccessor("summary")
+	cr.Add(summary)
+
+	resources, err := releaseResources(release, options)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+	resources.SetAccessor("resources")
+	cr.Add(resources)
+
+	historyTable := releaseHistoryTable(history)
+	historyTable.SetAccessor("history")
+	cr.Add(historyTable)
+
+	values, err := releaseValues(release)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+	values.SetAccessor("values")
+	cr.Add(values)
+
+	notes := component.NewText(release.Info.Notes)
+	notes.Metadata.Title = component.TitleFromString("Notes")
+	notes.SetAccessor("notes")
+	cr.Add(notes)
+
+	return *cr, nil
+}
+
+func releaseLink(release *helm.Release, options describer.Options) (component.Component, error) {
+	apiVersion, kind := gvk.HelmReleaseGVK.ToAPIVersionAndKind()
+	return options.Link.ForGVK(release.Namespace, apiVersion, kind, release.Name, release.Name)
+}
+
+// releaseResources lists the objects in a release's manifest. Objects which
+// Octant can't link to are listed by name.
+func releaseResources(release *helm.Release, options describer.Options) (*component.Table, error) {
+	objects, err := helm.ManifestObjects(release)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := component.NewTableCols("Name", "Kind", "API Version")
+	table := component.NewTable("Resources", cols)
+
+	for _, object := range objects {
+		var name component.Component = component.NewText(object.Name)
+		if l, err := options.Link.ForGVK(object.Namespace, object.APIVersion, object.Kind, object.Name, object.Name); err == nil {
+			name = l
+		}
+
+		table.Add(component.TableRow{
+			"Name":        name,
+			"Kind":        component.NewText(object.Kind),
+			"API Version": component.NewText(object.APIVersion),
+		})
+	}
+
+	return table, nil
+}
+
+func releaseHistoryTable(history *helm.History) *component.Table {
+	cols := component.NewTableCols("Revision", "Updated", "Status", "Chart", "App Version", "Description")
+	table := component.NewTable("History", cols)
+
+	for _, release := range history.Revisions {
+		table.Add(component.TableRow{
+			"Revision":    component.NewText(strconv.Itoa(release.Version)),
+			"Updated":     component.NewTimestamp(release.Info.LastDeployed.Time),
+			"Status":      component.NewText(release.Info.Status),
+			"Chart":       component.NewText(release.ChartName()),
+			"App Version": component.NewText(release.Chart.Metadata.AppVersion),
+			"Description": component.NewText(release.Info.Description),
+		})
+	}
+
+	return table
+}
+
+// releaseValues shows the computed values the release was rendered with.
+func releaseValues(release *helm.Release) (*component.YAML, error) {
+	values := release.ComputedValues()
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal release values")
+	}
+
+	return component.NewYAML(component.TitleFromString("Values"), "---\n"+string(data)), nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/helm"
+	linkFake "github.com/vmware/octant/internal/link/fake"
+	"github.com/vmware/octant/internal/testutil"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func helmReleaseSecret(t *testing.T, version int) *unstructured.Unstructured {
+	release := map[string]interface{}{
+		"name":      "app",
+		"namespace": "default",
+		"version":   version,
+		"info": map[string]interface{}{
+			"last_deployed": "2019-07-02T10:00:00Z",
+			"status":        "deployed",
+			"description":   "Upgrade complete",
+			"notes":         "notes",
+		},
+		"chart": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":       "app",
+				"version":    "1.0.0",
+				"appVersion": "2.0.0",
+			},
+			"values": map[string]interface{}{"replicas": 1, "port": 80},
+		},
+		"config":   map[string]interface{}{"replicas": 2},
+		"manifest": "apiVersion: v1\nkind: Service\nmetadata:\n  name: app\n",
+	}
+
+	data, err := json.Marshal(release)
+	require.NoError(t, err)
+
+	secret := testutil.CreateSecret("sh.helm.release.v1.app.v1")
+	secret.Labels = map[string]string{helm.OwnerLabel: helm.OwnerValue}
+	secret.Data = map[string][]byte{
+		"release": []byte(base64.StdEncoding.EncodeToString(data)),
+	}
+
+	return testutil.ToUnstructured(t, secret)
+}
+
+func Test_helmReleases_list(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	objectStore := storeFake.NewMockStore(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+
+	objectStore.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*unstructured.Unstructured{helmReleaseSecret(t, 1), helmReleaseSecret(t, 2)}, nil)
+
+	releaseLink := component.NewLink("", "app", "/app")
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().ForGVK("default", "helm.sh/v3", "Release", "app", "app").Return(releaseLink, nil)
+
+	options := describer.Options{
+		Dash:   dashConfig,
+		Link:   l,
+		Fields: map[string]string{},
+	}
+
+	d := newHelmReleases("/helm-releases")
+	got, err := d.Describe(context.Background(), "/prefix", "default", options)
+	require.NoError(t, err)
+
+	list := component.NewList("Helm Releases", nil)
+	table := component.NewTable("Helm Releases",
+		component.NewTableCols("Name", "Revision", "Status", "Chart", "App Version", "Updated"))
+	table.Add(component.TableRow{
+		"Name":        releaseLink,
+		"Revision":    component.NewText("2"),
+		"Status":      component.NewText("deployed"),
+		"Chart":       component.NewText("app-1.0.0"),
+		"App Version": component.NewText("2.0.0"),
+		"Updated":     component.NewTimestamp(time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC)),
+	})
+	list.Add(table)
+
+	expected := component.ContentResponse{
+		Components: []component.Component{list},
+	}
+
+	assert.Equal(t, expected, got)
+}
+
+func Test_helmReleases_release(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	objectStore := storeFake.NewMockStore(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+
+	objectStore.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return([]*unstructured.Unstructured{helmReleaseSecret(t, 1), helmReleaseSecret(t, 2)}, nil)
+
+	serviceLink := component.NewLink("", "app", "/service")
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().ForGVK("default", "v1", "Service", "app", "app").Return(serviceLink, nil)
+
+	options := describer.Options{
+		Dash:   dashConfig,
+		Link:   l,
+		Fields: map[string]string{"name": "app"},
+	}
+
+	d := newHelmReleases("/helm-releases")
+	got, err := d.Describe(context.Background(), "/prefix", "default", options)
+	require.NoError(t, err)
+
+	assert.Equal(t, component.Title(component.NewText("Helm Releases"), component.NewText("app")), got.Title)
+	require.Len(t, got.Components, 5)
+
+	var accessors []string
+	for _, c := range got.Components {
+		accessors = append(accessors, c.GetMetadata().Accessor)
+	}
+	assert.Equal(t, []string{"summary", "resources", "history", "values", "notes"}, accessors)
+
+	resources, ok := got.Components[1].(*component.Table)
+	require.True(t, ok)
+	require.Len(t, resources.Rows(), 1)
+	assert.Equal(t, serviceLink, resources.Rows()[0]["Name"])
+
+	history, ok := got.Components[2].(*component.Table)
+	require.True(t, ok)
+	assert.Len(t, history.Rows(), 2)
+
+	values, ok := got.Components[3].(*component.YAML)
+	require.True(t, ok)
+	assert.Equal(t, "---\nport: 80\nreplicas: 2\n", values.Config.Data)
+}
+
+func Test_helmReleases_PathFilters(t *testing.T) {
+	d := newHelmReleases("/helm-releases")
+	filters := d.PathFilters()
+	require.Len(t, filters, 2)
+
+	assert.True(t, filters[0].Match("/helm-releases"))
+	assert.Equal(t, map[string]string{"name": "app"}, filters[1].Fields("/helm-releases/app"))
+}
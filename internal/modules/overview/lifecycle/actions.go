@@ -0,0 +1,484 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const (
+	// RestartedAtAnnotation is set on pod templates to restart a rollout.
+	// It is the annotation kubectl rollout restart sets.
+	RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+	// instantiateAnnotation marks jobs created manually from a cron job.
+	instantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+	maxNameLength = 63
+)
+
+// mergePatch applies a JSON merge patch to an object.
+func mergePatch(client Client, object *unstructured.Unstructured, patch map[string]interface{}, subresources ...string) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrap(err, "marshal patch")
+	}
+
+	resourceClient, err := resourceClient(client, object)
+	if err != nil {
+		return err
+	}
+
+	if _, err := resourceClient.Patch(object.GetName(), types.MergePatchType, data, metav1.UpdateOptions{}, subresources...); err != nil {
+		return errors.Wrapf(err, "patch %s %s", object.GetKind(), object.GetName())
+	}
+
+	return nil
+}
+
+// Delete deletes an object.
+type Delete struct{}
+
+var _ Action = (*Delete)(nil)
+
+// NewDelete creates an instance of Delete.
+func NewDelete() *Delete {
+	return &Delete{}
+}
+
+// Name returns the name of the action.
+func (a *Delete) Name() string {
+	return "lifecycle/delete"
+}
+
+// Title returns the title of the action.
+func (a *Delete) Title() string {
+	return "Delete"
+}
+
+// Description describes the action.
+func (a *Delete) Description() string {
+	return "Deletes the object. Dependents are deleted in the background unless another cascade option is chosen."
+}
+
+// Supports returns true for all objects.
+func (a *Delete) Supports(object *unstructured.Unstructured) bool {
+	return object != nil
+}
+
+// Access requires delete access to the object.
+func (a *Delete) Access(object *unstructured.Unstructured) []Access {
+	return []Access{{Key: objectKey(object), Verb: "delete"}}
+}
+
+// Fields returns the cascade option and a confirmation of the name.
+func (a *Delete) Fields(object *unstructured.Unstructured) []component.FormField {
+	return []component.FormField{
+		component.NewFormFieldRadio("Cascade", "cascade", []component.InputChoice{
+			{Label: "Background", Value: string(metav1.DeletePropagationBackground), Checked: true},
+			{Label: "Foreground", Value: string(metav1.DeletePropagationForeground)},
+			{Label: "Orphan dependents", Value: string(metav1.DeletePropagationOrphan)},
+		}),
+		component.NewFormFieldText(fmt.Sprintf("Type %q to confirm", object.GetName()), "confirm", ""),
+	}
+}
+
+// Perform deletes the object.
+func (a *Delete) Perform(ctx context.Context, client Client, object *unstructured.Unstructured, payload action.Payload) error {
+	confirm, _ := payload.String("confirm")
+	if confirm != object.GetName() {
+		return errors.Errorf("type %q to confirm deleting %s", object.GetName(), object.GetKind())
+	}
+
+	policy := metav1.DeletePropagationBackground
+	if cascade, err := payload.String("cascade"); err == nil && cascade != "" {
+		policy = metav1.DeletionPropagation(cascade)
+	}
+
+	switch policy {
+	case metav1.DeletePropagationBackground, metav1.DeletePropagationForeground, metav1.DeletePropagationOrphan:
+	default:
+		return errors.Errorf("unknown cascade option %q", policy)
+	}
+
+	resourceClient, err := resourceClient(client, object)
+	if err != nil {
+		return err
+	}
+
+	options := &metav1.DeleteOptions{PropagationPolicy: &policy}
+	if err := resourceClient.Delete(object.GetName(), options); err != nil {
+		return errors.Wrapf(err, "delete %s %s", object.GetKind(), object.GetName())
+	}
+
+	return nil
+}
+
+// Restart restarts the rollout of a workload by annotating its pod
+// template.
+type Restart struct {
+	nowFunc func() time.Time
+}
+
+var _ Action = (*Restart)(nil)
+
+// NewRestart creates an instance of Restart.
+func NewRestart() *Restart {
+	return &Restart{nowFunc: time.Now}
+}
+
+// Name returns the name of the action.
+func (a *Restart) Name() string {
+	return "lifecycle/restart"
+}
+
+// Title returns the title of the action.
+func (a *Restart) Title() string {
+	return "Restart"
+}
+
+// Description describes the action.
+func (a *Restart) Description() string {
+	return "Replaces the pods using the workload's update strategy."
+}
+
+// Supports returns true for deployments, daemon sets and stateful sets.
+func (a *Restart) Supports(object *unstructured.Unstructured) bool {
+	return isKind(object, "apps", "Deployment", "DaemonSet", "StatefulSet")
+}
+
+// Access requires patch access to the object.
+func (a *Restart) Access(object *unstructured.Unstructured) []Access {
+	return []Access{{Key: objectKey(object), Verb: "patch"}}
+}
+
+// Fields returns no fields.
+func (a *Restart) Fields(object *unstructured.Unstructured) []component.FormField {
+	return nil
+}
+
+// Perform restarts the rollout.
+func (a *Restart) Perform(ctx context.Context, client Client, object *unstructured.Unstructured, payload action.Payload) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						RestartedAtAnnotation: a.nowFunc().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	}
+
+	return mergePatch(client, object, patch)
+}
+
+// Scale sets the number of replicas for stateful sets and replica sets.
+// Deployments are scaled with their configuration editor.
+type Scale struct{}
+
+var _ Action = (*Scale)(nil)
+
+// NewScale creates an instance of Scale.
+func NewScale() *Scale {
+	return &Scale{}
+}
+
+// Name returns the name of the action.
+func (a *Scale) Name() string {
+	return "lifecycle/scale"
+}
+
+// Title returns the title of the action.
+func (a *Scale) Title() string {
+	return "Scale"
+}
+
+// Description describes the action.
+func (a *Scale) Description() string {
+	return "Sets the number of replicas."
+}
+
+// Supports returns true for stateful sets and replica sets.
+func (a *Scale) Supports(object *unstructured.Unstructured) bool {
+	return isKind(object, "apps", "StatefulSet", "ReplicaSet")
+}
+
+// Access requires patch access to the scale subresource.
+func (a *Scale) Access(object *unstructured.Unstructured) []Access {
+	key := objectKey(object)
+	key.Subresource = "scale"
+	return []Access{{Key: key, Verb: "patch"}}
+}
+
+// Fields returns the number of replicas.
+func (a *Scale) Fields(object *unstructured.Unstructured) []component.FormField {
+	replicas, _, _ := unstructured.NestedInt64(object.Object, "spec", "replicas")
+	return []component.FormField{
+		component.NewFormFieldNumber("Replicas", "replicas", fmt.Sprintf("%d", replicas)),
+	}
+}
+
+// Perform scales the object.
+func (a *Scale) Perform(ctx context.Context, client Client, object *unstructured.Unstructured, payload action.Payload) error {
+	value, err := payload.Float64("replicas")
+	if err != nil {
+		return err
+	}
+
+	if value < 0 || value != math.Trunc(value) {
+		return errors.Errorf("replicas must be a whole number greater than or equal to zero")
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(value),
+		},
+	}
+
+	return mergePatch(client, object, patch, "scale")
+}
+
+// setField is an action which sets a boolean field in an object's spec.
+type setField struct {
+	name        string
+	title       string
+	description string
+	group       string
+	kind        string
+	field       string
+	value       bool
+}
+
+var _ Action = (*setField)(nil)
+
+// NewPauseRollout creates an action which pauses a deployment rollout.
+func NewPauseRollout() Action {
+	return &setField{
+		name:        "lifecycle/pauseRollout",
+		title:       "Pause Rollout",
+		description: "Stops changes to the pod template from being rolled out.",
+		group:       "apps",
+		kind:        "Deployment",
+		field:       "paused",
+		value:       true,
+	}
+}
+
+// NewResumeRollout creates an action which resumes a paused deployment
+// rollout.
+func NewResumeRollout() Action {
+	return &setField{
+		name:        "lifecycle/resumeRollout",
+		title:       "Resume Rollout",
+		description: "Rolls out changes made to the pod template while the rollout was paused.",
+		group:       "apps",
+		kind:        "Deployment",
+		field:       "paused",
+		value:       false,
+	}
+}
+
+// NewSuspendCronJob creates an action which suspends a cron job.
+func NewSuspendCronJob() Action {
+	return &setField{
+		name:        "lifecycle/suspendCronJob",
+		title:       "Suspend",
+		description: "Stops the cron job from scheduling jobs. Running jobs aren't affected.",
+		group:       "batch",
+		kind:        "CronJob",
+		field:       "suspend",
+		value:       true,
+	}
+}
+
+// NewResumeCronJob creates an action which resumes a suspended cron job.
+func NewResumeCronJob() Action {
+	return &setField{
+		name:        "lifecycle/resumeCronJob",
+		title:       "Resume",
+		description: "Resumes scheduling jobs for the cron job.",
+		group:       "batch",
+		kind:        "CronJob",
+		field:       "suspend",
+		value:       false,
+	}
+}
+
+func (a *setField) Name() string {
+	return a.name
+}
+
+func (a *setField) Title() string {
+	return a.title
+}
+
+func (a *setField) Description() string {
+	return a.description
+}
+
+// Supports returns true if the object is the action's kind and the field
+// isn't already set to the action's value.
+func (a *setField) Supports(object *unstructured.Unstructured) bool {
+	if !isKind(object, a.group, a.kind) {
+		return false
+	}
+
+	current, _, _ := unstructured.NestedBool(object.Object, "spec", a.field)
+	return current != a.value
+}
+
+func (a *setField) Access(object *unstructured.Unstructured) []Access {
+	return []Access{{Key: objectKey(object), Verb: "patch"}}
+}
+
+func (a *setField) Fields(object *unstructured.Unstructured) []component.FormField {
+	return nil
+}
+
+func (a *setField) Perform(ctx context.Context, client Client, object *unstructured.Unstructured, payload action.Payload) error {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			a.field: a.value,
+		},
+	}
+
+	return mergePatch(client, object, patch)
+}
+
+// CreateJob creates a job from a cron job's job template.
+type CreateJob struct {
+	nowFunc func() time.Time
+}
+
+var _ Action = (*CreateJob)(nil)
+
+// NewCreateJob creates an instance of CreateJob.
+func NewCreateJob() *CreateJob {
+	return &CreateJob{nowFunc: time.Now}
+}
+
+// Name returns the name of the action.
+func (a *CreateJob) Name() string {
+	return "lifecycle/createJob"
+}
+
+// Title returns the title of the action.
+func (a *CreateJob) Title() string {
+	return "Run Now"
+}
+
+// Description describes the action.
+func (a *CreateJob) Description() string {
+	return "Creates a job from the cron job's job template."
+}
+
+// Supports returns true for cron jobs.
+func (a *CreateJob) Supports(object *unstructured.Unstructured) bool {
+	return isKind(object, "batch", "CronJob")
+}
+
+// Access requires create access to jobs in the cron job's namespace.
+func (a *CreateJob) Access(object *unstructured.Unstructured) []Access {
+	key := store.Key{
+		Namespace:  object.GetNamespace(),
+		APIVersion: "batch/v1",
+		Kind:       "Job",
+	}
+	return []Access{{Key: key, Verb: "create"}}
+}
+
+// Fields returns the name of the job.
+func (a *CreateJob) Fields(object *unstructured.Unstructured) []component.FormField {
+	return []component.FormField{
+		component.NewFormFieldText("Job Name", "jobName", a.jobName(object)),
+	}
+}
+
+// Perform creates the job.
+func (a *CreateJob) Perform(ctx context.Context, client Client, object *unstructured.Unstructured, payload action.Payload) error {
+	name, _ := payload.String("jobName")
+	if name == "" {
+		name = a.jobName(object)
+	}
+
+	template, found, err := unstructured.NestedMap(object.Object, "spec", "jobTemplate")
+	if err != nil {
+		return errors.Wrap(err, "read job template")
+	}
+	if !found {
+		return errors.Errorf("cron job %s does not have a job template", object.GetName())
+	}
+
+	job := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	if spec, ok := template["spec"].(map[string]interface{}); ok {
+		job.Object["spec"] = spec
+	}
+
+	templateMetadata, _ := template["metadata"].(map[string]interface{})
+	labels, _, _ := unstructured.NestedStringMap(templateMetadata, "labels")
+	annotations, _, _ := unstructured.NestedStringMap(templateMetadata, "annotations")
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[instantiateAnnotation] = "manual"
+
+	job.SetAPIVersion("batch/v1")
+	job.SetKind("Job")
+	job.SetName(name)
+	job.SetNamespace(object.GetNamespace())
+	job.SetLabels(labels)
+	job.SetAnnotations(annotations)
+
+	isController := true
+	job.SetOwnerReferences([]metav1.OwnerReference{
+		{
+			APIVersion: object.GetAPIVersion(),
+			Kind:       object.GetKind(),
+			Name:       object.GetName(),
+			UID:        object.GetUID(),
+			Controller: &isController,
+		},
+	})
+
+	resourceClient, err := resourceClient(client, job)
+	if err != nil {
+		return err
+	}
+
+	if _, err := resourceClient.Create(job, metav1.CreateOptions{}); err != nil {
+		return errors.Wrapf(err, "create job %s", name)
+	}
+
+	return nil
+}
+
+// jobName generates a name for a manually created job.
+func (a *CreateJob) jobName(object *unstructured.Unstructured) string {
+	suffix := fmt.Sprintf("-manual-%d", a.nowFunc().Unix())
+
+	name := object.GetName()
+	if len(name)+len(suffix) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength-len(suffix)], "-.")
+	}
+
+	return name + suffix
+}
@@ -0,0 +1,231 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kTesting "k8s.io/client-go/testing"
+
+	"github.com/vmware/octant/pkg/action"
+)
+
+type fakeClient struct {
+	dynamicClient *dynamicFake.FakeDynamicClient
+}
+
+func newFakeClient() *fakeClient {
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(runtime.NewScheme())
+	dynamicClient.PrependReactor("*", "*", func(kTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	return &fakeClient{dynamicClient: dynamicClient}
+}
+
+func (c *fakeClient) Resource(gk schema.GroupKind) (schema.GroupVersionResource, error) {
+	resources := map[string]string{
+		"Deployment":  "deployments",
+		"StatefulSet": "statefulsets",
+		"ReplicaSet":  "replicasets",
+		"CronJob":     "cronjobs",
+		"Job":         "jobs",
+		"Pod":         "pods",
+	}
+
+	return schema.GroupVersionResource{Group: gk.Group, Version: "v1", Resource: resources[gk.Kind]}, nil
+}
+
+func (c *fakeClient) DynamicClient() (dynamic.Interface, error) {
+	return c.dynamicClient, nil
+}
+
+func newObject(apiVersion, kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetName(name)
+	object.SetNamespace("default")
+	object.SetUID("uid")
+	if spec != nil {
+		object.Object["spec"] = spec
+	}
+	return object
+}
+
+func TestActions_Perform(t *testing.T) {
+	now := time.Unix(1547211430, 0).UTC()
+
+	restart := NewRestart()
+	restart.nowFunc = func() time.Time { return now }
+
+	cases := []struct {
+		name          string
+		action        Action
+		object        *unstructured.Unstructured
+		payload       action.Payload
+		expectedVerb  string
+		expectedPatch string
+		subresource   string
+		isErr         bool
+	}{
+		{
+			name:          "restart",
+			action:        restart,
+			object:        newObject("apps/v1", "Deployment", "deployment", nil),
+			expectedVerb:  "patch",
+			expectedPatch: `{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"2019-01-11T12:57:10Z"}}}}}`,
+		},
+		{
+			name:          "scale",
+			action:        NewScale(),
+			object:        newObject("apps/v1", "StatefulSet", "statefulset", nil),
+			payload:       action.Payload{"replicas": float64(3)},
+			expectedVerb:  "patch",
+			expectedPatch: `{"spec":{"replicas":3}}`,
+			subresource:   "scale",
+		},
+		{
+			name:    "scale with invalid replicas",
+			action:  NewScale(),
+			object:  newObject("apps/v1", "ReplicaSet", "replicaset", nil),
+			payload: action.Payload{"replicas": float64(-1)},
+			isErr:   true,
+		},
+		{
+			name:          "pause rollout",
+			action:        NewPauseRollout(),
+			object:        newObject("apps/v1", "Deployment", "deployment", nil),
+			expectedVerb:  "patch",
+			expectedPatch: `{"spec":{"paused":true}}`,
+		},
+		{
+			name:          "resume cron job",
+			action:        NewResumeCronJob(),
+			object:        newObject("batch/v1beta1", "CronJob", "cronjob", map[string]interface{}{"suspend": true}),
+			expectedVerb:  "patch",
+			expectedPatch: `{"spec":{"suspend":false}}`,
+		},
+		{
+			name:         "delete",
+			action:       NewDelete(),
+			object:       newObject("v1", "Pod", "pod", nil),
+			payload:      action.Payload{"confirm": "pod", "cascade": "Foreground"},
+			expectedVerb: "delete",
+		},
+		{
+			name:    "delete without confirmation",
+			action:  NewDelete(),
+			object:  newObject("v1", "Pod", "pod", nil),
+			payload: action.Payload{"confirm": "other"},
+			isErr:   true,
+		},
+		{
+			name:    "delete with unknown cascade option",
+			action:  NewDelete(),
+			object:  newObject("v1", "Pod", "pod", nil),
+			payload: action.Payload{"confirm": "pod", "cascade": "Sideways"},
+			isErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newFakeClient()
+
+			err := tc.action.Perform(context.Background(), client, tc.object, tc.payload)
+			if tc.isErr {
+				require.Error(t, err)
+				assert.Empty(t, client.dynamicClient.Actions())
+				return
+			}
+			require.NoError(t, err)
+
+			actions := client.dynamicClient.Actions()
+			require.Len(t, actions, 1)
+			assert.Equal(t, tc.expectedVerb, actions[0].GetVerb())
+			assert.Equal(t, "default", actions[0].GetNamespace())
+			assert.Equal(t, tc.subresource, actions[0].GetSubresource())
+
+			switch a := actions[0].(type) {
+			case kTesting.PatchAction:
+				assert.Equal(t, tc.object.GetName(), a.GetName())
+				assert.JSONEq(t, tc.expectedPatch, string(a.GetPatch()))
+			case kTesting.DeleteAction:
+				assert.Equal(t, tc.object.GetName(), a.GetName())
+			}
+		})
+	}
+}
+
+func TestCreateJob_Perform(t *testing.T) {
+	now := time.Unix(1547211430, 0)
+
+	createJob := NewCreateJob()
+	createJob.nowFunc = func() time.Time { return now }
+
+	cronJob := newObject("batch/v1beta1", "CronJob", "cronjob", map[string]interface{}{
+		"jobTemplate": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"app": "report"},
+			},
+			"spec": map[string]interface{}{
+				"backoffLimit": int64(2),
+			},
+		},
+	})
+
+	fields := createJob.Fields(cronJob)
+	require.Len(t, fields, 1)
+	assert.Equal(t, "cronjob-manual-1547211430", fields[0].Value())
+
+	client := newFakeClient()
+	require.NoError(t, createJob.Perform(context.Background(), client, cronJob, action.Payload{}))
+
+	actions := client.dynamicClient.Actions()
+	require.Len(t, actions, 1)
+
+	create, ok := actions[0].(kTesting.CreateAction)
+	require.True(t, ok)
+	assert.Equal(t, "jobs", create.GetResource().Resource)
+
+	job, ok := create.GetObject().(*unstructured.Unstructured)
+	require.True(t, ok)
+
+	isController := true
+	assert.Equal(t, "cronjob-manual-1547211430", job.GetName())
+	assert.Equal(t, "default", job.GetNamespace())
+	assert.Equal(t, map[string]string{"app": "report"}, job.GetLabels())
+	assert.Equal(t, map[string]string{instantiateAnnotation: "manual"}, job.GetAnnotations())
+	assert.Equal(t, []metav1.OwnerReference{
+		{APIVersion: "batch/v1beta1", Kind: "CronJob", Name: "cronjob", UID: "uid", Controller: &isController},
+	}, job.GetOwnerReferences())
+
+	backoffLimit, _, err := unstructured.NestedInt64(job.Object, "spec", "backoffLimit")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), backoffLimit)
+}
+
+func TestCreateJob_jobName(t *testing.T) {
+	createJob := NewCreateJob()
+	createJob.nowFunc = func() time.Time { return time.Unix(1547211430, 0) }
+
+	name := "a-cron-job-with-a-very-long-name-which-needs-to-be-truncated-to-fit"
+	got := createJob.jobName(newObject("batch/v1beta1", "CronJob", name, nil))
+
+	assert.True(t, len(got) <= maxNameLength)
+	assert.Equal(t, "a-cron-job-with-a-very-long-name-which-needs-manual-1547211430", got)
+}
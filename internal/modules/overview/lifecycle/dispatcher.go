@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware/octant/internal/audit"
+	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+// Dispatcher handles the payloads for a lifecycle action.
+type Dispatcher struct {
+	action     Action
+	dashConfig config.Dash
+	trail      audit.Trail
+}
+
+// NewDispatcher creates an instance of Dispatcher. trail can be nil.
+func NewDispatcher(a Action, dashConfig config.Dash, trail audit.Trail) *Dispatcher {
+	return &Dispatcher{
+		action:     a,
+		dashConfig: dashConfig,
+		trail:      trail,
+	}
+}
+
+// ActionName returns the name of the action.
+func (d *Dispatcher) ActionName() string {
+	return d.action.Name()
+}
+
+// Handle performs the action on the object in the payload. The object is
+// reloaded and access is checked again since the page the action was
+// submitted from can be out of date.
+func (d *Dispatcher) Handle(ctx context.Context, payload action.Payload) error {
+	key, err := payloadKey(payload)
+	if err != nil {
+		return err
+	}
+
+	objectStore := d.dashConfig.ObjectStore()
+
+	object, err := objectStore.Get(ctx, key)
+	if err != nil {
+		return errors.Wrapf(err, "get %s", key)
+	}
+	if object == nil {
+		return errors.Errorf("%s was not found", key)
+	}
+
+	entry := audit.Entry{
+		Action:     d.action.Name(),
+		Context:    d.dashConfig.ContextName(),
+		Namespace:  key.Namespace,
+		APIVersion: key.APIVersion,
+		Kind:       key.Kind,
+		Name:       key.Name,
+	}
+
+	err = d.perform(ctx, objectStore, object, payload)
+
+	entry.Allowed = err == nil
+	if err != nil {
+		entry.Reason = err.Error()
+	}
+	if d.trail != nil {
+		d.trail.Record(ctx, entry)
+	}
+
+	return err
+}
+
+func (d *Dispatcher) perform(ctx context.Context, objectStore store.Store, object *unstructured.Unstructured, payload action.Payload) error {
+	if !d.action.Supports(object) {
+		return errors.Errorf("%s can't be performed on %s %s", d.action.Title(), object.GetKind(), object.GetName())
+	}
+
+	if err := CheckAccess(ctx, objectStore, d.action, object); err != nil {
+		return err
+	}
+
+	return d.action.Perform(ctx, d.dashConfig.ClusterClient(), object, payload)
+}
+
+func payloadKey(payload action.Payload) (store.Key, error) {
+	gvk, err := payload.GroupVersionKind()
+	if err != nil {
+		return store.Key{}, err
+	}
+
+	name, err := payload.String("name")
+	if err != nil {
+		return store.Key{}, err
+	}
+
+	namespace, err := payload.String("namespace")
+	if err != nil {
+		return store.Key{}, err
+	}
+
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+
+	return store.Key{
+		Namespace:  namespace,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+	}, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/audit"
+	clusterFake "github.com/vmware/octant/internal/cluster/fake"
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/pkg/action"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+)
+
+func TestDispatcher_Handle(t *testing.T) {
+	deployment := newObject("apps/v1", "Deployment", "deployment", nil)
+	key := objectKey(deployment)
+
+	payload := action.Payload{
+		"group":     "apps",
+		"version":   "v1",
+		"kind":      "Deployment",
+		"name":      "deployment",
+		"namespace": "default",
+		"action":    "lifecycle/pauseRollout",
+	}
+
+	cases := []struct {
+		name      string
+		object    *unstructured.Unstructured
+		accessErr error
+		isErr     bool
+		performed bool
+		expected  audit.Entry
+	}{
+		{
+			name:      "perform",
+			object:    deployment,
+			performed: true,
+			expected:  audit.Entry{Allowed: true},
+		},
+		{
+			name:      "access denied",
+			object:    deployment,
+			accessErr: errors.New("denied"),
+			isErr:     true,
+			expected:  audit.Entry{Reason: "Pause Rollout requires patch access to " + key.String() + ": denied"},
+		},
+		{
+			name: "not supported",
+			object: newObject("apps/v1", "Deployment", "deployment", map[string]interface{}{
+				"paused": true,
+			}),
+			isErr:    true,
+			expected: audit.Entry{Reason: "Pause Rollout can't be performed on Deployment deployment"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			objectStore := storeFake.NewMockStore(controller)
+			objectStore.EXPECT().Get(gomock.Any(), key).Return(tc.object, nil)
+			objectStore.EXPECT().HasAccess(gomock.Any(), key, "patch").Return(tc.accessErr).AnyTimes()
+
+			client := newFakeClient()
+
+			clusterClient := clusterFake.NewMockClientInterface(controller)
+			clusterClient.EXPECT().Resource(schema.GroupKind{Group: "apps", Kind: "Deployment"}).
+				Return(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, nil).AnyTimes()
+			clusterClient.EXPECT().DynamicClient().Return(client.dynamicClient, nil).AnyTimes()
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+			dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+			dashConfig.EXPECT().ContextName().Return("context").AnyTimes()
+
+			trail := audit.NewMemoryTrail(log.NopLogger(), 10)
+
+			dispatcher := NewDispatcher(NewPauseRollout(), dashConfig, trail)
+			assert.Equal(t, "lifecycle/pauseRollout", dispatcher.ActionName())
+
+			err := dispatcher.Handle(context.Background(), payload)
+			if tc.isErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tc.performed {
+				assert.Len(t, client.dynamicClient.Actions(), 1)
+			} else {
+				assert.Empty(t, client.dynamicClient.Actions())
+			}
+
+			entries := trail.Entries()
+			require.Len(t, entries, 1)
+
+			expected := tc.expected
+			expected.Time = entries[0].Time
+			expected.Action = "lifecycle/pauseRollout"
+			expected.Context = "context"
+			expected.Namespace = "default"
+			expected.APIVersion = "apps/v1"
+			expected.Kind = "Deployment"
+			expected.Name = "deployment"
+			assert.Equal(t, expected, entries[0])
+		})
+	}
+}
+
+func TestDispatcher_Handle_not_found(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+
+	dispatcher := NewDispatcher(NewDelete(), dashConfig, nil)
+
+	payload := action.Payload{
+		"group":     "",
+		"version":   "v1",
+		"kind":      "Pod",
+		"name":      "pod",
+		"namespace": "default",
+	}
+
+	err := dispatcher.Handle(context.Background(), payload)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "was not found")
+}
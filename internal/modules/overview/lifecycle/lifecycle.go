@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// Client performs requests against a cluster.
+type Client interface {
+	Resource(schema.GroupKind) (schema.GroupVersionResource, error)
+	DynamicClient() (dynamic.Interface, error)
+}
+
+// Access is a permission required to perform an action.
+type Access struct {
+	Key  store.Key
+	Verb string
+}
+
+// Action is an action which changes the lifecycle of an object.
+type Action interface {
+	// Name is the name the action is dispatched with.
+	Name() string
+	// Title is the title of the action.
+	Title() string
+	// Description describes what the action does.
+	Description() string
+	// Supports returns true if the action can be performed on an object.
+	Supports(object *unstructured.Unstructured) bool
+	// Access returns the permissions required to perform the action.
+	Access(object *unstructured.Unstructured) []Access
+	// Fields returns the form fields confirming the action.
+	Fields(object *unstructured.Unstructured) []component.FormField
+	// Perform performs the action.
+	Perform(ctx context.Context, client Client, object *unstructured.Unstructured, payload action.Payload) error
+}
+
+// Actions returns the built-in lifecycle actions.
+func Actions() []Action {
+	return []Action{
+		NewRestart(),
+		NewScale(),
+		NewPauseRollout(),
+		NewResumeRollout(),
+		NewSuspendCronJob(),
+		NewResumeCronJob(),
+		NewCreateJob(),
+		NewDelete(),
+	}
+}
+
+// Available returns the actions which support an object and which the
+// current user is allowed to perform.
+func Available(ctx context.Context, objectStore store.Store, actions []Action, object *unstructured.Unstructured) []Action {
+	var list []Action
+	for _, a := range actions {
+		if !a.Supports(object) {
+			continue
+		}
+
+		if err := CheckAccess(ctx, objectStore, a, object); err != nil {
+			continue
+		}
+
+		list = append(list, a)
+	}
+
+	return list
+}
+
+// CheckAccess returns an error if the current user isn't allowed to
+// perform an action.
+func CheckAccess(ctx context.Context, objectStore store.Store, a Action, object *unstructured.Unstructured) error {
+	if objectStore == nil {
+		return errors.New("object store is nil")
+	}
+
+	for _, access := range a.Access(object) {
+		if err := objectStore.HasAccess(ctx, access.Key, access.Verb); err != nil {
+			return errors.Wrapf(err, "%s requires %s access to %s", a.Title(), access.Verb, access.Key)
+		}
+	}
+
+	return nil
+}
+
+// ToComponent creates a summary listing the actions available for an
+// object. Each action has a form which confirms it. It returns nil if no
+// actions are available.
+func ToComponent(ctx context.Context, objectStore store.Store, actions []Action, object runtime.Object) (*component.Summary, error) {
+	if object == nil {
+		return nil, errors.New("object is nil")
+	}
+
+	u, err := toUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+
+	available := Available(ctx, objectStore, actions, u)
+	if len(available) == 0 {
+		return nil, nil
+	}
+
+	summary := component.NewSummary("Actions")
+
+	gvk := u.GroupVersionKind()
+
+	for _, a := range available {
+		summary.Add(component.SummarySection{
+			Header:  a.Title(),
+			Content: component.NewText(a.Description()),
+		})
+
+		fields := append(a.Fields(u),
+			component.NewFormFieldHidden("group", gvk.Group),
+			component.NewFormFieldHidden("version", gvk.Version),
+			component.NewFormFieldHidden("kind", gvk.Kind),
+			component.NewFormFieldHidden("name", u.GetName()),
+			component.NewFormFieldHidden("namespace", u.GetNamespace()),
+			component.NewFormFieldHidden("action", a.Name()),
+		)
+
+		summary.AddAction(component.Action{
+			Name:  a.Title(),
+			Title: a.Title() + " " + u.GetKind() + " " + u.GetName(),
+			Form:  component.Form{Fields: fields},
+		})
+	}
+
+	return summary, nil
+}
+
+func toUnstructured(object runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := object.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert object to unstructured")
+	}
+
+	return &unstructured.Unstructured{Object: m}, nil
+}
+
+// resourceClient returns a dynamic client for an object's resource.
+func resourceClient(client Client, object *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	if client == nil {
+		return nil, errors.New("cluster client is nil")
+	}
+
+	gvk := object.GroupVersionKind()
+	gvr, err := client.Resource(gvk.GroupKind())
+	if err != nil {
+		return nil, errors.Wrapf(err, "find resource for %s", gvk)
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "get dynamic client")
+	}
+
+	return dynamicClient.Resource(gvr).Namespace(object.GetNamespace()), nil
+}
+
+// objectKey returns the store key for an object.
+func objectKey(object *unstructured.Unstructured) store.Key {
+	return store.Key{
+		Namespace:  object.GetNamespace(),
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Name:       object.GetName(),
+	}
+}
+
+// isKind returns true if an object is one of the kinds in a group.
+func isKind(object *unstructured.Unstructured, group string, kinds ...string) bool {
+	gvk := object.GroupVersionKind()
+	if gvk.Group != group {
+		return false
+	}
+
+	for _, kind := range kinds {
+		if gvk.Kind == kind {
+			return true
+		}
+	}
+
+	return false
+}
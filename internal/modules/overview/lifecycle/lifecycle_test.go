@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func TestAvailable(t *testing.T) {
+	deployment := newObject("apps/v1", "Deployment", "deployment", map[string]interface{}{"paused": true})
+	deploymentKey := objectKey(deployment)
+
+	cases := []struct {
+		name     string
+		denied   map[string]bool
+		expected []string
+	}{
+		{
+			name:     "all allowed",
+			expected: []string{"lifecycle/restart", "lifecycle/resumeRollout", "lifecycle/delete"},
+		},
+		{
+			name:     "patch denied",
+			denied:   map[string]bool{"patch": true},
+			expected: []string{"lifecycle/delete"},
+		},
+		{
+			name:   "all denied",
+			denied: map[string]bool{"patch": true, "delete": true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			objectStore := storeFake.NewMockStore(controller)
+			objectStore.EXPECT().HasAccess(gomock.Any(), deploymentKey, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, key store.Key, verb string) error {
+					if tc.denied[verb] {
+						return errors.New("denied")
+					}
+					return nil
+				}).AnyTimes()
+
+			var got []string
+			for _, a := range Available(context.Background(), objectStore, Actions(), deployment) {
+				got = append(got, a.Name())
+			}
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestToComponent(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	pod := testutil.CreatePod("pod")
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().HasAccess(gomock.Any(), gomock.Any(), "delete").Return(nil)
+
+	got, err := ToComponent(context.Background(), objectStore, Actions(), pod)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+
+	expectedSections := []component.SummarySection{
+		{Header: "Delete", Content: component.NewText(NewDelete().Description())},
+	}
+	assert.Equal(t, expectedSections, got.Sections())
+
+	require.Len(t, got.Config.Actions, 1)
+	deleteAction := got.Config.Actions[0]
+	assert.Equal(t, "Delete", deleteAction.Name)
+	assert.Equal(t, "Delete Pod pod", deleteAction.Title)
+
+	values := make(map[string]interface{})
+	for _, field := range deleteAction.Form.Fields {
+		values[field.Name()] = field.Value()
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"cascade":   "Background",
+		"confirm":   "",
+		"group":     "",
+		"version":   "v1",
+		"kind":      "Pod",
+		"name":      "pod",
+		"namespace": "namespace",
+		"action":    "lifecycle/delete",
+	}, values)
+}
+
+func TestToComponent_none_available(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().HasAccess(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("denied")).AnyTimes()
+
+	got, err := ToComponent(context.Background(), objectStore, Actions(), testutil.CreatePod("pod"))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	container2 "github.com/vmware/octant/internal/modules/overview/container"
+)
+
+// logQuery is a query for log entries. It contains the options used to read
+// the log from the cluster and the filters applied to each line.
+type logQuery struct {
+	options    container2.LogOptions
+	timestamps bool
+
+	// substring is a lowercase substring lines must contain.
+	substring string
+	// pattern is a regular expression lines must match.
+	pattern *regexp.Regexp
+	// levels are the levels of structured lines to include.
+	levels map[string]bool
+	// untilTime excludes lines after a time.
+	untilTime *time.Time
+}
+
+// logQueryFromValues creates a log query from query parameters. In addition
+// to the log options, it accepts:
+//
+//	filter: a case-insensitive substring
+//	regex: a regular expression
+//	level: a comma separated list of levels, e.g. warning,error
+//	untilTime: an RFC3339 time, which with sinceTime selects a time range
+func logQueryFromValues(values url.Values) (logQuery, error) {
+	options, timestamps, err := logOptionsFromQuery(values)
+	if err != nil {
+		return logQuery{}, err
+	}
+
+	q := logQuery{
+		options:    options,
+		timestamps: timestamps,
+		substring:  strings.ToLower(values.Get("filter")),
+	}
+
+	if s := values.Get("regex"); s != "" {
+		pattern, err := regexp.Compile(s)
+		if err != nil {
+			return logQuery{}, errors.Wrapf(err, "invalid regex %q", s)
+		}
+		q.pattern = pattern
+	}
+
+	if s := values.Get("level"); s != "" {
+		q.levels = make(map[string]bool)
+		for _, level := range strings.Split(s, ",") {
+			normalized := container2.NormalizeLevel(level)
+			if normalized == "" {
+				return logQuery{}, errors.Errorf("invalid level %q", level)
+			}
+			q.levels[normalized] = true
+		}
+	}
+
+	if s := values.Get("untilTime"); s != "" {
+		untilTime, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return logQuery{}, errors.Errorf("invalid untilTime %q", s)
+		}
+
+		if options.SinceTime != nil && untilTime.Before(*options.SinceTime) {
+			return logQuery{}, errors.New("untilTime is before sinceTime")
+		}
+
+		q.untilTime = &untilTime
+	}
+
+	return q, nil
+}
+
+// defaultTail reads only the last lines of the log if the query doesn't
+// select lines by tail or time. Filtered queries search the whole log, so
+// matches older than the last lines aren't missed.
+func (q *logQuery) defaultTail(lines int64) {
+	if q.options.TailLines != nil || q.options.SinceTime != nil || q.options.SinceSeconds != nil {
+		return
+	}
+
+	if q.isFiltered() {
+		return
+	}
+
+	q.options.TailLines = &lines
+}
+
+// isFiltered returns true if the query filters lines.
+func (q logQuery) isFiltered() bool {
+	return q.substring != "" || q.pattern != nil || q.levels != nil || q.untilTime != nil
+}
+
+// entry creates a log entry from a line. It returns false if the line
+// doesn't match the query.
+func (q logQuery) entry(line container2.LogLine) (logEntry, bool) {
+	if q.untilTime != nil && line.Timestamp.After(*q.untilTime) {
+		return logEntry{}, false
+	}
+
+	if q.substring != "" && !strings.Contains(strings.ToLower(line.Message), q.substring) {
+		return logEntry{}, false
+	}
+
+	if q.pattern != nil && !q.pattern.MatchString(line.Message) {
+		return logEntry{}, false
+	}
+
+	entry := newLogEntry(line, q.timestamps)
+
+	if q.levels != nil && !q.levels[entry.Level] {
+		return logEntry{}, false
+	}
+
+	return entry, true
+}
@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	container2 "github.com/vmware/octant/internal/modules/overview/container"
+)
+
+func Test_logQueryFromValues(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		isFiltered bool
+		isErr      bool
+	}{
+		{
+			name: "no filters",
+		},
+		{
+			name:       "filters",
+			query:      "filter=Err&regex=^a.*z$&level=warn,ERROR&sinceTime=2019-07-02T10:00:00Z&untilTime=2019-07-02T11:00:00Z",
+			isFiltered: true,
+		},
+		{
+			name:  "invalid regex",
+			query: "regex=(",
+			isErr: true,
+		},
+		{
+			name:  "invalid level",
+			query: "level=loud",
+			isErr: true,
+		},
+		{
+			name:  "invalid until time",
+			query: "untilTime=tomorrow",
+			isErr: true,
+		},
+		{
+			name:  "until time before since time",
+			query: "sinceTime=2019-07-02T10:00:00Z&untilTime=2019-07-02T09:00:00Z",
+			isErr: true,
+		},
+		{
+			name:  "invalid log options",
+			query: "tailLines=-1",
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			require.NoError(t, err)
+
+			got, err := logQueryFromValues(values)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.isFiltered, got.isFiltered())
+		})
+	}
+}
+
+func Test_logQuery_defaultTail(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		expected int64
+	}{
+		{
+			name:     "no selection",
+			expected: 100,
+		},
+		{
+			name:     "tail lines",
+			query:    "tailLines=10",
+			expected: 10,
+		},
+		{
+			name:  "since seconds",
+			query: "sinceSeconds=60",
+		},
+		{
+			name:  "since time",
+			query: "sinceTime=2019-07-02T10:00:00Z",
+		},
+		{
+			name:  "filter",
+			query: "filter=error",
+		},
+		{
+			name:  "regex",
+			query: "regex=GET%20.*",
+		},
+		{
+			name:  "level",
+			query: "level=error",
+		},
+		{
+			name:  "until time",
+			query: "untilTime=2019-07-02T10:00:00Z",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			require.NoError(t, err)
+
+			q, err := logQueryFromValues(values)
+			require.NoError(t, err)
+
+			q.defaultTail(100)
+
+			if tc.expected == 0 {
+				assert.Nil(t, q.options.TailLines)
+				return
+			}
+
+			require.NotNil(t, q.options.TailLines)
+			assert.Equal(t, tc.expected, *q.options.TailLines)
+		})
+	}
+}
+
+func Test_logQuery_entry(t *testing.T) {
+	ts := time.Date(2019, 7, 2, 10, 0, 0, 0, time.UTC)
+
+	text := container2.LogLine{Timestamp: ts, Message: "GET /healthz 200"}
+	warning := container2.LogLine{Timestamp: ts, Message: `{"level":"warn","msg":"disk almost full","used":0.9}`}
+	later := container2.LogLine{Timestamp: ts.Add(time.Hour), Message: "shutting down"}
+
+	cases := []struct {
+		name     string
+		query    string
+		line     container2.LogLine
+		expected *logEntry
+	}{
+		{
+			name:     "no filters",
+			line:     text,
+			expected: &logEntry{Timestamp: &ts, Message: text.Message},
+		},
+		{
+			name:     "structured",
+			line:     warning,
+			expected: &logEntry{Timestamp: &ts, Message: warning.Message, Level: "warning", Fields: map[string]string{"msg": "disk almost full", "used": "0.9"}},
+		},
+		{
+			name:     "without timestamps",
+			query:    "timestamps=false",
+			line:     text,
+			expected: &logEntry{Message: text.Message},
+		},
+		{
+			name:     "substring is case insensitive",
+			query:    "filter=HEALTHZ",
+			line:     text,
+			expected: &logEntry{Timestamp: &ts, Message: text.Message},
+		},
+		{
+			name:  "substring does not match",
+			query: "filter=ready",
+			line:  text,
+		},
+		{
+			name:     "regex",
+			query:    "regex=" + url.QueryEscape(`\s2\d\d$`),
+			line:     text,
+			expected: &logEntry{Timestamp: &ts, Message: text.Message},
+		},
+		{
+			name:  "regex does not match",
+			query: "regex=" + url.QueryEscape(`\s5\d\d$`),
+			line:  text,
+		},
+		{
+			name:     "level",
+			query:    "level=warning,error",
+			line:     warning,
+			expected: &logEntry{Timestamp: &ts, Message: warning.Message, Level: "warning", Fields: map[string]string{"msg": "disk almost full", "used": "0.9"}},
+		},
+		{
+			name:  "level excludes unstructured lines",
+			query: "level=info",
+			line:  text,
+		},
+		{
+			name:  "after until time",
+			query: "untilTime=2019-07-02T10:30:00Z",
+			line:  later,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tc.query)
+			require.NoError(t, err)
+
+			q, err := logQueryFromValues(values)
+			require.NoError(t, err)
+
+			got, ok := q.entry(tc.line)
+			if tc.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, *tc.expected, got)
+		})
+	}
+}
@@ -7,19 +7,53 @@ package logviewer
 
 import (
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware/octant/pkg/view/component"
 )
 
+// HasLogs returns true if logs can be viewed for an object. Logs are
+// viewed for pods, or aggregated from the pods of workloads and services.
+func HasLogs(object runtime.Object) bool {
+	if object == nil {
+		return false
+	}
+
+	switch object.(type) {
+	case *corev1.Pod:
+		return true
+	case *unstructured.Unstructured:
+		gvk := object.GetObjectKind().GroupVersionKind()
+		return gvk.Group == "" && gvk.Version == "v1" && gvk.Kind == "Pod"
+	}
+
+	_, _, ok := aggregatedKind(object)
+	return ok
+}
+
 // ToComponent converts an object into a log viewer component.
 func ToComponent(object runtime.Object) (component.Component, error) {
 	if object == nil {
 		return nil, errors.Errorf("object is nil")
 	}
 
+	if kind, containers, ok := aggregatedKind(object); ok {
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			return nil, err
+		}
+
+		logsComponent := component.NewLogs(accessor.GetNamespace(), accessor.GetName(), containerNames(containers))
+		logsComponent.Config.Kind = kind
+
+		return logsComponent, nil
+	}
+
 	pod := &corev1.Pod{}
 
 	switch t := object.(type) {
@@ -37,17 +71,39 @@ func ToComponent(object runtime.Object) (component.Component, error) {
 		return nil, errors.Errorf("can't fetch logs from a %T", object)
 	}
 
-	var containerNames []string
+	var names []string
+	names = append(names, containerNames(pod.Spec.InitContainers)...)
+	names = append(names, containerNames(pod.Spec.Containers)...)
 
-	for _, c := range pod.Spec.InitContainers {
-		containerNames = append(containerNames, c.Name)
-	}
+	logsComponent := component.NewLogs(pod.Namespace, pod.Name, names)
+
+	return logsComponent, nil
+}
 
-	for _, c := range pod.Spec.Containers {
-		containerNames = append(containerNames, c.Name)
+// aggregatedKind returns the kind used to stream aggregated logs for an
+// object and the containers in its pods.
+func aggregatedKind(object runtime.Object) (string, []corev1.Container, bool) {
+	switch t := object.(type) {
+	case *appsv1.Deployment:
+		return "deployment", t.Spec.Template.Spec.Containers, true
+	case *appsv1.StatefulSet:
+		return "statefulset", t.Spec.Template.Spec.Containers, true
+	case *appsv1.DaemonSet:
+		return "daemonset", t.Spec.Template.Spec.Containers, true
+	case *batchv1.Job:
+		return "job", t.Spec.Template.Spec.Containers, true
+	case *corev1.Service:
+		return "service", nil, true
+	default:
+		return "", nil, false
 	}
+}
 
-	logsComponent := component.NewLogs(pod.Namespace, pod.Name, containerNames)
+func containerNames(containers []corev1.Container) []string {
+	var names []string
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
 
-	return logsComponent, nil
+	return names
 }
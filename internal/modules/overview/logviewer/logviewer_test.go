@@ -10,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -65,8 +67,45 @@ func Test_ToComponent(t *testing.T) {
 			isErr:  true,
 		},
 		{
-			name:   "not a v1 Pod",
-			object: &corev1.Service{},
+			name: "deployment",
+			object: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "deployment",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "one"},
+							},
+						},
+					},
+				},
+			},
+			expected: func() component.Component {
+				logs := component.NewLogs("default", "deployment", []string{"one"})
+				logs.Config.Kind = "deployment"
+				return logs
+			}(),
+		},
+		{
+			name: "service",
+			object: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service",
+					Namespace: "default",
+				},
+			},
+			expected: func() component.Component {
+				logs := component.NewLogs("default", "service", nil)
+				logs.Config.Kind = "service"
+				return logs
+			}(),
+		},
+		{
+			name:   "unsupported object",
+			object: &corev1.ConfigMap{},
 			isErr:  true,
 		},
 	}
@@ -83,5 +122,12 @@ func Test_ToComponent(t *testing.T) {
 			assert.Equal(t, tc.expected, got)
 		})
 	}
+}
 
+func Test_HasLogs(t *testing.T) {
+	assert.True(t, HasLogs(&corev1.Pod{}))
+	assert.True(t, HasLogs(&appsv1.StatefulSet{}))
+	assert.True(t, HasLogs(&batchv1.Job{}))
+	assert.False(t, HasLogs(&appsv1.ReplicaSet{}))
+	assert.False(t, HasLogs(nil))
 }
@@ -21,6 +21,9 @@ var (
 		"Custom Resources":             "custom-resources",
 		"RBAC":                         "rbac",
 		"Events":                       "events",
+		"Helm Releases":                "helm-releases",
+		"Applications":                 "applications",
+		"Resource Graph":               "resource-graph",
 	}
 )
 
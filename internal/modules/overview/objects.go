@@ -221,4 +221,12 @@ var (
 		Titles:                describer.ResourceTitle{List: "Events", Object: "Event"},
 		DisableResourceViewer: true,
 	})
+
+	explainDescriber = describer.NewExplain("/explain")
+
+	helmReleasesDescriber = newHelmReleases("/helm-releases")
+
+	applicationsDescriber = newApplications("/applications")
+
+	resourceGraphDescriber = newResourceGraph("/resource-graph")
 )
@@ -0,0 +1,61 @@
+package objectvisitor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/gvk"
+	"github.com/vmware/octant/internal/queryer"
+	"github.com/vmware/octant/internal/util/kubernetes"
+)
+
+// HorizontalPodAutoscaler is a typed visitor for horizontal pod autoscalers.
+type HorizontalPodAutoscaler struct {
+	queryer queryer.Queryer
+}
+
+var _ TypedVisitor = (*HorizontalPodAutoscaler)(nil)
+
+// NewHorizontalPodAutoscaler creates an instance of HorizontalPodAutoscaler.
+func NewHorizontalPodAutoscaler(q queryer.Queryer) *HorizontalPodAutoscaler {
+	return &HorizontalPodAutoscaler{queryer: q}
+}
+
+// Supports returns the gvk this typed visitor supports.
+func (HorizontalPodAutoscaler) Supports() schema.GroupVersionKind {
+	return gvk.HorizontalPodAutoscalerGVK
+}
+
+// Visit visits a horizontal pod autoscaler. It looks for its scale target.
+func (h *HorizontalPodAutoscaler) Visit(ctx context.Context, object runtime.Object, handler ObjectHandler, visitor Visitor) error {
+	ctx, span := trace.StartSpan(ctx, "visitHorizontalPodAutoscaler")
+	defer span.End()
+
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{}
+	if err := convertToType(object, hpa); err != nil {
+		return err
+	}
+
+	target, err := h.queryer.ScaleTarget(ctx, hpa)
+	if err != nil {
+		return err
+	}
+
+	if target != nil {
+		if err := visitor.Visit(ctx, target, handler); err != nil {
+			return errors.Wrapf(err, "horizontal pod autoscaler %s visit scale target %s",
+				kubernetes.PrintObject(hpa), kubernetes.PrintObject(target))
+		}
+
+		if err := handler.AddEdge(object, target, EdgeLabelScaleTarget); err != nil {
+			return err
+		}
+	}
+
+	return handler.Process(ctx, object)
+}
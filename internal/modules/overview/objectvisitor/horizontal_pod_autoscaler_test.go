@@ -0,0 +1,86 @@
+package objectvisitor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor"
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor/fake"
+	queryerFake "github.com/vmware/octant/internal/queryer/fake"
+	"github.com/vmware/octant/internal/testutil"
+)
+
+func TestHorizontalPodAutoscaler_Visit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.ToUnstructured(t, testutil.CreateDeployment("deployment"))
+
+	object := testutil.CreateHorizontalPodAutoscaler("hpa")
+	object.Spec.ScaleTargetRef = autoscalingv1.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "deployment",
+	}
+	u := testutil.ToUnstructured(t, object)
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().
+		ScaleTarget(gomock.Any(), object).
+		Return(deployment, nil)
+
+	handler := fake.NewMockObjectHandler(controller)
+	handler.EXPECT().
+		AddEdge(u, deployment, objectvisitor.EdgeLabelScaleTarget).
+		Return(nil)
+	handler.EXPECT().
+		Process(gomock.Any(), u).Return(nil)
+
+	var visited []runtime.Object
+	visitor := fake.NewMockVisitor(controller)
+	visitor.EXPECT().
+		Visit(gomock.Any(), gomock.Any(), handler).
+		DoAndReturn(func(ctx context.Context, object runtime.Object, handler objectvisitor.ObjectHandler) error {
+			visited = append(visited, object)
+			return nil
+		})
+
+	hpa := objectvisitor.NewHorizontalPodAutoscaler(q)
+
+	ctx := context.Background()
+	err := hpa.Visit(ctx, u, handler, visitor)
+
+	expected := []runtime.Object{deployment}
+	assert.Equal(t, expected, visited)
+	assert.NoError(t, err)
+}
+
+func TestHorizontalPodAutoscaler_Visit_missing_target(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	object := testutil.CreateHorizontalPodAutoscaler("hpa")
+	u := testutil.ToUnstructured(t, object)
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().
+		ScaleTarget(gomock.Any(), object).
+		Return(nil, nil)
+
+	handler := fake.NewMockObjectHandler(controller)
+	handler.EXPECT().
+		Process(gomock.Any(), u).Return(nil)
+
+	visitor := fake.NewMockVisitor(controller)
+
+	hpa := objectvisitor.NewHorizontalPodAutoscaler(q)
+
+	ctx := context.Background()
+	err := hpa.Visit(ctx, u, handler, visitor)
+	assert.NoError(t, err)
+}
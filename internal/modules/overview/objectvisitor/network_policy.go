@@ -0,0 +1,69 @@
+package objectvisitor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	"golang.org/x/sync/errgroup"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/gvk"
+	"github.com/vmware/octant/internal/queryer"
+	"github.com/vmware/octant/internal/util/kubernetes"
+)
+
+// NetworkPolicy is a typed visitor for network policies.
+type NetworkPolicy struct {
+	queryer queryer.Queryer
+}
+
+var _ TypedVisitor = (*NetworkPolicy)(nil)
+
+// NewNetworkPolicy creates an instance of NetworkPolicy.
+func NewNetworkPolicy(q queryer.Queryer) *NetworkPolicy {
+	return &NetworkPolicy{queryer: q}
+}
+
+// Supports returns the gvk this typed visitor supports.
+func (NetworkPolicy) Supports() schema.GroupVersionKind {
+	return gvk.NetworkPolicyGVK
+}
+
+// Visit visits a network policy. It looks for the pods it selects.
+func (n *NetworkPolicy) Visit(ctx context.Context, object runtime.Object, handler ObjectHandler, visitor Visitor) error {
+	ctx, span := trace.StartSpan(ctx, "visitNetworkPolicy")
+	defer span.End()
+
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	if err := convertToType(object, networkPolicy); err != nil {
+		return err
+	}
+
+	pods, err := n.queryer.PodsForNetworkPolicy(ctx, networkPolicy)
+	if err != nil {
+		return err
+	}
+
+	var g errgroup.Group
+
+	for i := range pods {
+		pod := pods[i]
+		g.Go(func() error {
+			if err := visitor.Visit(ctx, pod, handler); err != nil {
+				return errors.Wrapf(err, "network policy %s visit pod %s",
+					kubernetes.PrintObject(networkPolicy), kubernetes.PrintObject(pod))
+			}
+
+			return handler.AddEdge(object, pod, EdgeLabelNetworkPolicy)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	return handler.Process(ctx, object)
+}
@@ -0,0 +1,66 @@
+package objectvisitor_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor"
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor/fake"
+	queryerFake "github.com/vmware/octant/internal/queryer/fake"
+	"github.com/vmware/octant/internal/testutil"
+)
+
+func TestNetworkPolicy_Visit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	object := testutil.CreateNetworkPolicy("network-policy")
+	u := testutil.ToUnstructured(t, object)
+
+	pod1 := testutil.CreatePod("pod1")
+	pod2 := testutil.CreatePod("pod2")
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().
+		PodsForNetworkPolicy(gomock.Any(), object).
+		Return([]*corev1.Pod{pod1, pod2}, nil)
+
+	handler := fake.NewMockObjectHandler(controller)
+	handler.EXPECT().
+		AddEdge(u, pod1, objectvisitor.EdgeLabelNetworkPolicy).
+		Return(nil)
+	handler.EXPECT().
+		AddEdge(u, pod2, objectvisitor.EdgeLabelNetworkPolicy).
+		Return(nil)
+	handler.EXPECT().
+		Process(gomock.Any(), u).Return(nil)
+
+	var mu sync.Mutex
+	var visited []runtime.Object
+	visitor := fake.NewMockVisitor(controller)
+	visitor.EXPECT().
+		Visit(gomock.Any(), gomock.Any(), handler).
+		DoAndReturn(func(ctx context.Context, object runtime.Object, handler objectvisitor.ObjectHandler) error {
+			mu.Lock()
+			defer mu.Unlock()
+			visited = append(visited, object)
+			return nil
+		}).Times(2)
+
+	networkPolicy := objectvisitor.NewNetworkPolicy(q)
+
+	ctx := context.Background()
+	err := networkPolicy.Visit(ctx, u, handler, visitor)
+
+	sortObjectsByName(t, visited)
+
+	expected := []runtime.Object{pod1, pod2}
+	assert.Equal(t, expected, visited)
+	assert.NoError(t, err)
+}
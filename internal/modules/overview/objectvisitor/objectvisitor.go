@@ -11,6 +11,7 @@ import (
 
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -18,6 +19,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/gvk"
+	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/queryer"
 )
 
@@ -26,10 +29,36 @@ import (
 //go:generate mockgen -destination=./fake/mock_typed_visitor.go -package=fake github.com/vmware/octant/internal/modules/overview/objectvisitor TypedVisitor
 //go:generate mockgen -destination=./fake/mock_visitor.go -package=fake github.com/vmware/octant/internal/modules/overview/objectvisitor Visitor
 
+// EdgeLabel describes the relationship between two objects.
+type EdgeLabel string
+
+const (
+	// EdgeLabelOwner is an owner reference.
+	EdgeLabelOwner EdgeLabel = "owner"
+	// EdgeLabelSelector is a service selecting pods.
+	EdgeLabelSelector EdgeLabel = "selector"
+	// EdgeLabelBackend is an ingress routing to a service.
+	EdgeLabelBackend EdgeLabel = "backend"
+	// EdgeLabelServiceAccount is a pod running as a service account.
+	EdgeLabelServiceAccount EdgeLabel = "service account"
+	// EdgeLabelVolume is a pod mounting a config map, secret or persistent
+	// volume claim.
+	EdgeLabelVolume EdgeLabel = "volume"
+	// EdgeLabelEnvironment is a pod referencing a config map or secret in
+	// a container's environment.
+	EdgeLabelEnvironment EdgeLabel = "environment"
+	// EdgeLabelImagePullSecret is a pod pulling images with a secret.
+	EdgeLabelImagePullSecret EdgeLabel = "image pull secret"
+	// EdgeLabelScaleTarget is a horizontal pod autoscaler scaling an object.
+	EdgeLabelScaleTarget EdgeLabel = "scale target"
+	// EdgeLabelNetworkPolicy is a network policy selecting pods.
+	EdgeLabelNetworkPolicy EdgeLabel = "network policy"
+)
+
 // ObjectHandler performs actions on an object. Can be used to augment
 // visitor actions with extra functionality.
 type ObjectHandler interface {
-	AddEdge(v1, v2 runtime.Object) error
+	AddEdge(v1, v2 runtime.Object, label EdgeLabel) error
 	Process(ctx context.Context, object runtime.Object) error
 }
 
@@ -85,8 +114,15 @@ func NewDefaultVisitor(dashConfig config.Dash, q queryer.Queryer, options ...Def
 		queryer: q,
 		visited: make(map[types.UID]bool),
 		typedVisitors: []TypedVisitor{
+			NewHorizontalPodAutoscaler(q),
 			NewIngress(q),
+			NewNetworkPolicy(q),
 			NewPod(q),
+			NewScaleTarget(q, gvk.AppReplicaSetGVK),
+			NewScaleTarget(q, gvk.DeploymentGVK),
+			NewScaleTarget(q, gvk.ExtReplicaSet),
+			NewScaleTarget(q, gvk.ReplicationControllerGVK),
+			NewScaleTarget(q, gvk.StatefulSetGVK),
 			NewService(q),
 		},
 		defaultHandler: NewObject(dashConfig, q),
@@ -197,3 +233,14 @@ func convertToType(object runtime.Object, objectType interface{}) error {
 
 	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, objectType)
 }
+
+// skipForbidden returns nil if err is because the user can't read related
+// objects, so they are left out of the graph instead of failing it.
+func skipForbidden(ctx context.Context, err error, related string) error {
+	if !kerrors.IsForbidden(errors.Cause(err)) {
+		return err
+	}
+
+	log.From(ctx).WithErr(err).Debugf("skipping %s the user can't access", related)
+	return nil
+}
@@ -98,21 +98,15 @@ func TestDefaultVisitor_Visit_use_default_visitor(t *testing.T) {
 	accessor := meta.NewAccessor()
 
 	handler.EXPECT().
-		AddEdge(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(parent runtime.Object, children ...runtime.Object) error {
+		AddEdge(gomock.Any(), gomock.Any(), objectvisitor.EdgeLabelOwner).
+		DoAndReturn(func(parent, child runtime.Object, label objectvisitor.EdgeLabel) error {
 			parentName, err := accessor.Name(parent)
 			require.NoError(t, err)
 
-			cur := adjList[parentName]
-
-			for _, child := range children {
-				name, err := accessor.Name(child)
-				require.NoError(t, err)
-
-				cur = append(cur, name)
-			}
+			name, err := accessor.Name(child)
+			require.NoError(t, err)
 
-			adjList[parentName] = cur
+			adjList[parentName] = append(adjList[parentName], name)
 
 			return nil
 		}).AnyTimes()
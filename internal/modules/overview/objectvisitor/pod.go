@@ -7,6 +7,7 @@ import (
 	"go.opencensus.io/trace"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -34,7 +35,9 @@ func (p *Pod) Supports() schema.GroupVersionKind {
 	return gvk.PodGVK
 }
 
-// Visit visits a pod. It looks for service accounts and services.
+// Visit visits a pod. It looks for service accounts, services, the config maps,
+// secrets and persistent volume claims it references, and the network
+// policies which select it.
 func (p *Pod) Visit(ctx context.Context, object runtime.Object, handler ObjectHandler, visitor Visitor) error {
 	ctx, span := trace.StartSpan(ctx, "visitPod")
 	defer span.End()
@@ -60,7 +63,7 @@ func (p *Pod) Visit(ctx context.Context, object runtime.Object, handler ObjectHa
 						kubernetes.PrintObject(pod), kubernetes.PrintObject(service))
 				}
 
-				return handler.AddEdge(object, service)
+				return handler.AddEdge(object, service, EdgeLabelSelector)
 			})
 		}
 
@@ -78,7 +81,80 @@ func (p *Pod) Visit(ctx context.Context, object runtime.Object, handler ObjectHa
 					return errors.Wrapf(err, "pod %s visit service account %s",
 						kubernetes.PrintObject(pod), kubernetes.PrintObject(serviceAccount))
 				}
-				return handler.AddEdge(object, serviceAccount)
+				return handler.AddEdge(object, serviceAccount, EdgeLabelServiceAccount)
+			}
+		}
+
+		return nil
+	})
+	g.Go(func() error {
+		configMaps, err := p.queryer.ConfigMapsForPod(ctx, pod)
+		if err != nil {
+			return skipForbidden(ctx, err, "config maps")
+		}
+
+		for i := range configMaps {
+			configMap := configMaps[i]
+			label := podReferenceLabel(pod, "ConfigMap", configMap.Name)
+			g.Go(func() error {
+				return p.visitReference(ctx, object, pod, configMap, label, handler, visitor)
+			})
+		}
+
+		return nil
+	})
+	g.Go(func() error {
+		secrets, err := p.queryer.SecretsForPod(ctx, pod)
+		if err != nil {
+			return skipForbidden(ctx, err, "secrets")
+		}
+
+		for i := range secrets {
+			secret := secrets[i]
+			label := podReferenceLabel(pod, "Secret", secret.Name)
+			g.Go(func() error {
+				return p.visitReference(ctx, object, pod, secret, label, handler, visitor)
+			})
+		}
+
+		return nil
+	})
+	g.Go(func() error {
+		claims, err := p.queryer.PersistentVolumeClaimsForPod(ctx, pod)
+		if err != nil {
+			return err
+		}
+
+		for i := range claims {
+			claim := claims[i]
+			g.Go(func() error {
+				return p.visitReference(ctx, object, pod, claim, EdgeLabelVolume, handler, visitor)
+			})
+		}
+
+		return nil
+	})
+	g.Go(func() error {
+		networkPolicies, err := p.queryer.NetworkPoliciesForPod(ctx, pod)
+		if err != nil {
+			return skipForbidden(ctx, err, "network policies")
+		}
+
+		// network policies aren't visited since a policy can select every
+		// pod in a namespace.
+		for i := range networkPolicies {
+			m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(networkPolicies[i])
+			if err != nil {
+				return err
+			}
+
+			networkPolicy := &unstructured.Unstructured{Object: m}
+			if err := handler.Process(ctx, networkPolicy); err != nil {
+				return err
+			}
+
+			if err := handler.AddEdge(networkPolicy, object, EdgeLabelNetworkPolicy); err != nil {
+				return err
 			}
 		}
 
@@ -91,3 +167,44 @@ func (p *Pod) Visit(ctx context.Context, object runtime.Object, handler ObjectHa
 
 	return handler.Process(ctx, object)
 }
+
+// visitReference visits an object a pod references and adds an edge to it.
+func (p *Pod) visitReference(ctx context.Context, object runtime.Object, pod *corev1.Pod, referenced runtime.Object, label EdgeLabel, handler ObjectHandler, visitor Visitor) error {
+	if err := visitor.Visit(ctx, referenced, handler); err != nil {
+		return errors.Wrapf(err, "pod %s visit %s",
+			kubernetes.PrintObject(pod), kubernetes.PrintObject(referenced))
+	}
+
+	return handler.AddEdge(object, referenced, label)
+}
+
+// podReferenceLabel returns how a pod references a config map or secret.
+func podReferenceLabel(pod *corev1.Pod, kind, name string) EdgeLabel {
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case kind == "ConfigMap" && volume.ConfigMap != nil && volume.ConfigMap.Name == name:
+			return EdgeLabelVolume
+		case kind == "Secret" && volume.Secret != nil && volume.Secret.SecretName == name:
+			return EdgeLabelVolume
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if kind == "ConfigMap" && source.ConfigMap != nil && source.ConfigMap.Name == name {
+					return EdgeLabelVolume
+				}
+				if kind == "Secret" && source.Secret != nil && source.Secret.Name == name {
+					return EdgeLabelVolume
+				}
+			}
+		}
+	}
+
+	if kind == "Secret" {
+		for _, imagePullSecret := range pod.Spec.ImagePullSecrets {
+			if imagePullSecret.Name == name {
+				return EdgeLabelImagePullSecret
+			}
+		}
+	}
+
+	return EdgeLabelEnvironment
+}
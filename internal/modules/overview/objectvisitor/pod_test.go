@@ -2,12 +2,17 @@ package objectvisitor_test
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/vmware/octant/internal/modules/overview/objectvisitor"
 	"github.com/vmware/octant/internal/modules/overview/objectvisitor/fake"
@@ -20,9 +25,31 @@ func TestPod_Visit(t *testing.T) {
 	defer controller.Finish()
 
 	serviceAccount := testutil.CreateServiceAccount("service-account")
+	configMap := testutil.CreateConfigMap("config-map")
+	secret := testutil.CreateSecret("secret")
+	pullSecret := testutil.CreateSecret("pull-secret")
+	claim := testutil.CreatePersistentVolumeClaim("claim")
+	networkPolicy := testutil.CreateNetworkPolicy("network-policy")
 
 	object := testutil.CreatePod("pod")
 	object.Spec.ServiceAccountName = serviceAccount.Name
+	object.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+				},
+			},
+		},
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim.Name},
+			},
+		},
+	}
+	object.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: pullSecret.Name}}
 	u := testutil.ToUnstructured(t, object)
 
 	q := queryerFake.NewMockQueryer(controller)
@@ -33,22 +60,54 @@ func TestPod_Visit(t *testing.T) {
 	q.EXPECT().
 		ServiceAccountForPod(gomock.Any(), object).
 		Return(serviceAccount, nil)
+	q.EXPECT().
+		ConfigMapsForPod(gomock.Any(), object).
+		Return([]*corev1.ConfigMap{configMap}, nil)
+	q.EXPECT().
+		SecretsForPod(gomock.Any(), object).
+		Return([]*corev1.Secret{pullSecret, secret}, nil)
+	q.EXPECT().
+		PersistentVolumeClaimsForPod(gomock.Any(), object).
+		Return([]*corev1.PersistentVolumeClaim{claim}, nil)
+	q.EXPECT().
+		NetworkPoliciesForPod(gomock.Any(), object).
+		Return([]*networkingv1.NetworkPolicy{networkPolicy}, nil)
 
 	handler := fake.NewMockObjectHandler(controller)
 	handler.EXPECT().
-		AddEdge(u, service).
+		AddEdge(u, service, objectvisitor.EdgeLabelSelector).
+		Return(nil)
+	handler.EXPECT().
+		AddEdge(u, serviceAccount, objectvisitor.EdgeLabelServiceAccount).
+		Return(nil)
+	handler.EXPECT().
+		AddEdge(u, configMap, objectvisitor.EdgeLabelVolume).
 		Return(nil)
 	handler.EXPECT().
-		AddEdge(u, serviceAccount).
+		AddEdge(u, secret, objectvisitor.EdgeLabelEnvironment).
 		Return(nil)
+	handler.EXPECT().
+		AddEdge(u, pullSecret, objectvisitor.EdgeLabelImagePullSecret).
+		Return(nil)
+	handler.EXPECT().
+		AddEdge(u, claim, objectvisitor.EdgeLabelVolume).
+		Return(nil)
+	handler.EXPECT().
+		AddEdge(testutil.ToUnstructured(t, networkPolicy), u, objectvisitor.EdgeLabelNetworkPolicy).
+		Return(nil)
+	handler.EXPECT().
+		Process(gomock.Any(), testutil.ToUnstructured(t, networkPolicy)).Return(nil)
 	handler.EXPECT().
 		Process(gomock.Any(), u).Return(nil)
 
+	var mu sync.Mutex
 	var visited []runtime.Object
 	visitor := fake.NewMockVisitor(controller)
 	visitor.EXPECT().
 		Visit(gomock.Any(), gomock.Any(), handler).
 		DoAndReturn(func(ctx context.Context, object runtime.Object, handler objectvisitor.ObjectHandler) error {
+			mu.Lock()
+			defer mu.Unlock()
 			visited = append(visited, object)
 			return nil
 		}).AnyTimes()
@@ -60,7 +119,81 @@ func TestPod_Visit(t *testing.T) {
 
 	sortObjectsByName(t, visited)
 
-	expected := []runtime.Object{service, serviceAccount}
+	expected := []runtime.Object{claim, configMap, pullSecret, secret, service, serviceAccount}
 	assert.Equal(t, expected, visited)
 	assert.NoError(t, err)
 }
+
+func TestPod_Visit_forbidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	object := testutil.CreatePod("pod")
+	u := testutil.ToUnstructured(t, object)
+
+	forbidden := func(resource string) error {
+		err := kerrors.NewForbidden(schema.GroupResource{Resource: resource}, "", errors.New("denied"))
+		return errors.Wrap(err, "list access forbidden")
+	}
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().
+		ServicesForPod(gomock.Any(), object).
+		Return(nil, nil)
+	q.EXPECT().
+		ConfigMapsForPod(gomock.Any(), object).
+		Return(nil, forbidden("configmaps"))
+	q.EXPECT().
+		SecretsForPod(gomock.Any(), object).
+		Return(nil, forbidden("secrets"))
+	q.EXPECT().
+		PersistentVolumeClaimsForPod(gomock.Any(), object).
+		Return(nil, nil)
+	q.EXPECT().
+		NetworkPoliciesForPod(gomock.Any(), object).
+		Return(nil, forbidden("networkpolicies"))
+
+	handler := fake.NewMockObjectHandler(controller)
+	handler.EXPECT().
+		Process(gomock.Any(), u).Return(nil)
+
+	visitor := fake.NewMockVisitor(controller)
+
+	pod := objectvisitor.NewPod(q)
+
+	err := pod.Visit(context.Background(), u, handler, visitor)
+	assert.NoError(t, err)
+}
+
+func TestPod_Visit_error(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	object := testutil.CreatePod("pod")
+	u := testutil.ToUnstructured(t, object)
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().
+		ServicesForPod(gomock.Any(), object).
+		Return(nil, nil).AnyTimes()
+	q.EXPECT().
+		ConfigMapsForPod(gomock.Any(), object).
+		Return(nil, errors.New("failed"))
+	q.EXPECT().
+		SecretsForPod(gomock.Any(), object).
+		Return(nil, nil).AnyTimes()
+	q.EXPECT().
+		PersistentVolumeClaimsForPod(gomock.Any(), object).
+		Return(nil, nil).AnyTimes()
+	q.EXPECT().
+		NetworkPoliciesForPod(gomock.Any(), object).
+		Return(nil, nil).AnyTimes()
+
+	handler := fake.NewMockObjectHandler(controller)
+	visitor := fake.NewMockVisitor(controller)
+
+	pod := objectvisitor.NewPod(q)
+
+	err := pod.Visit(context.Background(), u, handler, visitor)
+	assert.Error(t, err)
+}
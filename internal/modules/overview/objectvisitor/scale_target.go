@@ -0,0 +1,68 @@
+package objectvisitor
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/queryer"
+	"github.com/vmware/octant/internal/util/kubernetes"
+)
+
+// ScaleTarget is a typed visitor for objects which can be scaled by a
+// horizontal pod autoscaler.
+type ScaleTarget struct {
+	queryer          queryer.Queryer
+	groupVersionKind schema.GroupVersionKind
+}
+
+var _ TypedVisitor = (*ScaleTarget)(nil)
+
+// NewScaleTarget creates an instance of ScaleTarget for a gvk.
+func NewScaleTarget(q queryer.Queryer, groupVersionKind schema.GroupVersionKind) *ScaleTarget {
+	return &ScaleTarget{
+		queryer:          q,
+		groupVersionKind: groupVersionKind,
+	}
+}
+
+// Supports returns the gvk this typed visitor supports.
+func (s *ScaleTarget) Supports() schema.GroupVersionKind {
+	return s.groupVersionKind
+}
+
+// Visit visits a scalable object. It looks for horizontal pod autoscalers
+// which scale it.
+func (s *ScaleTarget) Visit(ctx context.Context, object runtime.Object, handler ObjectHandler, visitor Visitor) error {
+	ctx, span := trace.StartSpan(ctx, "visitScaleTarget")
+	defer span.End()
+
+	hpas, err := s.queryer.HorizontalPodAutoscalersForObject(ctx, object)
+	if err := skipForbidden(ctx, err, "horizontal pod autoscalers"); err != nil {
+		return err
+	}
+
+	var g errgroup.Group
+
+	for i := range hpas {
+		hpa := hpas[i]
+		g.Go(func() error {
+			if err := visitor.Visit(ctx, hpa, handler); err != nil {
+				return errors.Wrapf(err, "%s visit horizontal pod autoscaler %s",
+					kubernetes.PrintObject(object), kubernetes.PrintObject(hpa))
+			}
+
+			return handler.AddEdge(hpa, object, EdgeLabelScaleTarget)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	return handler.Process(ctx, object)
+}
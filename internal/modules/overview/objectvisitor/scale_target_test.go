@@ -0,0 +1,88 @@
+package objectvisitor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/gvk"
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor"
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor/fake"
+	queryerFake "github.com/vmware/octant/internal/queryer/fake"
+	"github.com/vmware/octant/internal/testutil"
+)
+
+func TestScaleTarget_Visit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	object := testutil.CreateDeployment("deployment")
+	u := testutil.ToUnstructured(t, object)
+
+	hpa := testutil.CreateHorizontalPodAutoscaler("hpa")
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().
+		HorizontalPodAutoscalersForObject(gomock.Any(), u).
+		Return([]*autoscalingv1.HorizontalPodAutoscaler{hpa}, nil)
+
+	handler := fake.NewMockObjectHandler(controller)
+	handler.EXPECT().
+		AddEdge(hpa, u, objectvisitor.EdgeLabelScaleTarget).
+		Return(nil)
+	handler.EXPECT().
+		Process(gomock.Any(), u).Return(nil)
+
+	var visited []runtime.Object
+	visitor := fake.NewMockVisitor(controller)
+	visitor.EXPECT().
+		Visit(gomock.Any(), gomock.Any(), handler).
+		DoAndReturn(func(ctx context.Context, object runtime.Object, handler objectvisitor.ObjectHandler) error {
+			visited = append(visited, object)
+			return nil
+		})
+
+	scaleTarget := objectvisitor.NewScaleTarget(q, gvk.DeploymentGVK)
+	assert.Equal(t, gvk.DeploymentGVK, scaleTarget.Supports())
+
+	ctx := context.Background()
+	err := scaleTarget.Visit(ctx, u, handler, visitor)
+
+	expected := []runtime.Object{hpa}
+	assert.Equal(t, expected, visited)
+	assert.NoError(t, err)
+}
+
+func TestScaleTarget_Visit_forbidden(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	object := testutil.CreateDeployment("deployment")
+	u := testutil.ToUnstructured(t, object)
+
+	forbidden := kerrors.NewForbidden(
+		schema.GroupResource{Group: "autoscaling", Resource: "horizontalpodautoscalers"}, "", errors.New("denied"))
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().
+		HorizontalPodAutoscalersForObject(gomock.Any(), u).
+		Return(nil, errors.Wrap(forbidden, "retrieving horizontal pod autoscalers"))
+
+	handler := fake.NewMockObjectHandler(controller)
+	handler.EXPECT().
+		Process(gomock.Any(), u).Return(nil)
+
+	visitor := fake.NewMockVisitor(controller)
+
+	scaleTarget := objectvisitor.NewScaleTarget(q, gvk.DeploymentGVK)
+
+	err := scaleTarget.Visit(context.Background(), u, handler, visitor)
+	assert.NoError(t, err)
+}
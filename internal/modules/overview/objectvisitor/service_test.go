@@ -35,10 +35,10 @@ func TestService_Visit(t *testing.T) {
 
 	handler := fake.NewMockObjectHandler(controller)
 	handler.EXPECT().
-		AddEdge(u, ingress).
+		AddEdge(u, ingress, objectvisitor.EdgeLabelBackend).
 		Return(nil)
 	handler.EXPECT().
-		AddEdge(u, pod).
+		AddEdge(u, pod, objectvisitor.EdgeLabelSelector).
 		Return(nil)
 	handler.EXPECT().
 		Process(gomock.Any(), u).Return(nil)
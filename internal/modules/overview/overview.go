@@ -17,11 +17,15 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware/octant/internal/api"
+	"github.com/vmware/octant/internal/audit"
 	"github.com/vmware/octant/internal/config"
 	"github.com/vmware/octant/internal/describer"
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/internal/module"
+	"github.com/vmware/octant/internal/modules/overview/lifecycle"
+	"github.com/vmware/octant/internal/modules/overview/rollouthistory"
 	"github.com/vmware/octant/internal/octant"
+	"github.com/vmware/octant/internal/openapi"
 	"github.com/vmware/octant/pkg/action"
 	"github.com/vmware/octant/pkg/icon"
 	"github.com/vmware/octant/pkg/navigation"
@@ -42,6 +46,7 @@ type Overview struct {
 	dashConfig  config.Dash
 	contextName string
 	logger      log.Logger
+	auditTrail  audit.Trail
 
 	mu sync.Mutex
 }
@@ -63,6 +68,7 @@ func New(ctx context.Context, options Options) (*Overview, error) {
 		dashConfig: options.DashConfig,
 		logger:     options.DashConfig.Logger().With("module", "overview"),
 	}
+	co.auditTrail = audit.NewMemoryTrail(co.logger.With("audit", true), audit.DefaultSize)
 
 	if err := co.bootstrap(ctx); err != nil {
 		return nil, err
@@ -95,6 +101,32 @@ func (co *Overview) bootstrap(ctx context.Context) error {
 		pathMatcher.Register(ctx, pf)
 	}
 
+	for _, pf := range explainDescriber.PathFilters() {
+		pathMatcher.Register(ctx, pf)
+	}
+
+	for _, pf := range helmReleasesDescriber.PathFilters() {
+		pathMatcher.Register(ctx, pf)
+	}
+
+	for _, pf := range applicationsDescriber.PathFilters() {
+		pathMatcher.Register(ctx, pf)
+	}
+
+	for _, pf := range resourceGraphDescriber.PathFilters() {
+		pathMatcher.Register(ctx, pf)
+	}
+
+	secretValuesDescriber := newSecretValues("/config-and-storage/secret-values", co.auditTrail)
+	for _, pf := range secretValuesDescriber.PathFilters() {
+		pathMatcher.Register(ctx, pf)
+	}
+
+	rolloutHistoryDescriber := newRolloutHistoryDiff(rollouthistory.DiffPath)
+	for _, pf := range rolloutHistoryDescriber.PathFilters() {
+		pathMatcher.Register(ctx, pf)
+	}
+
 	g, err := newGenerator(pathMatcher, co.dashConfig)
 	if err != nil {
 		return errors.Wrap(err, "create overview generator")
@@ -112,7 +144,10 @@ func (co *Overview) bootstrap(ctx context.Context) error {
 	}
 
 	co.ObjectPath = objectPath
+
+	co.mu.Lock()
 	co.generator = g
+	co.mu.Unlock()
 
 	key := store.Key{
 		APIVersion: "apiextensions.k8s.io/v1beta1",
@@ -170,6 +205,9 @@ func (co *Overview) Navigation(ctx context.Context, namespace, root string) ([]n
 			"Custom Resources":             navigation.CRDEntries,
 			"RBAC":                         rbacEntries,
 			"Events":                       nil,
+			"Helm Releases":                nil,
+			"Applications":                 nil,
+			"Resource Graph":               nil,
 		},
 		Order: []string{
 			"Workloads",
@@ -178,6 +216,9 @@ func (co *Overview) Navigation(ctx context.Context, namespace, root string) ([]n
 			"Custom Resources",
 			"RBAC",
 			"Events",
+			"Helm Releases",
+			"Applications",
+			"Resource Graph",
 		},
 	}
 
@@ -221,13 +262,18 @@ func (co *Overview) Content(ctx context.Context, contentPath, prefix, namespace
 	ctx = log.WithLoggerContext(ctx, co.dashConfig.Logger())
 	genOpts := GeneratorOptions{
 		LabelSet: opts.LabelSet,
+		Filter:   opts.Filter,
 	}
-	return co.generator.Generate(ctx, contentPath, prefix, namespace, genOpts)
+	return co.currentGenerator().Generate(ctx, contentPath, prefix, namespace, genOpts)
 }
 
 type logEntry struct {
-	Timestamp time.Time `json:"timestamp,omitempty"`
-	Message   string    `json:"message,omitempty"`
+	Timestamp *time.Time        `json:"timestamp,omitempty"`
+	Message   string            `json:"message,omitempty"`
+	Level     string            `json:"level,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	Pod       string            `json:"pod,omitempty"`
+	Container string            `json:"container,omitempty"`
 }
 
 type logResponse struct {
@@ -237,9 +283,14 @@ type logResponse struct {
 // Handlers are extra handlers for overview
 func (co *Overview) Handlers(ctx context.Context) map[string]http.Handler {
 	return map[string]http.Handler{
-		"/logs/pod/{pod}/container/{container}": containerLogsHandler(ctx, co.dashConfig.ClusterClient()),
-		"/port-forwards":                        co.portForwardsHandler(),
-		"/port-forwards/{id}":                   co.portForwardHandler(),
+		"/logs/pod/{pod}/container/{container}":          containerLogsHandler(ctx, co.dashConfig),
+		"/logs/pod/{pod}/container/{container}/stream":   containerLogsStreamHandler(ctx, co.dashConfig),
+		"/logs/pod/{pod}/container/{container}/download": containerLogsDownloadHandler(ctx, co.dashConfig),
+		"/logs/{kind}/{name}/stream":                     aggregatedLogsStreamHandler(ctx, co.dashConfig),
+		"/terminal/pod/{pod}/container/{container}":      containerTerminalHandler(ctx, co.dashConfig),
+		"/resource-graph/export":                         resourceGraphExportHandler(newResourceGraphLoader(co.dashConfig)),
+		"/port-forwards":                                 co.portForwardsHandler(),
+		"/port-forwards/{id}":                            co.portForwardHandler(),
 	}
 }
 
@@ -310,10 +361,36 @@ func (co *Overview) portForwardHandler() http.HandlerFunc {
 
 func (co *Overview) ActionPaths() map[string]action.DispatcherFunc {
 	configurationEditor := NewConfigurationEditor(co.logger, co.dashConfig.ObjectStore())
+	yamlApplier := NewYAMLApplier(co.logger, co.dashConfig, co.schemas)
 
-	return map[string]action.DispatcherFunc{
+	actionPaths := map[string]action.DispatcherFunc{
 		configurationEditor.ActionName(): configurationEditor.Handle,
+		yamlApplier.ActionName():         yamlApplier.Handle,
+	}
+
+	rollback := rollouthistory.NewRollback(co.dashConfig, co.auditTrail)
+	actionPaths[rollback.ActionName()] = rollback.Handle
+
+	for _, a := range lifecycle.Actions() {
+		dispatcher := lifecycle.NewDispatcher(a, co.dashConfig, co.auditTrail)
+		actionPaths[dispatcher.ActionName()] = dispatcher.Handle
 	}
+
+	return actionPaths
+}
+
+// currentGenerator returns the generator created when the module was last
+// bootstrapped. The generator is replaced when the object store changes.
+func (co *Overview) currentGenerator() *realGenerator {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+
+	return co.generator
+}
+
+// schemas returns the schemas for the current cluster.
+func (co *Overview) schemas() (openapi.Interface, error) {
+	return co.currentGenerator().Schemas()
 }
 
 func roundToInt(val float64) int64 {
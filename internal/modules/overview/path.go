@@ -34,6 +34,7 @@ var (
 		gvk.RoleBindingGVK,
 		gvk.RoleGVK,
 		gvk.Event,
+		gvk.HelmReleaseGVK,
 	}
 )
 
@@ -91,6 +92,8 @@ func gvkPath(namespace, apiVersion, kind, name string) (string, error) {
 		p = "/events"
 	case apiVersion == "v1" && kind == "Pod":
 		p = "/workloads/pods"
+	case apiVersion == "helm.sh/v3" && kind == "Release":
+		p = "/helm-releases"
 	default:
 		return "", errors.Errorf("unknown object %s %s", apiVersion, kind)
 	}
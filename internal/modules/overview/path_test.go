@@ -39,6 +39,14 @@ func Test_gvk_path(t *testing.T) {
 			objectName: "pod",
 			expected:   path.Join("/content", "overview", "namespace", "default", "workloads", "pods", "pod"),
 		},
+		{
+			name:       "helm release",
+			namespace:  "default",
+			apiVersion: "helm.sh/v3",
+			kind:       "Release",
+			objectName: "release",
+			expected:   path.Join("/content", "overview", "namespace", "default", "helm-releases", "release"),
+		},
 		{
 			name:       "no namespace",
 			apiVersion: "v1",
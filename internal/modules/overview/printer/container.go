@@ -10,6 +10,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/vmware/octant/internal/metrics"
 	"github.com/vmware/octant/internal/portforward"
 
 	"github.com/pkg/errors"
@@ -29,6 +30,7 @@ type ContainerConfiguration struct {
 	portForwardService portforward.PortForwarder
 	isInit             bool
 	options            Options
+	podMetrics         *metrics.PodMetrics
 }
 
 // NewContainerConfiguration creates an instance of ContainerConfiguration.
@@ -104,6 +106,10 @@ func (cc *ContainerConfiguration) Create() (*component.Summary, error) {
 		sections.Add("Volume Mounts", describeVolumeMounts(c))
 	}
 
+	if resources := cc.describeResources(); resources != nil {
+		sections.Add("Resources", resources)
+	}
+
 	title := "Container"
 	if cc.isInit {
 		title = "Init Container"
@@ -113,6 +119,33 @@ func (cc *ContainerConfiguration) Create() (*component.Summary, error) {
 	return summary, nil
 }
 
+// describeResources compares the container's usage with its requests and
+// limits. It returns nil if there is nothing to compare.
+func (cc *ContainerConfiguration) describeResources() *component.Table {
+	c := cc.container
+
+	var usage corev1.ResourceList
+	var samples []metrics.Sample
+
+	if cc.podMetrics != nil && cc.options.Metrics != nil {
+		if cm, ok := cc.podMetrics.Container(c.Name); ok {
+			usage = cm.Usage
+			samples = cc.options.Metrics.History(metrics.ContainerKey(cc.podMetrics.Namespace, cc.podMetrics.Name, c.Name))
+		}
+	}
+
+	if usage == nil && len(c.Resources.Requests) == 0 && len(c.Resources.Limits) == 0 {
+		return nil
+	}
+
+	compareTo := map[string]corev1.ResourceList{
+		"Request": c.Resources.Requests,
+		"Limit":   c.Resources.Limits,
+	}
+
+	return resourceUsageTable("Resources", usage, samples, compareTo, "Request", "Limit")
+}
+
 func printContainerState(state corev1.ContainerState) string {
 	switch {
 	case state.Running != nil:
@@ -217,6 +250,7 @@ func describeContainerPorts(
 				for _, forwarded := range state.Ports {
 					if int(forwarded.Remote) == int(cPort.ContainerPort) {
 						pfs.Port = int(forwarded.Local)
+						pfs.ProxyURL = portforward.ProxyURL(state.ID, forwarded.Remote)
 					}
 				}
 				pfs.IsForwarded = true
@@ -16,14 +16,17 @@ import (
 )
 
 // DaemonSetListHandler is a printFunc that lists daemon sets
-func DaemonSetListHandler(_ context.Context, list *appsv1.DaemonSetList, opts Options) (component.Component, error) {
+func DaemonSetListHandler(ctx context.Context, list *appsv1.DaemonSetList, opts Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("daemon set list is nil")
 	}
 
 	cols := component.NewTableCols("Name", "Labels", "Desired", "Current", "Ready",
 		"Up-To-Date", "Age", "Node Selector")
-	table := component.NewTable("Daemon Sets", cols)
+
+	usage := loadPodUsage(ctx, list, opts)
+
+	table := component.NewTable("Daemon Sets", usage.Columns(cols))
 
 	for _, daemonSet := range list.Items {
 		row := component.TableRow{}
@@ -40,6 +43,7 @@ func DaemonSetListHandler(_ context.Context, list *appsv1.DaemonSetList, opts Op
 		row["Up-To-Date"] = component.NewText(fmt.Sprintf("%d", daemonSet.Status.UpdatedNumberScheduled))
 		row["Age"] = component.NewTimestamp(daemonSet.ObjectMeta.CreationTimestamp.Time)
 		row["Node Selector"] = printSelectorMap(daemonSet.Spec.Template.Spec.NodeSelector)
+		usage.AddSelectorUsage(row, daemonSet.Namespace, daemonSet.Spec.Selector)
 
 		table.Add(row)
 	}
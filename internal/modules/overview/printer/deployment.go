@@ -20,13 +20,16 @@ import (
 )
 
 // DeploymentListHandler is a printFunc that lists deployments
-func DeploymentListHandler(_ context.Context, list *appsv1.DeploymentList, opts Options) (component.Component, error) {
+func DeploymentListHandler(ctx context.Context, list *appsv1.DeploymentList, opts Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("nil list")
 	}
 
 	cols := component.NewTableCols("Name", "Labels", "Status", "Age", "Containers", "Selector")
-	tbl := component.NewTable("Deployments", cols)
+
+	usage := loadPodUsage(ctx, list, opts)
+
+	tbl := component.NewTable("Deployments", usage.Columns(cols))
 
 	for _, d := range list.Items {
 		row := component.TableRow{}
@@ -51,6 +54,8 @@ func DeploymentListHandler(_ context.Context, list *appsv1.DeploymentList, opts
 		row["Containers"] = containers
 		row["Selector"] = printSelector(d.Spec.Selector)
 
+		usage.AddSelectorUsage(row, d.Namespace, d.Spec.Selector)
+
 		tbl.Add(row)
 	}
 	return tbl, nil
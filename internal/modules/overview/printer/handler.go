@@ -31,6 +31,8 @@ func AddHandlers(p Handler) error {
 		IngressHandler,
 		JobListHandler,
 		JobHandler,
+		NodeListHandler,
+		NodeHandler,
 		ReplicaSetHandler,
 		ReplicaSetListHandler,
 		ReplicationControllerHandler,
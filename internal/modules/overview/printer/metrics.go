@@ -0,0 +1,260 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/metrics"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// metricsClient returns the metrics client for the current cluster. It returns
+// nil if the cluster doesn't serve the metrics API, so printers can omit usage.
+func metricsClient(ctx context.Context, dashConfig config.Dash) metrics.Interface {
+	if dashConfig == nil {
+		return nil
+	}
+
+	clusterClient := dashConfig.ClusterClient()
+	if clusterClient == nil {
+		return nil
+	}
+
+	client, err := clusterClient.MetricsClient()
+	if err != nil || client == nil {
+		return nil
+	}
+
+	if !client.Available(ctx) {
+		return nil
+	}
+
+	return client
+}
+
+// loadPodMetrics loads usage for a pod. Errors are logged since usage is optional.
+func loadPodMetrics(ctx context.Context, pod *corev1.Pod, options Options) *metrics.PodMetrics {
+	if options.Metrics == nil || pod == nil {
+		return nil
+	}
+
+	pm, err := options.Metrics.PodMetrics(ctx, pod.Namespace, pod.Name)
+	if err != nil {
+		log.From(ctx).WithErr(err).With("pod", pod.Name).Debugf("unable to load pod metrics")
+		return nil
+	}
+
+	return pm
+}
+
+// podUsage is the usage of the pods in the namespaces of a list. It is
+// used to add usage columns to list tables.
+type podUsage struct {
+	client metrics.Interface
+	// pods are pod metrics by namespace.
+	pods map[string][]metrics.PodMetrics
+}
+
+// loadPodUsage loads usage for the pods in the namespaces of a list's items.
+// It returns nil if the list is empty or usage is not available.
+func loadPodUsage(ctx context.Context, list runtime.Object, options Options) *podUsage {
+	if options.Metrics == nil {
+		return nil
+	}
+
+	namespaces, err := listNamespaces(list)
+	if err != nil {
+		log.From(ctx).WithErr(err).Debugf("unable to find namespaces for pod metrics")
+		return nil
+	}
+
+	if len(namespaces) == 0 {
+		return nil
+	}
+
+	pu := &podUsage{
+		client: options.Metrics,
+		pods:   make(map[string][]metrics.PodMetrics),
+	}
+
+	for _, namespace := range namespaces {
+		pods, err := options.Metrics.PodMetricsList(ctx, namespace, labels.Everything())
+		if err != nil {
+			log.From(ctx).WithErr(err).With("namespace", namespace).Debugf("unable to load pod metrics")
+			return nil
+		}
+
+		pu.pods[namespace] = pods
+	}
+
+	return pu
+}
+
+// listNamespaces returns the distinct namespaces of a list's items in the
+// order they are first seen.
+func listNamespaces(list runtime.Object) ([]string, error) {
+	var namespaces []string
+	seen := make(map[string]bool)
+
+	err := meta.EachListItem(list, func(object runtime.Object) error {
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			return err
+		}
+
+		namespace := accessor.GetNamespace()
+		if !seen[namespace] {
+			seen[namespace] = true
+			namespaces = append(namespaces, namespace)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaces, nil
+}
+
+// Columns appends the usage columns to a set of columns.
+func (pu *podUsage) Columns(cols []component.TableCol) []component.TableCol {
+	if pu == nil {
+		return cols
+	}
+
+	out := make([]component.TableCol, 0, len(cols)+2)
+	out = append(out, cols...)
+	return append(out, component.NewTableCols("CPU", "Memory")...)
+}
+
+// AddSelectorUsage adds the total usage of the pods in a namespace matching
+// a selector to a row.
+func (pu *podUsage) AddSelectorUsage(row component.TableRow, namespace string, labelSelector *metav1.LabelSelector) {
+	if pu == nil {
+		return
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil || selector.Empty() {
+		addUsageCells(row, corev1.ResourceList{})
+		return
+	}
+
+	var matched []metrics.PodMetrics
+	for _, pm := range pu.pods[namespace] {
+		if selector.Matches(labels.Set(pm.Labels)) {
+			matched = append(matched, pm)
+		}
+	}
+
+	addUsageCells(row, metrics.SumPodUsage(matched))
+}
+
+// AddPodUsage adds the usage of a pod and its recent history to a row.
+func (pu *podUsage) AddPodUsage(row component.TableRow, pod *corev1.Pod) {
+	if pu == nil {
+		return
+	}
+
+	pods := pu.pods[pod.Namespace]
+	for i := range pods {
+		pm := pods[i]
+		if pm.Name != pod.Name {
+			continue
+		}
+
+		samples := pu.client.History(metrics.PodKey(pod.Namespace, pod.Name))
+		usage := pm.Usage()
+		row["CPU"] = usageSparkline(corev1.ResourceCPU, usage, samples)
+		row["Memory"] = usageSparkline(corev1.ResourceMemory, usage, samples)
+		return
+	}
+
+	addUsageCells(row, corev1.ResourceList{})
+}
+
+func addUsageCells(row component.TableRow, usage corev1.ResourceList) {
+	row["CPU"] = component.NewText(formatUsage(corev1.ResourceCPU, usage))
+	row["Memory"] = component.NewText(formatUsage(corev1.ResourceMemory, usage))
+}
+
+// formatUsage formats a resource quantity the same way as `kubectl top`.
+func formatUsage(name corev1.ResourceName, list corev1.ResourceList) string {
+	q, ok := list[name]
+	if !ok {
+		return "<none>"
+	}
+
+	return formatQuantity(name, q)
+}
+
+func formatQuantity(name corev1.ResourceName, q resource.Quantity) string {
+	switch name {
+	case corev1.ResourceCPU:
+		return fmt.Sprintf("%dm", q.MilliValue())
+	case corev1.ResourceMemory:
+		return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+	default:
+		return q.String()
+	}
+}
+
+// usageSparkline creates a sparkline for a resource labeled with the current usage.
+func usageSparkline(name corev1.ResourceName, usage corev1.ResourceList, samples []metrics.Sample) *component.Sparkline {
+	values := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		switch name {
+		case corev1.ResourceCPU:
+			values = append(values, float64(sample.CPU))
+		case corev1.ResourceMemory:
+			values = append(values, float64(sample.Memory)/(1024*1024))
+		}
+	}
+
+	return component.NewSparkline(formatUsage(name, usage), values)
+}
+
+// resourceUsageTable compares usage with requests and limits, or capacity
+// and allocatable for nodes. The usage column is omitted when usage is nil.
+func resourceUsageTable(title string, usage corev1.ResourceList, samples []metrics.Sample, compareTo map[string]corev1.ResourceList, compareOrder ...string) *component.Table {
+	colNames := []string{"Resource"}
+	if usage != nil {
+		colNames = append(colNames, "Usage")
+	}
+	colNames = append(colNames, compareOrder...)
+
+	table := component.NewTable(title, component.NewTableCols(colNames...))
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		row := component.TableRow{
+			"Resource": component.NewText(string(name)),
+		}
+
+		if usage != nil {
+			row["Usage"] = usageSparkline(name, usage, samples)
+		}
+
+		for _, col := range compareOrder {
+			row[col] = component.NewText(formatUsage(name, compareTo[col]))
+		}
+
+		table.Add(row)
+	}
+
+	return table
+}
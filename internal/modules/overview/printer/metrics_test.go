@@ -0,0 +1,213 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/octant/internal/metrics"
+	metricsFake "github.com/vmware/octant/internal/metrics/fake"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func podMetricsFor(name string, podLabels map[string]string, cpu, memory string) metrics.PodMetrics {
+	return metrics.PodMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "namespace", Labels: podLabels},
+		Containers: []metrics.ContainerMetrics{
+			{
+				Name: "nginx",
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		},
+	}
+}
+
+func Test_podUsage(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	samples := []metrics.Sample{
+		{Timestamp: time.Unix(1, 0), CPU: 50, Memory: 32 * 1024 * 1024},
+		{Timestamp: time.Unix(2, 0), CPU: 100, Memory: 64 * 1024 * 1024},
+	}
+
+	metricsClient := metricsFake.NewMockInterface(controller)
+	metricsClient.EXPECT().
+		PodMetricsList(gomock.Any(), "namespace", gomock.Any()).
+		Return([]metrics.PodMetrics{
+			podMetricsFor("pod-1", map[string]string{"app": "app"}, "100m", "64Mi"),
+			podMetricsFor("pod-2", map[string]string{"app": "app"}, "50m", "32Mi"),
+			podMetricsFor("other", map[string]string{"app": "other"}, "10m", "1Mi"),
+		}, nil)
+	metricsClient.EXPECT().History(metrics.PodKey("namespace", "pod-1")).Return(samples)
+
+	list := &corev1.PodList{Items: []corev1.Pod{*testutil.CreatePod("pod-1")}}
+
+	usage := loadPodUsage(context.Background(), list, Options{Metrics: metricsClient})
+	require.NotNil(t, usage)
+
+	cols := usage.Columns(component.NewTableCols("Name"))
+	assert.Equal(t, component.NewTableCols("Name", "CPU", "Memory"), cols)
+
+	row := component.TableRow{}
+	usage.AddSelectorUsage(row, "namespace", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}})
+	assert.Equal(t, component.TableRow{
+		"CPU":    component.NewText("150m"),
+		"Memory": component.NewText("96Mi"),
+	}, row)
+
+	// pods in other namespaces aren't matched
+	row = component.TableRow{}
+	usage.AddSelectorUsage(row, "other", &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}})
+	assert.Equal(t, component.TableRow{
+		"CPU":    component.NewText("<none>"),
+		"Memory": component.NewText("<none>"),
+	}, row)
+
+	pod := testutil.CreatePod("pod-1")
+	row = component.TableRow{}
+	usage.AddPodUsage(row, pod)
+	assert.Equal(t, component.TableRow{
+		"CPU":    component.NewSparkline("100m", []float64{50, 100}),
+		"Memory": component.NewSparkline("64Mi", []float64{32, 64}),
+	}, row)
+
+	row = component.TableRow{}
+	usage.AddPodUsage(row, testutil.CreatePod("missing"))
+	assert.Equal(t, component.TableRow{
+		"CPU":    component.NewText("<none>"),
+		"Memory": component.NewText("<none>"),
+	}, row)
+}
+
+func Test_podUsage_unavailable(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	metricsClient := metricsFake.NewMockInterface(controller)
+	metricsClient.EXPECT().
+		PodMetricsList(gomock.Any(), "namespace", gomock.Any()).
+		Return(nil, metrics.ErrUnavailable)
+
+	list := &corev1.PodList{Items: []corev1.Pod{*testutil.CreatePod("pod")}}
+
+	usage := loadPodUsage(context.Background(), list, Options{Metrics: metricsClient})
+	require.Nil(t, usage)
+
+	// a nil usage leaves tables unchanged
+	cols := component.NewTableCols("Name")
+	assert.Equal(t, cols, usage.Columns(cols))
+
+	row := component.TableRow{}
+	usage.AddSelectorUsage(row, "namespace", &metav1.LabelSelector{})
+	assert.Empty(t, row)
+
+	assert.Nil(t, loadPodUsage(context.Background(), list, Options{}))
+	assert.Nil(t, loadPodUsage(context.Background(), &corev1.PodList{}, Options{Metrics: metricsClient}))
+}
+
+func Test_podUsage_namespaces(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	first := podMetricsFor("pod", map[string]string{"app": "app"}, "100m", "64Mi")
+	second := podMetricsFor("pod", map[string]string{"app": "app"}, "50m", "32Mi")
+	second.Namespace = "other"
+
+	metricsClient := metricsFake.NewMockInterface(controller)
+	metricsClient.EXPECT().
+		PodMetricsList(gomock.Any(), "namespace", gomock.Any()).
+		Return([]metrics.PodMetrics{first}, nil)
+	metricsClient.EXPECT().
+		PodMetricsList(gomock.Any(), "other", gomock.Any()).
+		Return([]metrics.PodMetrics{second}, nil)
+
+	firstPod := testutil.CreatePod("pod")
+	secondPod := testutil.CreatePod("pod")
+	secondPod.Namespace = "other"
+
+	// each namespace in the list is loaded once
+	list := &corev1.PodList{Items: []corev1.Pod{*firstPod, *secondPod, *firstPod}}
+
+	usage := loadPodUsage(context.Background(), list, Options{Metrics: metricsClient})
+	require.NotNil(t, usage)
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "app"}}
+
+	row := component.TableRow{}
+	usage.AddSelectorUsage(row, "namespace", selector)
+	assert.Equal(t, component.NewText("100m"), row["CPU"])
+
+	row = component.TableRow{}
+	usage.AddSelectorUsage(row, "other", selector)
+	assert.Equal(t, component.NewText("50m"), row["CPU"])
+}
+
+func Test_ContainerConfiguration_resources(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	pod := testutil.CreatePod("pod")
+	pod.Namespace = "namespace"
+
+	container := corev1.Container{
+		Name:  "nginx",
+		Image: "nginx:1.15",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+	}
+
+	metricsClient := metricsFake.NewMockInterface(controller)
+	metricsClient.EXPECT().History(metrics.ContainerKey("namespace", "pod", "nginx")).Return(nil)
+
+	pm := podMetricsFor("pod", nil, "20m", "16Mi")
+
+	cc := NewContainerConfiguration(pod, &container, nil, false, Options{Metrics: metricsClient})
+	cc.podMetrics = &pm
+
+	got := cc.describeResources()
+
+	expected := component.NewTable("Resources", component.NewTableCols("Resource", "Usage", "Request", "Limit"))
+	expected.Add(
+		component.TableRow{
+			"Resource": component.NewText("cpu"),
+			"Usage":    component.NewSparkline("20m", nil),
+			"Request":  component.NewText("100m"),
+			"Limit":    component.NewText("<none>"),
+		},
+		component.TableRow{
+			"Resource": component.NewText("memory"),
+			"Usage":    component.NewSparkline("16Mi", nil),
+			"Request":  component.NewText("64Mi"),
+			"Limit":    component.NewText("128Mi"),
+		},
+	)
+
+	assert.Equal(t, expected, got)
+
+	cc = NewContainerConfiguration(pod, &corev1.Container{Name: "nginx"}, nil, false, Options{})
+	assert.Nil(t, cc.describeResources())
+}
@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/metrics"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+
+// NodeListHandler is a printFunc that lists nodes.
+func NodeListHandler(ctx context.Context, list *corev1.NodeList, options Options) (component.Component, error) {
+	if list == nil {
+		return nil, errors.New("node list is nil")
+	}
+
+	cols := component.NewTableCols("Name", "Labels", "Status", "Roles", "Age", "Version")
+
+	usage := loadNodeUsage(ctx, options)
+	if usage != nil {
+		cols = append(cols, component.NewTableCols("CPU", "Memory")...)
+	}
+
+	table := component.NewTable("Nodes", cols)
+
+	for _, node := range list.Items {
+		row := component.TableRow{}
+		nameLink, err := options.Link.ForObject(&node, node.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		row["Name"] = nameLink
+		row["Labels"] = component.NewLabels(node.Labels)
+		row["Status"] = component.NewText(nodeStatus(&node))
+		row["Roles"] = component.NewText(strings.Join(nodeRoles(&node), ","))
+		row["Age"] = component.NewTimestamp(node.CreationTimestamp.Time)
+		row["Version"] = component.NewText(node.Status.NodeInfo.KubeletVersion)
+
+		if usage != nil {
+			nodeUsage := usage[node.Name]
+			samples := options.Metrics.History(metrics.NodeKey(node.Name))
+			row["CPU"] = usageSparkline(corev1.ResourceCPU, nodeUsage, samples)
+			row["Memory"] = usageSparkline(corev1.ResourceMemory, nodeUsage, samples)
+		}
+
+		table.Add(row)
+	}
+
+	table.Sort("Name", false)
+
+	return table, nil
+}
+
+// NodeHandler is a printFunc that prints a node.
+func NodeHandler(ctx context.Context, node *corev1.Node, options Options) (component.Component, error) {
+	if node == nil {
+		return nil, errors.New("node is nil")
+	}
+
+	o := NewObject(node)
+
+	o.RegisterConfig(createNodeConfiguration(node))
+	o.RegisterSummary(createNodeStatus(node))
+	o.EnableEvents()
+
+	var usage corev1.ResourceList
+	var samples []metrics.Sample
+	if options.Metrics != nil {
+		nm, err := options.Metrics.NodeMetrics(ctx, node.Name)
+		if err == nil {
+			usage = nm.Usage
+			samples = options.Metrics.History(metrics.NodeKey(node.Name))
+		} else {
+			log.From(ctx).WithErr(err).With("node", node.Name).Debugf("unable to load node metrics")
+		}
+	}
+
+	o.RegisterItems(
+		ItemDescriptor{
+			Width: component.WidthHalf,
+			Func: func() (component.Component, error) {
+				compareTo := map[string]corev1.ResourceList{
+					"Allocatable": node.Status.Allocatable,
+					"Capacity":    node.Status.Capacity,
+				}
+				return resourceUsageTable("Resources", usage, samples, compareTo, "Allocatable", "Capacity"), nil
+			},
+		},
+		ItemDescriptor{
+			Width: component.WidthHalf,
+			Func: func() (component.Component, error) {
+				return createNodeAddressesView(node), nil
+			},
+		},
+		ItemDescriptor{
+			Width: component.WidthFull,
+			Func: func() (component.Component, error) {
+				return createNodeConditionsView(node), nil
+			},
+		},
+	)
+
+	return o.ToComponent(ctx, options)
+}
+
+// loadNodeUsage loads the usage of all nodes. It returns nil if usage is not available.
+func loadNodeUsage(ctx context.Context, options Options) map[string]corev1.ResourceList {
+	if options.Metrics == nil {
+		return nil
+	}
+
+	list, err := options.Metrics.NodeMetricsList(ctx)
+	if err != nil {
+		log.From(ctx).WithErr(err).Debugf("unable to load node metrics")
+		return nil
+	}
+
+	usage := make(map[string]corev1.ResourceList)
+	for _, nm := range list {
+		usage[nm.Name] = nm.Usage
+	}
+
+	return usage
+}
+
+func createNodeConfiguration(node *corev1.Node) *component.Summary {
+	sections := component.SummarySections{}
+
+	info := node.Status.NodeInfo
+	sections.AddText("Architecture", info.Architecture)
+	sections.AddText("Operating System", info.OSImage)
+	sections.AddText("Kernel Version", info.KernelVersion)
+	sections.AddText("Container Runtime", info.ContainerRuntimeVersion)
+	sections.AddText("Kubelet Version", info.KubeletVersion)
+
+	if node.Spec.PodCIDR != "" {
+		sections.AddText("Pod CIDR", node.Spec.PodCIDR)
+	}
+
+	if node.Spec.ProviderID != "" {
+		sections.AddText("Provider ID", node.Spec.ProviderID)
+	}
+
+	return component.NewSummary("Configuration", sections...)
+}
+
+func createNodeStatus(node *corev1.Node) *component.Summary {
+	sections := component.SummarySections{}
+	sections.AddText("Status", nodeStatus(node))
+
+	if roles := nodeRoles(node); len(roles) > 0 {
+		sections.AddText("Roles", strings.Join(roles, ","))
+	}
+
+	return component.NewSummary("Status", sections...)
+}
+
+func createNodeAddressesView(node *corev1.Node) *component.Table {
+	cols := component.NewTableCols("Type", "Address")
+	table := component.NewTable("Addresses", cols)
+
+	for _, address := range node.Status.Addresses {
+		table.Add(component.TableRow{
+			"Type":    component.NewText(string(address.Type)),
+			"Address": component.NewText(address.Address),
+		})
+	}
+
+	return table
+}
+
+func createNodeConditionsView(node *corev1.Node) *component.Table {
+	cols := component.NewTableCols("Type", "Status", "Last Heartbeat Time", "Last Transition Time", "Reason", "Message")
+	table := component.NewTable("Conditions", cols)
+
+	for _, condition := range node.Status.Conditions {
+		table.Add(component.TableRow{
+			"Type":                 component.NewText(string(condition.Type)),
+			"Status":               component.NewText(string(condition.Status)),
+			"Last Heartbeat Time":  component.NewTimestamp(condition.LastHeartbeatTime.Time),
+			"Last Transition Time": component.NewTimestamp(condition.LastTransitionTime.Time),
+			"Reason":               component.NewText(condition.Reason),
+			"Message":              component.NewText(condition.Message),
+		})
+	}
+
+	return table
+}
+
+// nodeStatus returns the node status in the same format as `kubectl get nodes`.
+func nodeStatus(node *corev1.Node) string {
+	status := "Unknown"
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != corev1.NodeReady {
+			continue
+		}
+
+		if condition.Status == corev1.ConditionTrue {
+			status = "Ready"
+		} else {
+			status = "NotReady"
+		}
+	}
+
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+
+	return status
+}
+
+// nodeRoles returns the roles of a node from its role labels.
+func nodeRoles(node *corev1.Node) []string {
+	var roles []string
+	for key := range node.Labels {
+		if strings.HasPrefix(key, nodeRoleLabelPrefix) {
+			if role := strings.TrimPrefix(key, nodeRoleLabelPrefix); role != "" {
+				roles = append(roles, role)
+			}
+		}
+	}
+
+	sort.Strings(roles)
+	return roles
+}
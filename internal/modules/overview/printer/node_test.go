@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/octant/internal/metrics"
+	metricsFake "github.com/vmware/octant/internal/metrics/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func createNode(name string, now time.Time) *corev1.Node {
+	return &corev1.Node{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.Time{Time: now},
+			Labels: map[string]string{
+				"node-role.kubernetes.io/master": "",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			},
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.14.1"},
+		},
+	}
+}
+
+func Test_NodeListHandler(t *testing.T) {
+	now := time.Unix(1547211430, 0)
+
+	cases := []struct {
+		name       string
+		hasMetrics bool
+		expected   func(node *corev1.Node) *component.Table
+	}{
+		{
+			name: "without metrics",
+			expected: func(node *corev1.Node) *component.Table {
+				cols := component.NewTableCols("Name", "Labels", "Status", "Roles", "Age", "Version")
+				table := component.NewTable("Nodes", cols)
+				table.Add(component.TableRow{
+					"Name":    component.NewLink("", "node", "/node"),
+					"Labels":  component.NewLabels(node.Labels),
+					"Status":  component.NewText("Ready"),
+					"Roles":   component.NewText("master"),
+					"Age":     component.NewTimestamp(now),
+					"Version": component.NewText("v1.14.1"),
+				})
+				return table
+			},
+		},
+		{
+			name:       "with metrics",
+			hasMetrics: true,
+			expected: func(node *corev1.Node) *component.Table {
+				cols := component.NewTableCols("Name", "Labels", "Status", "Roles", "Age", "Version", "CPU", "Memory")
+				table := component.NewTable("Nodes", cols)
+				table.Add(component.TableRow{
+					"Name":    component.NewLink("", "node", "/node"),
+					"Labels":  component.NewLabels(node.Labels),
+					"Status":  component.NewText("Ready"),
+					"Roles":   component.NewText("master"),
+					"Age":     component.NewTimestamp(now),
+					"Version": component.NewText("v1.14.1"),
+					"CPU":     component.NewSparkline("1500m", []float64{1500}),
+					"Memory":  component.NewSparkline("2048Mi", []float64{2048}),
+				})
+				return table
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			tpo := newTestPrinterOptions(controller)
+			printOptions := tpo.ToOptions()
+
+			node := createNode("node", now)
+			tpo.PathForObject(node, node.Name, "/node")
+
+			if tc.hasMetrics {
+				usage := corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1500m"),
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				}
+
+				metricsClient := metricsFake.NewMockInterface(controller)
+				metricsClient.EXPECT().NodeMetricsList(gomock.Any()).Return([]metrics.NodeMetrics{
+					{ObjectMeta: metav1.ObjectMeta{Name: "node"}, Usage: usage},
+				}, nil)
+				metricsClient.EXPECT().History(metrics.NodeKey("node")).
+					Return([]metrics.Sample{metrics.NewSample(now, usage)})
+
+				printOptions.Metrics = metricsClient
+			}
+
+			list := &corev1.NodeList{Items: []corev1.Node{*node}}
+
+			got, err := NodeListHandler(context.Background(), list, printOptions)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected(node), got)
+		})
+	}
+}
+
+func Test_nodeStatus(t *testing.T) {
+	node := createNode("node", time.Now())
+	assert.Equal(t, "Ready", nodeStatus(node))
+
+	node.Spec.Unschedulable = true
+	assert.Equal(t, "Ready,SchedulingDisabled", nodeStatus(node))
+
+	node.Status.Conditions[0].Status = corev1.ConditionFalse
+	node.Spec.Unschedulable = false
+	assert.Equal(t, "NotReady", nodeStatus(node))
+
+	node.Status.Conditions = nil
+	assert.Equal(t, "Unknown", nodeStatus(node))
+}
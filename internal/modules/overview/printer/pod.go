@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 
+	"github.com/vmware/octant/internal/metrics"
 	"github.com/vmware/octant/pkg/store"
 	"github.com/vmware/octant/pkg/view/component"
 )
@@ -31,7 +32,7 @@ var (
 )
 
 // PodListHandler is a printFunc that prints pods
-func PodListHandler(_ context.Context, list *corev1.PodList, opts Options) (component.Component, error) {
+func PodListHandler(ctx context.Context, list *corev1.PodList, opts Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("list is nil")
 	}
@@ -41,7 +42,9 @@ func PodListHandler(_ context.Context, list *corev1.PodList, opts Options) (comp
 		cols = podColsWithOutLabels
 	}
 
-	tbl := component.NewTable("Pods", cols)
+	usage := loadPodUsage(ctx, list, opts)
+
+	tbl := component.NewTable("Pods", usage.Columns(cols))
 
 	for _, p := range list.Items {
 		if p.Status.Phase == corev1.PodSucceeded {
@@ -84,6 +87,8 @@ func PodListHandler(_ context.Context, list *corev1.PodList, opts Options) (comp
 		ts := p.CreationTimestamp.Time
 		row["Age"] = component.NewTimestamp(ts)
 
+		usage.AddPodUsage(row, &p)
+
 		tbl.Add(row)
 	}
 
@@ -109,6 +114,16 @@ func PodHandler(ctx context.Context, pod *corev1.Pod, opts Options) (component.C
 		return nil, err
 	}
 
+	podMetrics := loadPodMetrics(ctx, pod, opts)
+	if podMetrics != nil {
+		samples := opts.Metrics.History(metrics.PodKey(pod.Namespace, pod.Name))
+		usage := podMetrics.Usage()
+		statusSummary.Add(
+			component.SummarySection{Header: "CPU Usage", Content: usageSparkline(corev1.ResourceCPU, usage, samples)},
+			component.SummarySection{Header: "Memory Usage", Content: usageSparkline(corev1.ResourceMemory, usage, samples)},
+		)
+	}
+
 	o.RegisterConfig(configSummary)
 	o.RegisterSummary(statusSummary)
 	o.EnableEvents()
@@ -125,6 +140,7 @@ func PodHandler(ctx context.Context, pod *corev1.Pod, opts Options) (component.C
 	var initContainerItems []ItemDescriptor
 	for _, container := range pod.Spec.InitContainers {
 		cc := NewContainerConfiguration(pod, &container, portForwarder, true, opts)
+		cc.podMetrics = podMetrics
 		initContainerItems = append(initContainerItems, ItemDescriptor{
 			Width: component.WidthHalf,
 			Func: func() (component.Component, error) {
@@ -138,6 +154,7 @@ func PodHandler(ctx context.Context, pod *corev1.Pod, opts Options) (component.C
 	var containerItems []ItemDescriptor
 	for _, container := range pod.Spec.Containers {
 		cc := NewContainerConfiguration(pod, &container, portForwarder, false, opts)
+		cc.podMetrics = podMetrics
 		containerItems = append(initContainerItems, ItemDescriptor{
 			Width: component.WidthHalf,
 			Func: func() (component.Component, error) {
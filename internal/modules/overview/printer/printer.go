@@ -13,6 +13,8 @@ import (
 
 	"github.com/vmware/octant/internal/config"
 	"github.com/vmware/octant/internal/link"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/metrics"
 	"github.com/vmware/octant/pkg/plugin"
 
 	"github.com/pkg/errors"
@@ -30,6 +32,9 @@ type Options struct {
 	DisableLabels bool
 	DashConfig    config.Dash
 	Link          link.Interface
+	// Metrics queries resource usage. It is nil if the cluster doesn't
+	// serve the metrics API.
+	Metrics metrics.Interface
 }
 
 // Printer is an interface for printing runtime objects.
@@ -65,6 +70,7 @@ func (p *Resource) Print(ctx context.Context, object runtime.Object, pluginPrint
 	printOptions := Options{
 		DashConfig: p.dashConfig,
 		Link:       l,
+		Metrics:    metricsClient(ctx, p.dashConfig),
 	}
 
 	t := reflect.TypeOf(object)
@@ -83,6 +89,15 @@ func (p *Resource) Print(ctx context.Context, object runtime.Object, pluginPrint
 		return viewComponent, nil
 	}
 
+	// lists without a handler use the API server's columns if it can
+	// print them.
+	table, err := serverSideListTable(ctx, object, printOptions)
+	if err != nil {
+		log.From(ctx).WithErr(err).Debugf("unable to print %T with a server side table", object)
+	} else if table != nil {
+		return table, nil
+	}
+
 	return DefaultPrintFunc(ctx, object, printOptions)
 }
 
@@ -19,6 +19,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	clusterFake "github.com/vmware/octant/internal/cluster/fake"
+	metricsFake "github.com/vmware/octant/internal/metrics/fake"
 	"github.com/vmware/octant/pkg/plugin/fake"
 	"github.com/vmware/octant/pkg/view/component"
 )
@@ -28,6 +30,7 @@ func Test_Resource_Print(t *testing.T) {
 		name         string
 		printFunc    interface{}
 		object       runtime.Object
+		hasMetrics   bool
 		isErr        bool
 		isNil        bool
 		expectedType string
@@ -40,6 +43,29 @@ func Test_Resource_Print(t *testing.T) {
 			object:       &appsv1.Deployment{},
 			expectedType: "type1",
 		},
+		{
+			name: "print with metrics",
+			printFunc: func(ctx context.Context, deployment *appsv1.Deployment, options Options) (component.Component, error) {
+				if options.Metrics == nil {
+					return nil, errors.New("metrics client is nil")
+				}
+				return &stubComponent{Type: "type1"}, nil
+			},
+			object:       &appsv1.Deployment{},
+			hasMetrics:   true,
+			expectedType: "type1",
+		},
+		{
+			name: "print without metrics",
+			printFunc: func(ctx context.Context, deployment *appsv1.Deployment, options Options) (component.Component, error) {
+				if options.Metrics != nil {
+					return nil, errors.New("metrics client is not nil")
+				}
+				return &stubComponent{Type: "type1"}, nil
+			},
+			object:       &appsv1.Deployment{},
+			expectedType: "type1",
+		},
 		{
 			name:   "print unregistered type returns error",
 			object: &appsv1.Deployment{},
@@ -69,6 +95,13 @@ func Test_Resource_Print(t *testing.T) {
 
 			pluginPrinter := fake.NewMockManagerInterface(controller)
 
+			metricsClient := metricsFake.NewMockInterface(controller)
+			metricsClient.EXPECT().Available(gomock.Any()).Return(tc.hasMetrics).AnyTimes()
+
+			clusterClient := clusterFake.NewMockClientInterface(controller)
+			clusterClient.EXPECT().MetricsClient().Return(metricsClient, nil).AnyTimes()
+			tpo.dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+
 			p := NewResource(tpo.dashConfig)
 
 			if tc.printFunc != nil {
@@ -25,7 +25,10 @@ func ReplicaSetListHandler(ctx context.Context, list *appsv1.ReplicaSetList, opt
 	}
 
 	cols := component.NewTableCols("Name", "Labels", "Status", "Age", "Containers", "Selector")
-	tbl := component.NewTable("ReplicaSets", cols)
+
+	usage := loadPodUsage(ctx, list, opts)
+
+	tbl := component.NewTable("ReplicaSets", usage.Columns(cols))
 
 	for _, rs := range list.Items {
 		row := component.TableRow{}
@@ -50,6 +53,8 @@ func ReplicaSetListHandler(ctx context.Context, list *appsv1.ReplicaSetList, opt
 		row["Containers"] = containers
 		row["Selector"] = printSelector(rs.Spec.Selector)
 
+		usage.AddSelectorUsage(row, rs.Namespace, rs.Spec.Selector)
+
 		tbl.Add(row)
 	}
 	return tbl, nil
@@ -24,7 +24,10 @@ func ReplicationControllerListHandler(ctx context.Context, list *corev1.Replicat
 	}
 
 	cols := component.NewTableCols("Name", "Labels", "Status", "Age", "Containers", "Selector")
-	tbl := component.NewTable("ReplicationControllers", cols)
+
+	usage := loadPodUsage(ctx, list, options)
+
+	tbl := component.NewTable("ReplicationControllers", usage.Columns(cols))
 
 	for _, rc := range list.Items {
 		row := component.TableRow{}
@@ -51,6 +54,8 @@ func ReplicationControllerListHandler(ctx context.Context, list *corev1.Replicat
 
 		row["Selector"] = printSelectorMap(rc.Spec.Selector)
 
+		usage.AddSelectorUsage(row, rc.Namespace, &metav1.LabelSelector{MatchLabels: rc.Spec.Selector})
+
 		tbl.Add(row)
 	}
 	return tbl, nil
@@ -8,6 +8,8 @@ package printer
 import (
 	"context"
 	"fmt"
+	"path"
+	"sort"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -17,7 +19,7 @@ import (
 
 var (
 	secretTableCols = component.NewTableCols("Name", "Labels", "Type", "Data", "Age")
-	secretDataCols  = component.NewTableCols("Key")
+	secretDataCols  = component.NewTableCols("Key", "Size", "Value")
 )
 
 // SecretListHandler is a printFunc that lists secrets.
@@ -85,15 +87,30 @@ func secretConfiguration(secret corev1.Secret) (*component.Summary, error) {
 	return summary, nil
 }
 
+// secretData lists the keys in a secret. Values aren't shown; each key
+// links to a page which reveals its value.
 func secretData(secret corev1.Secret) (*component.Table, error) {
 	table := component.NewTable("Data", secretDataCols)
 
+	var keys []string
 	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
 		row := component.TableRow{}
 		row["Key"] = component.NewText(key)
+		row["Size"] = component.NewText(fmt.Sprintf("%d bytes", len(secret.Data[key])))
+		row["Value"] = component.NewLink("", "Reveal", secretValuePath(secret, key))
 
 		table.Add(row)
 	}
 
 	return table, nil
 }
+
+// secretValuePath is the content path which reveals a secret value.
+func secretValuePath(secret corev1.Secret, key string) string {
+	return path.Join("/content/overview/namespace", secret.Namespace, "config-and-storage/secret-values", secret.Name, key)
+}
@@ -72,3 +72,35 @@ func Test_SecretListHandler(t *testing.T) {
 
 	assert.Equal(t, expected, got)
 }
+
+func Test_secretData(t *testing.T) {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"tls.key": []byte("key"),
+			"tls.crt": []byte("certificate"),
+		},
+	}
+
+	got, err := secretData(secret)
+	require.NoError(t, err)
+
+	expected := component.NewTable("Data", secretDataCols)
+	expected.Add(
+		component.TableRow{
+			"Key":   component.NewText("tls.crt"),
+			"Size":  component.NewText("11 bytes"),
+			"Value": component.NewLink("", "Reveal", "/content/overview/namespace/default/config-and-storage/secret-values/secret/tls.crt"),
+		},
+		component.TableRow{
+			"Key":   component.NewText("tls.key"),
+			"Size":  component.NewText("3 bytes"),
+			"Value": component.NewLink("", "Reveal", "/content/overview/namespace/default/config-and-storage/secret-values/secret/tls.key"),
+		},
+	)
+
+	assert.Equal(t, expected, got)
+}
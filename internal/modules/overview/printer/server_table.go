@@ -0,0 +1,257 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// tableAcceptHeader requests the Table representation of a list from the API
+// server. meta.k8s.io/v1 is preferred, but older servers only support v1beta1.
+// Both versions share the same structure.
+const tableAcceptHeader = "application/json;as=Table;v=v1;g=meta.k8s.io," +
+	"application/json;as=Table;v=v1beta1;g=meta.k8s.io"
+
+// ServerSideTable prints the objects described by a key using the Table
+// representation generated by the API server. This provides the same
+// columns as `kubectl get` for kinds which don't have a print handler, e.g.
+// custom resources. Only rows for objects are printed, so the table matches
+// objects which have been filtered.
+func ServerSideTable(ctx context.Context, key store.Key, objects []*unstructured.Unstructured, options Options) (*component.Table, error) {
+	if options.DashConfig == nil {
+		return nil, errors.New("dash config is nil")
+	}
+
+	gv, err := schema.ParseGroupVersion(key.APIVersion)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse api version %q", key.APIVersion)
+	}
+
+	clusterClient := options.DashConfig.ClusterClient()
+
+	gvr, err := clusterClient.Resource(gv.WithKind(key.Kind).GroupKind())
+	if err != nil {
+		return nil, errors.Wrapf(err, "find resource for %s", key)
+	}
+
+	restClient, err := clusterClient.RESTClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "create REST client")
+	}
+
+	table, err := fetchTable(ctx, restClient, gv.WithResource(gvr.Resource), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return tableToComponent(key, table, objects, options)
+}
+
+// serverSideListTable prints a typed list using the Table representation
+// generated by the API server. It is used for built-in kinds which don't
+// have a print handler. It returns nil if object isn't a list or the list is
+// empty.
+func serverSideListTable(ctx context.Context, object runtime.Object, options Options) (*component.Table, error) {
+	if !meta.IsListType(object) {
+		return nil, nil
+	}
+
+	items, err := meta.ExtractList(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "extract list items")
+	}
+
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	gvks, _, err := scheme.Scheme.ObjectKinds(items[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "find kind for %T", items[0])
+	}
+
+	apiVersion, kind := gvks[0].ToAPIVersionAndKind()
+	key := store.Key{APIVersion: apiVersion, Kind: kind}
+
+	// items from more than one namespace are fetched from all namespaces.
+	namespaces, err := listNamespaces(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "find list namespaces")
+	}
+	if len(namespaces) == 1 {
+		key.Namespace = namespaces[0]
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, item := range items {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item)
+		if err != nil {
+			return nil, errors.Wrapf(err, "convert %T to unstructured", item)
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: m})
+	}
+
+	return ServerSideTable(ctx, key, objects, options)
+}
+
+// fetchTable requests a Table from the API server.
+func fetchTable(ctx context.Context, restClient rest.Interface, gvr schema.GroupVersionResource, key store.Key) (*metav1beta1.Table, error) {
+	var segments []string
+	if gvr.Group == "" {
+		segments = append(segments, "api", gvr.Version)
+	} else {
+		segments = append(segments, "apis", gvr.Group, gvr.Version)
+	}
+
+	if key.Namespace != "" {
+		segments = append(segments, "namespaces", key.Namespace)
+	}
+	segments = append(segments, gvr.Resource)
+
+	req := restClient.Get().
+		Context(ctx).
+		AbsPath(segments...).
+		SetHeader("Accept", tableAcceptHeader).
+		Param("includeObject", "Metadata")
+
+	if key.Selector != nil {
+		req = req.Param("labelSelector", labels.SelectorFromSet(*key.Selector).String())
+	}
+
+	data, err := req.DoRaw()
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch table for %s", gvr)
+	}
+
+	table := &metav1beta1.Table{}
+	if err := json.Unmarshal(data, table); err != nil {
+		return nil, errors.Wrap(err, "decode table")
+	}
+
+	if table.Kind != "Table" {
+		return nil, errors.Errorf("API server returned %q instead of a table for %s", table.Kind, gvr)
+	}
+
+	return table, nil
+}
+
+// tableToComponent converts a server side table to a table component. Columns
+// with a non zero priority are omitted to match the default output of
+// `kubectl get`. Rows for objects which aren't in objects are omitted.
+func tableToComponent(key store.Key, table *metav1beta1.Table, objects []*unstructured.Unstructured, options Options) (*component.Table, error) {
+	included := make(map[string]bool)
+	for _, object := range objects {
+		included[object.GetNamespace()+"/"+object.GetName()] = true
+	}
+
+	var names []string
+	var indexes []int
+	nameIndex := -1
+
+	for i, def := range table.ColumnDefinitions {
+		if def.Priority != 0 {
+			continue
+		}
+		if def.Format == "name" && nameIndex < 0 {
+			nameIndex = i
+		}
+		names = append(names, def.Name)
+		indexes = append(indexes, i)
+	}
+
+	title := key.Kind
+	if gv, err := schema.ParseGroupVersion(key.APIVersion); err == nil {
+		title = gv.WithKind(key.Kind).String()
+	}
+
+	tbl := component.NewTable(title, component.NewTableCols(names...))
+
+	for _, tableRow := range table.Rows {
+		if nameIndex < 0 || nameIndex >= len(tableRow.Cells) {
+			continue
+		}
+		name := formatCell(tableRow.Cells[nameIndex])
+		namespace := rowNamespace(tableRow, key.Namespace)
+		if !included[namespace+"/"+name] {
+			continue
+		}
+
+		row := component.TableRow{}
+
+		for j, i := range indexes {
+			if i >= len(tableRow.Cells) {
+				continue
+			}
+
+			var cell component.Component = component.NewText(formatCell(tableRow.Cells[i]))
+
+			if i == nameIndex && options.Link != nil {
+				l, err := options.Link.ForGVK(namespace, key.APIVersion, key.Kind, name, name)
+				if err != nil {
+					return nil, errors.Wrapf(err, "create link for %s", name)
+				}
+				cell = l
+			}
+
+			row[names[j]] = cell
+		}
+
+		tbl.Add(row)
+	}
+
+	return tbl, nil
+}
+
+// rowNamespace returns the namespace from the partial object metadata included
+// with a row.
+func rowNamespace(row metav1beta1.TableRow, defaultNamespace string) string {
+	if len(row.Object.Raw) == 0 {
+		return defaultNamespace
+	}
+
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(row.Object.Raw); err != nil || u.GetNamespace() == "" {
+		return defaultNamespace
+	}
+
+	return u.GetNamespace()
+}
+
+func formatCell(cell interface{}) string {
+	switch v := cell.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			parts = append(parts, formatCell(item))
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
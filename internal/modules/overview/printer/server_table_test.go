@@ -0,0 +1,220 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	clusterFake "github.com/vmware/octant/internal/cluster/fake"
+	pluginFake "github.com/vmware/octant/pkg/plugin/fake"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const serverTable = `{
+  "kind": "Table",
+  "apiVersion": "meta.k8s.io/v1",
+  "columnDefinitions": [
+    {"name": "Name", "type": "string", "format": "name", "priority": 0},
+    {"name": "Reference", "type": "string", "format": "", "priority": 0},
+    {"name": "Targets", "type": "string", "format": "", "priority": 0},
+    {"name": "Replicas", "type": "integer", "format": "", "priority": 0},
+    {"name": "Extra", "type": "string", "format": "", "priority": 1}
+  ],
+  "rows": [
+    {
+      "cells": ["hpa", "Deployment/app", null, 3, "extra"],
+      "object": {
+        "kind": "PartialObjectMetadata",
+        "apiVersion": "meta.k8s.io/v1beta1",
+        "metadata": {"name": "hpa", "namespace": "default"}
+      }
+    },
+    {
+      "cells": ["filtered", "Deployment/other", null, 1, "extra"],
+      "object": {
+        "kind": "PartialObjectMetadata",
+        "apiVersion": "meta.k8s.io/v1beta1",
+        "metadata": {"name": "filtered", "namespace": "default"}
+      }
+    }
+  ]
+}`
+
+func TestServerSideTable(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	var gotPath, gotAccept, gotSelector string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAccept = r.Header.Get("Accept")
+		gotSelector = r.URL.Query().Get("labelSelector")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, serverTable)
+	}))
+	defer server.Close()
+
+	restClient, err := rest.RESTClientFor(&rest.Config{
+		Host: server.URL,
+		ContentConfig: rest.ContentConfig{
+			GroupVersion:         &schema.GroupVersion{Version: "v1"},
+			NegotiatedSerializer: scheme.Codecs,
+		},
+	})
+	require.NoError(t, err)
+
+	tpo := newTestPrinterOptions(controller)
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	tpo.dashConfig.EXPECT().ClusterClient().Return(clusterClient)
+
+	gk := schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"}
+	gvr := schema.GroupVersionResource{Group: "autoscaling", Version: "v2beta2", Resource: "horizontalpodautoscalers"}
+	clusterClient.EXPECT().Resource(gk).Return(gvr, nil)
+	clusterClient.EXPECT().RESTClient().Return(restClient, nil)
+
+	tpo.PathForGVK("default", "autoscaling/v1", "HorizontalPodAutoscaler", "hpa", "hpa", "/hpa")
+
+	selector := labels.Set{"app": "app"}
+	key := store.Key{
+		Namespace:  "default",
+		APIVersion: "autoscaling/v1",
+		Kind:       "HorizontalPodAutoscaler",
+		Selector:   &selector,
+	}
+
+	// objects which weren't loaded, e.g. because they were filtered out,
+	// aren't printed.
+	hpa := &unstructured.Unstructured{}
+	hpa.SetNamespace("default")
+	hpa.SetName("hpa")
+	objects := []*unstructured.Unstructured{hpa}
+
+	got, err := ServerSideTable(context.Background(), key, objects, tpo.ToOptions())
+	require.NoError(t, err)
+
+	assert.Equal(t, "/apis/autoscaling/v1/namespaces/default/horizontalpodautoscalers", gotPath)
+	assert.Equal(t, tableAcceptHeader, gotAccept)
+	assert.Equal(t, "app=app", gotSelector)
+
+	cols := component.NewTableCols("Name", "Reference", "Targets", "Replicas")
+	expected := component.NewTable("autoscaling/v1, Kind=HorizontalPodAutoscaler", cols)
+	expected.Add(component.TableRow{
+		"Name":      component.NewLink("", "hpa", "/hpa"),
+		"Reference": component.NewText("Deployment/app"),
+		"Targets":   component.NewText("<none>"),
+		"Replicas":  component.NewText("3"),
+	})
+
+	assert.Equal(t, expected, got)
+}
+
+func TestResource_Print_server_side_table(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	var gotPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, serverTable)
+	}))
+	defer server.Close()
+
+	restClient, err := rest.RESTClientFor(&rest.Config{
+		Host: server.URL,
+		ContentConfig: rest.ContentConfig{
+			GroupVersion:         &schema.GroupVersion{Version: "v1"},
+			NegotiatedSerializer: scheme.Codecs,
+		},
+	})
+	require.NoError(t, err)
+
+	tpo := newTestPrinterOptions(controller)
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().MetricsClient().Return(nil, errors.New("unavailable")).AnyTimes()
+	tpo.dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+
+	gk := schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"}
+	gvr := schema.GroupVersionResource{Group: "autoscaling", Version: "v1", Resource: "horizontalpodautoscalers"}
+	clusterClient.EXPECT().Resource(gk).Return(gvr, nil)
+	clusterClient.EXPECT().RESTClient().Return(restClient, nil)
+
+	tpo.dashConfig.EXPECT().
+		ObjectPath("default", "autoscaling/v1", "HorizontalPodAutoscaler", "hpa").
+		Return("/hpa", nil)
+
+	// no handler is registered for HorizontalPodAutoscalerList.
+	list := &autoscalingv1.HorizontalPodAutoscalerList{
+		Items: []autoscalingv1.HorizontalPodAutoscaler{
+			{ObjectMeta: metav1.ObjectMeta{Name: "hpa", Namespace: "default"}},
+		},
+	}
+
+	p := NewResource(tpo.dashConfig)
+
+	got, err := p.Print(context.Background(), list, pluginFake.NewMockManagerInterface(controller))
+	require.NoError(t, err)
+
+	assert.Equal(t, "/apis/autoscaling/v1/namespaces/default/horizontalpodautoscalers", gotPath)
+
+	cols := component.NewTableCols("Name", "Reference", "Targets", "Replicas")
+	expected := component.NewTable("autoscaling/v1, Kind=HorizontalPodAutoscaler", cols)
+	expected.Add(component.TableRow{
+		"Name":      component.NewLink("", "hpa", "/hpa"),
+		"Reference": component.NewText("Deployment/app"),
+		"Targets":   component.NewText("<none>"),
+		"Replicas":  component.NewText("3"),
+	})
+
+	assert.Equal(t, expected, got)
+}
+
+func TestServerSideTable_not_a_table(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"kind": "PodList", "apiVersion": "v1", "items": []}`)
+	}))
+	defer server.Close()
+
+	restClient, err := rest.RESTClientFor(&rest.Config{
+		Host: server.URL,
+		ContentConfig: rest.ContentConfig{
+			GroupVersion:         &schema.GroupVersion{Version: "v1"},
+			NegotiatedSerializer: scheme.Codecs,
+		},
+	})
+	require.NoError(t, err)
+
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	_, err = fetchTable(context.Background(), restClient, gvr, store.Key{APIVersion: "v1", Kind: "Pod"})
+	require.Error(t, err)
+}
@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 
+	"github.com/vmware/octant/internal/portforward"
 	"github.com/vmware/octant/pkg/store"
 	"github.com/vmware/octant/pkg/view/component"
 )
@@ -75,11 +76,84 @@ func ServiceHandler(ctx context.Context, service *corev1.Service, options Option
 		Width: component.WidthFull,
 	})
 
+	// services without selectors don't have pods to forward to.
+	if options.DashConfig != nil && len(service.Spec.Selector) > 0 {
+		o.RegisterItems(ItemDescriptor{
+			Func: func() (component.Component, error) {
+				return servicePortForwards(service, options.DashConfig.PortForwarder())
+			},
+			Width: component.WidthHalf,
+		})
+	}
+
 	o.EnableEvents()
 
 	return o.ToComponent(ctx, options)
 }
 
+// servicePortForwards lists the TCP ports of a service so they can be
+// forwarded and opened through the port forward proxy.
+func servicePortForwards(service *corev1.Service, portForwarder portforward.PortForwarder) (*component.Summary, error) {
+	if service == nil {
+		return nil, errors.New("service is nil")
+	}
+
+	if portForwarder == nil {
+		return nil, errors.New("port forwarder is nil")
+	}
+
+	gvk := corev1.SchemeGroupVersion.WithKind("Service")
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+
+	state, err := portForwarder.Find(service.Namespace, gvk, service.Name)
+	isForwarded := err == nil
+	if err != nil {
+		if _, ok := err.(notFound); !ok {
+			return nil, errors.Wrap(err, "query port forward service for service")
+		}
+	}
+
+	var ports []component.Port
+	for _, servicePort := range service.Spec.Ports {
+		protocol := servicePort.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		if protocol != corev1.ProtocolTCP {
+			continue
+		}
+
+		pfs := component.PortForwardState{
+			IsForwardable: true,
+		}
+
+		if isForwarded {
+			for _, forwarded := range state.Ports {
+				if int32(forwarded.Remote) == servicePort.Port {
+					pfs.IsForwarded = true
+					pfs.ID = state.ID
+					pfs.Port = int(forwarded.Local)
+					pfs.ProxyURL = portforward.ProxyURL(state.ID, forwarded.Remote)
+				}
+			}
+		}
+
+		ports = append(ports, *component.NewPort(
+			service.Namespace,
+			apiVersion,
+			kind,
+			service.Name,
+			int(servicePort.Port),
+			string(protocol),
+			pfs))
+	}
+
+	return component.NewSummary("Port Forwards", component.SummarySection{
+		Header:  "Ports",
+		Content: component.NewPorts(ports),
+	}), nil
+}
+
 func printServicePorts(ports []corev1.ServicePort) component.Component {
 	out := make([]string, len(ports))
 	for i, port := range ports {
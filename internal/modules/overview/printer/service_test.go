@@ -19,6 +19,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
+	"github.com/vmware/octant/internal/portforward"
+	pffake "github.com/vmware/octant/internal/portforward/fake"
+	"github.com/vmware/octant/internal/testutil"
 	"github.com/vmware/octant/pkg/store"
 	"github.com/vmware/octant/pkg/view/component"
 )
@@ -318,3 +321,81 @@ func toUnstructured(t *testing.T, object runtime.Object) *unstructured.Unstructu
 
 	return &unstructured.Unstructured{Object: m}
 }
+
+type portForwardNotFound struct{}
+
+func (e *portForwardNotFound) Error() string {
+	return "port forward not found"
+}
+
+func (e *portForwardNotFound) NotFound() bool {
+	return true
+}
+
+func Test_servicePortForwards(t *testing.T) {
+	service := testutil.CreateService("service")
+	service.Spec.Ports = []corev1.ServicePort{
+		{Port: 80, Protocol: corev1.ProtocolTCP},
+		{Port: 443},
+		{Port: 53, Protocol: corev1.ProtocolUDP},
+	}
+
+	gvk := corev1.SchemeGroupVersion.WithKind("Service")
+
+	tests := []struct {
+		name     string
+		state    portforward.State
+		err      error
+		expected []component.Port
+	}{
+		{
+			name: "not forwarded",
+			err:  &portForwardNotFound{},
+			expected: []component.Port{
+				*component.NewPort("namespace", "v1", "Service", "service", 80, "TCP",
+					component.PortForwardState{IsForwardable: true}),
+				*component.NewPort("namespace", "v1", "Service", "service", 443, "TCP",
+					component.PortForwardState{IsForwardable: true}),
+			},
+		},
+		{
+			name: "forwarded",
+			state: portforward.State{
+				ID:    "id",
+				Ports: []portforward.ForwardedPort{{Local: 8080, Remote: 80}},
+			},
+			expected: []component.Port{
+				*component.NewPort("namespace", "v1", "Service", "service", 80, "TCP",
+					component.PortForwardState{
+						IsForwardable: true,
+						IsForwarded:   true,
+						ID:            "id",
+						Port:          8080,
+						ProxyURL:      "/proxy/port-forwards/id/80/",
+					}),
+				*component.NewPort("namespace", "v1", "Service", "service", 443, "TCP",
+					component.PortForwardState{IsForwardable: true}),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			pf := pffake.NewMockPortForwarder(controller)
+			pf.EXPECT().Find("namespace", gvk, "service").Return(test.state, test.err)
+
+			got, err := servicePortForwards(service, pf)
+			require.NoError(t, err)
+
+			expected := component.NewSummary("Port Forwards", component.SummarySection{
+				Header:  "Ports",
+				Content: component.NewPorts(test.expected),
+			})
+
+			assert.Equal(t, expected, got)
+		})
+	}
+}
@@ -16,13 +16,16 @@ import (
 )
 
 // StatefulSetListHandler is a printFunc that list stateful sets
-func StatefulSetListHandler(_ context.Context, list *appsv1.StatefulSetList, options Options) (component.Component, error) {
+func StatefulSetListHandler(ctx context.Context, list *appsv1.StatefulSetList, options Options) (component.Component, error) {
 	if list == nil {
 		return nil, errors.New("nil list")
 	}
 
 	cols := component.NewTableCols("Name", "Labels", "Desired", "Current", "Age", "Selector")
-	tbl := component.NewTable("StatefulSets", cols)
+
+	usage := loadPodUsage(ctx, list, options)
+
+	tbl := component.NewTable("StatefulSets", usage.Columns(cols))
 
 	for _, statefulSet := range list.Items {
 		row := component.TableRow{}
@@ -45,6 +48,8 @@ func StatefulSetListHandler(_ context.Context, list *appsv1.StatefulSetList, opt
 
 		row["Selector"] = printSelector(statefulSet.Spec.Selector)
 
+		usage.AddSelectorUsage(row, statefulSet.Namespace, statefulSet.Spec.Selector)
+
 		tbl.Add(row)
 	}
 
@@ -0,0 +1,196 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kLabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// resourceGraph describes the resource graph for a namespace. The graph is
+// built by visiting every top level workload, service and ingress in the
+// namespace.
+type resourceGraph struct {
+	path           string
+	resourceViewer appResourceViewerFunc
+}
+
+var _ describer.Describer = (*resourceGraph)(nil)
+
+func newResourceGraph(path string) *resourceGraph {
+	return &resourceGraph{
+		path:           path,
+		resourceViewer: createApplicationResourceViewer,
+	}
+}
+
+// PathFilters returns the path filters for the resource graph. The kinds
+// field is a comma separated list of kinds to include in the graph.
+func (rg *resourceGraph) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter(rg.path, rg),
+		*describer.NewPathFilter(fmt.Sprintf("%s/(?P<kinds>[^/]+)", rg.path), rg),
+	}
+}
+
+// Describe describes the resource graph for a namespace. Objects can be
+// filtered by kind using the kinds field and by label using the label set.
+func (rg *resourceGraph) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	kinds := parseGraphKinds(options.Fields["kinds"])
+
+	objects, available, err := listGraphObjects(ctx, options.ObjectStore(), namespace, options.LabelSet, kinds)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	title := component.Title(component.NewText("Resource Graph"))
+	cr := component.NewContentResponse(title)
+
+	var runtimeObjects []runtime.Object
+	for _, object := range objects {
+		runtimeObjects = append(runtimeObjects, object)
+	}
+
+	rv, err := rg.resourceViewer(ctx, runtimeObjects, options)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	summary := component.NewSummary("Graph",
+		component.SummarySection{
+			Header:  "Kinds",
+			Content: graphKindLinks(namespace, available, kinds),
+		},
+		component.SummarySection{
+			Header:  "Status",
+			Content: component.NewText(graphStatusSummary(rv)),
+		},
+	)
+	summary.SetAccessor("summary")
+	cr.Add(summary)
+
+	rv.SetAccessor("resourceViewer")
+	cr.Add(rv)
+
+	return *cr, nil
+}
+
+// listGraphObjects lists the top level workloads, services and ingresses in
+// a namespace which match the label set and kinds. It also returns the
+// sorted kinds which were found before filtering by kind.
+func listGraphObjects(ctx context.Context, objectStore store.Store, namespace string, labelSet *kLabels.Set, kinds map[string]bool) ([]*unstructured.Unstructured, []string, error) {
+	if objectStore == nil {
+		return nil, nil, errors.New("object store is nil")
+	}
+
+	var objects []*unstructured.Unstructured
+	available := make(map[string]bool)
+
+	for _, ak := range applicationObjectKeys {
+		if ak.category == appOther {
+			continue
+		}
+
+		key := ak.key
+		key.Namespace = namespace
+		key.Selector = labelSet
+
+		list, err := objectStore.List(ctx, key)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "list %s", key)
+		}
+
+		for _, object := range list {
+			if metav1.GetControllerOf(object) != nil {
+				continue
+			}
+
+			available[key.Kind] = true
+
+			if len(kinds) > 0 && !kinds[strings.ToLower(key.Kind)] {
+				continue
+			}
+
+			objects = append(objects, object)
+		}
+	}
+
+	var availableKinds []string
+	for kind := range available {
+		availableKinds = append(availableKinds, kind)
+	}
+	sort.Strings(availableKinds)
+
+	return objects, availableKinds, nil
+}
+
+// parseGraphKinds parses a comma separated list of kinds. Kinds are
+// compared case insensitively.
+func parseGraphKinds(s string) map[string]bool {
+	kinds := make(map[string]bool)
+	for _, kind := range strings.Split(s, ",") {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if kind != "" {
+			kinds[kind] = true
+		}
+	}
+
+	return kinds
+}
+
+// graphKindLinks creates links which filter the graph to a single kind.
+// The selected kinds are shown as text.
+func graphKindLinks(namespace string, available []string, selected map[string]bool) *component.List {
+	base := path.Join("/content/overview/namespace", namespace, "resource-graph")
+
+	var items []component.Component
+	if len(selected) == 0 {
+		items = append(items, component.NewText("All"))
+	} else {
+		items = append(items, component.NewLink("", "All", base))
+	}
+
+	for _, kind := range available {
+		if selected[strings.ToLower(kind)] {
+			items = append(items, component.NewText(kind))
+			continue
+		}
+		items = append(items, component.NewLink("", kind, path.Join(base, strings.ToLower(kind))))
+	}
+
+	return component.NewList("", items)
+}
+
+// graphStatusSummary counts the nodes in a graph by status.
+func graphStatusSummary(c component.Component) string {
+	rv, ok := c.(*component.ResourceViewer)
+	if !ok {
+		return ""
+	}
+
+	counts := make(map[component.NodeStatus]int)
+	for _, node := range rv.Config.Nodes {
+		counts[node.Status]++
+	}
+
+	return fmt.Sprintf("%d ok, %d warning, %d error",
+		counts[component.NodeStatusOK],
+		counts[component.NodeStatusWarning],
+		counts[component.NodeStatusError])
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kLabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func Test_resourceGraph(t *testing.T) {
+	cases := []struct {
+		name          string
+		fields        map[string]string
+		expectedNames []string
+		expectedKinds *component.List
+	}{
+		{
+			name:          "all kinds",
+			fields:        map[string]string{},
+			expectedNames: []string{"web", "web-2", "unlabeled", "db", "web"},
+			expectedKinds: component.NewList("", []component.Component{
+				component.NewText("All"),
+				component.NewLink("", "Deployment", "/content/overview/namespace/default/resource-graph/deployment"),
+				component.NewLink("", "Service", "/content/overview/namespace/default/resource-graph/service"),
+				component.NewLink("", "StatefulSet", "/content/overview/namespace/default/resource-graph/statefulset"),
+			}),
+		},
+		{
+			name:          "filtered by kind",
+			fields:        map[string]string{"kinds": "StatefulSet, service"},
+			expectedNames: []string{"db", "web"},
+			expectedKinds: component.NewList("", []component.Component{
+				component.NewLink("", "All", "/content/overview/namespace/default/resource-graph"),
+				component.NewLink("", "Deployment", "/content/overview/namespace/default/resource-graph/deployment"),
+				component.NewText("Service"),
+				component.NewText("StatefulSet"),
+			}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().ObjectStore().Return(mockApplicationStore(t, controller)).AnyTimes()
+
+			options := describer.Options{
+				Dash:   dashConfig,
+				Fields: tc.fields,
+			}
+
+			var visited []string
+
+			d := newResourceGraph("/resource-graph")
+			d.resourceViewer = func(ctx context.Context, objects []runtime.Object, options describer.Options) (component.Component, error) {
+				for _, object := range objects {
+					accessor, err := meta.Accessor(object)
+					require.NoError(t, err)
+					visited = append(visited, accessor.GetName())
+				}
+
+				rv := component.NewResourceViewer("Resource Viewer")
+				rv.AddNode("1", component.Node{Status: component.NodeStatusOK})
+				rv.AddNode("2", component.Node{Status: component.NodeStatusWarning})
+				return rv, nil
+			}
+
+			got, err := d.Describe(context.Background(), "/prefix", "default", options)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedNames, visited)
+			require.Len(t, got.Components, 2)
+
+			summary, ok := got.Components[0].(*component.Summary)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedKinds, summary.Config.Sections[0].Content)
+			assert.Equal(t, component.NewText("1 ok, 1 warning, 0 error"), summary.Config.Sections[1].Content)
+
+			assert.Equal(t, "resourceViewer", got.Components[1].GetMetadata().Accessor)
+		})
+	}
+}
+
+func Test_listGraphObjects_label_set(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	labelSet := &kLabels.Set{"app": "web"}
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key store.Key) ([]*unstructured.Unstructured, error) {
+			assert.Equal(t, labelSet, key.Selector)
+			assert.Equal(t, "default", key.Namespace)
+			return nil, nil
+		}).AnyTimes()
+
+	objects, kinds, err := listGraphObjects(context.Background(), objectStore, "default", labelSet, nil)
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+	assert.Empty(t, kinds)
+}
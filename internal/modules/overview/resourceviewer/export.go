@@ -0,0 +1,326 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resourceviewer
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// Graph is an exportable resource graph.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a node in an exported graph. Nodes are identified by the
+// object key of the object they represent.
+type GraphNode struct {
+	ID         string               `json:"id"`
+	Namespace  string               `json:"namespace,omitempty"`
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Name       string               `json:"name"`
+	Status     component.NodeStatus `json:"status,omitempty"`
+	Path       string               `json:"path,omitempty"`
+}
+
+// GraphEdge is a directed edge in an exported graph.
+type GraphEdge struct {
+	From  string             `json:"from"`
+	To    string             `json:"to"`
+	Type  component.EdgeType `json:"type"`
+	Label string             `json:"label,omitempty"`
+}
+
+// NewGraph creates an exportable graph from a resource viewer. Nodes and
+// edges are sorted so exports are stable.
+func NewGraph(rv *component.ResourceViewer) (*Graph, error) {
+	if rv == nil {
+		return nil, errors.New("resource viewer is nil")
+	}
+
+	g := &Graph{
+		Nodes: []GraphNode{},
+		Edges: []GraphEdge{},
+	}
+
+	for id, node := range rv.Config.Nodes {
+		gn := GraphNode{
+			ID:         id,
+			Namespace:  node.Namespace,
+			APIVersion: node.APIVersion,
+			Kind:       node.Kind,
+			Name:       node.Name,
+			Status:     node.Status,
+		}
+		if node.Path != nil {
+			gn.Path = node.Path.Config.Ref
+		}
+
+		g.Nodes = append(g.Nodes, gn)
+	}
+
+	for from, edges := range rv.Config.Edges {
+		for _, edge := range edges {
+			g.Edges = append(g.Edges, GraphEdge{
+				From:  from,
+				To:    edge.Node,
+				Type:  edge.Type,
+				Label: edge.Label,
+			})
+		}
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+
+	return g, nil
+}
+
+// DOT returns the graph in the graphviz DOT language.
+func (g *Graph) DOT() string {
+	var buf bytes.Buffer
+
+	buf.WriteString("digraph resources {\n")
+	buf.WriteString("  rankdir=LR;\n")
+	buf.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	buf.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	for _, node := range g.Nodes {
+		colors := statusColors(node.Status)
+		fmt.Fprintf(&buf, "  %s [label=%s, color=%s, fillcolor=%s];\n",
+			dotQuote(node.ID),
+			dotQuote(node.Kind+"\n"+node.Name),
+			dotQuote(colors.stroke),
+			dotQuote(colors.fill))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&buf, "  %s -> %s", dotQuote(edge.From), dotQuote(edge.To))
+		if edge.Label != "" {
+			fmt.Fprintf(&buf, " [label=%s]", dotQuote(edge.Label))
+		}
+		buf.WriteString(";\n")
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+func dotQuote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+type nodeColors struct {
+	fill   string
+	stroke string
+}
+
+func statusColors(status component.NodeStatus) nodeColors {
+	switch status {
+	case component.NodeStatusOK:
+		return nodeColors{fill: "#e6f4ea", stroke: "#34a853"}
+	case component.NodeStatusWarning:
+		return nodeColors{fill: "#fef7e0", stroke: "#f9ab00"}
+	case component.NodeStatusError:
+		return nodeColors{fill: "#fce8e6", stroke: "#ea4335"}
+	default:
+		return nodeColors{fill: "#f1f3f4", stroke: "#9aa0a6"}
+	}
+}
+
+const (
+	svgNodeWidth     = 200
+	svgNodeHeight    = 48
+	svgColumnSpacing = 100
+	svgRowSpacing    = 24
+	svgMargin        = 20
+	svgMaxNameLength = 28
+)
+
+// SVG renders the graph as a standalone SVG document. Nodes are placed in
+// columns by their distance from a root node and edges are drawn left to
+// right.
+func (g *Graph) SVG() []byte {
+	positions, width, height := g.layout()
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		width, height, width, height)
+	buf.WriteString(`  <defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="#5f6368"/></marker></defs>` + "\n")
+
+	for _, edge := range g.Edges {
+		from, ok := positions[edge.From]
+		if !ok {
+			continue
+		}
+		to, ok := positions[edge.To]
+		if !ok {
+			continue
+		}
+
+		x1, y1 := from.x+svgNodeWidth, from.y+svgNodeHeight/2
+		x2, y2 := to.x, to.y+svgNodeHeight/2
+		if x2 <= x1 {
+			// edges pointing to an earlier column leave from the left side.
+			x1 = from.x
+			x2 = to.x + svgNodeWidth
+		}
+		mid := (x1 + x2) / 2
+
+		fmt.Fprintf(&buf, `  <path d="M%d,%d C%d,%d %d,%d %d,%d" fill="none" stroke="#5f6368" marker-end="url(#arrow)"/>`+"\n",
+			x1, y1, mid, y1, mid, y2, x2, y2)
+
+		if edge.Label != "" {
+			fmt.Fprintf(&buf, `  <text x="%d" y="%d" font-size="10" text-anchor="middle" fill="#5f6368">%s</text>`+"\n",
+				mid, (y1+y2)/2-4, html.EscapeString(edge.Label))
+		}
+	}
+
+	for _, node := range g.Nodes {
+		p := positions[node.ID]
+		colors := statusColors(node.Status)
+
+		fmt.Fprintf(&buf, `  <g><title>%s</title>`, html.EscapeString(node.Kind+" "+node.Name))
+		fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d" rx="6" ry="6" fill="%s" stroke="%s"/>`,
+			p.x, p.y, svgNodeWidth, svgNodeHeight, colors.fill, colors.stroke)
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" font-size="10" fill="#5f6368">%s</text>`,
+			p.x+10, p.y+18, html.EscapeString(node.Kind))
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" font-size="13" fill="#202124">%s</text></g>`+"\n",
+			p.x+10, p.y+36, html.EscapeString(truncate(node.Name, svgMaxNameLength)))
+	}
+
+	buf.WriteString("</svg>\n")
+
+	return buf.Bytes()
+}
+
+type point struct {
+	x int
+	y int
+}
+
+// layout places nodes in columns using the longest path from a node
+// without incoming edges. Nodes in a column are ordered by the average row
+// of their parents to reduce crossings.
+func (g *Graph) layout() (map[string]point, int, int) {
+	column := make(map[string]int)
+	parents := make(map[string][]string)
+	for _, node := range g.Nodes {
+		column[node.ID] = 0
+	}
+	for _, edge := range g.Edges {
+		parents[edge.To] = append(parents[edge.To], edge.From)
+	}
+
+	// relax edges at most once per node so cycles can't loop forever.
+	for i := 0; i < len(g.Nodes); i++ {
+		changed := false
+		for _, edge := range g.Edges {
+			from, ok := column[edge.From]
+			if !ok {
+				continue
+			}
+			if to, ok := column[edge.To]; ok && to < from+1 {
+				column[edge.To] = from + 1
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	maxColumn := 0
+	for _, c := range column {
+		if c > maxColumn {
+			maxColumn = c
+		}
+	}
+
+	columns := make([][]string, maxColumn+1)
+	for _, node := range g.Nodes {
+		c := column[node.ID]
+		columns[c] = append(columns[c], node.ID)
+	}
+
+	row := make(map[string]int)
+	maxRows := 0
+	for _, ids := range columns {
+		barycenter := make(map[string]float64)
+		for _, id := range ids {
+			total, count := 0, 0
+			for _, parent := range parents[id] {
+				if r, ok := row[parent]; ok {
+					total += r
+					count++
+				}
+			}
+			if count > 0 {
+				barycenter[id] = float64(total) / float64(count)
+			}
+		}
+
+		sort.SliceStable(ids, func(i, j int) bool {
+			if barycenter[ids[i]] != barycenter[ids[j]] {
+				return barycenter[ids[i]] < barycenter[ids[j]]
+			}
+			return ids[i] < ids[j]
+		})
+
+		for i, id := range ids {
+			row[id] = i
+		}
+
+		if len(ids) > maxRows {
+			maxRows = len(ids)
+		}
+	}
+
+	positions := make(map[string]point)
+	for id, c := range column {
+		positions[id] = point{
+			x: svgMargin + c*(svgNodeWidth+svgColumnSpacing),
+			y: svgMargin + row[id]*(svgNodeHeight+svgRowSpacing),
+		}
+	}
+
+	width := 2*svgMargin + (maxColumn+1)*svgNodeWidth + maxColumn*svgColumnSpacing
+	height := 2*svgMargin + maxRows*svgNodeHeight
+	if maxRows > 0 {
+		height += (maxRows - 1) * svgRowSpacing
+	}
+
+	return positions, width, height
+}
+
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+
+	return string(r[:max-1]) + "…"
+}
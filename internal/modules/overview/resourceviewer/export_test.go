@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resourceviewer
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func exportResourceViewer(t *testing.T) *component.ResourceViewer {
+	rv := component.NewResourceViewer("Resource Viewer")
+	rv.AddNode("deployment", component.Node{
+		Name:       "web",
+		Namespace:  "default",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Status:     component.NodeStatusOK,
+		Path:       component.NewLink("", "web", "/deployment"),
+	})
+	rv.AddNode("replica-set", component.Node{
+		Name:       "web-123",
+		Namespace:  "default",
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Status:     component.NodeStatusWarning,
+	})
+	rv.AddNode("web-123 pods", component.Node{
+		Name:       `web-123 "pods"`,
+		Namespace:  "default",
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Status:     component.NodeStatusError,
+	})
+
+	require.NoError(t, rv.AddLabeledEdge("deployment", "replica-set", component.EdgeTypeExplicit, "owner"))
+	require.NoError(t, rv.AddLabeledEdge("replica-set", "web-123 pods", component.EdgeTypeExplicit, "owner"))
+
+	return rv
+}
+
+func TestNewGraph(t *testing.T) {
+	rv := exportResourceViewer(t)
+	rv.AddNode("cluster-role", component.Node{
+		Name:       "view",
+		APIVersion: "rbac.authorization.k8s.io/v1",
+		Kind:       "ClusterRole",
+		Status:     component.NodeStatusOK,
+	})
+
+	got, err := NewGraph(rv)
+	require.NoError(t, err)
+
+	expected := `
+{
+  "nodes": [
+    {"id": "cluster-role", "apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "name": "view", "status": "ok"},
+    {"id": "deployment", "namespace": "default", "apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "status": "ok", "path": "/deployment"},
+    {"id": "replica-set", "namespace": "default", "apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "web-123", "status": "warning"},
+    {"id": "web-123 pods", "namespace": "default", "apiVersion": "v1", "kind": "Pod", "name": "web-123 \"pods\"", "status": "error"}
+  ],
+  "edges": [
+    {"from": "deployment", "to": "replica-set", "type": "explicit", "label": "owner"},
+    {"from": "replica-set", "to": "web-123 pods", "type": "explicit", "label": "owner"}
+  ]
+}`
+
+	data, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, string(data))
+
+	_, err = NewGraph(nil)
+	require.Error(t, err)
+}
+
+func TestGraph_DOT(t *testing.T) {
+	g, err := NewGraph(exportResourceViewer(t))
+	require.NoError(t, err)
+
+	expected := `digraph resources {
+  rankdir=LR;
+  node [shape=box, style="rounded,filled", fontname="Helvetica"];
+  edge [fontname="Helvetica", fontsize=10];
+  "deployment" [label="Deployment\nweb", color="#34a853", fillcolor="#e6f4ea"];
+  "replica-set" [label="ReplicaSet\nweb-123", color="#f9ab00", fillcolor="#fef7e0"];
+  "web-123 pods" [label="Pod\nweb-123 \"pods\"", color="#ea4335", fillcolor="#fce8e6"];
+  "deployment" -> "replica-set" [label="owner"];
+  "replica-set" -> "web-123 pods" [label="owner"];
+}
+`
+	assert.Equal(t, expected, g.DOT())
+}
+
+func TestGraph_SVG(t *testing.T) {
+	g, err := NewGraph(exportResourceViewer(t))
+	require.NoError(t, err)
+
+	got := g.SVG()
+
+	// the document must be well formed XML.
+	decoder := xml.NewDecoder(strings.NewReader(string(got)))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			require.Equal(t, io.EOF, err)
+			break
+		}
+	}
+
+	svg := string(got)
+	assert.Contains(t, svg, `width="840" height="88"`)
+	assert.Contains(t, svg, `web-123 &#34;pods&#34;`)
+	assert.Equal(t, 3, strings.Count(svg, "<rect"))
+	assert.Equal(t, 2, strings.Count(svg, `marker-end="url(#arrow)"`))
+}
+
+func TestGraph_layout(t *testing.T) {
+	g := &Graph{
+		Nodes: []GraphNode{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}},
+		Edges: []GraphEdge{
+			{From: "a", To: "b"},
+			{From: "b", To: "c"},
+			{From: "a", To: "c"},
+			{From: "c", To: "a"},
+		},
+	}
+
+	positions, width, height := g.layout()
+	assert.Len(t, positions, 4)
+	assert.True(t, width > 0)
+	assert.True(t, height > 0)
+	assert.Equal(t, svgMargin, positions["d"].x)
+}
+
+func Test_truncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+	assert.Equal(t, "abcd…", truncate("abcdefgh", 5))
+}
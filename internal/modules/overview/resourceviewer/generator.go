@@ -37,7 +37,7 @@ func GenerateComponent(ctx context.Context, details Details, selected types.UID)
 	for k, list := range *edges {
 		for i := range list {
 			item := list[i]
-			if err := rv.AddEdge(k, item.Node, item.Type); err != nil {
+			if err := rv.AddLabeledEdge(k, item.Node, item.Type, item.Label); err != nil {
 				return nil, err
 			}
 		}
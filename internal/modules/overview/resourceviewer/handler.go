@@ -42,7 +42,7 @@ type Handler struct {
 	link          link.Interface
 	pluginPrinter plugin.ManagerInterface
 
-	adjList map[types.UID][]runtime.Object
+	adjList map[types.UID][]adjacentObject
 	nodes   map[types.UID]runtime.Object
 
 	mu           sync.Mutex
@@ -51,6 +51,12 @@ type Handler struct {
 
 var _ objectvisitor.ObjectHandler = (*Handler)(nil)
 
+// adjacentObject is an object connected to a node and how they are related.
+type adjacentObject struct {
+	object runtime.Object
+	label  objectvisitor.EdgeLabel
+}
+
 // NewHandler creates an instance of Handler.
 func NewHandler(dashConfig config.Dash, options ...HandlerOption) (*Handler, error) {
 	l, err := link.NewFromDashConfig(dashConfig)
@@ -62,7 +68,7 @@ func NewHandler(dashConfig config.Dash, options ...HandlerOption) (*Handler, err
 		objectStore:   dashConfig.ObjectStore(),
 		link:          l,
 		pluginPrinter: dashConfig.PluginManager(),
-		adjList:       make(map[types.UID][]runtime.Object),
+		adjList:       make(map[types.UID][]adjacentObject),
 		nodes:         make(map[types.UID]runtime.Object),
 		objectStatus:  NewHandlerObjectStatus(dashConfig.ObjectStore(), dashConfig.PluginManager()),
 	}
@@ -75,7 +81,7 @@ func NewHandler(dashConfig config.Dash, options ...HandlerOption) (*Handler, err
 }
 
 // AddEdge adds edges to the graph.
-func (h *Handler) AddEdge(v1, v2 runtime.Object) error {
+func (h *Handler) AddEdge(v1, v2 runtime.Object, label objectvisitor.EdgeLabel) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -88,7 +94,7 @@ func (h *Handler) AddEdge(v1, v2 runtime.Object) error {
 	h.nodes[v1UID] = v1
 
 	cur := h.adjList[v1UID]
-	cur = append(cur, v2)
+	cur = append(cur, adjacentObject{object: v2, label: label})
 	h.adjList[v1UID] = cur
 
 	return nil
@@ -137,7 +143,7 @@ func (h *Handler) AdjacencyList() (*component.AdjList, error) {
 			}
 
 			for i := range connections {
-				child := connections[i]
+				child := connections[i].object
 
 				edges, ok := podGroupEdges[name]
 				if !ok {
@@ -160,8 +166,9 @@ func (h *Handler) AdjacencyList() (*component.AdjList, error) {
 
 				id := string(childAccessor.GetUID())
 				edges[id] = component.Edge{
-					Node: id,
-					Type: component.EdgeTypeExplicit,
+					Node:  id,
+					Type:  component.EdgeTypeExplicit,
+					Label: string(connections[i].label),
 				}
 
 				podGroupEdges[name] = edges
@@ -173,7 +180,8 @@ func (h *Handler) AdjacencyList() (*component.AdjList, error) {
 		edgeMap := make(map[string]component.Edge)
 
 		for i := range connections {
-			child := connections[i]
+			child := connections[i].object
+			label := string(connections[i].label)
 
 			inGroup, err := isPodInGroup(parent)
 			if err != nil {
@@ -187,8 +195,9 @@ func (h *Handler) AdjacencyList() (*component.AdjList, error) {
 				}
 
 				edge := component.Edge{
-					Node: name,
-					Type: component.EdgeTypeExplicit,
+					Node:  name,
+					Type:  component.EdgeTypeExplicit,
+					Label: label,
 				}
 
 				edgeMap[name] = edge
@@ -213,8 +222,9 @@ func (h *Handler) AdjacencyList() (*component.AdjList, error) {
 			}
 
 			edge := component.Edge{
-				Node: name,
-				Type: component.EdgeTypeExplicit,
+				Node:  name,
+				Type:  component.EdgeTypeExplicit,
+				Label: label,
 			}
 
 			key := string(parentUID)
@@ -228,6 +238,24 @@ func (h *Handler) AdjacencyList() (*component.AdjList, error) {
 		}
 	}
 
+	for release, objects := range releaseGroups(h.nodes) {
+		key := releaseGroupName(release)
+		for _, object := range objects {
+			name, err := edgeName(object)
+			if err != nil {
+				if isSkippedNode(err) {
+					continue
+				}
+				return nil, err
+			}
+
+			adjList[key] = append(adjList[key], component.Edge{
+				Node: name,
+				Type: component.EdgeTypeExplicit,
+			})
+		}
+	}
+
 	adjList = deDupEdges(adjList)
 
 	return &adjList, nil
@@ -287,6 +315,18 @@ func (h *Handler) Nodes(ctx context.Context) (component.Nodes, error) {
 		nodes[podGroupName] = *group
 	}
 
+	for release, objects := range releaseGroups(h.nodes) {
+		rgn := releaseGroupNode{
+			link:         h.link,
+			objectStatus: h.objectStatus,
+		}
+		group, err := rgn.Create(ctx, release, objects)
+		if err != nil {
+			return nil, err
+		}
+		nodes[releaseGroupName(release)] = *group
+	}
+
 	return nodes, nil
 }
 
@@ -490,6 +530,8 @@ func deDupEdges(adjList component.AdjList) component.AdjList {
 
 	lookup := make(map[string]string)
 	edgeTypeLookup := make(map[string]component.EdgeType)
+	// labels are kept for both directions since only one direction survives.
+	labelLookup := make(map[[2]string]string)
 
 	var keys []string
 	for k := range adjList {
@@ -501,6 +543,9 @@ func deDupEdges(adjList component.AdjList) component.AdjList {
 		v := adjList[k]
 		for _, edge := range v {
 			edgeTypeLookup[edge.Node] = edge.Type
+			if edge.Label != "" {
+				labelLookup[[2]string{k, edge.Node}] = edge.Label
+			}
 
 			if lookup[k] == edge.Node {
 				continue
@@ -511,7 +556,11 @@ func deDupEdges(adjList component.AdjList) component.AdjList {
 	}
 
 	for k, v := range lookup {
-		list[v] = append(list[v], component.Edge{Node: k, Type: edgeTypeLookup[k]})
+		label, ok := labelLookup[[2]string{v, k}]
+		if !ok {
+			label = labelLookup[[2]string{k, v}]
+		}
+		list[v] = append(list[v], component.Edge{Node: k, Type: edgeTypeLookup[k], Label: label})
 	}
 
 	for k := range list {
@@ -14,7 +14,9 @@ import (
 	"k8s.io/utils/pointer"
 
 	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/helm"
 	"github.com/vmware/octant/internal/modules/overview/objectstatus"
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor"
 	"github.com/vmware/octant/internal/modules/overview/resourceviewer/fake"
 	"github.com/vmware/octant/internal/testutil"
 	pluginFake "github.com/vmware/octant/pkg/plugin/fake"
@@ -80,29 +82,29 @@ func TestHandler(t *testing.T) {
 	require.NoError(t, err)
 
 	ctx := context.Background()
-	mockRelation := func(a, b runtime.Object) {
-		require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, a), testutil.ToUnstructured(t, b)))
-		require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, b), testutil.ToUnstructured(t, a)))
+	mockRelation := func(a, b runtime.Object, label objectvisitor.EdgeLabel) {
+		require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, a), testutil.ToUnstructured(t, b), label))
+		require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, b), testutil.ToUnstructured(t, a), label))
 		require.NoError(t, handler.Process(ctx, testutil.ToUnstructured(t, a)))
 		require.NoError(t, handler.Process(ctx, testutil.ToUnstructured(t, b)))
 	}
 
-	mockRelation(cr, deployment)
-	mockRelation(deployment, replicaSet1)
-	mockRelation(deployment, replicaSet2)
-	mockRelation(deployment, replicaSet3)
-	mockRelation(replicaSet1, pod1)
-	mockRelation(replicaSet1, pod2)
-	mockRelation(replicaSet3, pod4)
-	mockRelation(service1, pod1)
-	mockRelation(service1, pod2)
-	mockRelation(service1, pod4)
+	mockRelation(cr, deployment, objectvisitor.EdgeLabelOwner)
+	mockRelation(deployment, replicaSet1, objectvisitor.EdgeLabelOwner)
+	mockRelation(deployment, replicaSet2, objectvisitor.EdgeLabelOwner)
+	mockRelation(deployment, replicaSet3, objectvisitor.EdgeLabelOwner)
+	mockRelation(replicaSet1, pod1, objectvisitor.EdgeLabelOwner)
+	mockRelation(replicaSet1, pod2, objectvisitor.EdgeLabelOwner)
+	mockRelation(replicaSet3, pod4, objectvisitor.EdgeLabelOwner)
+	mockRelation(service1, pod1, objectvisitor.EdgeLabelSelector)
+	mockRelation(service1, pod2, objectvisitor.EdgeLabelSelector)
+	mockRelation(service1, pod4, objectvisitor.EdgeLabelSelector)
 
 	require.NoError(t, handler.Process(ctx, pod3))
-	require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, pod1), testutil.ToUnstructured(t, serviceAccount)))
-	require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, pod2), testutil.ToUnstructured(t, serviceAccount)))
-	require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, pod3), testutil.ToUnstructured(t, serviceAccount)))
-	require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, pod4), testutil.ToUnstructured(t, serviceAccount)))
+	require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, pod1), testutil.ToUnstructured(t, serviceAccount), objectvisitor.EdgeLabelServiceAccount))
+	require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, pod2), testutil.ToUnstructured(t, serviceAccount), objectvisitor.EdgeLabelServiceAccount))
+	require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, pod3), testutil.ToUnstructured(t, serviceAccount), objectvisitor.EdgeLabelServiceAccount))
+	require.NoError(t, handler.AddEdge(testutil.ToUnstructured(t, pod4), testutil.ToUnstructured(t, serviceAccount), objectvisitor.EdgeLabelServiceAccount))
 	require.NoError(t, handler.Process(ctx, testutil.ToUnstructured(t, serviceAccount)))
 
 	mockLinkPath(t, dashConfig, cr)
@@ -118,21 +120,21 @@ func TestHandler(t *testing.T) {
 
 	expectedAdjList := &component.AdjList{
 		string(cr.UID): {
-			{Node: string(deployment.UID), Type: component.EdgeTypeExplicit},
+			{Node: string(deployment.UID), Type: component.EdgeTypeExplicit, Label: "owner"},
 		},
 		string(deployment.UID): {
-			{Node: string(replicaSet1.UID), Type: component.EdgeTypeExplicit},
-			{Node: string(replicaSet3.UID), Type: component.EdgeTypeExplicit},
+			{Node: string(replicaSet1.UID), Type: component.EdgeTypeExplicit, Label: "owner"},
+			{Node: string(replicaSet3.UID), Type: component.EdgeTypeExplicit, Label: "owner"},
 		},
 		string(replicaSet3.UID): {
-			{Node: fmt.Sprintf("%s pods", replicaSet3.Name), Type: component.EdgeTypeExplicit},
+			{Node: fmt.Sprintf("%s pods", replicaSet3.Name), Type: component.EdgeTypeExplicit, Label: "owner"},
 		},
 		fmt.Sprintf("%s pods", replicaSet3.Name): {
-			{Node: string(serviceAccount.UID), Type: component.EdgeTypeExplicit},
-			{Node: string(service1.UID), Type: component.EdgeTypeExplicit},
+			{Node: string(serviceAccount.UID), Type: component.EdgeTypeExplicit, Label: "service account"},
+			{Node: string(service1.UID), Type: component.EdgeTypeExplicit, Label: "selector"},
 		},
 		string(service1.UID): {
-			{Node: fmt.Sprintf("%s pods", replicaSet1.Name), Type: component.EdgeTypeExplicit},
+			{Node: fmt.Sprintf("%s pods", replicaSet1.Name), Type: component.EdgeTypeExplicit, Label: "selector"},
 		},
 	}
 
@@ -157,6 +159,7 @@ func TestHandler(t *testing.T) {
 	expectedNodes := component.Nodes{
 		string(cr.UID): {
 			Name:       cr.Name,
+			Namespace:  cr.Namespace,
 			APIVersion: cr.APIVersion,
 			Kind:       cr.Kind,
 			Status:     component.NodeStatusOK,
@@ -164,6 +167,7 @@ func TestHandler(t *testing.T) {
 		},
 		string(deployment.UID): {
 			Name:       deployment.Name,
+			Namespace:  deployment.Namespace,
 			APIVersion: deployment.APIVersion,
 			Kind:       deployment.Kind,
 			Status:     component.NodeStatusOK,
@@ -171,6 +175,7 @@ func TestHandler(t *testing.T) {
 		},
 		string(replicaSet1.UID): {
 			Name:       replicaSet1.Name,
+			Namespace:  replicaSet1.Namespace,
 			APIVersion: "apps/v1",
 			Kind:       replicaSet1.Kind,
 			Status:     component.NodeStatusOK,
@@ -178,6 +183,7 @@ func TestHandler(t *testing.T) {
 		},
 		string(replicaSet3.UID): {
 			Name:       replicaSet3.Name,
+			Namespace:  replicaSet3.Namespace,
 			APIVersion: "extensions/v1beta1",
 			Kind:       replicaSet3.Kind,
 			Status:     component.NodeStatusOK,
@@ -185,6 +191,7 @@ func TestHandler(t *testing.T) {
 		},
 		string(pod3.UID): {
 			Name:       pod3.Name,
+			Namespace:  pod3.Namespace,
 			APIVersion: pod3.APIVersion,
 			Kind:       pod3.Kind,
 			Status:     component.NodeStatusOK,
@@ -192,6 +199,7 @@ func TestHandler(t *testing.T) {
 		},
 		fmt.Sprintf("%s pods", replicaSet1.Name): {
 			Name:       fmt.Sprintf("%s pods", replicaSet1.Name),
+			Namespace:  replicaSet1.Namespace,
 			APIVersion: "v1",
 			Kind:       "Pod",
 			Status:     component.NodeStatusOK,
@@ -199,6 +207,7 @@ func TestHandler(t *testing.T) {
 		},
 		fmt.Sprintf("%s pods", replicaSet3.Name): {
 			Name:       fmt.Sprintf("%s pods", replicaSet3.Name),
+			Namespace:  replicaSet3.Namespace,
 			APIVersion: "v1",
 			Kind:       "Pod",
 			Status:     component.NodeStatusOK,
@@ -206,6 +215,7 @@ func TestHandler(t *testing.T) {
 		},
 		string(serviceAccount.UID): {
 			Name:       serviceAccount.Name,
+			Namespace:  serviceAccount.Namespace,
 			APIVersion: serviceAccount.APIVersion,
 			Kind:       serviceAccount.Kind,
 			Status:     component.NodeStatusOK,
@@ -213,6 +223,7 @@ func TestHandler(t *testing.T) {
 		},
 		string(service1.UID): {
 			Name:       service1.Name,
+			Namespace:  service1.Namespace,
 			APIVersion: service1.APIVersion,
 			Kind:       service1.Kind,
 			Status:     component.NodeStatusOK,
@@ -226,6 +237,76 @@ func TestHandler(t *testing.T) {
 	testutil.AssertJSONEqual(t, expectedNodes, nodes)
 }
 
+func TestHandler_helm_release(t *testing.T) {
+	deployment := testutil.CreateDeployment("deployment")
+	deployment.Annotations = map[string]string{helm.ReleaseNameAnnotation: "app"}
+
+	service := testutil.CreateService("service")
+	service.Annotations = map[string]string{helm.ReleaseNameAnnotation: "app"}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+
+	objectStore := storeFake.NewMockStore(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+
+	pluginManager := pluginFake.NewMockManagerInterface(controller)
+	dashConfig.EXPECT().PluginManager().Return(pluginManager).AnyTimes()
+
+	warningStatus := &objectstatus.ObjectStatus{}
+	warningStatus.SetWarning()
+
+	objectStatus := fake.NewMockObjectStatus(controller)
+	objectStatus.EXPECT().
+		Status(gomock.Any(), testutil.ToUnstructured(t, deployment)).
+		Return(&objectstatus.ObjectStatus{}, nil).
+		AnyTimes()
+	objectStatus.EXPECT().
+		Status(gomock.Any(), testutil.ToUnstructured(t, service)).
+		Return(warningStatus, nil).
+		AnyTimes()
+
+	handler, err := NewHandler(dashConfig, SetHandlerObjectStatus(objectStatus))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, handler.Process(ctx, testutil.ToUnstructured(t, deployment)))
+	require.NoError(t, handler.Process(ctx, testutil.ToUnstructured(t, service)))
+
+	mockLinkPath(t, dashConfig, deployment)
+	mockLinkPath(t, dashConfig, service)
+	dashConfig.EXPECT().
+		ObjectPath(deployment.Namespace, "helm.sh/v3", "Release", "app").
+		Return("/app", nil)
+
+	list, err := handler.AdjacencyList()
+	require.NoError(t, err)
+
+	edges := (*list)["app release"]
+	assert.ElementsMatch(t, []component.Edge{
+		{Node: string(deployment.UID), Type: component.EdgeTypeExplicit},
+		{Node: string(service.UID), Type: component.EdgeTypeExplicit},
+	}, edges)
+
+	nodes, err := handler.Nodes(ctx)
+	require.NoError(t, err)
+
+	expected := component.Node{
+		Name:       "app",
+		Namespace:  "namespace",
+		APIVersion: "helm.sh/v3",
+		Kind:       "Release",
+		Status:     component.NodeStatusWarning,
+		Details: []component.Component{
+			component.NewText("Helm release with 2 objects"),
+		},
+		Path: component.NewLink("", "app", "/app"),
+	}
+	assert.Equal(t, expected, nodes["app release"])
+}
+
 func Test_edgeName(t *testing.T) {
 	replicaSet := testutil.CreateAppReplicaSet("replica-set")
 	replicaSetPod := testutil.CreatePod("pod")
@@ -314,18 +395,18 @@ func Test_isObjectParent(t *testing.T) {
 
 func Test_deDupEdges(t *testing.T) {
 	list := component.AdjList{
-		"pod group":       []component.Edge{{Node: "service-account"}, {Node: "service"}, {Node: "replica-set"}},
+		"pod group":       []component.Edge{{Node: "service-account", Label: "service account"}, {Node: "service"}, {Node: "replica-set"}},
 		"service-account": []component.Edge{{Node: "pod group"}},
-		"replica-set":     []component.Edge{{Node: "pod group"}, {Node: "deployment"}},
-		"service":         []component.Edge{{Node: "pod group"}},
+		"replica-set":     []component.Edge{{Node: "pod group"}, {Node: "deployment", Label: "owner"}},
+		"service":         []component.Edge{{Node: "pod group", Label: "selector"}},
 		"deployment":      []component.Edge{{Node: "replica-set"}},
 	}
 
 	got := deDupEdges(list)
 
 	expected := component.AdjList{
-		"pod group":   []component.Edge{{Node: "replica-set"}, {Node: "service"}, {Node: "service-account"}},
-		"replica-set": []component.Edge{{Node: "deployment"}},
+		"pod group":   []component.Edge{{Node: "replica-set"}, {Node: "service", Label: "selector"}, {Node: "service-account", Label: "service account"}},
+		"replica-set": []component.Edge{{Node: "deployment", Label: "owner"}},
 	}
 
 	assert.Equal(t, expected, got)
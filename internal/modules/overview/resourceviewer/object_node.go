@@ -43,9 +43,11 @@ func (o *objectNode) Create(ctx context.Context, object runtime.Object) (*compon
 		}
 	}
 
+	// objects without a content path, e.g. horizontal pod autoscalers, are
+	// still shown in the graph; they just can't be linked to.
 	objectPath, err := o.objectPath(object)
 	if err != nil {
-		return nil, err
+		objectPath = nil
 	}
 
 	status, err := o.objectStatus.Status(ctx, object)
@@ -55,6 +57,7 @@ func (o *objectNode) Create(ctx context.Context, object runtime.Object) (*compon
 
 	node := &component.Node{
 		Name:       accessor.GetName(),
+		Namespace:  accessor.GetNamespace(),
 		APIVersion: apiVersion,
 		Kind:       kind,
 		Status:     status.Status(),
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	linkFake "github.com/vmware/octant/internal/link/fake"
@@ -46,6 +47,7 @@ func Test_objectNode(t *testing.T) {
 
 	expected := &component.Node{
 		Name:       deployment.Name,
+		Namespace:  deployment.Namespace,
 		APIVersion: deployment.APIVersion,
 		Kind:       deployment.Kind,
 		Status:     component.NodeStatusOK,
@@ -54,3 +56,42 @@ func Test_objectNode(t *testing.T) {
 
 	testutil.AssertJSONEqual(t, expected, got)
 }
+
+func Test_objectNode_without_path(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	hpa := testutil.CreateHorizontalPodAutoscaler("hpa")
+
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().
+		ForObjectWithQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("unknown path"))
+
+	pluginPrinter := pluginFake.NewMockManagerInterface(controller)
+	objectStatus := fake.NewMockObjectStatus(controller)
+	objectStatus.EXPECT().
+		Status(gomock.Any(), gomock.Any()).
+		Return(&objectstatus.ObjectStatus{}, nil)
+
+	on := objectNode{
+		link:          l,
+		pluginPrinter: pluginPrinter,
+		objectStatus:  objectStatus,
+	}
+
+	ctx := context.Background()
+
+	got, err := on.Create(ctx, hpa)
+	require.NoError(t, err)
+
+	expected := &component.Node{
+		Name:       hpa.Name,
+		Namespace:  hpa.Namespace,
+		APIVersion: hpa.APIVersion,
+		Kind:       hpa.Kind,
+		Status:     component.NodeStatusOK,
+	}
+
+	testutil.AssertJSONEqual(t, expected, got)
+}
@@ -14,6 +14,7 @@ type podGroupNode struct {
 
 func (pgn *podGroupNode) Create(ctx context.Context, podGroupName string, objects []runtime.Object) (*component.Node, error) {
 	podStatus := component.NewPodStatus()
+	namespace := ""
 
 	for _, object := range objects {
 		if !isObjectPod(object) {
@@ -30,10 +31,12 @@ func (pgn *podGroupNode) Create(ctx context.Context, podGroupName string, object
 		}
 
 		podStatus.AddSummary(pod.Name, status.Details, status.Status())
+		namespace = pod.Namespace
 	}
 
 	node := &component.Node{
 		Name:       podGroupName,
+		Namespace:  namespace,
 		APIVersion: "v1",
 		Kind:       "Pod",
 		Status:     podStatus.Status(),
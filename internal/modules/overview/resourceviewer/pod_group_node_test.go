@@ -39,6 +39,7 @@ func Test_podGroupNode(t *testing.T) {
 
 	expected := &component.Node{
 		Name:       "foo pods",
+		Namespace:  pod.Namespace,
 		APIVersion: "v1",
 		Kind:       "Pod",
 		Status:     component.NodeStatusOK,
@@ -0,0 +1,92 @@
+package resourceviewer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware/octant/internal/gvk"
+	"github.com/vmware/octant/internal/helm"
+	"github.com/vmware/octant/internal/link"
+	"github.com/vmware/octant/internal/modules/overview/objectstatus"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// releaseGroupName is the node name for objects managed by a Helm release.
+func releaseGroupName(release string) string {
+	return fmt.Sprintf("%s release", release)
+}
+
+// releaseGroups groups objects by the Helm release which manages them.
+func releaseGroups(objects map[types.UID]runtime.Object) map[string][]runtime.Object {
+	groups := make(map[string][]runtime.Object)
+
+	for _, object := range objects {
+		release, ok := helm.ReleaseName(object)
+		if !ok {
+			continue
+		}
+
+		groups[release] = append(groups[release], object)
+	}
+
+	return groups
+}
+
+type releaseGroupNode struct {
+	link         link.Interface
+	objectStatus ObjectStatus
+}
+
+// Create creates a node for a release. The node's status is the worst status
+// of the objects in the release.
+func (rgn *releaseGroupNode) Create(ctx context.Context, release string, objects []runtime.Object) (*component.Node, error) {
+	if len(objects) == 0 {
+		return nil, errors.Errorf("release %s has no objects", release)
+	}
+
+	releaseStatus := objectstatus.ObjectStatus{}
+	for _, object := range objects {
+		status, err := rgn.objectStatus.Status(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+
+		switch status.Status() {
+		case component.NodeStatusError:
+			releaseStatus.SetError()
+		case component.NodeStatusWarning:
+			releaseStatus.SetWarning()
+		}
+	}
+
+	accessor, err := meta.Accessor(objects[0])
+	if err != nil {
+		return nil, err
+	}
+
+	apiVersion, kind := gvk.HelmReleaseGVK.ToAPIVersionAndKind()
+
+	releasePath, err := rgn.link.ForGVK(accessor.GetNamespace(), apiVersion, kind, release, release)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &component.Node{
+		Name:       release,
+		Namespace:  accessor.GetNamespace(),
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Status:     releaseStatus.Status(),
+		Details: []component.Component{
+			component.NewText(fmt.Sprintf("Helm release with %d objects", len(objects))),
+		},
+		Path: releasePath,
+	}
+
+	return node, nil
+}
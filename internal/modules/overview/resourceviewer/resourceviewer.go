@@ -88,6 +88,26 @@ func (rv *ResourceViewer) Visit(ctx context.Context, object runtime.Object) (*co
 	return GenerateComponent(ctx, handler, uid)
 }
 
+// VisitObjects visits a set of objects and creates a single view component
+// containing all of their relationships. No node is selected.
+func (rv *ResourceViewer) VisitObjects(ctx context.Context, objects []runtime.Object) (*component.ResourceViewer, error) {
+	ctx, span := trace.StartSpan(ctx, "resourceViewerObjects")
+	defer span.End()
+
+	handler, err := NewHandler(rv.dashConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Create handler")
+	}
+
+	for _, object := range objects {
+		if err := rv.visitor.Visit(ctx, object, handler); err != nil {
+			return nil, errors.Wrapf(err, "error unable to visit object %s", kubernetes.PrintObject(object))
+		}
+	}
+
+	return GenerateComponent(ctx, handler, "")
+}
+
 // CachedResourceViewer returns a RV component from the component cache and starts a new visit.
 func CachedResourceViewer(object runtime.Object, dashConfig config.Dash, q queryer.Queryer) componentcache.UpdateFn {
 	return func(ctx context.Context, cacheChan chan componentcache.Event) (string, error) {
@@ -102,3 +102,41 @@ func Test_ResourceViewer_visitor_fails(t *testing.T) {
 	_, err = rv.Visit(ctx, deployment)
 	require.Error(t, err)
 }
+
+func Test_ResourceViewer_VisitObjects(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "deployment",
+			UID:  types.UID("deployment"),
+		},
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "statefulset",
+			UID:  types.UID("statefulset"),
+		},
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := storeFake.NewMockStore(controller)
+
+	pluginManager := pluginFake.NewMockManagerInterface(controller)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+	dashConfig.EXPECT().PluginManager().Return(pluginManager).AnyTimes()
+
+	rv, err := New(dashConfig, stubVisitor(false))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	vc, err := rv.VisitObjects(ctx, []runtime.Object{deployment, statefulSet})
+	require.NoError(t, err)
+	assert.NotNil(t, vc)
+}
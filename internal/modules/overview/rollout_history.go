@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware/octant/internal/api"
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/modules/overview/rollouthistory"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// rolloutHistoryDiff shows the pod template changes between two revisions
+// of a workload.
+type rolloutHistoryDiff struct {
+	path string
+}
+
+var _ describer.Describer = (*rolloutHistoryDiff)(nil)
+
+func newRolloutHistoryDiff(p string) *rolloutHistoryDiff {
+	return &rolloutHistoryDiff{
+		path: p,
+	}
+}
+
+func (rh *rolloutHistoryDiff) PathFilters() []describer.PathFilter {
+	filter := fmt.Sprintf("%s/(?P<resource>deployments|daemonsets|statefulsets)/(?P<name>[^/]+)/(?P<from>\\d+)/(?P<to>\\d+)", rh.path)
+	return []describer.PathFilter{
+		*describer.NewPathFilter(filter, rh),
+	}
+}
+
+func (rh *rolloutHistoryDiff) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	kind, ok := rollouthistory.KindForResource(options.Fields["resource"])
+	if !ok {
+		return describer.EmptyContentResponse, errors.Errorf("%q does not have a rollout history", options.Fields["resource"])
+	}
+
+	name := options.Fields["name"]
+
+	from, err := strconv.ParseInt(options.Fields["from"], 10, 64)
+	if err != nil {
+		return describer.EmptyContentResponse, errors.Wrap(err, "parse revision")
+	}
+
+	to, err := strconv.ParseInt(options.Fields["to"], 10, 64)
+	if err != nil {
+		return describer.EmptyContentResponse, errors.Wrap(err, "parse revision")
+	}
+
+	key := store.Key{
+		Namespace:  namespace,
+		APIVersion: "apps/v1",
+		Kind:       kind,
+		Name:       name,
+	}
+
+	object, err := options.ObjectStore().Get(ctx, key)
+	if err != nil {
+		return describer.EmptyContentResponse, errors.Wrapf(err, "get %s", key)
+	}
+	if object == nil {
+		return describer.EmptyContentResponse, api.NewNotFoundError(path.Join(namespace, kind, name))
+	}
+
+	revisions, err := rollouthistory.Load(ctx, options.Queryer, object)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	fromRevision, ok := rollouthistory.Find(revisions, from)
+	if !ok {
+		return describer.EmptyContentResponse, errors.Errorf("%s %s does not have revision %d", kind, name, from)
+	}
+
+	toRevision, ok := rollouthistory.Find(revisions, to)
+	if !ok {
+		return describer.EmptyContentResponse, errors.Errorf("%s %s does not have revision %d", kind, name, to)
+	}
+
+	objectLink, err := options.Link.ForGVK(namespace, "apps/v1", kind, name, name)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	title := component.Title(
+		component.NewText("Rollout History"),
+		objectLink,
+		component.NewText(fmt.Sprintf("Revision %d to %d", from, to)))
+	cr := component.NewContentResponse(title)
+
+	fromCol := fmt.Sprintf("Revision %d", from)
+	toCol := fmt.Sprintf("Revision %d", to)
+
+	changes := component.NewTable("Template Changes", component.NewTableCols("Field", fromCol, toCol))
+	changes.SetAccessor("changes")
+	for _, change := range rollouthistory.Compare(fromRevision, toRevision) {
+		changes.Add(component.TableRow{
+			"Field": component.NewText(change.Path),
+			fromCol: component.NewText(change.Live),
+			toCol:   component.NewText(change.Edited),
+		})
+	}
+
+	others := component.NewTable("Compare Revisions", component.NewTableCols("Revision", "Change Cause", "Compare"))
+	others.SetAccessor("revisions")
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+		if revision.Number == to {
+			continue
+		}
+
+		others.Add(component.TableRow{
+			"Revision":     component.NewText(fmt.Sprintf("%d", revision.Number)),
+			"Change Cause": component.NewText(revision.ChangeCause),
+			"Compare": component.NewLink("",
+				fmt.Sprintf("Compare %d to %d", revision.Number, to),
+				rollouthistory.DiffLink(namespace, kind, name, revision.Number, to)),
+		})
+	}
+
+	cr.Add(changes, others)
+
+	return *cr, nil
+}
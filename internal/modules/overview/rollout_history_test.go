@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/describer"
+	linkFake "github.com/vmware/octant/internal/link/fake"
+	"github.com/vmware/octant/internal/modules/overview/rollouthistory"
+	queryerFake "github.com/vmware/octant/internal/queryer/fake"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func Test_rolloutHistoryDiff(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	daemonSet := testutil.ToUnstructured(t, testutil.CreateDaemonSet("daemonset"))
+	key := store.Key{Namespace: "default", APIVersion: "apps/v1", Kind: "DaemonSet", Name: "daemonset"}
+
+	revision := func(name string, number int64, image string) runtime.Object {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "ControllerRevision",
+			"metadata":   map[string]interface{}{"name": name},
+			"revision":   number,
+			"data": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "app", "image": image},
+							},
+						},
+					},
+				},
+			},
+		}}
+		return u
+	}
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().Get(gomock.Any(), key).Return(daemonSet, nil)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().Children(gomock.Any(), gomock.Any()).Return([]runtime.Object{
+		revision("cr-1", 1, "nginx:1"),
+		revision("cr-2", 2, "nginx:2"),
+		revision("cr-3", 3, "nginx:3"),
+	}, nil)
+
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().ForGVK("default", "apps/v1", "DaemonSet", "daemonset", "daemonset").
+		Return(component.NewLink("", "daemonset", "/daemonset"), nil)
+
+	options := describer.Options{
+		Dash:    dashConfig,
+		Queryer: q,
+		Link:    l,
+		Fields:  map[string]string{"resource": "daemonsets", "name": "daemonset", "from": "1", "to": "3"},
+	}
+
+	rh := newRolloutHistoryDiff(rollouthistory.DiffPath)
+
+	got, err := rh.Describe(context.Background(), "/prefix", "default", options)
+	require.NoError(t, err)
+	require.Len(t, got.Components, 2)
+
+	changes, ok := got.Components[0].(*component.Table)
+	require.True(t, ok)
+	assert.Equal(t, []component.TableRow{
+		{
+			"Field":      component.NewText("spec.containers[0].image"),
+			"Revision 1": component.NewText(`"nginx:1"`),
+			"Revision 3": component.NewText(`"nginx:3"`),
+		},
+	}, changes.Rows())
+
+	others, ok := got.Components[1].(*component.Table)
+	require.True(t, ok)
+	assert.Len(t, others.Rows(), 2)
+}
+
+func Test_rolloutHistoryDiff_PathFilters(t *testing.T) {
+	rh := newRolloutHistoryDiff(rollouthistory.DiffPath)
+
+	filters := rh.PathFilters()
+	require.Len(t, filters, 1)
+
+	assert.True(t, filters[0].Match("/workloads/rollout-history/deployments/web/1/2"))
+	assert.False(t, filters[0].Match("/workloads/rollout-history/pods/web/1/2"))
+	assert.False(t, filters[0].Match("/workloads/rollout-history/deployments/web/1/latest"))
+}
@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollouthistory
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/modules/overview/yamleditor"
+	"github.com/vmware/octant/pkg/view/component"
+	"github.com/vmware/octant/pkg/view/flexlayout"
+)
+
+const (
+	// RollbackActionName is the name of the action which rolls back a
+	// workload.
+	RollbackActionName = "rollout/rollback"
+
+	// DiffPath is the path of the revision diff page in a namespace.
+	DiffPath = "/workloads/rollout-history"
+)
+
+// resources maps kinds to the resource names used in diff paths.
+var resources = map[string]string{
+	"Deployment":  "deployments",
+	"DaemonSet":   "daemonsets",
+	"StatefulSet": "statefulsets",
+}
+
+// KindForResource returns the kind for a resource name in a diff path.
+func KindForResource(resource string) (string, bool) {
+	for kind, name := range resources {
+		if name == resource {
+			return kind, true
+		}
+	}
+
+	return "", false
+}
+
+// DiffLink returns the path to the diff between two revisions.
+func DiffLink(namespace, kind, name string, from, to int64) string {
+	return path.Join("/content/overview/namespace", namespace, DiffPath,
+		resources[kind], name, fmt.Sprintf("%d", from), fmt.Sprintf("%d", to))
+}
+
+// Compare compares the templates of two revisions. Live values of the
+// changes are from the first revision and edited values are from the
+// second.
+func Compare(from, to Revision) []yamleditor.Change {
+	return yamleditor.Diff(nil, from.Template, to.Template)
+}
+
+// ToComponent creates the rollout history for a workload. It lists the
+// revisions with links to what changed in each of them, and a form to roll
+// back to a previous revision.
+func ToComponent(object runtime.Object, revisions []Revision) (component.Component, error) {
+	if object == nil {
+		return nil, errors.New("object is nil")
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "access object metadata")
+	}
+
+	kind := kindOf(object)
+
+	table := component.NewTable("Revisions", component.NewTableCols("Revision", "Change Cause", "Images", "Age", "Changes"))
+
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := revisions[i]
+
+		changes := component.Component(component.NewText("-"))
+		if i > 0 {
+			previous := revisions[i-1]
+			changes = component.NewLink("",
+				fmt.Sprintf("Compare with revision %d", previous.Number),
+				DiffLink(accessor.GetNamespace(), kind, accessor.GetName(), previous.Number, revision.Number))
+		}
+
+		number := fmt.Sprintf("%d", revision.Number)
+		if i == len(revisions)-1 {
+			number += " (current)"
+		}
+
+		table.Add(component.TableRow{
+			"Revision":     component.NewText(number),
+			"Change Cause": component.NewText(revision.ChangeCause),
+			"Images":       component.NewText(strings.Join(revision.Images(), ", ")),
+			"Age":          component.NewTimestamp(revision.CreationTimestamp),
+			"Changes":      changes,
+		})
+	}
+
+	fl := flexlayout.New()
+
+	section := fl.AddSection()
+	if err := section.Add(table, component.WidthFull); err != nil {
+		return nil, errors.Wrap(err, "add revisions to layout")
+	}
+
+	if rollback := rollbackSummary(object, kind, accessor, revisions); rollback != nil {
+		rollbackSection := fl.AddSection()
+		if err := rollbackSection.Add(rollback, component.WidthHalf); err != nil {
+			return nil, errors.Wrap(err, "add rollback to layout")
+		}
+	}
+
+	return fl.ToComponent("Rollout History"), nil
+}
+
+func rollbackSummary(object runtime.Object, kind string, accessor metav1.Object, revisions []Revision) *component.Summary {
+	if len(revisions) < 2 {
+		return nil
+	}
+
+	current := revisions[len(revisions)-1]
+
+	summary := component.NewSummary("Rollback", component.SummarySection{
+		Header:  "Current Revision",
+		Content: component.NewText(fmt.Sprintf("%d", current.Number)),
+	})
+
+	var choices []component.InputChoice
+	for i := len(revisions) - 2; i >= 0; i-- {
+		revision := revisions[i]
+
+		label := fmt.Sprintf("Revision %d", revision.Number)
+		if revision.ChangeCause != "" {
+			label = fmt.Sprintf("%s: %s", label, revision.ChangeCause)
+		}
+
+		choices = append(choices, component.InputChoice{
+			Label:   label,
+			Value:   fmt.Sprintf("%d", revision.Number),
+			Checked: i == len(revisions)-2,
+		})
+	}
+
+	gvk := object.GetObjectKind().GroupVersionKind()
+	if gvk.Empty() {
+		gvk = appsv1.SchemeGroupVersion.WithKind(kind)
+	}
+
+	summary.AddAction(component.Action{
+		Name:  "Rollback",
+		Title: fmt.Sprintf("Roll back %s %s", kind, accessor.GetName()),
+		Form: component.Form{
+			Fields: []component.FormField{
+				component.NewFormFieldRadio("Revision", "revision", choices),
+				component.NewFormFieldHidden("group", gvk.Group),
+				component.NewFormFieldHidden("version", gvk.Version),
+				component.NewFormFieldHidden("kind", kind),
+				component.NewFormFieldHidden("name", accessor.GetName()),
+				component.NewFormFieldHidden("namespace", accessor.GetNamespace()),
+				component.NewFormFieldHidden("action", RollbackActionName),
+			},
+		},
+	})
+
+	return summary
+}
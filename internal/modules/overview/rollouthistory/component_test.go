@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollouthistory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/modules/overview/yamleditor"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func TestToComponent(t *testing.T) {
+	deployment := testutil.CreateDeployment("deployment")
+
+	revisions := []Revision{
+		{Number: 1, ChangeCause: "create", CreationTimestamp: revisionTime, Template: podTemplate("nginx:1")},
+		{Number: 3, ChangeCause: "update image", CreationTimestamp: revisionTime, Template: podTemplate("nginx:2")},
+	}
+
+	got, err := ToComponent(deployment, revisions)
+	require.NoError(t, err)
+
+	fl, ok := got.(*component.FlexLayout)
+	require.True(t, ok)
+	require.Len(t, fl.Config.Sections, 2)
+
+	table, ok := fl.Config.Sections[0][0].View.(*component.Table)
+	require.True(t, ok)
+
+	expectedRows := []component.TableRow{
+		{
+			"Revision":     component.NewText("3 (current)"),
+			"Change Cause": component.NewText("update image"),
+			"Images":       component.NewText("nginx:2"),
+			"Age":          component.NewTimestamp(revisionTime),
+			"Changes": component.NewLink("", "Compare with revision 1",
+				"/content/overview/namespace/namespace/workloads/rollout-history/deployments/deployment/1/3"),
+		},
+		{
+			"Revision":     component.NewText("1"),
+			"Change Cause": component.NewText("create"),
+			"Images":       component.NewText("nginx:1"),
+			"Age":          component.NewTimestamp(revisionTime),
+			"Changes":      component.NewText("-"),
+		},
+	}
+	assert.Equal(t, expectedRows, table.Rows())
+
+	rollback, ok := fl.Config.Sections[1][0].View.(*component.Summary)
+	require.True(t, ok)
+	require.Len(t, rollback.Config.Actions, 1)
+
+	values := make(map[string]interface{})
+	for _, field := range rollback.Config.Actions[0].Form.Fields {
+		values[field.Name()] = field.Value()
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"revision":  "1",
+		"group":     "apps",
+		"version":   "v1",
+		"kind":      "Deployment",
+		"name":      "deployment",
+		"namespace": "namespace",
+		"action":    RollbackActionName,
+	}, values)
+}
+
+func TestToComponent_single_revision(t *testing.T) {
+	revisions := []Revision{{Number: 1, Template: podTemplate("nginx:1")}}
+
+	got, err := ToComponent(testutil.CreateStatefulSet("statefulset"), revisions)
+	require.NoError(t, err)
+
+	fl, ok := got.(*component.FlexLayout)
+	require.True(t, ok)
+	assert.Len(t, fl.Config.Sections, 1)
+}
+
+func TestCompare(t *testing.T) {
+	from := Revision{Number: 1, Template: podTemplate("nginx:1")}
+	to := Revision{Number: 2, Template: podTemplate("nginx:2")}
+
+	expected := []yamleditor.Change{
+		{Path: "spec.containers[0].image", Live: `"nginx:1"`, Edited: `"nginx:2"`},
+	}
+	assert.Equal(t, expected, Compare(from, to))
+}
@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollouthistory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/queryer"
+)
+
+const (
+	// RevisionAnnotation is the revision of a deployment's replica set.
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// ChangeCauseAnnotation describes why a revision was created.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// Revision is a revision of a workload's pod template.
+type Revision struct {
+	// Number is the revision number.
+	Number int64
+	// Name is the name of the replica set or controller revision which
+	// stores the revision.
+	Name string
+	// ChangeCause describes why the revision was created.
+	ChangeCause string
+	// CreationTimestamp is when the revision was created.
+	CreationTimestamp time.Time
+	// Template is the pod template for the revision.
+	Template map[string]interface{}
+}
+
+// Images returns the container images in the revision's template.
+func (r Revision) Images() []string {
+	containers, _, _ := unstructured.NestedSlice(r.Template, "spec", "containers")
+
+	var images []string
+	for _, container := range containers {
+		m, ok := container.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if image, ok := m["image"].(string); ok {
+			images = append(images, image)
+		}
+	}
+
+	return images
+}
+
+// Supports returns true if an object has a rollout history.
+func Supports(object runtime.Object) bool {
+	if object == nil {
+		return false
+	}
+
+	switch object.(type) {
+	case *appsv1.Deployment, *appsv1.DaemonSet, *appsv1.StatefulSet:
+		return true
+	}
+
+	gvk := object.GetObjectKind().GroupVersionKind()
+	if gvk.Group != "apps" && gvk.Group != "extensions" {
+		return false
+	}
+
+	switch gvk.Kind {
+	case "Deployment", "DaemonSet", "StatefulSet":
+		return true
+	}
+
+	return false
+}
+
+// Load loads the revisions of a workload, oldest first. Revisions of
+// deployments are stored in the replica sets they own. Revisions of daemon
+// sets and stateful sets are stored in controller revisions.
+func Load(ctx context.Context, q queryer.Queryer, object runtime.Object) ([]Revision, error) {
+	if q == nil {
+		return nil, errors.New("queryer is nil")
+	}
+
+	if !Supports(object) {
+		return nil, errors.Errorf("%T does not have a rollout history", object)
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "access object metadata")
+	}
+
+	children, err := q.Children(ctx, accessor)
+	if err != nil {
+		return nil, errors.Wrapf(err, "find children for %s", accessor.GetName())
+	}
+
+	kind := kindOf(object)
+
+	var revisions []Revision
+	for _, child := range children {
+		u, err := toUnstructured(child)
+		if err != nil {
+			return nil, err
+		}
+
+		var revision *Revision
+		switch {
+		case kind == "Deployment" && u.GetKind() == "ReplicaSet":
+			revision, err = fromReplicaSet(u)
+		case kind != "Deployment" && u.GetKind() == "ControllerRevision":
+			revision, err = fromControllerRevision(u)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		if revision != nil {
+			revisions = append(revisions, *revision)
+		}
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+
+	return revisions, nil
+}
+
+// Find returns the revision with a number.
+func Find(revisions []Revision, number int64) (Revision, bool) {
+	for _, revision := range revisions {
+		if revision.Number == number {
+			return revision, true
+		}
+	}
+
+	return Revision{}, false
+}
+
+func fromReplicaSet(u *unstructured.Unstructured) (*Revision, error) {
+	value, ok := u.GetAnnotations()[RevisionAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse revision of replica set %s", u.GetName())
+	}
+
+	template, _, err := unstructured.NestedMap(u.Object, "spec", "template")
+	if err != nil {
+		return nil, errors.Wrapf(err, "read template of replica set %s", u.GetName())
+	}
+
+	// the hash is added by the deployment controller and isn't part of the
+	// deployment's template.
+	unstructured.RemoveNestedField(template, "metadata", "labels", appsv1.DefaultDeploymentUniqueLabelKey)
+
+	return &Revision{
+		Number:            number,
+		Name:              u.GetName(),
+		ChangeCause:       u.GetAnnotations()[ChangeCauseAnnotation],
+		CreationTimestamp: u.GetCreationTimestamp().Time,
+		Template:          template,
+	}, nil
+}
+
+func fromControllerRevision(u *unstructured.Unstructured) (*Revision, error) {
+	number, _, err := unstructured.NestedInt64(u.Object, "revision")
+	if err != nil {
+		return nil, errors.Wrapf(err, "read revision of controller revision %s", u.GetName())
+	}
+
+	// the data is a patch which replaces the workload's template.
+	template, _, err := unstructured.NestedMap(u.Object, "data", "spec", "template")
+	if err != nil {
+		return nil, errors.Wrapf(err, "read template of controller revision %s", u.GetName())
+	}
+	delete(template, "$patch")
+
+	return &Revision{
+		Number:            number,
+		Name:              u.GetName(),
+		ChangeCause:       u.GetAnnotations()[ChangeCauseAnnotation],
+		CreationTimestamp: u.GetCreationTimestamp().Time,
+		Template:          template,
+	}, nil
+}
+
+func kindOf(object runtime.Object) string {
+	switch object.(type) {
+	case *appsv1.Deployment:
+		return "Deployment"
+	case *appsv1.DaemonSet:
+		return "DaemonSet"
+	case *appsv1.StatefulSet:
+		return "StatefulSet"
+	}
+
+	return object.GetObjectKind().GroupVersionKind().Kind
+}
+
+func toUnstructured(object runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := object.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert object to unstructured")
+	}
+
+	return &unstructured.Unstructured{Object: m}, nil
+}
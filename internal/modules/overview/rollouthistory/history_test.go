@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollouthistory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	queryerFake "github.com/vmware/octant/internal/queryer/fake"
+	"github.com/vmware/octant/internal/testutil"
+)
+
+var revisionTime = time.Unix(1547211430, 0)
+
+func podTemplate(image string) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web", "image": image},
+			},
+		},
+	}
+}
+
+func replicaSet(name, revision, changeCause, image string) *unstructured.Unstructured {
+	template := podTemplate(image)
+	_ = unstructured.SetNestedField(template, "hash", "metadata", "labels", "pod-template-hash")
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"template": template},
+	}}
+	u.SetAPIVersion("extensions/v1beta1")
+	u.SetKind("ReplicaSet")
+	u.SetName(name)
+	u.SetNamespace("namespace")
+	u.SetCreationTimestamp(metav1.NewTime(revisionTime))
+
+	annotations := map[string]string{}
+	if revision != "" {
+		annotations[RevisionAnnotation] = revision
+	}
+	if changeCause != "" {
+		annotations[ChangeCauseAnnotation] = changeCause
+	}
+	u.SetAnnotations(annotations)
+
+	return u
+}
+
+func controllerRevision(name string, revision int64, image string) *unstructured.Unstructured {
+	template := podTemplate(image)
+	template["$patch"] = "replace"
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"revision": revision,
+		"data": map[string]interface{}{
+			"spec": map[string]interface{}{"template": template},
+		},
+	}}
+	u.SetAPIVersion("apps/v1")
+	u.SetKind("ControllerRevision")
+	u.SetName(name)
+	u.SetNamespace("namespace")
+	u.SetCreationTimestamp(metav1.NewTime(revisionTime))
+
+	return u
+}
+
+func TestLoad(t *testing.T) {
+	deployment := testutil.CreateDeployment("deployment")
+	daemonSet := testutil.CreateDaemonSet("daemonset")
+
+	cases := []struct {
+		name     string
+		object   runtime.Object
+		children []runtime.Object
+		expected []Revision
+	}{
+		{
+			name:   "deployment",
+			object: deployment,
+			children: []runtime.Object{
+				replicaSet("rs-2", "2", "update image", "nginx:2"),
+				replicaSet("rs-1", "1", "", "nginx:1"),
+				replicaSet("rs-none", "", "", "nginx:0"),
+				controllerRevision("cr-1", 1, "nginx:1"),
+			},
+			expected: []Revision{
+				{Number: 1, Name: "rs-1", CreationTimestamp: revisionTime, Template: podTemplate("nginx:1")},
+				{Number: 2, Name: "rs-2", ChangeCause: "update image", CreationTimestamp: revisionTime, Template: podTemplate("nginx:2")},
+			},
+		},
+		{
+			name:   "daemon set",
+			object: daemonSet,
+			children: []runtime.Object{
+				controllerRevision("cr-3", 3, "nginx:3"),
+				controllerRevision("cr-1", 1, "nginx:1"),
+				replicaSet("rs-2", "2", "", "nginx:2"),
+			},
+			expected: []Revision{
+				{Number: 1, Name: "cr-1", CreationTimestamp: revisionTime, Template: podTemplate("nginx:1")},
+				{Number: 3, Name: "cr-3", CreationTimestamp: revisionTime, Template: podTemplate("nginx:3")},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			q := queryerFake.NewMockQueryer(controller)
+			q.EXPECT().Children(gomock.Any(), gomock.Any()).Return(tc.children, nil)
+
+			got, err := Load(context.Background(), q, tc.object)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestLoad_unsupported(t *testing.T) {
+	_, err := Load(context.Background(), nil, testutil.CreatePod("pod"))
+	assert.Error(t, err)
+}
+
+func TestSupports(t *testing.T) {
+	assert.True(t, Supports(testutil.CreateDeployment("deployment")))
+	assert.True(t, Supports(testutil.CreateStatefulSet("statefulset")))
+	assert.True(t, Supports(testutil.ToUnstructured(t, testutil.CreateDaemonSet("daemonset"))))
+	assert.False(t, Supports(testutil.CreatePod("pod")))
+	assert.False(t, Supports(nil))
+}
+
+func TestRevision_Images(t *testing.T) {
+	revision := Revision{Template: podTemplate("nginx:1")}
+	assert.Equal(t, []string{"nginx:1"}, revision.Images())
+}
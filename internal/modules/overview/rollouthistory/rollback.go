@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollouthistory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/audit"
+	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/queryer"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+// Rollback restores the pod template of a previous revision.
+type Rollback struct {
+	dashConfig     config.Dash
+	trail          audit.Trail
+	queryerFactory func() (queryer.Queryer, error)
+}
+
+// NewRollback creates an instance of Rollback. trail can be nil.
+func NewRollback(dashConfig config.Dash, trail audit.Trail) *Rollback {
+	r := &Rollback{
+		dashConfig: dashConfig,
+		trail:      trail,
+	}
+
+	r.queryerFactory = func() (queryer.Queryer, error) {
+		discoveryClient, err := dashConfig.ClusterClient().DiscoveryClient()
+		if err != nil {
+			return nil, errors.Wrap(err, "get discovery client")
+		}
+
+		return queryer.New(dashConfig.ObjectStore(), discoveryClient), nil
+	}
+
+	return r
+}
+
+// ActionName returns the name of the action.
+func (r *Rollback) ActionName() string {
+	return RollbackActionName
+}
+
+// Handle rolls back the workload in the payload to the revision in the
+// payload.
+func (r *Rollback) Handle(ctx context.Context, payload action.Payload) error {
+	gvk, err := payload.GroupVersionKind()
+	if err != nil {
+		return err
+	}
+
+	name, err := payload.String("name")
+	if err != nil {
+		return err
+	}
+
+	namespace, err := payload.String("namespace")
+	if err != nil {
+		return err
+	}
+
+	value, err := payload.String("revision")
+	if err != nil {
+		return err
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return errors.Errorf("revision %q is not a number", value)
+	}
+
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+
+	key := store.Key{
+		Namespace:  namespace,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+	}
+
+	err = r.rollback(ctx, key, number)
+
+	entry := audit.Entry{
+		Action:     RollbackActionName,
+		Context:    r.dashConfig.ContextName(),
+		Namespace:  namespace,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+		Key:        fmt.Sprintf("revision %d", number),
+		Allowed:    err == nil,
+	}
+	if err != nil {
+		entry.Reason = err.Error()
+	}
+	if r.trail != nil {
+		r.trail.Record(ctx, entry)
+	}
+
+	return err
+}
+
+func (r *Rollback) rollback(ctx context.Context, key store.Key, number int64) error {
+	objectStore := r.dashConfig.ObjectStore()
+
+	if err := objectStore.HasAccess(ctx, key, "update"); err != nil {
+		return errors.Wrapf(err, "roll back %s", key.Name)
+	}
+
+	object, err := objectStore.Get(ctx, key)
+	if err != nil {
+		return errors.Wrapf(err, "get %s", key)
+	}
+	if object == nil {
+		return errors.Errorf("%s was not found", key)
+	}
+
+	if !Supports(object) {
+		return errors.Errorf("%s %s does not have a rollout history", key.Kind, key.Name)
+	}
+
+	paused, _, _ := unstructured.NestedBool(object.Object, "spec", "paused")
+	if paused {
+		return errors.Errorf("%s %s is paused; resume its rollout before rolling back", key.Kind, key.Name)
+	}
+
+	q, err := r.queryerFactory()
+	if err != nil {
+		return err
+	}
+
+	revisions, err := Load(ctx, q, object)
+	if err != nil {
+		return err
+	}
+
+	revision, ok := Find(revisions, number)
+	if !ok {
+		return errors.Errorf("%s %s does not have revision %d", key.Kind, key.Name, number)
+	}
+
+	if len(revision.Template) == 0 {
+		return errors.Errorf("revision %d of %s %s does not have a template", number, key.Kind, key.Name)
+	}
+
+	updater := func(object *unstructured.Unstructured) error {
+		template := runtime.DeepCopyJSON(revision.Template)
+		return unstructured.SetNestedMap(object.Object, template, "spec", "template")
+	}
+
+	if err := objectStore.Update(ctx, key, updater); err != nil {
+		return errors.Wrapf(err, "roll back %s %s to revision %d", key.Kind, key.Name, number)
+	}
+
+	return nil
+}
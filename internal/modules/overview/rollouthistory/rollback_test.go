@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollouthistory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/audit"
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/queryer"
+	queryerFake "github.com/vmware/octant/internal/queryer/fake"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+)
+
+func TestRollback_Handle(t *testing.T) {
+	key := store.Key{Namespace: "namespace", APIVersion: "apps/v1", Kind: "Deployment", Name: "deployment"}
+
+	newDeployment := func(paused bool) *unstructured.Unstructured {
+		deployment := testutil.CreateDeployment("deployment")
+		deployment.Spec.Paused = paused
+		return testutil.ToUnstructured(t, deployment)
+	}
+
+	children := []runtime.Object{
+		replicaSet("rs-1", "1", "", "nginx:1"),
+		replicaSet("rs-2", "2", "", "nginx:2"),
+	}
+
+	cases := []struct {
+		name      string
+		revision  string
+		object    *unstructured.Unstructured
+		accessErr error
+		updated   bool
+		reason    string
+	}{
+		{
+			name:     "roll back",
+			revision: "1",
+			object:   newDeployment(false),
+			updated:  true,
+		},
+		{
+			name:      "access denied",
+			revision:  "1",
+			accessErr: errors.New("denied"),
+			reason:    "roll back deployment: denied",
+		},
+		{
+			name:     "paused",
+			revision: "1",
+			object:   newDeployment(true),
+			reason:   "Deployment deployment is paused; resume its rollout before rolling back",
+		},
+		{
+			name:     "unknown revision",
+			revision: "5",
+			object:   newDeployment(false),
+			reason:   "Deployment deployment does not have revision 5",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			objectStore := storeFake.NewMockStore(controller)
+			objectStore.EXPECT().HasAccess(gomock.Any(), key, "update").Return(tc.accessErr)
+			if tc.object != nil {
+				objectStore.EXPECT().Get(gomock.Any(), key).Return(tc.object, nil)
+			}
+
+			var updated *unstructured.Unstructured
+			if tc.updated {
+				objectStore.EXPECT().Update(gomock.Any(), key, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key store.Key, fn func(*unstructured.Unstructured) error) error {
+						updated = tc.object.DeepCopy()
+						return fn(updated)
+					})
+			}
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+			dashConfig.EXPECT().ContextName().Return("context").AnyTimes()
+
+			q := queryerFake.NewMockQueryer(controller)
+			q.EXPECT().Children(gomock.Any(), gomock.Any()).Return(children, nil).AnyTimes()
+
+			trail := audit.NewMemoryTrail(log.NopLogger(), 10)
+
+			rollback := NewRollback(dashConfig, trail)
+			rollback.queryerFactory = func() (queryer.Queryer, error) {
+				return q, nil
+			}
+
+			payload := action.Payload{
+				"group":     "apps",
+				"version":   "v1",
+				"kind":      "Deployment",
+				"name":      "deployment",
+				"namespace": "namespace",
+				"revision":  tc.revision,
+			}
+
+			err := rollback.Handle(context.Background(), payload)
+
+			entries := trail.Entries()
+			require.Len(t, entries, 1)
+			assert.Equal(t, RollbackActionName, entries[0].Action)
+			assert.Equal(t, "revision "+tc.revision, entries[0].Key)
+
+			if tc.reason != "" {
+				require.Error(t, err)
+				assert.False(t, entries[0].Allowed)
+				assert.Equal(t, tc.reason, entries[0].Reason)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, entries[0].Allowed)
+
+			template, _, err := unstructured.NestedMap(updated.Object, "spec", "template")
+			require.NoError(t, err)
+			assert.Equal(t, podTemplate("nginx:1"), template)
+		})
+	}
+}
+
+func TestRollback_Handle_invalid_revision(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	rollback := NewRollback(configFake.NewMockDash(controller), nil)
+
+	payload := action.Payload{
+		"group":     "apps",
+		"version":   "v1",
+		"kind":      "Deployment",
+		"name":      "deployment",
+		"namespace": "namespace",
+		"revision":  "latest",
+	}
+
+	assert.Error(t, rollback.Handle(context.Background(), payload))
+}
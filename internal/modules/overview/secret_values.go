@@ -0,0 +1,233 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/api"
+	"github.com/vmware/octant/internal/audit"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/describer"
+	"github.com/vmware/octant/internal/modules/overview/secretviewer"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const (
+	secretRevealAction = "secret/reveal"
+
+	// secretRevealWindow is how long a reveal is remembered. Content is
+	// refreshed while the page is open, so repeated reveals of the same value
+	// within the window are only recorded once.
+	secretRevealWindow = time.Minute
+)
+
+// secretValues reveals individual secret values. Every reveal re-checks
+// that the current user can get the secret and is recorded in the audit
+// trail.
+type secretValues struct {
+	path  string
+	trail audit.Trail
+	now   func() time.Time
+}
+
+var _ describer.Describer = (*secretValues)(nil)
+
+func newSecretValues(p string, trail audit.Trail) *secretValues {
+	return &secretValues{
+		path:  p,
+		trail: trail,
+		now:   time.Now,
+	}
+}
+
+func (sv *secretValues) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter(fmt.Sprintf("%s/(?P<name>[^/]+)/(?P<key>[^/]+)", sv.path), sv),
+	}
+}
+
+func (sv *secretValues) Describe(ctx context.Context, prefix, namespace string, options describer.Options) (component.ContentResponse, error) {
+	name, key := options.Fields["name"], options.Fields["key"]
+
+	entry := audit.Entry{
+		Action:     secretRevealAction,
+		Context:    options.ContextName(),
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Name:       name,
+		Key:        key,
+	}
+
+	components, err := sv.reveal(ctx, namespace, name, key, options)
+	if err != nil {
+		entry.Reason = err.Error()
+		sv.record(ctx, entry)
+		return describer.EmptyContentResponse, err
+	}
+
+	entry.Allowed = true
+	sv.record(ctx, entry)
+
+	secretLink, err := options.Link.ForGVK(namespace, "v1", "Secret", name, name)
+	if err != nil {
+		return describer.EmptyContentResponse, err
+	}
+
+	title := component.Title(
+		component.NewText("Secrets"),
+		secretLink,
+		component.NewText(key))
+	cr := component.NewContentResponse(title)
+	cr.Add(components...)
+	cr.Add(sv.history(namespace, name))
+
+	return *cr, nil
+}
+
+func (sv *secretValues) reveal(ctx context.Context, namespace, name, key string, options describer.Options) ([]component.Component, error) {
+	objectStore := options.ObjectStore()
+	if objectStore == nil {
+		return nil, errors.New("object store is nil")
+	}
+
+	storeKey := store.Key{
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Name:       name,
+	}
+
+	if err := checkSecretAccess(options.ClusterClient(), namespace, name); err != nil {
+		return nil, errors.Wrapf(err, "reveal secret %s", name)
+	}
+
+	object, err := objectStore.Get(ctx, storeKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get secret %s", name)
+	}
+
+	if object == nil {
+		return nil, api.NewNotFoundError(path.Join(namespace, "Secret", name))
+	}
+
+	secret := &corev1.Secret{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, secret); err != nil {
+		return nil, errors.Wrapf(err, "convert secret %s", name)
+	}
+
+	return secretviewer.ToComponent(secret, key, sv.now())
+}
+
+// checkSecretAccess asks the cluster if the current user can get a secret.
+// Unlike the object store's access checks, the review names the secret and
+// isn't cached, so access granted to specific secrets is respected and
+// revoked access applies to the next reveal.
+func checkSecretAccess(clusterClient cluster.ClientInterface, namespace, name string) error {
+	if clusterClient == nil {
+		return errors.New("cluster client is nil")
+	}
+
+	kubeClient, err := clusterClient.KubernetesClient()
+	if err != nil {
+		return errors.Wrap(err, "client kubernetes")
+	}
+
+	sar := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Resource:  "secrets",
+				Name:      name,
+				Verb:      "get",
+			},
+		},
+	}
+
+	review, err := kubeClient.AuthorizationV1().SelfSubjectAccessReviews().Create(sar)
+	if err != nil {
+		return errors.Wrap(err, "client auth")
+	}
+
+	if !review.Status.Allowed {
+		reason := review.Status.Reason
+		if reason == "" {
+			reason = "access denied"
+		}
+		return kerrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, name, errors.New(reason))
+	}
+
+	return nil
+}
+
+// record records an entry unless the same result was recorded for the same
+// value within the reveal window.
+func (sv *secretValues) record(ctx context.Context, entry audit.Entry) {
+	if sv.trail == nil {
+		return
+	}
+
+	now := sv.now()
+	entries := sv.trail.Entries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		previous := entries[i]
+		if now.Sub(previous.Time) > secretRevealWindow {
+			break
+		}
+
+		previous.Time = time.Time{}
+		if previous == entry {
+			return
+		}
+	}
+
+	entry.Time = now
+	sv.trail.Record(ctx, entry)
+}
+
+// history lists the recorded reveals for a secret, most recent first.
+func (sv *secretValues) history(namespace, name string) *component.Table {
+	table := component.NewTable("Reveal History", component.NewTableCols("Time", "Context", "Key", "Result"))
+	table.SetAccessor("history")
+
+	if sv.trail == nil {
+		return table
+	}
+
+	entries := sv.trail.Entries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.Action != secretRevealAction || entry.Namespace != namespace || entry.Name != name {
+			continue
+		}
+
+		result := "Revealed"
+		if !entry.Allowed {
+			result = fmt.Sprintf("Failed: %s", entry.Reason)
+		}
+
+		table.Add(component.TableRow{
+			"Time":    component.NewTimestamp(entry.Time),
+			"Context": component.NewText(entry.Context),
+			"Key":     component.NewText(entry.Key),
+			"Result":  component.NewText(result),
+		})
+	}
+
+	return table
+}
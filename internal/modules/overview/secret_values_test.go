@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	kTesting "k8s.io/client-go/testing"
+
+	"github.com/vmware/octant/internal/audit"
+	clusterFake "github.com/vmware/octant/internal/cluster/fake"
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/describer"
+	linkFake "github.com/vmware/octant/internal/link/fake"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func Test_secretValues(t *testing.T) {
+	now := time.Unix(1547211430, 0)
+
+	secretKey := store.Key{Namespace: "default", APIVersion: "v1", Kind: "Secret", Name: "secret"}
+
+	secret := testutil.CreateSecret("secret")
+	secret.Namespace = "default"
+	secret.Data = map[string][]byte{"password": []byte("hunter2")}
+
+	cases := []struct {
+		name     string
+		key      string
+		denied   bool
+		isErr    bool
+		expected []audit.Entry
+	}{
+		{
+			name: "reveal",
+			key:  "password",
+			expected: []audit.Entry{
+				{
+					Time: now, Action: secretRevealAction, Context: "context",
+					Namespace: "default", APIVersion: "v1", Kind: "Secret", Name: "secret", Key: "password",
+					Allowed: true,
+				},
+			},
+		},
+		{
+			name:   "access denied",
+			key:    "password",
+			denied: true,
+			isErr:  true,
+			expected: []audit.Entry{
+				{
+					Time: now, Action: secretRevealAction, Context: "context",
+					Namespace: "default", APIVersion: "v1", Kind: "Secret", Name: "secret", Key: "password",
+					Reason: `reveal secret secret: secrets "secret" is forbidden: denied`,
+				},
+			},
+		},
+		{
+			name:  "missing key",
+			key:   "token",
+			isErr: true,
+			expected: []audit.Entry{
+				{
+					Time: now, Action: secretRevealAction, Context: "context",
+					Namespace: "default", APIVersion: "v1", Kind: "Secret", Name: "secret", Key: "token",
+					Reason: `secret secret does not contain key "token"`,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			objectStore := storeFake.NewMockStore(controller)
+			if !tc.denied {
+				objectStore.EXPECT().Get(gomock.Any(), secretKey).Return(testutil.ToUnstructured(t, secret), nil).Times(2)
+			}
+
+			// every reveal sends a review for the secret.
+			var reviews []authorizationv1.ResourceAttributes
+			kubeClient := fake.NewSimpleClientset()
+			kubeClient.PrependReactor("create", "selfsubjectaccessreviews", func(action kTesting.Action) (bool, runtime.Object, error) {
+				sar := action.(kTesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+				reviews = append(reviews, *sar.Spec.ResourceAttributes)
+				sar.Status.Allowed = !tc.denied
+				if tc.denied {
+					sar.Status.Reason = "denied"
+				}
+				return true, sar, nil
+			})
+
+			clusterClient := clusterFake.NewMockClientInterface(controller)
+			clusterClient.EXPECT().KubernetesClient().Return(kubeClient, nil).AnyTimes()
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+			dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+			dashConfig.EXPECT().ContextName().Return("context").AnyTimes()
+
+			l := linkFake.NewMockInterface(controller)
+			l.EXPECT().ForGVK("default", "v1", "Secret", "secret", "secret").
+				Return(component.NewLink("", "secret", "/secret"), nil).AnyTimes()
+
+			options := describer.Options{
+				Dash:   dashConfig,
+				Link:   l,
+				Fields: map[string]string{"name": "secret", "key": tc.key},
+			}
+
+			trail := audit.NewMemoryTrail(log.NopLogger(), 10)
+
+			sv := newSecretValues("/config-and-storage/secret-values", trail)
+			sv.now = func() time.Time { return now }
+
+			ctx := context.Background()
+
+			// refreshing the page within the reveal window isn't recorded again.
+			for i := 0; i < 2; i++ {
+				got, err := sv.Describe(ctx, "/prefix", "default", options)
+				if tc.isErr {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+
+				require.Len(t, got.Components, 2)
+				history, ok := got.Components[1].(*component.Table)
+				require.True(t, ok)
+				assert.Len(t, history.Rows(), 1)
+			}
+
+			assert.Equal(t, tc.expected, trail.Entries())
+
+			review := authorizationv1.ResourceAttributes{Namespace: "default", Resource: "secrets", Name: "secret", Verb: "get"}
+			assert.Equal(t, []authorizationv1.ResourceAttributes{review, review}, reviews)
+		})
+	}
+}
+
+func Test_secretValues_record_window(t *testing.T) {
+	now := time.Unix(1547211430, 0)
+
+	trail := audit.NewMemoryTrail(log.NopLogger(), 10)
+	sv := newSecretValues("/config-and-storage/secret-values", trail)
+
+	entry := audit.Entry{Action: secretRevealAction, Name: "secret", Key: "password", Allowed: true}
+
+	ctx := context.Background()
+	for _, offset := range []time.Duration{0, 30 * time.Second, 2 * time.Minute} {
+		sv.now = func() time.Time { return now.Add(offset) }
+		sv.record(ctx, entry)
+	}
+
+	entries := trail.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, now, entries[0].Time)
+	assert.Equal(t, now.Add(2*time.Minute), entries[1].Time)
+}
@@ -0,0 +1,282 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secretviewer
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// ToComponent creates components which reveal a secret value. Values of
+// known types are decoded: docker configs, x509 certificates and basic auth
+// credentials.
+func ToComponent(secret *corev1.Secret, key string, now time.Time) ([]component.Component, error) {
+	if secret == nil {
+		return nil, errors.New("secret is nil")
+	}
+
+	data, ok := secret.Data[key]
+	if !ok {
+		return nil, errors.Errorf("secret %s does not contain key %q", secret.Name, key)
+	}
+
+	var components []component.Component
+
+	valueSummary := component.NewSummary("Value",
+		component.SummarySection{Header: "Key", Content: component.NewText(key)},
+		component.SummarySection{Header: "Size", Content: component.NewText(fmt.Sprintf("%d bytes", len(data)))},
+		valueSection(data),
+	)
+	valueSummary.SetAccessor("value")
+	components = append(components, valueSummary)
+
+	switch {
+	case key == corev1.DockerConfigJsonKey || key == corev1.DockerConfigKey:
+		credentials, err := DecodeDockerConfig(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode docker config")
+		}
+		components = append(components, registryCredentialsTable(credentials))
+	case HasCertificates(data):
+		certificates, err := DecodeCertificates(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode certificates")
+		}
+		components = append(components, certificatesTable(certificates, now))
+	case secret.Type == corev1.SecretTypeBasicAuth:
+		components = append(components, basicAuthSummary(secret))
+	}
+
+	return components, nil
+}
+
+// valueSection shows text values as they are. Binary values are base64
+// encoded.
+func valueSection(data []byte) component.SummarySection {
+	if utf8.Valid(data) {
+		return component.SummarySection{Header: "Value", Content: component.NewText(string(data))}
+	}
+
+	return component.SummarySection{
+		Header:  "Value (base64)",
+		Content: component.NewText(base64.StdEncoding.EncodeToString(data)),
+	}
+}
+
+// RegistryCredential is a credential for an image registry.
+type RegistryCredential struct {
+	Registry string
+	Username string
+	Password string
+	Email    string
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+	Auth     string `json:"auth"`
+}
+
+// DecodeDockerConfig decodes the registry credentials in a
+// .dockerconfigjson or .dockercfg value. The auth field is unpacked into
+// the username and password.
+func DecodeDockerConfig(data []byte) ([]RegistryCredential, error) {
+	var config struct {
+		Auths map[string]dockerConfigEntry `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	entries := config.Auths
+	if entries == nil {
+		// .dockercfg values don't have the auths wrapper.
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, err
+		}
+	}
+
+	var credentials []RegistryCredential
+	for registry, entry := range entries {
+		credential := RegistryCredential{
+			Registry: registry,
+			Username: entry.Username,
+			Password: entry.Password,
+			Email:    entry.Email,
+		}
+
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, errors.Wrapf(err, "decode auth for %s", registry)
+			}
+
+			parts := strings.SplitN(string(decoded), ":", 2)
+			if len(parts) != 2 {
+				return nil, errors.Errorf("auth for %s is not in username:password format", registry)
+			}
+
+			if credential.Username == "" {
+				credential.Username = parts[0]
+			}
+			if credential.Password == "" {
+				credential.Password = parts[1]
+			}
+		}
+
+		credentials = append(credentials, credential)
+	}
+
+	sort.Slice(credentials, func(i, j int) bool {
+		return credentials[i].Registry < credentials[j].Registry
+	})
+
+	return credentials, nil
+}
+
+func registryCredentialsTable(credentials []RegistryCredential) *component.Table {
+	table := component.NewTable("Registry Credentials",
+		component.NewTableCols("Registry", "Username", "Password", "Email"))
+
+	for _, credential := range credentials {
+		table.Add(component.TableRow{
+			"Registry": component.NewText(credential.Registry),
+			"Username": component.NewText(credential.Username),
+			"Password": component.NewText(credential.Password),
+			"Email":    component.NewText(credential.Email),
+		})
+	}
+
+	table.SetAccessor("registryCredentials")
+	return table
+}
+
+// Certificate describes an x509 certificate.
+type Certificate struct {
+	Subject   string
+	Issuer    string
+	SANs      []string
+	NotBefore time.Time
+	NotAfter  time.Time
+	IsCA      bool
+}
+
+// HasCertificates returns true if data contains a PEM encoded certificate.
+func HasCertificates(data []byte) bool {
+	return strings.Contains(string(data), "-----BEGIN CERTIFICATE-----")
+}
+
+// DecodeCertificates decodes the PEM encoded certificates in data. Other
+// PEM blocks are ignored.
+func DecodeCertificates(data []byte) ([]Certificate, error) {
+	var certificates []Certificate
+
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		var sans []string
+		sans = append(sans, cert.DNSNames...)
+		for _, ip := range cert.IPAddresses {
+			sans = append(sans, ip.String())
+		}
+		sans = append(sans, cert.EmailAddresses...)
+		for _, uri := range cert.URIs {
+			sans = append(sans, uri.String())
+		}
+
+		certificates = append(certificates, Certificate{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			SANs:      sans,
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+			IsCA:      cert.IsCA,
+		})
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificates found")
+	}
+
+	return certificates, nil
+}
+
+// certificateStatus describes the validity of a certificate at a time.
+func certificateStatus(certificate Certificate, now time.Time) string {
+	switch {
+	case now.Before(certificate.NotBefore):
+		return "Not yet valid"
+	case now.After(certificate.NotAfter):
+		return "Expired"
+	default:
+		days := int(certificate.NotAfter.Sub(now).Hours() / 24)
+		return fmt.Sprintf("Valid (expires in %d days)", days)
+	}
+}
+
+func certificatesTable(certificates []Certificate, now time.Time) *component.Table {
+	table := component.NewTable("Certificates",
+		component.NewTableCols("Subject", "Issuer", "SANs", "Not Before", "Expires", "CA", "Status"))
+
+	for _, certificate := range certificates {
+		table.Add(component.TableRow{
+			"Subject":    component.NewText(certificate.Subject),
+			"Issuer":     component.NewText(certificate.Issuer),
+			"SANs":       component.NewText(strings.Join(certificate.SANs, ", ")),
+			"Not Before": component.NewText(certificate.NotBefore.UTC().Format(time.RFC3339)),
+			"Expires":    component.NewText(certificate.NotAfter.UTC().Format(time.RFC3339)),
+			"CA":         component.NewText(fmt.Sprintf("%t", certificate.IsCA)),
+			"Status":     component.NewText(certificateStatus(certificate, now)),
+		})
+	}
+
+	table.SetAccessor("certificates")
+	return table
+}
+
+// basicAuthSummary shows the basic auth username and the authorization
+// header for the credentials.
+func basicAuthSummary(secret *corev1.Secret) *component.Summary {
+	username := secret.Data[corev1.BasicAuthUsernameKey]
+	password := secret.Data[corev1.BasicAuthPasswordKey]
+
+	header := base64.StdEncoding.EncodeToString([]byte(string(username) + ":" + string(password)))
+
+	summary := component.NewSummary("Basic Auth",
+		component.SummarySection{Header: "Username", Content: component.NewText(string(username))},
+		component.SummarySection{Header: "Authorization Header", Content: component.NewText("Basic " + header)},
+	)
+	summary.SetAccessor("basicAuth")
+
+	return summary
+}
@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package secretviewer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+var (
+	notBefore = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter  = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func createCertificate(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func createSecret(secretType corev1.SecretType, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "default"},
+		Type:       secretType,
+		Data:       data,
+	}
+}
+
+func TestToComponent(t *testing.T) {
+	now := time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	dockerConfig := []byte(`{"auths":{"registry.example.com":{"auth":"dXNlcjpwYXNz","email":"user@example.com"}}}`)
+
+	cases := []struct {
+		name     string
+		secret   *corev1.Secret
+		key      string
+		expected []component.Component
+		isErr    bool
+	}{
+		{
+			name:   "opaque",
+			secret: createSecret(corev1.SecretTypeOpaque, map[string][]byte{"key": []byte("value")}),
+			key:    "key",
+		},
+		{
+			name:   "binary",
+			secret: createSecret(corev1.SecretTypeOpaque, map[string][]byte{"key": {0xff, 0xfe}}),
+			key:    "key",
+		},
+		{
+			name:   "docker config",
+			secret: createSecret(corev1.SecretTypeDockerConfigJson, map[string][]byte{corev1.DockerConfigJsonKey: dockerConfig}),
+			key:    corev1.DockerConfigJsonKey,
+		},
+		{
+			name:   "tls",
+			secret: createSecret(corev1.SecretTypeTLS, map[string][]byte{corev1.TLSCertKey: createCertificate(t)}),
+			key:    corev1.TLSCertKey,
+		},
+		{
+			name: "basic auth",
+			secret: createSecret(corev1.SecretTypeBasicAuth, map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("user"),
+				corev1.BasicAuthPasswordKey: []byte("pass"),
+			}),
+			key: corev1.BasicAuthPasswordKey,
+		},
+		{
+			name:   "missing key",
+			secret: createSecret(corev1.SecretTypeOpaque, nil),
+			key:    "key",
+			isErr:  true,
+		},
+		{
+			name:  "nil secret",
+			key:   "key",
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToComponent(tc.secret, tc.key, now)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NotEmpty(t, got)
+			value, ok := got[0].(*component.Summary)
+			require.True(t, ok)
+			assert.Equal(t, "value", value.Metadata.Accessor)
+		})
+	}
+}
+
+func TestToComponent_value(t *testing.T) {
+	secret := createSecret(corev1.SecretTypeOpaque, map[string][]byte{"key": {0xff, 0xfe}})
+
+	got, err := ToComponent(secret, "key", time.Now())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+
+	expected := component.NewSummary("Value",
+		component.SummarySection{Header: "Key", Content: component.NewText("key")},
+		component.SummarySection{Header: "Size", Content: component.NewText("2 bytes")},
+		component.SummarySection{Header: "Value (base64)", Content: component.NewText("//4=")},
+	)
+	expected.SetAccessor("value")
+	assert.Equal(t, expected, got[0])
+}
+
+func TestToComponent_basic_auth(t *testing.T) {
+	secret := createSecret(corev1.SecretTypeBasicAuth, map[string][]byte{
+		corev1.BasicAuthUsernameKey: []byte("user"),
+		corev1.BasicAuthPasswordKey: []byte("pass"),
+	})
+
+	got, err := ToComponent(secret, corev1.BasicAuthPasswordKey, time.Now())
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	expected := component.NewSummary("Basic Auth",
+		component.SummarySection{Header: "Username", Content: component.NewText("user")},
+		component.SummarySection{Header: "Authorization Header", Content: component.NewText("Basic dXNlcjpwYXNz")},
+	)
+	expected.SetAccessor("basicAuth")
+	assert.Equal(t, expected, got[1])
+}
+
+func TestDecodeDockerConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected []RegistryCredential
+		isErr    bool
+	}{
+		{
+			name: "dockerconfigjson",
+			data: `{"auths":{"b.example.com":{"username":"b","password":"secret"},"a.example.com":{"auth":"dXNlcjpwYXNz","email":"user@example.com"}}}`,
+			expected: []RegistryCredential{
+				{Registry: "a.example.com", Username: "user", Password: "pass", Email: "user@example.com"},
+				{Registry: "b.example.com", Username: "b", Password: "secret"},
+			},
+		},
+		{
+			name: "dockercfg",
+			data: `{"a.example.com":{"auth":"dXNlcjpwYXNz"}}`,
+			expected: []RegistryCredential{
+				{Registry: "a.example.com", Username: "user", Password: "pass"},
+			},
+		},
+		{
+			name:  "invalid auth",
+			data:  `{"auths":{"a.example.com":{"auth":"dXNlcg=="}}}`,
+			isErr: true,
+		},
+		{
+			name:  "invalid json",
+			data:  `{`,
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DecodeDockerConfig([]byte(tc.data))
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestDecodeCertificates(t *testing.T) {
+	data := createCertificate(t)
+	require.True(t, HasCertificates(data))
+
+	got, err := DecodeCertificates(data)
+	require.NoError(t, err)
+
+	expected := []Certificate{
+		{
+			Subject:   "CN=example.com",
+			Issuer:    "CN=example.com",
+			SANs:      []string{"example.com", "www.example.com", "10.0.0.1"},
+			NotBefore: notBefore,
+			NotAfter:  notAfter,
+		},
+	}
+	assert.Equal(t, expected, got)
+
+	_, err = DecodeCertificates([]byte("not a certificate"))
+	require.Error(t, err)
+}
+
+func Test_certificateStatus(t *testing.T) {
+	certificate := Certificate{NotBefore: notBefore, NotAfter: notAfter}
+
+	assert.Equal(t, "Not yet valid", certificateStatus(certificate, notBefore.Add(-time.Hour)))
+	assert.Equal(t, "Valid (expires in 10 days)", certificateStatus(certificate, notAfter.Add(-10*24*time.Hour)))
+	assert.Equal(t, "Expired", certificateStatus(certificate, notAfter.Add(time.Hour)))
+}
@@ -0,0 +1,227 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"golang.org/x/net/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/vmware/octant/internal/api"
+	"github.com/vmware/octant/internal/cluster"
+	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/modules/overview/container"
+	"github.com/vmware/octant/internal/modules/overview/terminalviewer"
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	terminalMessageStdin  = "stdin"
+	terminalMessageResize = "resize"
+)
+
+// terminalMessage is a message sent from the frontend to a terminal.
+type terminalMessage struct {
+	Type string `json:"type"`
+	// Data is the input for stdin messages.
+	Data string `json:"data,omitempty"`
+	// Cols and Rows are the terminal size for resize messages.
+	Cols uint16 `json:"cols,omitempty"`
+	Rows uint16 `json:"rows,omitempty"`
+}
+
+type execFunc func(client cluster.RESTInterface, options container.ExecOptions) error
+
+// terminalHandler connects a WebSocket to a shell in a container. The
+// cluster client is looked up for each terminal so it follows the current
+// kube context.
+type terminalHandler struct {
+	dashConfig  config.Dash
+	objectStore store.Store
+	exec        execFunc
+	logger      log.Logger
+}
+
+func containerTerminalHandler(ctx context.Context, dashConfig config.Dash) http.Handler {
+	return &terminalHandler{
+		dashConfig:  dashConfig,
+		objectStore: dashConfig.ObjectStore(),
+		exec:        container.Exec,
+		logger:      log.From(ctx),
+	}
+}
+
+func (h *terminalHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	options := container.ExecOptions{
+		Namespace: vars["namespace"],
+		PodName:   vars["pod"],
+		Container: vars["container"],
+	}
+
+	shell := r.URL.Query().Get("shell")
+	if shell == "" {
+		shell = terminalviewer.Shells[0]
+	}
+
+	if !terminalviewer.IsShell(shell) {
+		http.Error(w, "unsupported shell", http.StatusBadRequest)
+		return
+	}
+
+	options.Command = []string{shell}
+
+	// check access before upgrading so the frontend gets a useful status
+	if err := terminalviewer.HasAccess(r.Context(), h.objectStore, options.Namespace); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	logger := h.logger.With(
+		"namespace", options.Namespace,
+		"pod", options.PodName,
+		"container", options.Container)
+
+	server := websocket.Server{
+		Handshake: checkTerminalOrigin,
+		Handler: func(ws *websocket.Conn) {
+			defer func() {
+				if err := ws.Close(); err != nil {
+					logger.WithErr(err).Debugf("closing terminal websocket")
+				}
+			}()
+
+			session := newTerminalSession(ws)
+			go session.receive()
+
+			options.Stdin = session
+			options.Stdout = session
+			options.SizeQueue = session
+
+			logger.Debugf("starting terminal")
+
+			if err := h.exec(h.dashConfig.ClusterClient(), options); err != nil {
+				logger.WithErr(err).Errorf("terminal exited")
+				_, _ = session.Write([]byte("\r\n" + err.Error() + "\r\n"))
+			}
+
+			session.Close()
+		},
+	}
+
+	server.ServeHTTP(w, r)
+}
+
+// checkTerminalOrigin rejects WebSockets opened by other sites, so a page
+// the user visits can't open a shell in their pods.
+func checkTerminalOrigin(config *websocket.Config, r *http.Request) error {
+	if !api.IsSameOrigin(r) {
+		return errors.Errorf("origin %q is not allowed", r.Header.Get("Origin"))
+	}
+
+	return nil
+}
+
+// terminalSession adapts a WebSocket to the streams used by exec. Input
+// and resize messages are received as JSON and output is sent as binary
+// frames.
+type terminalSession struct {
+	ws *websocket.Conn
+
+	stdinReader *io.PipeReader
+	stdinWriter *io.PipeWriter
+
+	sizeCh    chan remotecommand.TerminalSize
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ remotecommand.TerminalSizeQueue = (*terminalSession)(nil)
+
+func newTerminalSession(ws *websocket.Conn) *terminalSession {
+	r, w := io.Pipe()
+
+	return &terminalSession{
+		ws:          ws,
+		stdinReader: r,
+		stdinWriter: w,
+		sizeCh:      make(chan remotecommand.TerminalSize, 1),
+		done:        make(chan struct{}),
+	}
+}
+
+// receive reads messages from the WebSocket until it is closed.
+func (s *terminalSession) receive() {
+	defer s.Close()
+
+	for {
+		var msg terminalMessage
+		if err := websocket.JSON.Receive(s.ws, &msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case terminalMessageStdin:
+			if _, err := s.stdinWriter.Write([]byte(msg.Data)); err != nil {
+				return
+			}
+		case terminalMessageResize:
+			s.resize(remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows})
+		}
+	}
+}
+
+// resize queues a resize. Only the latest size matters, so a pending size
+// is replaced instead of blocking the receiver.
+func (s *terminalSession) resize(size remotecommand.TerminalSize) {
+	for {
+		select {
+		case s.sizeCh <- size:
+			return
+		case <-s.sizeCh:
+		}
+	}
+}
+
+// Read reads stdin.
+func (s *terminalSession) Read(p []byte) (int, error) {
+	return s.stdinReader.Read(p)
+}
+
+// Write writes stdout to the WebSocket.
+func (s *terminalSession) Write(p []byte) (int, error) {
+	if err := websocket.Message.Send(s.ws, p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Next returns the next terminal size. It returns nil once the session is closed.
+func (s *terminalSession) Next() *remotecommand.TerminalSize {
+	select {
+	case size := <-s.sizeCh:
+		return &size
+	case <-s.done:
+		return nil
+	}
+}
+
+// Close closes stdin and stops resize events.
+func (s *terminalSession) Close() {
+	s.closeOnce.Do(func() {
+		_ = s.stdinWriter.Close()
+		close(s.done)
+	})
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/vmware/octant/internal/cluster"
+	clusterfake "github.com/vmware/octant/internal/cluster/fake"
+	configFake "github.com/vmware/octant/internal/config/fake"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/modules/overview/container"
+	"github.com/vmware/octant/pkg/store"
+	storefake "github.com/vmware/octant/pkg/store/fake"
+)
+
+func execAccessKey() store.Key {
+	return store.Key{
+		Namespace:   "default",
+		APIVersion:  "v1",
+		Kind:        "Pod",
+		Subresource: "exec",
+	}
+}
+
+func newTerminalServer(t *testing.T, h *terminalHandler) *httptest.Server {
+	router := mux.NewRouter()
+	router.Handle("/namespace/{namespace}/terminal/pod/{pod}/container/{container}", h)
+	return httptest.NewServer(router)
+}
+
+func Test_terminalHandler(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := storefake.NewMockStore(controller)
+	objectStore.EXPECT().HasAccess(gomock.Any(), execAccessKey(), "create").Return(nil)
+
+	clusterClient := clusterfake.NewMockClientInterface(controller)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ClusterClient().Return(clusterClient)
+
+	var gotOptions container.ExecOptions
+	sizes := make(chan remotecommand.TerminalSize, 1)
+
+	h := &terminalHandler{
+		dashConfig:  dashConfig,
+		objectStore: objectStore,
+		logger:      log.NopLogger(),
+		exec: func(client cluster.RESTInterface, options container.ExecOptions) error {
+			if client != clusterClient {
+				return errors.New("unexpected cluster client")
+			}
+			gotOptions = options
+
+			sizes <- *options.SizeQueue.Next()
+
+			// echo a line of input
+			line, err := bufio.NewReader(options.Stdin).ReadString('\n')
+			if err != nil {
+				return err
+			}
+			_, err = options.Stdout.Write([]byte("echo: " + line))
+			return err
+		},
+	}
+
+	server := newTerminalServer(t, h)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") +
+		"/namespace/default/terminal/pod/pod/container/nginx?shell=/bin/bash"
+	ws, err := websocket.Dial(wsURL, "", server.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	require.NoError(t, websocket.JSON.Send(ws, terminalMessage{Type: terminalMessageResize, Cols: 80, Rows: 24}))
+	assert.Equal(t, remotecommand.TerminalSize{Width: 80, Height: 24}, <-sizes)
+
+	require.NoError(t, websocket.JSON.Send(ws, terminalMessage{Type: terminalMessageStdin, Data: "ls\n"}))
+
+	var out []byte
+	require.NoError(t, websocket.Message.Receive(ws, &out))
+	assert.Equal(t, "echo: ls\n", string(out))
+
+	assert.Equal(t, "default", gotOptions.Namespace)
+	assert.Equal(t, "pod", gotOptions.PodName)
+	assert.Equal(t, "nginx", gotOptions.Container)
+	assert.Equal(t, []string{"/bin/bash"}, gotOptions.Command)
+}
+
+func Test_terminalHandler_cross_site(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := storefake.NewMockStore(controller)
+	objectStore.EXPECT().HasAccess(gomock.Any(), execAccessKey(), "create").Return(nil)
+
+	h := &terminalHandler{
+		objectStore: objectStore,
+		logger:      log.NopLogger(),
+		exec: func(cluster.RESTInterface, container.ExecOptions) error {
+			return errors.New("unexpected exec")
+		},
+	}
+
+	server := newTerminalServer(t, h)
+	defer server.Close()
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") +
+		"/namespace/default/terminal/pod/pod/container/nginx"
+	_, err := websocket.Dial(wsURL, "", "http://hacker.com")
+	require.Error(t, err)
+}
+
+func Test_terminalHandler_errors(t *testing.T) {
+	cases := []struct {
+		name         string
+		shell        string
+		checksAccess bool
+		expectedCode int
+	}{
+		{
+			name:         "unsupported shell",
+			shell:        "rm",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "no access",
+			shell:        "/bin/sh",
+			checksAccess: true,
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			objectStore := storefake.NewMockStore(controller)
+			if tc.checksAccess {
+				objectStore.EXPECT().
+					HasAccess(gomock.Any(), execAccessKey(), "create").
+					Return(errors.New("denied"))
+			}
+
+			h := &terminalHandler{
+				objectStore: objectStore,
+				logger:      log.NopLogger(),
+				exec: func(cluster.RESTInterface, container.ExecOptions) error {
+					return errors.New("unexpected exec")
+				},
+			}
+
+			server := newTerminalServer(t, h)
+			defer server.Close()
+
+			res, err := http.Get(server.URL + "/namespace/default/terminal/pod/pod/container/nginx?shell=" + tc.shell)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, res.StatusCode)
+		})
+	}
+}
+
+func Test_terminalSession_resize(t *testing.T) {
+	s := newTerminalSession(nil)
+
+	s.resize(remotecommand.TerminalSize{Width: 1, Height: 1})
+	s.resize(remotecommand.TerminalSize{Width: 2, Height: 2})
+
+	assert.Equal(t, &remotecommand.TerminalSize{Width: 2, Height: 2}, s.Next())
+
+	s.Close()
+	assert.Nil(t, s.Next())
+}
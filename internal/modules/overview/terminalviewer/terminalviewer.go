@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package terminalviewer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// Shells are the shells a terminal can be started with. The first shell
+// is the default since it is available in most images.
+var Shells = []string{"/bin/sh", "/bin/bash", "/bin/ash"}
+
+// IsShell returns true if a shell can be used to start a terminal.
+func IsShell(shell string) bool {
+	for _, s := range Shells {
+		if s == shell {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasAccess returns an error if the current user can't exec into pods
+// in a namespace.
+func HasAccess(ctx context.Context, objectStore store.Store, namespace string) error {
+	if objectStore == nil {
+		return errors.New("object store is nil")
+	}
+
+	key := store.Key{
+		Namespace:   namespace,
+		APIVersion:  "v1",
+		Kind:        "Pod",
+		Subresource: "exec",
+	}
+
+	return objectStore.HasAccess(ctx, key, "create")
+}
+
+// ToComponent converts an object into a terminal component.
+func ToComponent(object runtime.Object) (component.Component, error) {
+	if object == nil {
+		return nil, errors.Errorf("object is nil")
+	}
+
+	pod := &corev1.Pod{}
+
+	switch t := object.(type) {
+	case *unstructured.Unstructured:
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(t.Object, pod); err != nil {
+			return nil, err
+		}
+	case *corev1.Pod:
+		pod = t
+	default:
+		pod = nil
+	}
+
+	if pod == nil {
+		return nil, errors.Errorf("can't open a terminal in a %T", object)
+	}
+
+	// init containers have exited by the time a terminal could be opened
+	var containerNames []string
+	for _, c := range pod.Spec.Containers {
+		containerNames = append(containerNames, c.Name)
+	}
+
+	return component.NewTerminal(pod.Namespace, pod.Name, containerNames, Shells), nil
+}
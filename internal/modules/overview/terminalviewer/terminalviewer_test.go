@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package terminalviewer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/pkg/store"
+	storefake "github.com/vmware/octant/pkg/store/fake"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func Test_ToComponent(t *testing.T) {
+	cases := []struct {
+		name     string
+		object   runtime.Object
+		expected component.Component
+		isErr    bool
+	}{
+		{
+			name: "with containers",
+			object: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "default",
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{Name: "init"},
+					},
+					Containers: []corev1.Container{
+						{Name: "one"},
+						{Name: "two"},
+					},
+				},
+			},
+			expected: component.NewTerminal("default", "pod", []string{"one", "two"}, Shells),
+		},
+		{
+			name:   "nil object",
+			object: nil,
+			isErr:  true,
+		},
+		{
+			name:   "not a pod",
+			object: &corev1.Service{},
+			isErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ToComponent(tc.object)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_HasAccess(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	key := store.Key{
+		Namespace:   "default",
+		APIVersion:  "v1",
+		Kind:        "Pod",
+		Subresource: "exec",
+	}
+
+	objectStore := storefake.NewMockStore(controller)
+	objectStore.EXPECT().HasAccess(gomock.Any(), key, "create").Return(nil)
+	objectStore.EXPECT().HasAccess(gomock.Any(), key, "create").Return(errors.New("denied"))
+
+	ctx := context.Background()
+	assert.NoError(t, HasAccess(ctx, objectStore, "default"))
+	assert.Error(t, HasAccess(ctx, objectStore, "default"))
+	assert.Error(t, HasAccess(ctx, nil, "default"))
+}
+
+func Test_IsShell(t *testing.T) {
+	assert.True(t, IsShell("/bin/sh"))
+	assert.False(t, IsShell("rm"))
+}
@@ -0,0 +1,282 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelineviewer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware/octant/internal/componentcache"
+	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/link"
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor"
+	"github.com/vmware/octant/internal/queryer"
+	"github.com/vmware/octant/internal/util/kubernetes"
+	"github.com/vmware/octant/pkg/store"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+// Collector is an object handler which collects the objects a visitor visits.
+type Collector struct {
+	objects map[types.UID]runtime.Object
+	mu      sync.Mutex
+}
+
+var _ objectvisitor.ObjectHandler = (*Collector)(nil)
+
+// NewCollector creates an instance of Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		objects: make(map[types.UID]runtime.Object),
+	}
+}
+
+// AddEdge does nothing since every visited object is processed.
+func (c *Collector) AddEdge(v1, v2 runtime.Object, label objectvisitor.EdgeLabel) error {
+	return nil
+}
+
+// Process collects an object.
+func (c *Collector) Process(ctx context.Context, object runtime.Object) error {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.objects[accessor.GetUID()] = object
+
+	return nil
+}
+
+// Objects returns the collected objects.
+func (c *Collector) Objects() []runtime.Object {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var objects []runtime.Object
+	for _, object := range c.objects {
+		objects = append(objects, object)
+	}
+
+	return objects
+}
+
+// TimelineViewer visits an object and creates a timeline of the events for
+// it and its ancestors and descendants.
+type TimelineViewer struct {
+	visitor    objectvisitor.Visitor
+	queryer    queryer.Queryer
+	linkConfig link.Config
+}
+
+// New creates an instance of TimelineViewer.
+func New(visitor objectvisitor.Visitor, q queryer.Queryer, linkConfig link.Config) (*TimelineViewer, error) {
+	if visitor == nil {
+		return nil, errors.New("timeline viewer visitor is nil")
+	}
+
+	if q == nil {
+		return nil, errors.New("timeline viewer queryer is nil")
+	}
+
+	return &TimelineViewer{
+		visitor:    visitor,
+		queryer:    q,
+		linkConfig: linkConfig,
+	}, nil
+}
+
+// Visit visits an object and creates a timeline component.
+func (tv *TimelineViewer) Visit(ctx context.Context, object runtime.Object) (*component.Timeline, error) {
+	ctx, span := trace.StartSpan(ctx, "timelineViewer")
+	defer span.End()
+
+	collector := NewCollector()
+	if err := tv.visitor.Visit(ctx, object, collector); err != nil {
+		return nil, errors.Wrapf(err, "unable to visit object %s", kubernetes.PrintObject(object))
+	}
+
+	var events []*corev1.Event
+	for _, visited := range collector.Objects() {
+		accessor, err := meta.Accessor(visited)
+		if err != nil {
+			return nil, err
+		}
+
+		objectEvents, err := tv.queryer.Events(ctx, accessor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "get events for %s", kubernetes.PrintObject(visited))
+		}
+
+		events = append(events, objectEvents...)
+	}
+
+	return ToComponent(events, tv.linkConfig), nil
+}
+
+// ToComponent creates a timeline from events. Events with the same object,
+// type, reason, message and source are combined and their counts are added.
+// Events are sorted by when they were first seen.
+func ToComponent(events []*corev1.Event, linkConfig link.Config) *component.Timeline {
+	seen := make(map[string]int)
+	var timelineEvents []component.TimelineEvent
+
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		involved := event.InvolvedObject
+		source := formatSource(event.Source)
+		firstSeen, lastSeen := eventTimes(event)
+
+		count := event.Count
+		if count < 1 {
+			count = 1
+		}
+
+		key := strings.Join([]string{
+			involved.Namespace, involved.APIVersion, involved.Kind, involved.Name,
+			event.Type, event.Reason, event.Message, source,
+		}, "\x00")
+
+		if i, ok := seen[key]; ok {
+			te := &timelineEvents[i]
+			te.Count += count
+			if firstSeen.Unix() < te.FirstSeen {
+				te.FirstSeen = firstSeen.Unix()
+			}
+			if lastSeen.Unix() > te.LastSeen {
+				te.LastSeen = lastSeen.Unix()
+			}
+			continue
+		}
+
+		te := component.TimelineEvent{
+			Kind:      involved.Kind,
+			Name:      involved.Name,
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Message:   event.Message,
+			Source:    source,
+			Count:     count,
+			FirstSeen: firstSeen.Unix(),
+			LastSeen:  lastSeen.Unix(),
+		}
+
+		if linkConfig != nil {
+			if p, err := linkConfig.ObjectPath(involved.Namespace, involved.APIVersion, involved.Kind, involved.Name); err == nil {
+				te.Path = p
+			}
+		}
+
+		seen[key] = len(timelineEvents)
+		timelineEvents = append(timelineEvents, te)
+	}
+
+	sort.SliceStable(timelineEvents, func(i, j int) bool {
+		a, b := timelineEvents[i], timelineEvents[j]
+		if a.FirstSeen != b.FirstSeen {
+			return a.FirstSeen < b.FirstSeen
+		}
+		if a.LastSeen != b.LastSeen {
+			return a.LastSeen < b.LastSeen
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	return component.NewTimeline("Timeline", timelineEvents)
+}
+
+// eventTimes returns when an event was first and last seen. Events created
+// with the events API may only have an event time.
+func eventTimes(event *corev1.Event) (time.Time, time.Time) {
+	firstSeen := event.FirstTimestamp.Time
+	if firstSeen.IsZero() {
+		firstSeen = event.EventTime.Time
+	}
+	if firstSeen.IsZero() {
+		firstSeen = event.CreationTimestamp.Time
+	}
+
+	lastSeen := event.LastTimestamp.Time
+	if lastSeen.IsZero() && event.Series != nil {
+		lastSeen = event.Series.LastObservedTime.Time
+	}
+	if lastSeen.IsZero() {
+		lastSeen = firstSeen
+	}
+
+	return firstSeen, lastSeen
+}
+
+func formatSource(es corev1.EventSource) string {
+	if es.Host == "" {
+		return es.Component
+	}
+
+	return fmt.Sprintf("%s on %s", es.Component, es.Host)
+}
+
+// CachedTimeline returns a timeline component from the component cache and starts a new visit.
+func CachedTimeline(object runtime.Object, dashConfig config.Dash, q queryer.Queryer) componentcache.UpdateFn {
+	return func(ctx context.Context, cacheChan chan componentcache.Event) (string, error) {
+		var event componentcache.Event
+		event.Name = "Timeline"
+
+		copyObject := object.DeepCopyObject()
+
+		key, err := store.KeyFromObject(copyObject)
+		if err != nil {
+			return "", err
+		}
+		sKey := fmt.Sprintf("%s-%s", "timeline", key.String())
+		event.Key = sKey
+
+		componentCache := dashConfig.ComponentCache()
+		if _, ok := componentCache.Get(sKey); !ok {
+			title := component.Title(component.NewText("Timeline"))
+			loading := component.NewLoading(title, "Timeline")
+			componentCache.Add(sKey, loading)
+		}
+
+		visitor, err := objectvisitor.NewDefaultVisitor(dashConfig, q)
+		if err != nil {
+			return sKey, err
+		}
+
+		tv, err := New(visitor, q, dashConfig)
+		if err != nil {
+			return sKey, err
+		}
+
+		go func() {
+			c, err := tv.Visit(ctx, copyObject)
+			event.Err = err
+			event.Component = c
+			cacheChan <- event
+		}()
+
+		return sKey, nil
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelineviewer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/modules/overview/objectvisitor"
+	objectvisitorFake "github.com/vmware/octant/internal/modules/overview/objectvisitor/fake"
+	queryerFake "github.com/vmware/octant/internal/queryer/fake"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+type fakeLinkConfig struct{}
+
+func (fakeLinkConfig) ObjectPath(namespace, apiVersion, kind, name string) (string, error) {
+	if kind == "Unknown" {
+		return "", errors.New("unknown")
+	}
+	return fmt.Sprintf("/%s/%s/%s", namespace, kind, name), nil
+}
+
+func createEvent(name string, involved runtime.Object, eventType, reason, message string, count int32, first, last int64) *corev1.Event {
+	event := testutil.CreateEvent(name)
+	if involved != nil {
+		accessor, _ := meta.Accessor(involved)
+		event.InvolvedObject = corev1.ObjectReference{
+			Namespace:  accessor.GetNamespace(),
+			APIVersion: involved.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+			Kind:       involved.GetObjectKind().GroupVersionKind().Kind,
+			Name:       accessor.GetName(),
+		}
+	}
+	event.Type = eventType
+	event.Reason = reason
+	event.Message = message
+	event.Count = count
+	event.FirstTimestamp = metav1.Time{Time: time.Unix(first, 0)}
+	event.LastTimestamp = metav1.Time{Time: time.Unix(last, 0)}
+	event.Source = corev1.EventSource{Component: "controller"}
+	return event
+}
+
+func TestTimelineViewer_Visit(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.CreateDeployment("deployment")
+	deployment.UID = "deployment"
+	replicaSet := testutil.CreateAppReplicaSet("replica-set")
+	replicaSet.UID = "replica-set"
+	pod := testutil.CreatePod("pod")
+	pod.UID = "pod"
+
+	events := map[string][]*corev1.Event{
+		"deployment": {
+			createEvent("d1", deployment, "Normal", "ScalingReplicaSet", "Scaled up replica set", 1, 10, 10),
+		},
+		"replica-set": {
+			createEvent("r1", replicaSet, "Normal", "SuccessfulCreate", "Created pod: pod", 1, 11, 11),
+		},
+		"pod": {
+			createEvent("p1", pod, "Warning", "BackOff", "Back-off restarting failed container", 3, 20, 40),
+			createEvent("p2", pod, "Warning", "BackOff", "Back-off restarting failed container", 2, 15, 50),
+			createEvent("p3", pod, "Normal", "Pulled", "Container image pulled", 1, 12, 12),
+		},
+	}
+
+	visitor := objectvisitorFake.NewMockVisitor(controller)
+	visitor.EXPECT().
+		Visit(gomock.Any(), deployment, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, object runtime.Object, handler objectvisitor.ObjectHandler) error {
+			for _, o := range []runtime.Object{deployment, replicaSet, pod, pod} {
+				if err := handler.Process(ctx, o); err != nil {
+					return err
+				}
+			}
+			return handler.AddEdge(replicaSet, pod, objectvisitor.EdgeLabelOwner)
+		})
+
+	q := queryerFake.NewMockQueryer(controller)
+	q.EXPECT().
+		Events(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, object metav1.Object) ([]*corev1.Event, error) {
+			return events[object.GetName()], nil
+		}).
+		Times(3)
+
+	tv, err := New(visitor, q, fakeLinkConfig{})
+	require.NoError(t, err)
+
+	got, err := tv.Visit(context.Background(), deployment)
+	require.NoError(t, err)
+
+	expected := component.NewTimeline("Timeline", []component.TimelineEvent{
+		{
+			Kind: "Deployment", Name: "deployment", Path: "/namespace/Deployment/deployment",
+			Type: "Normal", Reason: "ScalingReplicaSet", Message: "Scaled up replica set",
+			Source: "controller", Count: 1, FirstSeen: 10, LastSeen: 10,
+		},
+		{
+			Kind: "ReplicaSet", Name: "replica-set", Path: "/namespace/ReplicaSet/replica-set",
+			Type: "Normal", Reason: "SuccessfulCreate", Message: "Created pod: pod",
+			Source: "controller", Count: 1, FirstSeen: 11, LastSeen: 11,
+		},
+		{
+			Kind: "Pod", Name: "pod", Path: "/namespace/Pod/pod",
+			Type: "Normal", Reason: "Pulled", Message: "Container image pulled",
+			Source: "controller", Count: 1, FirstSeen: 12, LastSeen: 12,
+		},
+		{
+			Kind: "Pod", Name: "pod", Path: "/namespace/Pod/pod",
+			Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container",
+			Source: "controller", Count: 5, FirstSeen: 15, LastSeen: 50,
+		},
+	})
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, []string{"Normal", "Warning"}, got.Config.Types)
+	assert.Equal(t, []string{"BackOff", "Pulled", "ScalingReplicaSet", "SuccessfulCreate"}, got.Config.Reasons)
+}
+
+func TestTimelineViewer_Visit_error(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	pod := testutil.CreatePod("pod")
+
+	visitor := objectvisitorFake.NewMockVisitor(controller)
+	visitor.EXPECT().Visit(gomock.Any(), pod, gomock.Any()).Return(errors.New("failed"))
+
+	q := queryerFake.NewMockQueryer(controller)
+
+	tv, err := New(visitor, q, fakeLinkConfig{})
+	require.NoError(t, err)
+
+	_, err = tv.Visit(context.Background(), pod)
+	require.Error(t, err)
+}
+
+func TestToComponent(t *testing.T) {
+	seriesEvent := testutil.CreateEvent("series")
+	seriesEvent.InvolvedObject = corev1.ObjectReference{Kind: "Unknown", Name: "thing"}
+	seriesEvent.Reason = "Tick"
+	seriesEvent.EventTime = metav1.NewMicroTime(time.Unix(100, 0))
+	seriesEvent.Series = &corev1.EventSeries{LastObservedTime: metav1.NewMicroTime(time.Unix(200, 0))}
+
+	got := ToComponent([]*corev1.Event{nil, seriesEvent}, fakeLinkConfig{})
+
+	expected := component.NewTimeline("Timeline", []component.TimelineEvent{
+		{Kind: "Unknown", Name: "thing", Reason: "Tick", Count: 1, FirstSeen: 100, LastSeen: 200},
+	})
+
+	assert.Equal(t, expected, got)
+}
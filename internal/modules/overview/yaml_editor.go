@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package overview
+
+import (
+	"context"
+
+	"github.com/vmware/octant/internal/config"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/modules/overview/yamleditor"
+	"github.com/vmware/octant/internal/openapi"
+	"github.com/vmware/octant/pkg/action"
+	"github.com/vmware/octant/pkg/store"
+)
+
+// YAMLApplier applies YAML edited in the YAML editor.
+type YAMLApplier struct {
+	logger     log.Logger
+	dashConfig config.Dash
+	schemas    func() (openapi.Interface, error)
+}
+
+// NewYAMLApplier creates an instance of YAMLApplier. schemas returns the
+// schemas edits are validated against. The object store and schemas are
+// resolved for each request so edits are applied to the current cluster.
+func NewYAMLApplier(logger log.Logger, dashConfig config.Dash, schemas func() (openapi.Interface, error)) *YAMLApplier {
+	return &YAMLApplier{
+		logger:     logger,
+		dashConfig: dashConfig,
+		schemas:    schemas,
+	}
+}
+
+// ActionName returns the name of the action.
+func (a *YAMLApplier) ActionName() string {
+	return yamleditor.ActionName
+}
+
+// Handle applies edited YAML.
+func (a *YAMLApplier) Handle(ctx context.Context, payload action.Payload) error {
+	gvk, err := payload.GroupVersionKind()
+	if err != nil {
+		return err
+	}
+
+	name, err := payload.String("name")
+	if err != nil {
+		return err
+	}
+
+	namespace, err := payload.String("namespace")
+	if err != nil {
+		return err
+	}
+
+	text, err := payload.String("yaml")
+	if err != nil {
+		return err
+	}
+
+	// the force check box isn't sent if it isn't checked.
+	force := false
+	if options, err := payload.StringSlice("force"); err == nil {
+		force = len(options) > 0
+	}
+
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+
+	key := store.Key{
+		Namespace:  namespace,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+	}
+
+	logger := a.logger.With("actionName", yamleditor.ActionName, "key", key.String(), "force", force)
+
+	var schemas openapi.Interface
+	if a.schemas != nil {
+		schemas, err = a.schemas()
+		if err != nil {
+			logger.WithErr(err).Errorf("load schemas; YAML won't be validated")
+		}
+	}
+
+	editor := yamleditor.NewEditor(a.dashConfig.ObjectStore(), schemas)
+
+	result, err := editor.Apply(ctx, yamleditor.Request{Key: key, YAML: text, Force: force})
+	if err != nil {
+		logger.WithErr(err).Infof("apply YAML")
+		return err
+	}
+
+	logger.With("changes", len(result.Changes)).Infof("applied YAML")
+	return nil
+}
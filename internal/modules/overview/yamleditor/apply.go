@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware/octant/internal/openapi"
+	"github.com/vmware/octant/pkg/store"
+)
+
+// Request is a request to apply edited YAML.
+type Request struct {
+	// Key is the key for the object which was edited.
+	Key store.Key
+	// YAML is the edited YAML.
+	YAML string
+	// Force overwrites changes made since the object was last applied.
+	Force bool
+}
+
+// Result is the result of applying edited YAML.
+type Result struct {
+	// Changes are the changes which were applied.
+	Changes []Change
+}
+
+// ConflictError is returned when edited YAML changes fields which were
+// changed since the object was last applied.
+type ConflictError struct {
+	Changes []Change
+}
+
+func (e *ConflictError) Error() string {
+	var paths []string
+	for _, change := range e.Changes {
+		paths = append(paths, change.Path)
+	}
+
+	return fmt.Sprintf("fields were changed since the object was last applied: %s; apply again with overwrite to replace them",
+		strings.Join(paths, ", "))
+}
+
+// Editor applies edited YAML to objects.
+type Editor struct {
+	objectStore store.Store
+	schemas     openapi.Interface
+}
+
+// NewEditor creates an instance of Editor. schemas can be nil, in which
+// case edits aren't validated before they are applied.
+func NewEditor(objectStore store.Store, schemas openapi.Interface) *Editor {
+	return &Editor{
+		objectStore: objectStore,
+		schemas:     schemas,
+	}
+}
+
+// Apply validates edited YAML and updates the object with it. Read-only
+// fields are ignored. The last applied configuration annotation is set to
+// the edits so later edits are checked against them. If the edits change fields which were changed since
+// the object was last applied, a ConflictError is returned unless the
+// request is forced. Edits made to an outdated version of the object are
+// rejected unless the request is forced.
+func (e *Editor) Apply(ctx context.Context, request Request) (*Result, error) {
+	if e.objectStore == nil {
+		return nil, errors.New("object store is nil")
+	}
+
+	edited, err := Parse(request.YAML)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkIdentity(request.Key, edited); err != nil {
+		return nil, err
+	}
+
+	Strip(edited.Object)
+
+	if e.schemas != nil {
+		schema, err := e.schemas.Lookup(ctx, edited.GroupVersionKind())
+		if err != nil && err != openapi.ErrSchemaNotFound {
+			return nil, errors.Wrap(err, "look up schema")
+		}
+
+		if errs := Validate(schema, edited.Object, request.YAML); len(errs) > 0 {
+			return nil, errs
+		}
+	}
+
+	live, err := e.objectStore.Get(ctx, request.Key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get %s", request.Key)
+	}
+	if live == nil {
+		return nil, errors.Errorf("%s was not found", request.Key)
+	}
+
+	lastApplied, err := lastAppliedConfiguration(live.GetAnnotations())
+	if err != nil {
+		return nil, err
+	}
+
+	liveObject := live.DeepCopy().Object
+	Strip(liveObject)
+
+	changes := Diff(lastApplied, liveObject, edited.Object)
+
+	var conflicts []Change
+	for _, change := range changes {
+		if change.Conflict {
+			conflicts = append(conflicts, change)
+		}
+	}
+	if len(conflicts) > 0 && !request.Force {
+		return nil, &ConflictError{Changes: conflicts}
+	}
+
+	if len(changes) == 0 {
+		return &Result{}, nil
+	}
+
+	editedVersion := edited.GetResourceVersion()
+
+	if err := setLastAppliedConfiguration(edited); err != nil {
+		return nil, err
+	}
+
+	updater := func(object *unstructured.Unstructured) error {
+		currentVersion := object.GetResourceVersion()
+		if !request.Force && editedVersion != "" && editedVersion != currentVersion {
+			return errors.Errorf("%s was modified while it was edited (resource version %s, edited %s); reload it or apply with overwrite",
+				request.Key, currentVersion, editedVersion)
+		}
+
+		updated := edited.DeepCopy()
+		updated.SetResourceVersion(currentVersion)
+		object.Object = updated.Object
+		return nil
+	}
+
+	if err := e.objectStore.Update(ctx, request.Key, updater); err != nil {
+		return nil, errors.Wrapf(err, "update %s", request.Key)
+	}
+
+	return &Result{Changes: changes}, nil
+}
+
+// checkIdentity ensures the edited YAML describes the object which was
+// edited.
+func checkIdentity(key store.Key, edited *unstructured.Unstructured) error {
+	fields := []struct {
+		name     string
+		expected string
+		got      string
+	}{
+		{name: "apiVersion", expected: key.APIVersion, got: edited.GetAPIVersion()},
+		{name: "kind", expected: key.Kind, got: edited.GetKind()},
+		{name: "metadata.name", expected: key.Name, got: edited.GetName()},
+		{name: "metadata.namespace", expected: key.Namespace, got: edited.GetNamespace()},
+	}
+
+	for _, field := range fields {
+		if field.expected != field.got {
+			return errors.Errorf("%s can't be changed (expected %q, got %q)", field.name, field.expected, field.got)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	openapiFake "github.com/vmware/octant/internal/openapi/fake"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+)
+
+func TestEditor_Apply(t *testing.T) {
+	key := store.Key{Namespace: "default", APIVersion: "v1", Kind: "Pod", Name: "pod"}
+
+	newLive := func(resourceVersion string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":            "pod",
+				"namespace":       "default",
+				"uid":             "uid",
+				"resourceVersion": resourceVersion,
+				"annotations": map[string]interface{}{
+					corev1.LastAppliedConfigAnnotation: `{"spec":{"containers":[{"name":"app","image":"nginx:1"}]}}`,
+				},
+			},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "nginx:1", "stdin": true},
+				},
+			},
+			"status": map[string]interface{}{"phase": "Running"},
+		}}
+	}
+
+	edit := func(image string) string {
+		return `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: default
+  resourceVersion: "1"
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"spec":{"containers":[{"name":"app","image":"nginx:1"}]}}'
+spec:
+  containers:
+  - name: app
+    image: ` + image + `
+    stdin: true
+status:
+  phase: Unknown
+`
+	}
+
+	cases := []struct {
+		name          string
+		yaml          string
+		force         bool
+		live          *unstructured.Unstructured
+		current       *unstructured.Unstructured
+		expectUpdate  bool
+		expected      []Change
+		expectedImage string
+		errContains   string
+	}{
+		{
+			name:          "apply",
+			yaml:          edit("nginx:2"),
+			live:          newLive("1"),
+			current:       newLive("1"),
+			expectUpdate:  true,
+			expected:      []Change{{Path: "spec.containers[0].image", LastApplied: `"nginx:1"`, Live: `"nginx:1"`, Edited: `"nginx:2"`}},
+			expectedImage: "nginx:2",
+		},
+		{
+			name: "no changes",
+			yaml: edit("nginx:1"),
+			live: newLive("1"),
+		},
+		{
+			name:        "changed identity",
+			yaml:        edit("nginx:2") + "\n" + "kind: Service\n",
+			errContains: "kind can't be changed",
+		},
+		{
+			name:        "invalid",
+			yaml:        edit("[nginx]"),
+			errContains: "line 12: spec.containers[0].image: expected string, got array",
+		},
+		{
+			name:        "conflict",
+			yaml:        edit("nginx:2"),
+			live:        withImage(newLive("1"), "nginx:3"),
+			errContains: "spec.containers[0].image",
+		},
+		{
+			name:          "forced conflict",
+			yaml:          edit("nginx:2"),
+			force:         true,
+			live:          withImage(newLive("1"), "nginx:3"),
+			current:       withImage(newLive("2"), "nginx:3"),
+			expectUpdate:  true,
+			expected:      []Change{{Path: "spec.containers[0].image", LastApplied: `"nginx:1"`, Live: `"nginx:3"`, Edited: `"nginx:2"`, Conflict: true}},
+			expectedImage: "nginx:2",
+		},
+		{
+			name:         "modified while editing",
+			yaml:         edit("nginx:2"),
+			live:         newLive("1"),
+			current:      newLive("2"),
+			expectUpdate: true,
+			errContains:  "was modified while it was edited",
+		},
+		{
+			name:        "not found",
+			yaml:        edit("nginx:2"),
+			errContains: "was not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			schemas := openapiFake.NewMockInterface(controller)
+			schemas.EXPECT().Lookup(gomock.Any(), gomock.Any()).Return(podSchema(), nil).AnyTimes()
+
+			objectStore := storeFake.NewMockStore(controller)
+			objectStore.EXPECT().Get(gomock.Any(), key).Return(tc.live, nil).AnyTimes()
+
+			var updated *unstructured.Unstructured
+			if tc.expectUpdate {
+				objectStore.EXPECT().Update(gomock.Any(), key, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, key store.Key, fn func(*unstructured.Unstructured) error) error {
+						updated = tc.current.DeepCopy()
+						return fn(updated)
+					})
+			}
+
+			editor := NewEditor(objectStore, schemas)
+
+			got, err := editor.Apply(context.Background(), Request{Key: key, YAML: tc.yaml, Force: tc.force})
+			if tc.errContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got.Changes)
+
+			if tc.expectedImage != "" {
+				require.NotNil(t, updated)
+				containers, _, err := unstructured.NestedSlice(updated.Object, "spec", "containers")
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedImage, containers[0].(map[string]interface{})["image"])
+				assert.Equal(t, tc.current.GetResourceVersion(), updated.GetResourceVersion())
+
+				_, found, err := unstructured.NestedMap(updated.Object, "status")
+				require.NoError(t, err)
+				assert.False(t, found)
+
+				lastApplied, err := lastAppliedConfiguration(updated.GetAnnotations())
+				require.NoError(t, err)
+				assert.Equal(t, map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Pod",
+					"metadata": map[string]interface{}{
+						"name":      "pod",
+						"namespace": "default",
+					},
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "app", "image": tc.expectedImage, "stdin": true},
+						},
+					},
+				}, lastApplied)
+			}
+		})
+	}
+}
+
+func withImage(object *unstructured.Unstructured, image string) *unstructured.Unstructured {
+	containers, _, _ := unstructured.NestedSlice(object.Object, "spec", "containers")
+	containers[0].(map[string]interface{})["image"] = image
+	_ = unstructured.SetNestedSlice(object.Object, containers, "spec", "containers")
+	return object
+}
@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Change is a field which differs between versions of an object. Values
+// are JSON encoded and are empty if the field isn't set.
+type Change struct {
+	Path        string
+	LastApplied string
+	Live        string
+	Edited      string
+	// Conflict is true if the live value was changed since the object was
+	// last applied. Applying the change overwrites it.
+	Conflict bool
+}
+
+// ignoredDiffPaths are fields which are expected to change and aren't
+// reported in diffs.
+var ignoredDiffPaths = map[string]bool{
+	"metadata.resourceVersion":                                   true,
+	"metadata.annotations." + corev1.LastAppliedConfigAnnotation: true,
+}
+
+// Diff creates a three way diff. Every field which differs between live
+// and edited is a change. A change conflicts if lastApplied sets the field
+// and the live value no longer matches it. lastApplied can be nil if the
+// object was never applied, in which case there are no conflicts.
+func Diff(lastApplied, live, edited map[string]interface{}) []Change {
+	lastAppliedValues := flatten(lastApplied)
+	liveValues := flatten(live)
+	editedValues := flatten(edited)
+
+	paths := make(map[string]bool)
+	for p := range liveValues {
+		paths[p] = true
+	}
+	for p := range editedValues {
+		paths[p] = true
+	}
+
+	var changes []Change
+	for p := range paths {
+		if ignoredDiffPaths[p] || liveValues[p] == editedValues[p] {
+			continue
+		}
+
+		lastAppliedValue, applied := lastAppliedValues[p]
+
+		changes = append(changes, Change{
+			Path:        p,
+			LastApplied: lastAppliedValue,
+			Live:        liveValues[p],
+			Edited:      editedValues[p],
+			Conflict:    applied && lastAppliedValue != liveValues[p],
+		})
+	}
+
+	sortChanges(changes)
+	return changes
+}
+
+// Drift lists fields set by the last applied configuration whose live
+// values have since changed.
+func Drift(lastApplied, live map[string]interface{}) []Change {
+	liveValues := flatten(live)
+
+	var changes []Change
+	for p, value := range flatten(lastApplied) {
+		if ignoredDiffPaths[p] || liveValues[p] == value {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Path:        p,
+			LastApplied: value,
+			Live:        liveValues[p],
+			Conflict:    true,
+		})
+	}
+
+	sortChanges(changes)
+	return changes
+}
+
+// flatten converts an object into a map of leaf paths to JSON encoded
+// values. Empty objects and arrays are leaves.
+func flatten(object map[string]interface{}) map[string]string {
+	values := make(map[string]string)
+	if object != nil {
+		flattenValue("", object, values)
+	}
+	return values
+}
+
+func flattenValue(path string, value interface{}, values map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) > 0 {
+			for key, child := range v {
+				flattenValue(joinPath(path, key), child, values)
+			}
+			return
+		}
+	case []interface{}:
+		if len(v) > 0 {
+			for i, child := range v {
+				flattenValue(fmt.Sprintf("%s[%d]", path, i), child, values)
+			}
+			return
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", value))
+	}
+	values[path] = string(data)
+}
+
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+}
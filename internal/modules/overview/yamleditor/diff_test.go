@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	lastApplied := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": float64(1),
+			"image":    "nginx:1",
+		},
+	}
+
+	live := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "2",
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"image":    "nginx:1",
+			"paused":   true,
+		},
+	}
+
+	edited := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "1",
+			"labels":          map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": float64(2),
+			"image":    "nginx:2",
+			"paused":   true,
+		},
+	}
+
+	expected := []Change{
+		{Path: "metadata.labels.app", Edited: `"web"`},
+		{Path: "spec.image", LastApplied: `"nginx:1"`, Live: `"nginx:1"`, Edited: `"nginx:2"`},
+		{Path: "spec.replicas", LastApplied: "1", Live: "3", Edited: "2", Conflict: true},
+	}
+
+	assert.Equal(t, expected, Diff(lastApplied, live, edited))
+}
+
+func TestDiff_no_last_applied(t *testing.T) {
+	live := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ports": []interface{}{float64(80)},
+		},
+	}
+
+	edited := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ports": []interface{}{},
+		},
+	}
+
+	expected := []Change{
+		{Path: "spec.ports", Edited: "[]"},
+		{Path: "spec.ports[0]", Live: "80"},
+	}
+
+	assert.Equal(t, expected, Diff(nil, live, edited))
+}
+
+func TestDrift(t *testing.T) {
+	lastApplied := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": float64(1),
+			"image":    "nginx:1",
+		},
+	}
+
+	live := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"image":    "nginx:1",
+		},
+	}
+
+	expected := []Change{
+		{Path: "spec.replicas", LastApplied: "1", Live: "3", Conflict: true},
+	}
+
+	assert.Equal(t, expected, Drift(lastApplied, live))
+}
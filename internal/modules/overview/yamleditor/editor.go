@@ -0,0 +1,217 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+const (
+	// ActionName is the name of the action which applies edited YAML.
+	ActionName = "overview/applyYAML"
+
+	forceValue = "force"
+)
+
+// readOnlyFields are set by the cluster and are removed before an object
+// is edited. The resource version is kept so conflicting edits can be
+// detected.
+var readOnlyFields = [][]string{
+	{"status"},
+	{"metadata", "managedFields"},
+	{"metadata", "uid"},
+	{"metadata", "selfLink"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "deletionGracePeriodSeconds"},
+}
+
+// Strip removes read-only fields from an object.
+func Strip(object map[string]interface{}) {
+	for _, fields := range readOnlyFields {
+		unstructured.RemoveNestedField(object, fields...)
+	}
+}
+
+// Editable converts an object to the YAML which is presented for editing.
+func Editable(object runtime.Object) (string, error) {
+	if object == nil {
+		return "", errors.New("object is nil")
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return "", errors.Wrap(err, "convert object to unstructured")
+	}
+
+	m = runtime.DeepCopyJSON(m)
+	Strip(m)
+
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal object to YAML")
+	}
+
+	return string(data), nil
+}
+
+// Parse parses edited YAML into an object.
+func Parse(text string) (*unstructured.Unstructured, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("YAML is empty")
+	}
+
+	data, err := yaml.YAMLToJSON([]byte(text))
+	if err != nil {
+		return nil, errors.Wrap(err, "parse YAML")
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, errors.New("YAML is not an object")
+	}
+
+	return &unstructured.Unstructured{Object: m}, nil
+}
+
+// ToComponent creates a summary for an object with an action to edit its
+// YAML. Secrets aren't editable since the form would contain their values,
+// so nil is returned for them.
+func ToComponent(object runtime.Object) (component.Component, error) {
+	if object == nil {
+		return nil, errors.New("can't create YAML editor for nil object")
+	}
+
+	if isSecret(object) {
+		return nil, nil
+	}
+
+	text, err := Editable(object)
+	if err != nil {
+		return nil, err
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "access object metadata")
+	}
+
+	lastApplied, err := lastAppliedConfiguration(accessor.GetAnnotations())
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert object to unstructured")
+	}
+
+	var sections component.SummarySections
+	if lastApplied == nil {
+		sections.AddText("Last Applied Configuration", "Not found. Edits can't be checked for conflicts.")
+	} else {
+		sections.AddText("Last Applied Configuration", "Found")
+
+		drift := "None"
+		if changes := Drift(lastApplied, m); len(changes) > 0 {
+			var paths []string
+			for _, change := range changes {
+				paths = append(paths, change.Path)
+			}
+			drift = strings.Join(paths, ", ")
+		}
+		sections.AddText("Changed Since Last Apply", drift)
+	}
+
+	summary := component.NewSummary("YAML Editor", sections...)
+
+	gvk := object.GetObjectKind().GroupVersionKind()
+
+	summary.AddAction(component.Action{
+		Name:  "Edit",
+		Title: fmt.Sprintf("Edit %s %s", gvk.Kind, accessor.GetName()),
+		Form: component.Form{
+			Fields: []component.FormField{
+				component.NewFormFieldTextarea("YAML", "yaml", text),
+				component.NewFormFieldCheckBox("Conflicts", "force", []component.InputChoice{
+					{Label: "Overwrite changes made since the last apply", Value: forceValue},
+				}),
+				component.NewFormFieldHidden("group", gvk.Group),
+				component.NewFormFieldHidden("version", gvk.Version),
+				component.NewFormFieldHidden("kind", gvk.Kind),
+				component.NewFormFieldHidden("name", accessor.GetName()),
+				component.NewFormFieldHidden("namespace", accessor.GetNamespace()),
+				component.NewFormFieldHidden("action", ActionName),
+			},
+		},
+	})
+
+	return summary, nil
+}
+
+// lastAppliedConfiguration parses the last applied configuration
+// annotation. It returns nil if the annotation isn't set.
+func lastAppliedConfiguration(annotations map[string]string) (map[string]interface{}, error) {
+	value, ok := annotations[corev1.LastAppliedConfigAnnotation]
+	if !ok || value == "" {
+		return nil, nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &m); err != nil {
+		return nil, errors.Wrap(err, "parse last applied configuration")
+	}
+
+	return m, nil
+}
+
+// setLastAppliedConfiguration sets the last applied configuration
+// annotation to the object's configuration, like kubectl apply does. The
+// annotation itself and the resource version aren't part of the
+// configuration.
+func setLastAppliedConfiguration(object *unstructured.Unstructured) error {
+	applied := object.DeepCopy()
+	unstructured.RemoveNestedField(applied.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(applied.Object, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	if len(applied.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(applied.Object, "metadata", "annotations")
+	}
+
+	data, err := json.Marshal(applied.Object)
+	if err != nil {
+		return errors.Wrap(err, "marshal last applied configuration")
+	}
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[corev1.LastAppliedConfigAnnotation] = string(data)
+	object.SetAnnotations(annotations)
+
+	return nil
+}
+
+func isSecret(object runtime.Object) bool {
+	if _, ok := object.(*corev1.Secret); ok {
+		return true
+	}
+
+	gvk := object.GetObjectKind().GroupVersionKind()
+	return gvk.Group == "" && gvk.Kind == "Secret"
+}
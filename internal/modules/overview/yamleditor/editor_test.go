@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/view/component"
+)
+
+func TestEditable(t *testing.T) {
+	pod := testutil.CreatePod("pod")
+	pod.UID = "uid"
+	pod.ResourceVersion = "1"
+	pod.Status.Phase = corev1.PodRunning
+
+	got, err := Editable(pod)
+	require.NoError(t, err)
+
+	assert.Contains(t, got, "resourceVersion: \"1\"")
+	assert.NotContains(t, got, "uid:")
+	assert.NotContains(t, got, "creationTimestamp")
+	assert.NotContains(t, got, "status:")
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse("kind: Pod\nspec:\n  replicas: 2\n")
+	require.NoError(t, err)
+	assert.Equal(t, "Pod", got.GetKind())
+
+	_, err = Parse("")
+	assert.Error(t, err)
+
+	_, err = Parse("- a\n- b\n")
+	assert.Error(t, err)
+}
+
+func TestToComponent(t *testing.T) {
+	deployment := testutil.CreateDeployment("deployment")
+	deployment.Annotations = map[string]string{
+		corev1.LastAppliedConfigAnnotation: `{"metadata":{"labels":{"app":"web"}}}`,
+	}
+	deployment.Labels = map[string]string{"app": "api"}
+
+	got, err := ToComponent(deployment)
+	require.NoError(t, err)
+
+	summary, ok := got.(*component.Summary)
+	require.True(t, ok)
+
+	expectedSections := []component.SummarySection{
+		{Header: "Last Applied Configuration", Content: component.NewText("Found")},
+		{Header: "Changed Since Last Apply", Content: component.NewText("metadata.labels.app")},
+	}
+	assert.Equal(t, expectedSections, summary.Sections())
+
+	actions := summary.Config.Actions
+	require.Len(t, actions, 1)
+
+	var names []string
+	for _, field := range actions[0].Form.Fields {
+		names = append(names, field.Name())
+	}
+	assert.Equal(t, []string{"yaml", "force", "group", "version", "kind", "name", "namespace", "action"}, names)
+	assert.Equal(t, ActionName, actions[0].Form.Fields[7].Value())
+}
+
+func TestToComponent_secret(t *testing.T) {
+	secret := testutil.CreateSecret("secret")
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+
+	got, err := ToComponent(secret)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
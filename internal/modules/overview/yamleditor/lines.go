@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// lineIndex maps field paths to the line in a YAML document where they are
+// defined. Paths use the same format as validation errors, e.g.
+// spec.containers[0].image. Only block style YAML is indexed; values in
+// flow style collections resolve to the line of their parent.
+type lineIndex map[string]int
+
+type lineFrame struct {
+	indent int
+	path   string
+	item   bool
+	next   int
+}
+
+// newLineIndex indexes a YAML document.
+func newLineIndex(doc string) lineIndex {
+	index := lineIndex{}
+
+	stack := []*lineFrame{{indent: -1}}
+	blockIndent := -1
+
+	scanner := bufio.NewScanner(strings.NewReader(doc))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if blockIndent >= 0 {
+			if trimmed == "" || indent > blockIndent {
+				continue
+			}
+			blockIndent = -1
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+
+		col := indent
+		content := trimmed
+
+		// sequence items, possibly nested on one line.
+		for content == "-" || strings.HasPrefix(content, "- ") {
+			for len(stack) > 1 {
+				top := stack[len(stack)-1]
+				if top.indent > col || (top.indent == col && top.item) {
+					stack = stack[:len(stack)-1]
+					continue
+				}
+				break
+			}
+
+			parent := stack[len(stack)-1]
+			itemPath := fmt.Sprintf("%s[%d]", parent.path, parent.next)
+			parent.next++
+			index[itemPath] = n
+
+			stack = append(stack, &lineFrame{indent: col, path: itemPath, item: true})
+
+			rest := strings.TrimPrefix(content, "-")
+			trimmedRest := strings.TrimLeft(rest, " ")
+			col += 1 + len(rest) - len(trimmedRest)
+			content = trimmedRest
+			if content == "" {
+				break
+			}
+		}
+
+		if content == "" {
+			continue
+		}
+
+		key, value, ok := splitKey(content)
+		if !ok {
+			continue
+		}
+
+		for len(stack) > 1 && stack[len(stack)-1].indent >= col {
+			stack = stack[:len(stack)-1]
+		}
+
+		parent := stack[len(stack)-1]
+		p := joinPath(parent.path, key)
+		index[p] = n
+
+		switch {
+		case value == "":
+			stack = append(stack, &lineFrame{indent: col, path: p})
+		case strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">"):
+			blockIndent = col
+		}
+	}
+
+	return index
+}
+
+// splitKey splits a mapping line into its key and value.
+func splitKey(content string) (string, string, bool) {
+	if strings.HasPrefix(content, `"`) || strings.HasPrefix(content, `'`) {
+		quote := content[:1]
+		end := strings.Index(content[1:], quote)
+		if end < 0 {
+			return "", "", false
+		}
+		key := content[1 : end+1]
+		rest := strings.TrimLeft(content[end+2:], " ")
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest[1:]), true
+	}
+
+	for i := 0; i < len(content); i++ {
+		if content[i] != ':' {
+			continue
+		}
+		if i+1 == len(content) || content[i+1] == ' ' {
+			return strings.TrimSpace(content[:i]), strings.TrimSpace(content[i+1:]), true
+		}
+	}
+
+	return "", "", false
+}
+
+// Line returns the line for a path. If the path isn't indexed, the line of
+// the closest indexed ancestor is returned. Zero is returned if no ancestor
+// is indexed.
+func (li lineIndex) Line(path string) int {
+	for path != "" {
+		if line, ok := li[path]; ok {
+			return line
+		}
+		path = parentPath(path)
+	}
+
+	return 0
+}
+
+// parentPath returns the parent of a path.
+func parentPath(path string) string {
+	if strings.HasSuffix(path, "]") {
+		if i := strings.LastIndex(path, "["); i >= 0 {
+			return path[:i]
+		}
+	}
+
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i]
+	}
+
+	return ""
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lineIndex(t *testing.T) {
+	doc := `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  annotations:
+    "example.com/note": |
+      text: not a field
+    other: value
+spec:
+  containers:
+  - name: app
+    image: nginx
+    ports:
+    - containerPort: 80
+      protocol: TCP
+  - name: sidecar
+    args: [a, b]
+`
+
+	index := newLineIndex(doc)
+
+	cases := []struct {
+		path     string
+		expected int
+	}{
+		{path: "apiVersion", expected: 1},
+		{path: "metadata.name", expected: 4},
+		{path: "metadata.annotations.example.com/note", expected: 6},
+		{path: "metadata.annotations.other", expected: 8},
+		{path: "spec.containers", expected: 10},
+		{path: "spec.containers[0]", expected: 11},
+		{path: "spec.containers[0].image", expected: 12},
+		{path: "spec.containers[0].ports[0].protocol", expected: 15},
+		{path: "spec.containers[1].name", expected: 16},
+		{path: "spec.containers[1].args[1]", expected: 17},
+		{path: "spec.containers[0].resources.limits", expected: 11},
+		{path: "missing", expected: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, index.Line(tc.path))
+		})
+	}
+}
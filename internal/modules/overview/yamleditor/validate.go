@@ -0,0 +1,200 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/vmware/octant/internal/openapi"
+)
+
+// ValidationError is a schema violation in edited YAML.
+type ValidationError struct {
+	// Path is the path to the invalid field.
+	Path string
+	// Line is the line in the YAML document where the field is defined. It
+	// is zero if the line is unknown.
+	Line int
+	// Message describes the violation.
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.Path, e.Message)
+	}
+	return fmt.Sprintf("line %d: %s: %s", e.Line, e.Path, e.Message)
+}
+
+// ValidationErrors are the schema violations in edited YAML.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	var messages []string
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("invalid YAML:\n%s", strings.Join(messages, "\n"))
+}
+
+// Validate validates an object against a schema. Fields are checked for
+// their type, required fields must be present, and fields which aren't in
+// the schema are reported. Objects without child fields in the schema
+// aren't checked since they can contain anything. The lines of the errors
+// are looked up in doc, which is the YAML the object was parsed from.
+func Validate(schema *openapi.Field, object map[string]interface{}, doc string) ValidationErrors {
+	if schema == nil {
+		return nil
+	}
+
+	var errs ValidationErrors
+	validateValue(schema.Type, schema.Fields, "", object, &errs)
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	index := newLineIndex(doc)
+	for i := range errs {
+		errs[i].Line = index.Line(errs[i].Path)
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Line < errs[j].Line
+	})
+
+	return errs
+}
+
+func validateValue(typeName string, fields []openapi.Field, path string, value interface{}, errs *ValidationErrors) {
+	if value == nil {
+		return
+	}
+
+	invalid := func(expected string) {
+		*errs = append(*errs, ValidationError{
+			Path:    path,
+			Message: fmt.Sprintf("expected %s, got %s", expected, describeValue(value)),
+		})
+	}
+
+	switch {
+	case strings.HasPrefix(typeName, "[]"):
+		items, ok := value.([]interface{})
+		if !ok {
+			invalid("array")
+			return
+		}
+		for i, item := range items {
+			validateValue(strings.TrimPrefix(typeName, "[]"), fields, fmt.Sprintf("%s[%d]", path, i), item, errs)
+		}
+	case strings.HasPrefix(typeName, "map[string]"):
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			invalid("object")
+			return
+		}
+		for _, key := range sortedKeys(m) {
+			validateValue(strings.TrimPrefix(typeName, "map[string]"), fields, joinPath(path, key), m[key], errs)
+		}
+	case typeName == "Object":
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			invalid("object")
+			return
+		}
+		validateObject(fields, path, m, errs)
+	case typeName == "string":
+		// int-or-string and quantity fields are published as strings.
+		switch value.(type) {
+		case string, float64, int64:
+		default:
+			invalid("string")
+		}
+	case typeName == "integer":
+		switch v := value.(type) {
+		case int64:
+		case float64:
+			if v != math.Trunc(v) {
+				invalid("integer")
+			}
+		default:
+			invalid("integer")
+		}
+	case typeName == "number":
+		switch value.(type) {
+		case float64, int64:
+		default:
+			invalid("number")
+		}
+	case typeName == "boolean":
+		if _, ok := value.(bool); !ok {
+			invalid("boolean")
+		}
+	}
+}
+
+func validateObject(fields []openapi.Field, path string, m map[string]interface{}, errs *ValidationErrors) {
+	if len(fields) == 0 {
+		return
+	}
+
+	known := make(map[string]*openapi.Field)
+	for i := range fields {
+		known[fields[i].Name] = &fields[i]
+	}
+
+	for _, key := range sortedKeys(m) {
+		fieldPath := joinPath(path, key)
+
+		field, ok := known[key]
+		if !ok {
+			*errs = append(*errs, ValidationError{Path: fieldPath, Message: "unknown field"})
+			continue
+		}
+
+		validateValue(field.Type, field.Fields, fieldPath, m[key], errs)
+	}
+
+	for i := range fields {
+		field := fields[i]
+		if _, ok := m[field.Name]; field.Required && !ok {
+			*errs = append(*errs, ValidationError{
+				Path:    joinPath(path, field.Name),
+				Message: "required field is missing",
+			})
+		}
+	}
+}
+
+func describeValue(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, int64:
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamleditor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/openapi"
+)
+
+func podSchema() *openapi.Field {
+	return &openapi.Field{
+		Name: "Pod",
+		Type: "Object",
+		Fields: []openapi.Field{
+			{Name: "apiVersion", Type: "string"},
+			{Name: "kind", Type: "string"},
+			{
+				Name: "metadata",
+				Type: "Object",
+				Fields: []openapi.Field{
+					{Name: "name", Type: "string"},
+					{Name: "namespace", Type: "string"},
+					{Name: "resourceVersion", Type: "string"},
+					{Name: "labels", Type: "map[string]string"},
+					{Name: "annotations", Type: "map[string]string"},
+				},
+			},
+			{
+				Name: "spec",
+				Type: "Object",
+				Fields: []openapi.Field{
+					{
+						Name:     "containers",
+						Type:     "[]Object",
+						Required: true,
+						Fields: []openapi.Field{
+							{Name: "name", Type: "string", Required: true},
+							{Name: "image", Type: "string"},
+							{Name: "stdin", Type: "boolean"},
+							{Name: "resources", Type: "Object"},
+							{
+								Name: "ports",
+								Type: "[]Object",
+								Fields: []openapi.Field{
+									{Name: "containerPort", Type: "integer"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name     string
+		doc      string
+		expected ValidationErrors
+	}{
+		{
+			name: "valid",
+			doc: `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  labels:
+    app: "1"
+spec:
+  containers:
+  - name: app
+    image: nginx
+    resources:
+      anything: goes
+    ports:
+    - containerPort: 80
+`,
+		},
+		{
+			name: "invalid",
+			doc: `apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  labels:
+    app: [a]
+spec:
+  containers:
+  - image: nginx
+    stdin: "yes"
+    ports:
+    - containerPort: 80.5
+      hostPort: 80
+`,
+			expected: ValidationErrors{
+				{Path: "metadata.labels.app", Line: 6, Message: "expected string, got array"},
+				{Path: "spec.containers[0].name", Line: 9, Message: "required field is missing"},
+				{Path: "spec.containers[0].stdin", Line: 10, Message: "expected boolean, got string"},
+				{Path: "spec.containers[0].ports[0].containerPort", Line: 12, Message: "expected integer, got number"},
+				{Path: "spec.containers[0].ports[0].hostPort", Line: 13, Message: "unknown field"},
+			},
+		},
+		{
+			name: "missing required field",
+			doc: `apiVersion: v1
+kind: Pod
+spec: {}
+`,
+			expected: ValidationErrors{
+				{Path: "spec.containers", Line: 3, Message: "required field is missing"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			object, err := Parse(tc.doc)
+			require.NoError(t, err)
+
+			got := Validate(podSchema(), object.Object, tc.doc)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestValidationErrors_Error(t *testing.T) {
+	errs := ValidationErrors{
+		{Path: "spec.replicas", Line: 7, Message: "expected integer, got string"},
+		{Path: "spec.selector", Message: "required field is missing"},
+	}
+
+	expected := "invalid YAML:\nline 7: spec.replicas: expected integer, got string\nspec.selector: required field is missing"
+	assert.Equal(t, expected, errs.Error())
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamlviewer
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// redactedValue replaces values which shouldn't be shown.
+const redactedValue = "<redacted>"
+
+// redact returns a copy of a secret with its values replaced. The last
+// applied configuration is replaced as well since it can contain the
+// values. Other objects are returned as is.
+func redact(object runtime.Object) (runtime.Object, error) {
+	if !isSecret(object) {
+		return object, nil
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert secret to unstructured")
+	}
+	u := &unstructured.Unstructured{Object: runtime.DeepCopyJSON(m)}
+
+	for _, field := range []string{"data", "stringData"} {
+		values, found, err := unstructured.NestedMap(u.Object, field)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read secret %s", field)
+		}
+		if !found {
+			continue
+		}
+
+		for key := range values {
+			values[key] = redactedValue
+		}
+
+		if err := unstructured.SetNestedMap(u.Object, values, field); err != nil {
+			return nil, errors.Wrapf(err, "redact secret %s", field)
+		}
+	}
+
+	annotations := u.GetAnnotations()
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		annotations[corev1.LastAppliedConfigAnnotation] = redactedValue
+		u.SetAnnotations(annotations)
+	}
+
+	return u, nil
+}
+
+func isSecret(object runtime.Object) bool {
+	if _, ok := object.(*corev1.Secret); ok {
+		return true
+	}
+
+	gvk := object.GetObjectKind().GroupVersionKind()
+	return gvk.Group == "" && gvk.Kind == "Secret"
+}
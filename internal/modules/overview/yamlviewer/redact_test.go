@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package yamlviewer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/testutil"
+)
+
+func Test_redact(t *testing.T) {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "secret",
+			Annotations: map[string]string{
+				corev1.LastAppliedConfigAnnotation: `{"data":{"password":"c2VjcmV0"}}`,
+				"app":                              "web",
+			},
+		},
+		Data:       map[string][]byte{"password": []byte("secret")},
+		StringData: map[string]string{"token": "secret"},
+	}
+
+	cases := []struct {
+		name   string
+		object runtime.Object
+	}{
+		{name: "typed", object: secret},
+		{name: "unstructured", object: testutil.ToUnstructured(t, secret)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := redact(tc.object)
+			require.NoError(t, err)
+
+			u, ok := got.(*unstructured.Unstructured)
+			require.True(t, ok)
+
+			data, _, err := unstructured.NestedStringMap(u.Object, "data")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"password": redactedValue}, data)
+
+			stringData, _, err := unstructured.NestedStringMap(u.Object, "stringData")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"token": redactedValue}, stringData)
+
+			assert.Equal(t, map[string]string{
+				corev1.LastAppliedConfigAnnotation: redactedValue,
+				"app":                              "web",
+			}, u.GetAnnotations())
+		})
+	}
+
+	assert.Equal(t, []byte("secret"), secret.Data["password"], "original secret is unchanged")
+}
+
+func Test_redact_other_objects(t *testing.T) {
+	pod := testutil.CreatePod("pod")
+
+	got, err := redact(pod)
+	require.NoError(t, err)
+	assert.Equal(t, pod, got)
+}
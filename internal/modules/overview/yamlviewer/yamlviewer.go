@@ -6,14 +6,30 @@ SPDX-License-Identifier: Apache-2.0
 package yamlviewer
 
 import (
-	"github.com/vmware/octant/pkg/view/component"
+	"sort"
+	"strings"
+
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/internal/openapi"
+	"github.com/vmware/octant/pkg/view/component"
 )
 
+// Option is an option for configuring the YAML viewer.
+type Option func(yv *yamlViewer)
+
+// WithSchema annotates the YAML with documentation for the fields
+// present in the object.
+func WithSchema(schema *openapi.Field) Option {
+	return func(yv *yamlViewer) {
+		yv.schema = schema
+	}
+}
+
 // ToComponent converts an object into a YAML component.
-func ToComponent(object runtime.Object) (*component.YAML, error) {
-	yv, err := new(object)
+func ToComponent(object runtime.Object, options ...Option) (*component.YAML, error) {
+	yv, err := new(object, options...)
 	if err != nil {
 		return nil, errors.Wrap(err, "create YAML viewer")
 	}
@@ -24,25 +40,98 @@ func ToComponent(object runtime.Object) (*component.YAML, error) {
 // YAMLViewer is a YAML viewer for objects.
 type yamlViewer struct {
 	object runtime.Object
+	schema *openapi.Field
 }
 
 // New creates an instance of YAMLViewer.
-func new(object runtime.Object) (*yamlViewer, error) {
+func new(object runtime.Object, options ...Option) (*yamlViewer, error) {
 	if object == nil {
 		return nil, errors.New("can't create YAML view for nil object")
 	}
 
-	return &yamlViewer{
+	yv := &yamlViewer{
 		object: object,
-	}, nil
+	}
+
+	for _, option := range options {
+		option(yv)
+	}
+
+	return yv, nil
 }
 
 // ToComponent converts the YAMLViewer to a component.
+// Secret values are redacted.
 func (yv *yamlViewer) ToComponent() (*component.YAML, error) {
+	object, err := redact(yv.object)
+	if err != nil {
+		return nil, err
+	}
+
 	y := component.NewYAML(component.TitleFromString("YAML"), "")
-	if err := y.Data(yv.object); err != nil {
+	if err := y.Data(object); err != nil {
 		return nil, errors.Wrap(err, "add YAML data")
 	}
 
+	if yv.schema != nil {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return nil, errors.Wrap(err, "convert object to unstructured")
+		}
+
+		y.Config.Fields = fieldDocs(yv.schema, m)
+	}
+
 	return y, nil
 }
+
+// fieldDocs generates documentation for every schema field present in an object.
+func fieldDocs(schema *openapi.Field, object map[string]interface{}) []component.YAMLField {
+	seen := make(map[string]bool)
+	var docs []component.YAMLField
+
+	var walk func(field *openapi.Field, value interface{}, isElement bool)
+	walk = func(field *openapi.Field, value interface{}, isElement bool) {
+		if field.Path != "" && !isElement && !seen[field.Path] {
+			seen[field.Path] = true
+			docs = append(docs, component.YAMLField{
+				Path:        field.Path,
+				Type:        field.Type,
+				Description: field.Description,
+				Required:    field.Required,
+				Deprecated:  field.Deprecated,
+			})
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if strings.HasPrefix(field.Type, "map[") && !isElement {
+				for _, child := range v {
+					walk(field, child, true)
+				}
+				return
+			}
+
+			for key, child := range v {
+				for i := range field.Fields {
+					if field.Fields[i].Name == key {
+						walk(&field.Fields[i], child, false)
+						break
+					}
+				}
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(field, item, true)
+			}
+		}
+	}
+
+	walk(schema, object, false)
+
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].Path < docs[j].Path
+	})
+
+	return docs
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/vmware/octant/internal/openapi"
 	"github.com/vmware/octant/pkg/view/component"
 
 	corev1 "k8s.io/api/core/v1"
@@ -27,3 +28,83 @@ func Test_ToComponent(t *testing.T) {
 
 	assert.Equal(t, expected, got)
 }
+
+func Test_fieldDocs(t *testing.T) {
+	schema := &openapi.Field{
+		Name: "Pod",
+		Type: "Object",
+		Fields: []openapi.Field{
+			{
+				Name: "metadata",
+				Path: "metadata",
+				Type: "Object",
+				Fields: []openapi.Field{
+					{
+						Name: "labels",
+						Path: "metadata.labels",
+						Type: "map[string]string",
+					},
+				},
+			},
+			{
+				Name: "spec",
+				Path: "spec",
+				Type: "Object",
+				Fields: []openapi.Field{
+					{
+						Name:     "containers",
+						Path:     "spec.containers",
+						Type:     "[]Object",
+						Required: true,
+						Fields: []openapi.Field{
+							{
+								Name:        "image",
+								Path:        "spec.containers[].image",
+								Type:        "string",
+								Description: "Docker image name.",
+							},
+							{
+								Name: "name",
+								Path: "spec.containers[].name",
+								Type: "string",
+							},
+						},
+					},
+					{
+						Name:       "serviceAccount",
+						Path:       "spec.serviceAccount",
+						Type:       "string",
+						Deprecated: true,
+					},
+				},
+			},
+		},
+	}
+
+	object := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{
+				"app": "app",
+			},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"image": "nginx"},
+				map[string]interface{}{"image": "busybox"},
+			},
+			"unknown": "value",
+		},
+	}
+
+	got := fieldDocs(schema, object)
+
+	expected := []component.YAMLField{
+		{Path: "metadata", Type: "Object"},
+		{Path: "metadata.labels", Type: "map[string]string"},
+		{Path: "spec", Type: "Object"},
+		{Path: "spec.containers", Type: "[]Object", Required: true},
+		{Path: "spec.containers[].image", Type: "string", Description: "Docker image name."},
+	}
+
+	assert.Equal(t, expected, got)
+}
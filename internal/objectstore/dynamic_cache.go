@@ -65,10 +65,11 @@ func currentInformer(
 }
 
 type accessKey struct {
-	Namespace string
-	Group     string
-	Resource  string
-	Verb      string
+	Namespace   string
+	Group       string
+	Resource    string
+	Subresource string
+	Verb        string
 }
 type accessMap map[accessKey]bool
 
@@ -131,10 +132,11 @@ func (dc *DynamicCache) fetchAccess(key accessKey, verb string) (bool, error) {
 	sar := &authorizationv1.SelfSubjectAccessReview{
 		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authorizationv1.ResourceAttributes{
-				Namespace: key.Namespace,
-				Group:     key.Group,
-				Resource:  key.Resource,
-				Verb:      verb,
+				Namespace:   key.Namespace,
+				Group:       key.Group,
+				Resource:    key.Resource,
+				Subresource: key.Subresource,
+				Verb:        verb,
 			},
 		},
 	}
@@ -164,10 +166,11 @@ func (dc *DynamicCache) HasAccess(ctx context.Context, key store.Key, verb strin
 	}
 
 	aKey := accessKey{
-		Namespace: key.Namespace,
-		Group:     gvr.Group,
-		Resource:  gvr.Resource,
-		Verb:      verb,
+		Namespace:   key.Namespace,
+		Group:       gvr.Group,
+		Resource:    gvr.Resource,
+		Subresource: key.Subresource,
+		Verb:        verb,
 	}
 
 	access, ok := dc.access.get(aKey)
@@ -185,7 +188,8 @@ func (dc *DynamicCache) HasAccess(ctx context.Context, key store.Key, verb strin
 	}
 
 	if !access {
-		return errors.Errorf("denied %+v", aKey)
+		groupResource := schema.GroupResource{Group: gvr.Group, Resource: gvr.Resource}
+		return kerrors.NewForbidden(groupResource, key.Name, errors.Errorf("denied %+v", aKey))
 	}
 
 	return nil
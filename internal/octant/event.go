@@ -16,6 +16,8 @@ const (
 	EventTypeNavigation EventType = "navigation"
 	// EventTypeObjectNotFound is an object not found event.
 	EventTypeObjectNotFound EventType = "objectNotFound"
+	// EventTypePreferences is a preferences event.
+	EventTypePreferences EventType = "preferences"
 )
 
 // Event is an event for the dash frontend.
@@ -0,0 +1,233 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+const (
+	typeObject = "Object"
+
+	// maxDepth limits how deep a schema tree is expanded.
+	maxDepth = 15
+)
+
+// Field describes a field in an object schema.
+type Field struct {
+	// Name is the name of the field.
+	Name string
+	// Path is the dotted path to the field from the root of the object.
+	// Array items are denoted with `[]` and map values with `*`.
+	Path string
+	// Type is a kubectl explain style type name.
+	Type string
+	// Description is the field's description.
+	Description string
+	// Required is true if the field is required by its parent.
+	Required bool
+	// Deprecated is true if the field is documented as deprecated.
+	Deprecated bool
+	// Fields are the child fields.
+	Fields []Field
+}
+
+// Find finds a field by path.
+func (f *Field) Find(path string) (*Field, bool) {
+	if f.Path == path {
+		return f, true
+	}
+
+	for i := range f.Fields {
+		child := &f.Fields[i]
+		if child.Path == path ||
+			strings.HasPrefix(path, child.Path+".") ||
+			strings.HasPrefix(path, child.Path+"[]") {
+			if found, ok := child.Find(path); ok {
+				return found, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Walk calls fn for every field in the tree in depth first order.
+func (f *Field) Walk(fn func(field *Field, depth int)) {
+	f.walk(fn, 0)
+}
+
+func (f *Field) walk(fn func(field *Field, depth int), depth int) {
+	fn(f, depth)
+	for i := range f.Fields {
+		f.Fields[i].walk(fn, depth+1)
+	}
+}
+
+// fieldFromSchema converts an OpenAPI schema into a field tree.
+func fieldFromSchema(name, path string, schema proto.Schema, required bool, ancestors []string) Field {
+	description := schema.GetDescription()
+
+	field := Field{
+		Name:        name,
+		Path:        path,
+		Type:        typeName(schema),
+		Description: description,
+		Required:    required,
+		Deprecated:  isDeprecated(description),
+	}
+
+	if len(ancestors) > maxDepth {
+		return field
+	}
+
+	field.Fields = childFields(path, schema, ancestors)
+
+	return field
+}
+
+func childFields(path string, schema proto.Schema, ancestors []string) []Field {
+	switch s := schema.(type) {
+	case *proto.Kind:
+		var fields []Field
+		for _, key := range s.Keys() {
+			fields = append(fields, fieldFromSchema(key, joinPath(path, key), s.Fields[key], s.IsRequired(key), ancestors))
+		}
+		return fields
+	case *proto.Array:
+		return childFields(path+"[]", s.SubType, ancestors)
+	case *proto.Map:
+		return childFields(joinPath(path, "*"), s.SubType, ancestors)
+	case proto.Reference:
+		ref := s.Reference()
+		for _, ancestor := range ancestors {
+			if ancestor == ref {
+				return nil
+			}
+		}
+		return childFields(path, s.SubSchema(), append(ancestors, ref))
+	default:
+		return nil
+	}
+}
+
+func typeName(schema proto.Schema) string {
+	switch s := schema.(type) {
+	case *proto.Kind:
+		return typeObject
+	case *proto.Array:
+		return "[]" + typeName(s.SubType)
+	case *proto.Map:
+		return "map[string]" + typeName(s.SubType)
+	case *proto.Primitive:
+		return s.Type
+	case proto.Reference:
+		return typeName(s.SubSchema())
+	default:
+		return typeObject
+	}
+}
+
+// fieldFromJSONSchema converts a JSON schema found in a custom resource
+// definition into a field tree.
+func fieldFromJSONSchema(name, path string, props map[string]interface{}, required bool, depth int) Field {
+	description, _ := props["description"].(string)
+
+	field := Field{
+		Name:        name,
+		Path:        path,
+		Type:        jsonSchemaTypeName(props),
+		Description: description,
+		Required:    required,
+		Deprecated:  isDeprecated(description),
+	}
+
+	if depth > maxDepth {
+		return field
+	}
+
+	field.Fields = jsonSchemaChildFields(path, props, depth)
+
+	return field
+}
+
+func jsonSchemaChildFields(path string, props map[string]interface{}, depth int) []Field {
+	if items, ok := props["items"].(map[string]interface{}); ok {
+		return jsonSchemaChildFields(path+"[]", items, depth+1)
+	}
+
+	if additional, ok := props["additionalProperties"].(map[string]interface{}); ok {
+		return jsonSchemaChildFields(joinPath(path, "*"), additional, depth+1)
+	}
+
+	properties, ok := props["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	requiredFields := map[string]bool{}
+	if list, ok := props["required"].([]interface{}); ok {
+		for _, item := range list {
+			requiredFields[fmt.Sprint(item)] = true
+		}
+	}
+
+	var keys []string
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var fields []Field
+	for _, key := range keys {
+		childProps, ok := properties[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fields = append(fields, fieldFromJSONSchema(key, joinPath(path, key), childProps, requiredFields[key], depth+1))
+	}
+
+	return fields
+}
+
+func jsonSchemaTypeName(props map[string]interface{}) string {
+	t, _ := props["type"].(string)
+	switch t {
+	case "array":
+		if items, ok := props["items"].(map[string]interface{}); ok {
+			return "[]" + jsonSchemaTypeName(items)
+		}
+		return "[]" + typeObject
+	case "object", "":
+		if additional, ok := props["additionalProperties"].(map[string]interface{}); ok {
+			return "map[string]" + jsonSchemaTypeName(additional)
+		}
+		if v, ok := props["x-kubernetes-int-or-string"].(bool); ok && v {
+			return "string"
+		}
+		return typeObject
+	default:
+		return t
+	}
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func isDeprecated(description string) bool {
+	lower := strings.ToLower(description)
+	return strings.HasPrefix(lower, "deprecated") ||
+		strings.Contains(lower, "deprecated:") ||
+		strings.Contains(lower, "this field is deprecated")
+}
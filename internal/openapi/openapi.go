@@ -0,0 +1,287 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/kube-openapi/pkg/util/proto"
+
+	"github.com/vmware/octant/pkg/store"
+)
+
+const (
+	gvkExtension = "x-kubernetes-group-version-kind"
+
+	// refreshInterval is the minimum amount of time between schema
+	// fetches when a lookup misses. New CRDs are published to the schema
+	// asynchronously, so misses will retry periodically.
+	refreshInterval = time.Minute
+)
+
+// ErrSchemaNotFound is returned when a schema for a group version kind can't be found.
+var ErrSchemaNotFound = errors.New("schema not found")
+
+//go:generate mockgen -destination=./fake/mock_interface.go -package=fake github.com/vmware/octant/internal/openapi Interface
+
+// Interface looks up schemas for objects.
+type Interface interface {
+	// Lookup returns the field tree for a group version kind.
+	Lookup(ctx context.Context, gvk schema.GroupVersionKind) (*Field, error)
+}
+
+// Schemas fetches the OpenAPI schema published by a cluster and caches it.
+// If a group version kind isn't published by the cluster, Schemas will
+// fall back to the structural schema in the matching custom resource
+// definition.
+type Schemas struct {
+	client      discovery.OpenAPISchemaInterface
+	objectStore store.Store
+	nowFunc     func() time.Time
+
+	models    proto.Models
+	index     map[schema.GroupVersionKind]string
+	fields    map[schema.GroupVersionKind]*Field
+	fetchedAt time.Time
+
+	// fetches shares one schema fetch between concurrent lookups. Fetches
+	// run without mu held so cached lookups aren't blocked by the network.
+	fetches singleflight.Group
+
+	mu sync.Mutex
+}
+
+var _ Interface = (*Schemas)(nil)
+
+// NewSchemas creates an instance of Schemas.
+func NewSchemas(client discovery.OpenAPISchemaInterface, objectStore store.Store) *Schemas {
+	return &Schemas{
+		client:      client,
+		objectStore: objectStore,
+		nowFunc:     time.Now,
+		fields:      make(map[schema.GroupVersionKind]*Field),
+	}
+}
+
+// Lookup returns the field tree for a group version kind.
+func (s *Schemas) Lookup(ctx context.Context, gvk schema.GroupVersionKind) (*Field, error) {
+	s.mu.Lock()
+	field, ok := s.fields[gvk]
+	s.mu.Unlock()
+
+	if ok {
+		return field, nil
+	}
+
+	field, err := s.lookupModel(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	if field == nil {
+		field, err = s.lookupCRD(ctx, gvk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if field == nil {
+		return nil, ErrSchemaNotFound
+	}
+
+	s.mu.Lock()
+	s.fields[gvk] = field
+	s.mu.Unlock()
+
+	return field, nil
+}
+
+func (s *Schemas) lookupModel(gvk schema.GroupVersionKind) (*Field, error) {
+	models, name, found, stale := s.indexed(gvk)
+	if !found && stale {
+		if err := s.refresh(); err != nil {
+			return nil, err
+		}
+		models, name, found, _ = s.indexed(gvk)
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	model := models.LookupModel(name)
+	if model == nil {
+		return nil, nil
+	}
+
+	field := fieldFromSchema(gvk.Kind, "", model, false, []string{name})
+	return &field, nil
+}
+
+// indexed returns the models and the name of the model for a group
+// version kind. stale is true if the models can be fetched again.
+func (s *Schemas) indexed(gvk schema.GroupVersionKind) (models proto.Models, name string, found, stale bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name, found = s.index[gvk]
+	stale = s.models == nil || s.nowFunc().Sub(s.fetchedAt) > refreshInterval
+
+	return s.models, name, found, stale
+}
+
+// refresh fetches the schema. Concurrent callers wait for the same fetch.
+func (s *Schemas) refresh() error {
+	_, err, _ := s.fetches.Do("schema", func() (interface{}, error) {
+		// another lookup may have fetched the schema while this one was
+		// checking the index.
+		s.mu.Lock()
+		fresh := s.models != nil && s.nowFunc().Sub(s.fetchedAt) <= refreshInterval
+		s.mu.Unlock()
+		if fresh {
+			return nil, nil
+		}
+
+		return nil, s.fetch()
+	})
+
+	return err
+}
+
+func (s *Schemas) fetch() error {
+	if s.client == nil {
+		return errors.New("OpenAPI schema client is nil")
+	}
+
+	doc, err := s.client.OpenAPISchema()
+	if err != nil {
+		return errors.Wrap(err, "fetch OpenAPI schema")
+	}
+
+	models, err := proto.NewOpenAPIData(doc)
+	if err != nil {
+		return errors.Wrap(err, "parse OpenAPI schema")
+	}
+
+	index := make(map[schema.GroupVersionKind]string)
+	for _, name := range models.ListModels() {
+		model := models.LookupModel(name)
+		if model == nil {
+			continue
+		}
+
+		for _, gvk := range modelGroupVersionKinds(model) {
+			index[gvk] = name
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.models = models
+	s.index = index
+	s.fields = make(map[schema.GroupVersionKind]*Field)
+	s.fetchedAt = s.nowFunc()
+
+	return nil
+}
+
+func (s *Schemas) lookupCRD(ctx context.Context, gvk schema.GroupVersionKind) (*Field, error) {
+	if s.objectStore == nil {
+		return nil, nil
+	}
+
+	key := store.Key{
+		APIVersion: "apiextensions.k8s.io/v1beta1",
+		Kind:       "CustomResourceDefinition",
+	}
+
+	crds, err := s.objectStore.List(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "list custom resource definitions")
+	}
+
+	for _, crd := range crds {
+		group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+		if group != gvk.Group || kind != gvk.Kind {
+			continue
+		}
+
+		props, ok := crdSchema(crd, gvk.Version)
+		if !ok {
+			return nil, nil
+		}
+
+		field := fieldFromJSONSchema(gvk.Kind, "", props, false, 0)
+		return &field, nil
+	}
+
+	return nil, nil
+}
+
+// crdSchema returns the structural schema for a version in a custom resource definition.
+// Per version schemas take precedence over the top level validation schema.
+func crdSchema(crd *unstructured.Unstructured, version string) (map[string]interface{}, bool) {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		m, ok := v.(map[string]interface{})
+		if !ok || m["name"] != version {
+			continue
+		}
+
+		props, found, _ := unstructured.NestedMap(m, "schema", "openAPIV3Schema")
+		if found {
+			return props, true
+		}
+	}
+
+	props, found, _ := unstructured.NestedMap(crd.Object, "spec", "validation", "openAPIV3Schema")
+	return props, found
+}
+
+func modelGroupVersionKinds(model proto.Schema) []schema.GroupVersionKind {
+	extension, ok := model.GetExtensions()[gvkExtension]
+	if !ok {
+		return nil
+	}
+
+	list, ok := extension.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var gvks []schema.GroupVersionKind
+	for _, item := range list {
+		m, ok := item.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		gvks = append(gvks, schema.GroupVersionKind{
+			Group:   stringValue(m["group"]),
+			Version: stringValue(m["version"]),
+			Kind:    stringValue(m["kind"]),
+		})
+	}
+
+	return gvks
+}
+
+func stringValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,289 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package openapi
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+)
+
+type fakeSchemaClient struct {
+	doc   *openapi_v2.Document
+	err   error
+	calls int
+	// block, if set, blocks fetches until it is closed.
+	block chan struct{}
+
+	mu sync.Mutex
+}
+
+func (c *fakeSchemaClient) OpenAPISchema() (*openapi_v2.Document, error) {
+	if c.block != nil {
+		<-c.block
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls++
+	return c.doc, c.err
+}
+
+func loadDocument(t *testing.T) *openapi_v2.Document {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "swagger.json"))
+	require.NoError(t, err)
+
+	var info yaml.MapSlice
+	require.NoError(t, yaml.Unmarshal(data, &info))
+
+	doc, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	require.NoError(t, err)
+
+	return doc
+}
+
+func TestSchemas_Lookup(t *testing.T) {
+	client := &fakeSchemaClient{doc: loadDocument(t)}
+	s := NewSchemas(client, nil)
+
+	ctx := context.Background()
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+
+	got, err := s.Lookup(ctx, gvk)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Pod", got.Name)
+	assert.Equal(t, "Pod is a collection of containers that can run on a host.", got.Description)
+
+	containers, ok := got.Find("spec.containers")
+	require.True(t, ok)
+	assert.Equal(t, "[]Object", containers.Type)
+	assert.True(t, containers.Required)
+
+	image, ok := got.Find("spec.containers[].image")
+	require.True(t, ok)
+	assert.Equal(t, "string", image.Type)
+	assert.Equal(t, "Docker image name.", image.Description)
+
+	name, ok := got.Find("spec.containers[].name")
+	require.True(t, ok)
+	assert.True(t, name.Required)
+
+	nodeSelector, ok := got.Find("spec.nodeSelector")
+	require.True(t, ok)
+	assert.Equal(t, "map[string]string", nodeSelector.Type)
+
+	serviceAccount, ok := got.Find("spec.serviceAccount")
+	require.True(t, ok)
+	assert.True(t, serviceAccount.Deprecated)
+
+	_, err = s.Lookup(ctx, gvk)
+	require.NoError(t, err)
+	assert.Equal(t, 1, client.calls, "expected schema to be cached")
+}
+
+func TestSchemas_Lookup_fetch_error(t *testing.T) {
+	client := &fakeSchemaClient{err: errors.New("error")}
+	s := NewSchemas(client, nil)
+
+	_, err := s.Lookup(context.Background(), schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+	require.Error(t, err)
+}
+
+func TestSchemas_Lookup_refresh(t *testing.T) {
+	client := &fakeSchemaClient{doc: loadDocument(t)}
+	s := NewSchemas(client, nil)
+
+	now := time.Unix(1547472896, 0)
+	s.nowFunc = func() time.Time {
+		return now
+	}
+
+	ctx := context.Background()
+	missing := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Missing"}
+
+	_, err := s.Lookup(ctx, missing)
+	require.Equal(t, ErrSchemaNotFound, err)
+
+	_, err = s.Lookup(ctx, missing)
+	require.Equal(t, ErrSchemaNotFound, err)
+	assert.Equal(t, 1, client.calls, "expected misses to be rate limited")
+
+	now = now.Add(2 * refreshInterval)
+
+	_, err = s.Lookup(ctx, missing)
+	require.Equal(t, ErrSchemaNotFound, err)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestSchemas_Lookup_fetch_unlocked(t *testing.T) {
+	client := &fakeSchemaClient{doc: loadDocument(t)}
+	s := NewSchemas(client, nil)
+
+	var mu sync.Mutex
+	now := time.Unix(1547472896, 0)
+	s.nowFunc = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	ctx := context.Background()
+	pod := schema.GroupVersionKind{Version: "v1", Kind: "Pod"}
+	missing := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Missing"}
+
+	_, err := s.Lookup(ctx, pod)
+	require.NoError(t, err)
+
+	mu.Lock()
+	now = now.Add(2 * refreshInterval)
+	mu.Unlock()
+
+	client.block = make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Lookup(ctx, missing)
+			assert.Equal(t, ErrSchemaNotFound, err)
+		}()
+	}
+
+	// cached lookups aren't blocked by the slow fetch.
+	done := make(chan struct{})
+	go func() {
+		_, err := s.Lookup(ctx, pod)
+		assert.NoError(t, err)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cached lookup was blocked by a schema fetch")
+	}
+
+	close(client.block)
+	wg.Wait()
+
+	assert.Equal(t, 2, client.calls, "expected concurrent misses to share a fetch")
+}
+
+func TestSchemas_Lookup_crd(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	crd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1beta1",
+			"kind":       "CustomResourceDefinition",
+			"metadata": map[string]interface{}{
+				"name": "crontabs.stable.example.com",
+			},
+			"spec": map[string]interface{}{
+				"group": "stable.example.com",
+				"names": map[string]interface{}{
+					"kind": "CronTab",
+				},
+				"versions": []interface{}{
+					map[string]interface{}{
+						"name": "v1",
+						"schema": map[string]interface{}{
+							"openAPIV3Schema": map[string]interface{}{
+								"description": "CronTab runs a command on a schedule.",
+								"type":        "object",
+								"properties": map[string]interface{}{
+									"spec": map[string]interface{}{
+										"type":     "object",
+										"required": []interface{}{"cronSpec"},
+										"properties": map[string]interface{}{
+											"cronSpec": map[string]interface{}{
+												"type":        "string",
+												"description": "Schedule in cron format.",
+											},
+											"replicas": map[string]interface{}{
+												"type": "integer",
+											},
+											"images": map[string]interface{}{
+												"type": "array",
+												"items": map[string]interface{}{
+													"type": "string",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objectStore := storeFake.NewMockStore(controller)
+	key := store.Key{APIVersion: "apiextensions.k8s.io/v1beta1", Kind: "CustomResourceDefinition"}
+	objectStore.EXPECT().List(gomock.Any(), key).Return([]*unstructured.Unstructured{crd}, nil)
+
+	client := &fakeSchemaClient{doc: loadDocument(t)}
+	s := NewSchemas(client, objectStore)
+
+	got, err := s.Lookup(context.Background(), schema.GroupVersionKind{Group: "stable.example.com", Version: "v1", Kind: "CronTab"})
+	require.NoError(t, err)
+
+	expected := &Field{
+		Name:        "CronTab",
+		Type:        "Object",
+		Description: "CronTab runs a command on a schedule.",
+		Fields: []Field{
+			{
+				Name: "spec",
+				Path: "spec",
+				Type: "Object",
+				Fields: []Field{
+					{
+						Name:        "cronSpec",
+						Path:        "spec.cronSpec",
+						Type:        "string",
+						Description: "Schedule in cron format.",
+						Required:    true,
+					},
+					{
+						Name: "images",
+						Path: "spec.images",
+						Type: "[]string",
+					},
+					{
+						Name: "replicas",
+						Path: "spec.replicas",
+						Type: "integer",
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, got)
+}
@@ -12,21 +12,17 @@ import (
 	"github.com/vmware/octant/internal/cluster"
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/pkg/store"
-
-	"github.com/pkg/errors"
 )
 
-// Default create a portforward instance.
-func Default(ctx context.Context, client cluster.ClientInterface, objectStore store.Store) (PortForwarder, error) {
+// Default create a portforward instance. Saved profiles for contextName are
+// restored from profiles, which can be nil.
+func Default(ctx context.Context, client cluster.ClientInterface, objectStore store.Store, profiles ProfileStore, contextName string) (PortForwarder, error) {
 	logger := log.From(ctx)
-	restClient, err := client.RESTClient()
-	if err != nil {
-		return nil, errors.Wrap(err, "fetching RESTClient")
-	}
 	pfOpts := ServiceOptions{
-		RESTClient:  restClient,
-		Config:      client.RESTConfig(),
-		ObjectStore: objectStore,
+		ClusterClient: client,
+		ObjectStore:   objectStore,
+		Profiles:      profiles,
+		ContextName:   contextName,
 		PortForwarder: &DefaultPortForwarder{
 			IOStreams: IOStreams{
 				In:     os.Stdin,
@@ -39,5 +35,9 @@ func Default(ctx context.Context, client cluster.ClientInterface, objectStore st
 	// FIXME: logger is in context
 	svc := New(ctx, pfOpts, logger)
 
+	if err := svc.RestoreProfiles(); err != nil {
+		logger.Errorf("restoring port forward profiles: %v", err)
+	}
+
 	return svc, nil
 }
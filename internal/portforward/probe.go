@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ProbeTimeout is how long a probe waits for a response.
+const ProbeTimeout = 2 * time.Second
+
+// ProbeResult is the result of an HTTP health check of a forwarded port.
+type ProbeResult struct {
+	// StatusCode is the status of the response. It is zero if there was
+	// no response.
+	StatusCode int
+	// Latency is how long the response took.
+	Latency time.Duration
+	// Err is why there was no response.
+	Err error
+}
+
+// Healthy returns true if the port responded without a server error.
+func (r ProbeResult) Healthy() bool {
+	return r.Err == nil && r.StatusCode < http.StatusInternalServerError
+}
+
+func (r ProbeResult) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("unreachable: %v", r.Err)
+	}
+
+	return fmt.Sprintf("%d %s (%s)", r.StatusCode, http.StatusText(r.StatusCode), r.Latency.Round(time.Millisecond))
+}
+
+// Probe checks if a local port serves HTTP by requesting its root path.
+// Redirects aren't followed since any response means the port is serving.
+func Probe(ctx context.Context, local uint16) ProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, ProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:%d/", local), nil)
+	if err != nil {
+		return ProbeResult{Err: err}
+	}
+	req = req.WithContext(ctx)
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return ProbeResult{Err: err}
+	}
+	defer resp.Body.Close()
+
+	return ProbeResult{
+		StatusCode: resp.StatusCode,
+		Latency:    time.Since(start),
+	}
+}
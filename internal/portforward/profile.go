@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+)
+
+// ProfileFileName is the name of the file profiles are saved in.
+const ProfileFileName = "port-forwards.yaml"
+
+// Profile is a named port forward which is saved and restored on startup.
+// A profile targets a service or workload rather than a pod, so it can
+// follow the target to a new pod when the current one goes away. It is only
+// started while its kube context is the active one.
+type Profile struct {
+	Name       string                `json:"name"`
+	Context    string                `json:"context"`
+	Namespace  string                `json:"namespace"`
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Target     string                `json:"target"`
+	Ports      []PortForwardPortSpec `json:"ports"`
+}
+
+// Validate validates a profile. Every port in a profile needs a local port
+// so the forward is reachable at the same address after it is restarted.
+func (p Profile) Validate() error {
+	if p.Name == "" {
+		return errors.New("profile name is required")
+	}
+
+	if p.Kind == "Pod" {
+		return errors.New("profiles target services or workloads rather than pods")
+	}
+
+	if err := validateRequest(p.request()); err != nil {
+		return err
+	}
+
+	if len(p.Ports) == 0 {
+		return errors.New("profile has no ports")
+	}
+
+	for _, port := range p.Ports {
+		if port.Local == 0 {
+			return errors.Errorf("local port for remote port %d is required", port.Remote)
+		}
+	}
+
+	return nil
+}
+
+func (p Profile) request() CreateRequest {
+	return CreateRequest{
+		Namespace:  p.Namespace,
+		APIVersion: p.APIVersion,
+		Kind:       p.Kind,
+		Name:       p.Target,
+		Ports:      p.Ports,
+	}
+}
+
+// ProfileStore loads and saves profiles.
+type ProfileStore interface {
+	Load() ([]Profile, error)
+	Save(profiles []Profile) error
+}
+
+// profileFile is the layout of the profile file.
+type profileFile struct {
+	Profiles []Profile `json:"profiles"`
+}
+
+// FileProfileStore saves profiles in a YAML file.
+type FileProfileStore struct {
+	fs   afero.Fs
+	path string
+}
+
+var _ ProfileStore = (*FileProfileStore)(nil)
+
+// NewFileProfileStore creates an instance of FileProfileStore which saves
+// profiles to ProfileFileName in dir.
+func NewFileProfileStore(fs afero.Fs, dir string) *FileProfileStore {
+	return &FileProfileStore{
+		fs:   fs,
+		path: filepath.Join(dir, ProfileFileName),
+	}
+}
+
+// Load loads profiles. A missing file has no profiles.
+func (s *FileProfileStore) Load() ([]Profile, error) {
+	data, err := afero.ReadFile(s.fs, s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "read %s", s.path)
+	}
+
+	var file profileFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrapf(err, "parse %s", s.path)
+	}
+
+	return file.Profiles, nil
+}
+
+// Save saves profiles sorted by name.
+func (s *FileProfileStore) Save(profiles []Profile) error {
+	file := profileFile{
+		Profiles: make([]Profile, len(profiles)),
+	}
+	copy(file.Profiles, profiles)
+	sort.Slice(file.Profiles, func(i, j int) bool {
+		return file.Profiles[i].Name < file.Profiles[j].Name
+	})
+
+	data, err := yaml.Marshal(&file)
+	if err != nil {
+		return errors.Wrap(err, "encode profiles")
+	}
+
+	if err := s.fs.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return errors.Wrapf(err, "create %s", filepath.Dir(s.path))
+	}
+
+	if err := afero.WriteFile(s.fs, s.path, data, 0600); err != nil {
+		return errors.Wrapf(err, "write %s", s.path)
+	}
+
+	return nil
+}
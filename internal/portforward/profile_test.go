@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfile_Validate(t *testing.T) {
+	valid := Profile{
+		Name:       "web",
+		Namespace:  "default",
+		APIVersion: "v1",
+		Kind:       "Service",
+		Target:     "web",
+		Ports:      []PortForwardPortSpec{{Local: 8080, Remote: 80}},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *Profile)
+		isErr  bool
+	}{
+		{
+			name:   "valid",
+			modify: func(p *Profile) {},
+		},
+		{
+			name:   "missing name",
+			modify: func(p *Profile) { p.Name = "" },
+			isErr:  true,
+		},
+		{
+			name:   "pod target",
+			modify: func(p *Profile) { p.Kind = "Pod" },
+			isErr:  true,
+		},
+		{
+			name:   "unsupported kind",
+			modify: func(p *Profile) { p.Kind = "ConfigMap" },
+			isErr:  true,
+		},
+		{
+			name:   "no ports",
+			modify: func(p *Profile) { p.Ports = nil },
+			isErr:  true,
+		},
+		{
+			name:   "missing local port",
+			modify: func(p *Profile) { p.Ports = []PortForwardPortSpec{{Remote: 80}} },
+			isErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := valid
+			test.modify(&profile)
+
+			err := profile.Validate()
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFileProfileStore(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	s := NewFileProfileStore(fs, "/config/octant")
+
+	got, err := s.Load()
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	profiles := []Profile{
+		{
+			Name:       "web",
+			Namespace:  "default",
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Target:     "web",
+			Ports:      []PortForwardPortSpec{{Local: 8080, Remote: 80}},
+		},
+		{
+			Name:       "db",
+			Namespace:  "default",
+			APIVersion: "v1",
+			Kind:       "Service",
+			Target:     "db",
+			Ports:      []PortForwardPortSpec{{Local: 5432, Remote: 5432}},
+		},
+	}
+
+	require.NoError(t, s.Save(profiles))
+
+	exists, err := afero.Exists(fs, "/config/octant/port-forwards.yaml")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	got, err = s.Load()
+	require.NoError(t, err)
+
+	expected := []Profile{profiles[1], profiles[0]}
+	assert.Equal(t, expected, got)
+}
+
+func TestFileProfileStore_Load_invalid(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/config/port-forwards.yaml", []byte("profiles: {"), 0600))
+
+	s := NewFileProfileStore(fs, "/config")
+	_, err := s.Load()
+	require.Error(t, err)
+}
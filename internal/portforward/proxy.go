@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+// ProxyPrefix is the path the port forward proxy is served from.
+const ProxyPrefix = "/proxy/port-forwards"
+
+// proxyContentSecurityPolicy sandboxes proxied apps. Without
+// allow-same-origin they run in an opaque origin, so they can't read the
+// dash's cookies or call its API even though they are served from the same
+// host.
+const proxyContentSecurityPolicy = "sandbox allow-forms allow-modals allow-popups allow-scripts"
+
+// ProxyURL returns the path which proxies to the local port of a forwarded
+// remote port.
+func ProxyURL(id string, remote uint16) string {
+	return fmt.Sprintf("%s/%s/%d/", ProxyPrefix, id, remote)
+}
+
+// Proxy is a reverse proxy to forwarded HTTP ports, so they can be opened
+// in the browser through the dash server. Requests to
+// ProxyPrefix/{id}/{remote port}/{path} are sent to {path} on the forward's
+// local port. WebSocket upgrades are proxied as well.
+type Proxy struct {
+	portForwarder PortForwarder
+	logger        log.Logger
+}
+
+var _ http.Handler = (*Proxy)(nil)
+
+// NewProxy creates an instance of Proxy.
+func NewProxy(portForwarder PortForwarder, logger log.Logger) *Proxy {
+	return &Proxy{
+		portForwarder: portForwarder,
+		logger:        logger,
+	}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// a policy sent by the proxied app is added to this one, and browsers
+	// enforce both.
+	w.Header().Set("Content-Security-Policy", proxyContentSecurityPolicy)
+
+	id, remote, rest, ok := parseProxyPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	prefix := strings.TrimSuffix(ProxyURL(id, remote), "/")
+
+	// relative links in the proxied app only resolve if the root of the
+	// app ends with a slash.
+	if rest == "" {
+		u := *r.URL
+		u.Path = prefix + "/"
+		http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
+		return
+	}
+
+	state, found := p.portForwarder.Get(id)
+	if !found {
+		http.Error(w, fmt.Sprintf("port forward %s not found", id), http.StatusNotFound)
+		return
+	}
+
+	if state.Status != StatusForwarding {
+		message := fmt.Sprintf("port forward is %s", state.Status)
+		if state.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, state.Message)
+		}
+		http.Error(w, message, http.StatusServiceUnavailable)
+		return
+	}
+
+	var local uint16
+	for _, port := range state.Ports {
+		if port.Remote == remote {
+			local = port.Local
+		}
+	}
+	if local == 0 {
+		http.Error(w, fmt.Sprintf("port %d is not forwarded", remote), http.StatusNotFound)
+		return
+	}
+
+	target := &url.URL{
+		Scheme: "http",
+		Host:   fmt.Sprintf("localhost:%d", local),
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.URL.Path = rest
+			req.URL.RawPath = ""
+			req.Host = target.Host
+
+			req.Header.Set("X-Forwarded-Prefix", prefix)
+			req.Header.Set("X-Forwarded-Host", r.Host)
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			if location := resp.Header.Get("Location"); location != "" {
+				resp.Header.Set("Location", rewriteLocation(location, target, prefix))
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			p.logger.With("id", id, "port", remote).Warnf("proxying port forward: %v", err)
+			http.Error(w, fmt.Sprintf("proxy to port %d: %v", remote, err), http.StatusBadGateway)
+		},
+	}
+
+	proxy.ServeHTTP(w, r)
+}
+
+// parseProxyPath parses ProxyPrefix/{id}/{remote port}/{path}. rest is
+// empty if the path stops at the port without a trailing slash.
+func parseProxyPath(p string) (id string, remote uint16, rest string, ok bool) {
+	if !strings.HasPrefix(p, ProxyPrefix+"/") {
+		return "", 0, "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(p, ProxyPrefix+"/"), "/", 3)
+	if len(parts) < 2 || parts[0] == "" {
+		return "", 0, "", false
+	}
+
+	port, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil || port == 0 {
+		return "", 0, "", false
+	}
+
+	if len(parts) == 3 {
+		rest = "/" + parts[2]
+	}
+
+	return parts[0], uint16(port), rest, true
+}
+
+// rewriteLocation rewrites redirects to the proxied app so they stay
+// behind the proxy. Redirects to other hosts are left alone.
+func rewriteLocation(location string, target *url.URL, prefix string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+
+	if u.Host != "" && u.Host != target.Host {
+		return location
+	}
+
+	if !path.IsAbs(u.Path) {
+		return location
+	}
+
+	u.Scheme = ""
+	u.Host = ""
+	u.Path = prefix + u.Path
+
+	return u.String()
+}
@@ -0,0 +1,304 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+// stubPortForwarder returns states by id.
+type stubPortForwarder struct {
+	PortForwarder
+	states map[string]State
+}
+
+func (s *stubPortForwarder) Get(id string) (State, bool) {
+	state, ok := s.states[id]
+	return state, ok
+}
+
+func localPort(t *testing.T, server *httptest.Server) uint16 {
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+
+	return uint16(port)
+}
+
+func newBackend(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/home?from=login", http.StatusFound)
+	})
+	mux.HandleFunc("/socket", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" {
+			http.Error(w, "upgrade required", http.StatusUpgradeRequired)
+			return
+		}
+
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		_ = rw.Flush()
+
+		line, err := rw.ReadString('\n')
+		if err != nil {
+			return
+		}
+		_, _ = rw.WriteString("echo: " + line)
+		_ = rw.Flush()
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s?%s prefix=%s", r.URL.Path, r.URL.RawQuery, r.Header.Get("X-Forwarded-Prefix"))
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func newProxyServer(t *testing.T, backend *httptest.Server, status Status) *httptest.Server {
+	pf := &stubPortForwarder{
+		states: map[string]State{
+			"id": {
+				ID:      "id",
+				Status:  status,
+				Message: "pod went away",
+				Ports:   []ForwardedPort{{Local: localPort(t, backend), Remote: 80}},
+			},
+		},
+	}
+
+	return httptest.NewServer(NewProxy(pf, log.NopLogger()))
+}
+
+func noRedirectClient() *http.Client {
+	return &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func TestProxy(t *testing.T) {
+	backend := newBackend(t)
+	defer backend.Close()
+
+	server := newProxyServer(t, backend, StatusForwarding)
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+		expectedBody string
+		location     string
+	}{
+		{
+			name:         "path is rewritten",
+			path:         "/proxy/port-forwards/id/80/api/items?page=2",
+			expectedCode: http.StatusOK,
+			expectedBody: "/api/items?page=2 prefix=/proxy/port-forwards/id/80",
+		},
+		{
+			name:         "root",
+			path:         "/proxy/port-forwards/id/80/",
+			expectedCode: http.StatusOK,
+			expectedBody: "/? prefix=/proxy/port-forwards/id/80",
+		},
+		{
+			name:         "root without trailing slash",
+			path:         "/proxy/port-forwards/id/80",
+			expectedCode: http.StatusMovedPermanently,
+			location:     "/proxy/port-forwards/id/80/",
+		},
+		{
+			name:         "redirects stay behind the proxy",
+			path:         "/proxy/port-forwards/id/80/login",
+			expectedCode: http.StatusFound,
+			location:     "/proxy/port-forwards/id/80/home?from=login",
+		},
+		{
+			name:         "unknown port forward",
+			path:         "/proxy/port-forwards/other/80/",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "port which isn't forwarded",
+			path:         "/proxy/port-forwards/id/443/",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "invalid path",
+			path:         "/proxy/port-forwards/id/http/",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := noRedirectClient().Get(server.URL + test.path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, test.expectedCode, resp.StatusCode)
+			assert.Equal(t, proxyContentSecurityPolicy, resp.Header.Get("Content-Security-Policy"))
+			assert.NotContains(t, resp.Header.Get("Content-Security-Policy"), "allow-same-origin")
+
+			if test.expectedBody != "" {
+				body, err := ioutil.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedBody, string(body))
+			}
+
+			if test.location != "" {
+				assert.Equal(t, test.location, resp.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func TestProxy_not_forwarding(t *testing.T) {
+	backend := newBackend(t)
+	defer backend.Close()
+
+	server := newProxyServer(t, backend, StatusRetargeting)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/proxy/port-forwards/id/80/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "retargeting: pod went away")
+}
+
+func TestProxy_websocket(t *testing.T) {
+	backend := newBackend(t)
+	defer backend.Close()
+
+	server := newProxyServer(t, backend, StatusForwarding)
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", u.Host)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "GET /proxy/port-forwards/id/80/socket HTTP/1.1\r\nHost: %s\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n", u.Host)
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	_, err = fmt.Fprint(conn, "ping\n")
+	require.NoError(t, err)
+
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "echo: ping\n", line)
+}
+
+func Test_rewriteLocation(t *testing.T) {
+	target := &url.URL{Scheme: "http", Host: "localhost:8080"}
+	prefix := "/proxy/port-forwards/id/80"
+
+	tests := []struct {
+		location string
+		expected string
+	}{
+		{location: "/home", expected: "/proxy/port-forwards/id/80/home"},
+		{location: "http://localhost:8080/home?a=b", expected: "/proxy/port-forwards/id/80/home?a=b"},
+		{location: "https://example.com/home", expected: "https://example.com/home"},
+		{location: "home", expected: "home"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, rewriteLocation(test.location, target, prefix))
+	}
+}
+
+func TestProbe(t *testing.T) {
+	backend := newBackend(t)
+
+	result := Probe(context.Background(), localPort(t, backend))
+	require.NoError(t, result.Err)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.True(t, result.Healthy())
+
+	port := localPort(t, backend)
+	backend.Close()
+
+	result = Probe(context.Background(), port)
+	assert.Error(t, result.Err)
+	assert.False(t, result.Healthy())
+	assert.Contains(t, result.String(), "unreachable")
+}
+
+func TestService_probePorts(t *testing.T) {
+	svc := New(context.Background(), ServiceOptions{}, log.NopLogger())
+	defer svc.Stop()
+
+	var probes int32
+	svc.probeInterval = 10 * time.Millisecond
+	svc.probe = func(ctx context.Context, local uint16) ProbeResult {
+		atomic.AddInt32(&probes, 1)
+		return ProbeResult{StatusCode: int(local)}
+	}
+
+	svc.state.portForwards["id"] = State{
+		ID:     "id",
+		Status: StatusForwarding,
+		Ports:  []ForwardedPort{{Local: 200, Remote: 80}, {Local: 500, Remote: 443}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.probePorts(ctx, "id")
+		close(done)
+	}()
+
+	waitFor(t, "ports to be probed twice", func() bool {
+		return atomic.LoadInt32(&probes) >= 4
+	})
+
+	state, ok := svc.Get("id")
+	require.True(t, ok)
+	assert.Equal(t, []ProbeResult{{StatusCode: 200}, {StatusCode: 500}}, state.Health)
+
+	cancel()
+	<-done
+
+	state, ok = svc.Get("id")
+	require.True(t, ok)
+	assert.Empty(t, state.Health)
+}
@@ -16,9 +16,8 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/client-go/rest"
-	restclient "k8s.io/client-go/rest"
 
+	"github.com/vmware/octant/internal/cluster"
 	"github.com/vmware/octant/internal/log"
 	"github.com/vmware/octant/pkg/store"
 )
@@ -37,6 +36,10 @@ type PortForwarder interface {
 	Find(namespace string, gvk schema.GroupVersionKind, name string) (State, error)
 	Stop()
 	StopForwarder(id string)
+	Profiles() []Profile
+	SaveProfile(profile Profile) error
+	DeleteProfile(name string) error
+	UseContext(contextName string, clusterClient cluster.RESTInterface)
 }
 
 // PortForwardPortSpec describes a forwarded port.
@@ -72,6 +75,22 @@ type Target struct {
 	Name      string
 }
 
+// Status is the status of a port forward.
+type Status string
+
+const (
+	// StatusStarting means the port forward is connecting to its first pod.
+	StatusStarting Status = "starting"
+	// StatusForwarding means ports are being forwarded to a pod.
+	StatusForwarding Status = "forwarding"
+	// StatusRetargeting means the pod went away and the port forward is
+	// moving to another ready pod.
+	StatusRetargeting Status = "retargeting"
+	// StatusFailed means the last attempt to forward ports failed. Port
+	// forwards for profiles are retried.
+	StatusFailed Status = "failed"
+)
+
 // State describes a single port-forward's runtime state
 type State struct {
 	ID        string
@@ -79,6 +98,14 @@ type State struct {
 	Ports     []ForwardedPort
 	Target    Target
 	Pod       Target
+	Status    Status
+	// Message describes why the port forward is retargeting or failed.
+	Message string
+	// Profile is the name of the profile the port forward was started for.
+	Profile string
+	// Health is the result of the last probe of each port, in the order of
+	// Ports. It is empty until the ports are probed.
+	Health []ProbeResult
 
 	cancel context.CancelFunc
 }
@@ -91,9 +118,16 @@ func (pf *State) Clone() State {
 		Ports:     make([]ForwardedPort, len(pf.Ports)),
 		Target:    pf.Target,
 		Pod:       pf.Pod,
+		Status:    pf.Status,
+		Message:   pf.Message,
+		Profile:   pf.Profile,
 		cancel:    pf.cancel,
 	}
 	copy(pfCpy.Ports, pf.Ports)
+	if pf.Health != nil {
+		pfCpy.Health = make([]ProbeResult, len(pf.Health))
+		copy(pfCpy.Health, pf.Health)
+	}
 	return pfCpy
 }
 
@@ -105,10 +139,17 @@ type States struct {
 
 // PortForwardSvcOptions contains all the options for running a port-forward service
 type ServiceOptions struct {
-	RESTClient    rest.Interface
-	Config        *restclient.Config
+	// ClusterClient is the client for the initial kube context. It is
+	// replaced by UseContext.
+	ClusterClient cluster.RESTInterface
 	ObjectStore   store.Store
 	PortForwarder portForwarder
+	// Profiles saves port forward profiles. Profiles are unavailable if it
+	// is nil.
+	Profiles ProfileStore
+	// ContextName is the active kube context. Only profiles saved for it
+	// are started.
+	ContextName string
 }
 
 type forwarderEvent struct {
@@ -124,6 +165,22 @@ type Service struct {
 	cancel   context.CancelFunc
 	notifyCh chan forwarderEvent
 	state    States
+
+	profileMu   sync.Mutex
+	profiles    []Profile
+	contextName string
+
+	clusterClientMu sync.Mutex
+	clusterClient   cluster.RESTInterface
+
+	// retryInterval is how long a profile waits before it retries
+	// forwarding after a failure.
+	retryInterval time.Duration
+	// checkInterval is how often a profile checks its pod is still ready.
+	checkInterval time.Duration
+	// probeInterval is how often forwarded ports are probed.
+	probeInterval time.Duration
+	probe         func(ctx context.Context, local uint16) ProbeResult
 }
 
 var _ PortForwarder = (*Service)(nil)
@@ -140,6 +197,12 @@ func New(ctx context.Context, opts ServiceOptions, logger log.Logger) *Service {
 		state: States{
 			portForwards: make(map[string]State),
 		},
+		contextName:   opts.ContextName,
+		clusterClient: opts.ClusterClient,
+		retryInterval: 5 * time.Second,
+		checkInterval: 5 * time.Second,
+		probeInterval: 10 * time.Second,
+		probe:         Probe,
 	}
 }
 
@@ -151,7 +214,7 @@ func (s *Service) Stop() {
 	}
 }
 
-func (s *Service) validateCreateRequest(r CreateRequest) error {
+func validateRequest(r CreateRequest) error {
 	if r.Namespace == "" {
 		return errors.New("namespace field required")
 	}
@@ -159,8 +222,8 @@ func (s *Service) validateCreateRequest(r CreateRequest) error {
 		return errors.New("name field required")
 	}
 
-	if r.APIVersion != "v1" || r.Kind != "Pod" {
-		return errors.Errorf("port forwards only work with pods")
+	if !targetKinds[r.Kind] {
+		return errors.Errorf("port forwards only work with pods, services, and workloads")
 	}
 
 	for _, p := range r.Ports {
@@ -173,34 +236,53 @@ func (s *Service) validateCreateRequest(r CreateRequest) error {
 }
 
 // resolvePod attempts to resolve a port forward request into an active pod we can
-// forward to. Service/deployments selectors will be resolved into pods and a random
-// one will be chosen. A pod has to be active.
-// Returns: pod name or error.
-func (s *Service) resolvePod(ctx context.Context, r CreateRequest) (string, error) {
+// forward to. Service/workload selectors will be resolved into pods and the first
+// ready one will be chosen. A pod has to be active.
+func (s *Service) resolvePod(ctx context.Context, r CreateRequest) (*corev1.Pod, error) {
 	o := s.opts.ObjectStore
 	if o == nil {
-		return "", errors.New("nil objectstore")
+		return nil, errors.New("nil objectstore")
 	}
 
-	switch {
-	case r.APIVersion == "v1" && r.Kind == "Pod":
+	if r.Kind == "Pod" {
 		// Verify pod exists and status is running
-		if ok, err := s.verifyPod(ctx, r.Namespace, r.Name); !ok || err != nil {
-			return "", errors.Errorf("verifying pod %q: %v", r.Name, err)
+		pod, err := s.verifyPod(ctx, r.Namespace, r.Name)
+		if err != nil {
+			return nil, errors.Errorf("verifying pod %q: %v", r.Name, err)
 		}
-		return r.Name, nil
-	default:
-		return "", errors.New("not implemented")
+		return pod, nil
+	}
+
+	pods, err := readyPods(ctx, o, r)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, errors.Errorf("%s %q does not have any ready pods", r.Kind, r.Name)
+	}
+
+	return &pods[0], nil
+}
+
+// verifyPod returns the pod if it can be found and is in the running phase.
+// Otherwise returns an error describing the cause.
+func (s *Service) verifyPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	pod, err := s.getPod(ctx, namespace, name)
+	if err != nil {
+		return nil, err
 	}
 
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, errors.Errorf("pod not running, phase=%v", pod.Status.Phase)
+	}
+
+	return pod, nil
 }
 
-// verifyPod returns true if the specified pod can be found and is in the running phase.
-// Otherwise returns false and an error describing the cause.
-func (s *Service) verifyPod(ctx context.Context, namespace, name string) (bool, error) {
+func (s *Service) getPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
 	o := s.opts.ObjectStore
 	if o == nil {
-		return false, errors.New("nil objectstore")
+		return nil, errors.New("nil objectstore")
 	}
 
 	key := store.Key{
@@ -211,40 +293,24 @@ func (s *Service) verifyPod(ctx context.Context, namespace, name string) (bool,
 	}
 	var pod corev1.Pod
 	if err := store.GetAs(ctx, o, key, &pod); err != nil {
-		return false, err
+		return nil, err
 	}
 	if pod.Name == "" {
-		return false, errors.New("pod not found")
-	}
-
-	if pod.Status.Phase != corev1.PodRunning {
-		return false, errors.Errorf("pod not running, phase=%v", pod.Status.Phase)
+		return nil, errors.New("pod not found")
 	}
 
-	return true, nil
+	return &pod, nil
 }
 
-// createForwarder creates a port forwarder, forwards traffic, and blocks until
-// port state information is populated.
+// createForwarder creates a port forwarder for a target which was resolved to
+// a pod, forwards traffic, and blocks until port state information is populated.
 // Returns forwarder id.
-func (s *Service) createForwarder(r CreateRequest) (string, error) {
-	logger := s.logger.With("context", "PortForwardService.createForwarder")
-
-	if s.opts.PortForwarder == nil {
-		return "", errors.New("portforwarder is nil")
-	}
-
+func (s *Service) createForwarder(r CreateRequest, podName string, ports []PortForwardPortSpec) (string, error) {
 	randomUUID, err := uuid.NewRandom()
 	if err != nil {
 		return "", errors.Wrap(err, "generating uuid")
 	}
 	forwarderID := randomUUID.String()
-	logger = logger.With("id", forwarderID)
-
-	var ports []string
-	for _, p := range r.Ports {
-		ports = append(ports, fmt.Sprintf("%d:%d", p.Local, p.Remote))
-	}
 
 	// Target coordinates to preserve in state
 	gv, err := schema.ParseGroupVersion(r.APIVersion)
@@ -256,22 +322,6 @@ func (s *Service) createForwarder(r CreateRequest) (string, error) {
 	// This child context will be cancelled if our parent context is cancelled
 	ctx, cancel := context.WithCancel(s.ctx)
 
-	// Spawns goroutine to update state as ports become available
-	portsChannel, portsReady := s.localPortsHandler(ctx, forwarderID)
-
-	// TODO resolve request gvk/name to pod name
-	o := &s.opts
-	opts := Options{
-		Config:        o.Config,
-		RESTClient:    o.RESTClient,
-		Address:       []string{"localhost"},
-		Ports:         ports,
-		PortForwarder: o.PortForwarder,
-		StopChannel:   ctx.Done(),
-		ReadyChannel:  make(chan struct{}),
-		PortsChannel:  portsChannel,
-	}
-
 	// NOTE: ports will be updated in the state struct by
 	// localPortsHandler when they become available.
 	forwardState := State{
@@ -282,12 +332,8 @@ func (s *Service) createForwarder(r CreateRequest) (string, error) {
 			Namespace: r.Namespace,
 			Name:      r.Name,
 		},
-		// TODO Target and Pod may be different
-		Pod: Target{
-			GVK:       gvk,
-			Namespace: r.Namespace,
-			Name:      r.Name,
-		},
+		Pod:    podTarget(r.Namespace, podName),
+		Status: StatusStarting,
 		cancel: cancel,
 	}
 
@@ -295,18 +341,15 @@ func (s *Service) createForwarder(r CreateRequest) (string, error) {
 	s.state.portForwards[forwarderID] = forwardState
 	s.state.Unlock()
 
-	req := o.RESTClient.Post().
-		Resource("pods").
-		Namespace(r.Namespace).
-		Name(r.Name).
-		SubResource("portforward")
+	done, err := s.forward(ctx, forwarderID, r.Namespace, podName, ports, r.Ports)
+	if err != nil {
+		s.StopForwarder(forwarderID)
+		return "", err
+	}
+	s.setStatus(forwarderID, StatusForwarding, "")
 
 	go func() {
-		// Blocks until forwarder completes
-		logger.With("url", req.URL()).Debugf("starting port-forward")
-		err := s.opts.PortForwarder.ForwardPorts("POST", req.URL(), opts)
-
-		logger.Debugf("forwarding terminated: %v", err)
+		err := <-done
 
 		// Notify the main forwarder of the termination
 		event := forwarderEvent{
@@ -322,14 +365,172 @@ func (s *Service) createForwarder(r CreateRequest) (string, error) {
 		s.StopForwarder(forwarderID)
 	}()
 
+	return forwarderID, nil
+}
+
+// forward forwards ports to a pod for the port forward with id, and blocks
+// until port state information is populated. The state reports the remote
+// ports which were requested, which differ from the pod's ports for services.
+// The returned channel receives the result of the forwarder when it terminates.
+func (s *Service) forward(ctx context.Context, id, namespace, podName string, ports, requested []PortForwardPortSpec) (<-chan error, error) {
+	logger := s.logger.With("context", "PortForwardService.forward", "id", id)
+
+	if s.opts.PortForwarder == nil {
+		return nil, errors.New("portforwarder is nil")
+	}
+
+	clusterClient := s.currentClusterClient()
+	if clusterClient == nil {
+		return nil, errors.New("cluster client is nil")
+	}
+
+	restClient, err := clusterClient.RESTClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching RESTClient")
+	}
+
+	var portSpecs []string
+	for _, p := range ports {
+		portSpecs = append(portSpecs, fmt.Sprintf("%d:%d", p.Local, p.Remote))
+	}
+
+	// Spawns goroutine to update state as ports become available
+	portsChannel, portsReady := s.localPortsHandler(ctx, id, requested)
+
+	o := &s.opts
+	opts := Options{
+		Config:        clusterClient.RESTConfig(),
+		RESTClient:    restClient,
+		Address:       []string{"localhost"},
+		Ports:         portSpecs,
+		PortForwarder: o.PortForwarder,
+		StopChannel:   ctx.Done(),
+		ReadyChannel:  make(chan struct{}),
+		PortsChannel:  portsChannel,
+	}
+
+	req := restClient.Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward")
+
+	done := make(chan error, 1)
+
+	go func() {
+		// Blocks until forwarder completes
+		logger.With("url", req.URL()).Debugf("starting port-forward")
+		err := o.PortForwarder.ForwardPorts("POST", req.URL(), opts)
+
+		logger.Debugf("forwarding terminated: %v", err)
+		done <- err
+	}()
+
 	// Block until ports state is ready
 	select {
 	case <-ctx.Done():
-		return "", errors.Errorf("portforward terminated due to parent context: %v", forwarderID)
+		return nil, errors.Errorf("portforward terminated due to parent context: %v", id)
+	case err := <-done:
+		if err == nil {
+			err = errors.New("forwarder stopped")
+		}
+		return nil, errors.Wrapf(err, "forwarding ports to pod %q", podName)
 	case <-portsReady:
 	}
 
-	return forwarderID, nil
+	go s.probePorts(ctx, id)
+
+	return done, nil
+}
+
+// probePorts probes the local ports of the port forward with id until ctx is
+// cancelled, so listing port forwards never waits on a slow port. The
+// results are cleared when forwarding stops.
+func (s *Service) probePorts(ctx context.Context, id string) {
+	ticker := time.NewTicker(s.probeInterval)
+	defer ticker.Stop()
+
+	for {
+		state, ok := s.Get(id)
+		if !ok {
+			return
+		}
+
+		health := make([]ProbeResult, len(state.Ports))
+		for i := range state.Ports {
+			health[i] = s.probe(ctx, state.Ports[i].Local)
+		}
+
+		if ctx.Err() == nil {
+			s.setHealth(id, health)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.setHealth(id, nil)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// setStatus updates the status of an existing port forward, specified by id.
+func (s *Service) setStatus(id string, status Status, message string) {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	state, ok := s.state.portForwards[id]
+	if !ok {
+		return
+	}
+	state.Status = status
+	state.Message = message
+	s.state.portForwards[id] = state
+}
+
+// currentClusterClient returns the client for the active kube context. It is
+// looked up for each forward so forwards use the current context and
+// credentials.
+func (s *Service) currentClusterClient() cluster.RESTInterface {
+	s.clusterClientMu.Lock()
+	defer s.clusterClientMu.Unlock()
+
+	return s.clusterClient
+}
+
+// setHealth updates the probe results of an existing port forward, specified
+// by id.
+func (s *Service) setHealth(id string, health []ProbeResult) {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	state, ok := s.state.portForwards[id]
+	if !ok {
+		return
+	}
+	state.Health = health
+	s.state.portForwards[id] = state
+}
+
+// setPod updates the pod of an existing port forward, specified by id.
+func (s *Service) setPod(id, namespace, podName string) {
+	s.state.Lock()
+	defer s.state.Unlock()
+
+	state, ok := s.state.portForwards[id]
+	if !ok {
+		return
+	}
+	state.Pod = podTarget(namespace, podName)
+	s.state.portForwards[id] = state
+}
+
+func podTarget(namespace, name string) Target {
+	return Target{
+		GVK:       corev1.SchemeGroupVersion.WithKind("Pod"),
+		Namespace: namespace,
+		Name:      name,
+	}
 }
 
 // responseForCreate creates a create response based on the state for the specified forward (by id)
@@ -355,7 +556,7 @@ func (s *Service) responseForCreate(id string) (CreateResponse, error) {
 	return response, nil
 }
 
-func (s *Service) localPortsHandler(ctx context.Context, id string) (portsChan chan []ForwardedPort, portsReady <-chan struct{}) {
+func (s *Service) localPortsHandler(ctx context.Context, id string, requested []PortForwardPortSpec) (portsChan chan []ForwardedPort, portsReady <-chan struct{}) {
 	logger := s.logger.With("context", "PortForwardService.localPortsHandler", "id", id)
 	portsChan = make(chan []ForwardedPort, 1)
 	readyChan := make(chan struct{})
@@ -365,6 +566,11 @@ func (s *Service) localPortsHandler(ctx context.Context, id string) (portsChan c
 		select {
 		case p := <-portsChan:
 			logger.With("ports", p).Debugf("received ports for port-forward")
+			for i := range p {
+				if i < len(requested) {
+					p[i].Remote = requested[i].Remote
+				}
+			}
 			if err := s.updatePorts(id, p); err != nil {
 				logger.Warnf("%s", err.Error())
 			}
@@ -426,7 +632,7 @@ func (s *Service) Create(ctx context.Context, gvk schema.GroupVersionKind, name
 	logger := s.logger.With("context", "PortForwardService.Create")
 	req := newForwardRequest(gvk, name, namespace, remotePort)
 
-	if err := s.validateCreateRequest(req); err != nil {
+	if err := validateRequest(req); err != nil {
 		return emptyPortForwardResponse, errors.Wrap(err, "invalid request")
 	}
 
@@ -437,15 +643,18 @@ func (s *Service) Create(ctx context.Context, gvk schema.GroupVersionKind, name
 		"name", req.Name,
 		"namespace", req.Namespace,
 	).Debugf("resolving pod from object")
-	podName, err := s.resolvePod(ctx, req)
+	pod, err := s.resolvePod(ctx, req)
 	if err != nil {
 		return emptyPortForwardResponse, errors.Wrap(err, "resolving pod")
 	}
-	logger.Debugf("resolved to pod %q", podName)
-	podReq := req
-	podReq.Name = podName
+	logger.Debugf("resolved to pod %q", pod.Name)
 
-	id, err := s.createForwarder(req)
+	ports, err := remotePorts(ctx, s.opts.ObjectStore, req, *pod)
+	if err != nil {
+		return emptyPortForwardResponse, errors.Wrap(err, "resolving ports")
+	}
+
+	id, err := s.createForwarder(req, pod.Name, ports)
 	if err != nil {
 		return emptyPortForwardResponse, errors.Wrap(err, "creating forwarder")
 	}
@@ -459,8 +668,9 @@ func (s *Service) Create(ctx context.Context, gvk schema.GroupVersionKind, name
 	return response, nil
 }
 
-// StopForwarder stops an individual port forward specified by id.
-// Implements PortForwardInterface.
+// StopForwarder stops an individual port forward specified by id. Port forwards
+// for profiles are stopped until the next startup; use DeleteProfile to
+// remove the profile. Implements PortForwardInterface.
 func (s *Service) StopForwarder(id string) {
 	s.state.Lock()
 	defer s.state.Unlock()
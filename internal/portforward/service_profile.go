@@ -0,0 +1,331 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware/octant/internal/cluster"
+)
+
+// Profiles lists the saved profiles sorted by name.
+func (s *Service) Profiles() []Profile {
+	s.profileMu.Lock()
+	defer s.profileMu.Unlock()
+
+	profiles := make([]Profile, len(s.profiles))
+	copy(profiles, s.profiles)
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles
+}
+
+// SaveProfile saves a profile for the active kube context and starts
+// forwarding its ports. A profile with the same name is replaced.
+func (s *Service) SaveProfile(profile Profile) error {
+	if s.opts.Profiles == nil {
+		return errors.New("port forward profiles are not available")
+	}
+
+	if err := profile.Validate(); err != nil {
+		return errors.Wrap(err, "invalid profile")
+	}
+
+	s.profileMu.Lock()
+	defer s.profileMu.Unlock()
+
+	if s.contextName == "" {
+		return errors.New("port forward profiles need an active kube context")
+	}
+	profile.Context = s.contextName
+
+	profiles := []Profile{profile}
+	for _, existing := range s.profiles {
+		if existing.Name == profile.Name {
+			continue
+		}
+
+		if existing.Context != profile.Context {
+			profiles = append(profiles, existing)
+			continue
+		}
+
+		if port, ok := sharedLocalPort(existing, profile); ok {
+			return errors.Errorf("local port %d is used by profile %q", port, existing.Name)
+		}
+
+		profiles = append(profiles, existing)
+	}
+
+	if err := s.opts.Profiles.Save(profiles); err != nil {
+		return errors.Wrap(err, "save profiles")
+	}
+	s.profiles = profiles
+
+	s.stopProfile(profile.Name)
+	s.startProfile(profile)
+
+	return nil
+}
+
+// DeleteProfile stops the port forward for a profile and deletes it.
+func (s *Service) DeleteProfile(name string) error {
+	if s.opts.Profiles == nil {
+		return errors.New("port forward profiles are not available")
+	}
+
+	s.profileMu.Lock()
+	defer s.profileMu.Unlock()
+
+	var profiles []Profile
+	for _, existing := range s.profiles {
+		if existing.Name != name {
+			profiles = append(profiles, existing)
+		}
+	}
+
+	if len(profiles) == len(s.profiles) {
+		return errors.Errorf("profile %q not found", name)
+	}
+
+	if err := s.opts.Profiles.Save(profiles); err != nil {
+		return errors.Wrap(err, "save profiles")
+	}
+	s.profiles = profiles
+
+	s.stopProfile(name)
+
+	return nil
+}
+
+// RestoreProfiles loads the saved profiles and starts forwarding ports for
+// the ones saved for the active kube context. Invalid profiles are kept but
+// not started.
+func (s *Service) RestoreProfiles() error {
+	if s.opts.Profiles == nil {
+		return nil
+	}
+
+	profiles, err := s.opts.Profiles.Load()
+	if err != nil {
+		return errors.Wrap(err, "load profiles")
+	}
+
+	s.profileMu.Lock()
+	defer s.profileMu.Unlock()
+
+	s.profiles = profiles
+	s.startProfiles()
+
+	return nil
+}
+
+// UseContext switches to the client for a kube context. New port forwards
+// use clusterClient. If the context changed, the port forwards for the
+// profiles of the previous context are stopped and the ones saved for
+// contextName are started.
+func (s *Service) UseContext(contextName string, clusterClient cluster.RESTInterface) {
+	s.clusterClientMu.Lock()
+	s.clusterClient = clusterClient
+	s.clusterClientMu.Unlock()
+
+	s.profileMu.Lock()
+	defer s.profileMu.Unlock()
+
+	if contextName == s.contextName {
+		return
+	}
+
+	for _, profile := range s.profiles {
+		s.stopProfile(profile.Name)
+	}
+
+	s.contextName = contextName
+	s.startProfiles()
+}
+
+// startProfiles starts the port forwards for the valid profiles saved for
+// the active kube context. profileMu must be held.
+func (s *Service) startProfiles() {
+	for _, profile := range s.profiles {
+		logger := s.logger.With("profile", profile.Name)
+
+		if profile.Context == "" {
+			logger.Warnf("skipping port forward profile without a kube context; save it again to restore it")
+			continue
+		}
+
+		if profile.Context != s.contextName {
+			logger.With("profile-context", profile.Context).Debugf("skipping port forward profile for another kube context")
+			continue
+		}
+
+		if err := profile.Validate(); err != nil {
+			logger.Warnf("skipping port forward profile: %v", err)
+			continue
+		}
+
+		s.startProfile(profile)
+	}
+}
+
+// startProfile starts the port forward for a profile. The port forward is
+// kept pointed at a ready pod of the profile's target until it is stopped.
+func (s *Service) startProfile(profile Profile) {
+	r := profile.request()
+
+	gv, err := schema.ParseGroupVersion(r.APIVersion)
+	if err != nil {
+		s.logger.With("profile", profile.Name).Warnf("parsing APIVersion: %v", err)
+		return
+	}
+
+	randomUUID, err := uuid.NewRandom()
+	if err != nil {
+		s.logger.With("profile", profile.Name).Warnf("generating uuid: %v", err)
+		return
+	}
+	id := randomUUID.String()
+
+	ctx, cancel := context.WithCancel(s.ctx)
+
+	ports := make([]ForwardedPort, len(r.Ports))
+	for i := range r.Ports {
+		ports[i] = ForwardedPort{
+			Local:  r.Ports[i].Local,
+			Remote: r.Ports[i].Remote,
+		}
+	}
+
+	s.state.Lock()
+	s.state.portForwards[id] = State{
+		ID:        id,
+		CreatedAt: time.Now(),
+		Ports:     ports,
+		Target: Target{
+			GVK:       gv.WithKind(r.Kind),
+			Namespace: r.Namespace,
+			Name:      r.Name,
+		},
+		Status:  StatusStarting,
+		Profile: profile.Name,
+		cancel:  cancel,
+	}
+	s.state.Unlock()
+
+	go s.superviseProfile(ctx, id, r)
+}
+
+// stopProfile stops the port forward for a profile.
+func (s *Service) stopProfile(name string) {
+	for _, state := range s.List() {
+		if state.Profile == name {
+			s.StopForwarder(state.ID)
+		}
+	}
+}
+
+// superviseProfile forwards ports for a profile until ctx is cancelled. When
+// the pod goes away or forwarding fails, it waits and forwards to a ready pod
+// again.
+func (s *Service) superviseProfile(ctx context.Context, id string, r CreateRequest) {
+	logger := s.logger.With("context", "PortForwardService.superviseProfile", "id", id)
+
+	for {
+		forwarded, err := s.forwardToReadyPod(ctx, id, r)
+		if ctx.Err() != nil {
+			logger.Debugf("stopped")
+			return
+		}
+
+		status := StatusFailed
+		if forwarded {
+			status = StatusRetargeting
+		}
+		logger.With("status", status).Debugf("port-forward interrupted: %v", err)
+		s.setStatus(id, status, err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.retryInterval):
+		}
+	}
+}
+
+// forwardToReadyPod forwards ports to a ready pod of the target in r. It
+// returns when forwarding stops or the pod is no longer ready. forwarded is
+// true if ports were forwarded before it returned.
+func (s *Service) forwardToReadyPod(ctx context.Context, id string, r CreateRequest) (forwarded bool, err error) {
+	pod, err := s.resolvePod(ctx, r)
+	if err != nil {
+		return false, errors.Wrap(err, "resolving pod")
+	}
+
+	ports, err := remotePorts(ctx, s.opts.ObjectStore, r, *pod)
+	if err != nil {
+		return false, errors.Wrap(err, "resolving ports")
+	}
+
+	s.setPod(id, r.Namespace, pod.Name)
+
+	forwardCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done, err := s.forward(forwardCtx, id, r.Namespace, pod.Name, ports, r.Ports)
+	if err != nil {
+		return false, err
+	}
+	s.setStatus(id, StatusForwarding, "")
+
+	ticker := time.NewTicker(s.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case err := <-done:
+			if err == nil {
+				err = errors.New("forwarder stopped")
+			}
+			return true, errors.Wrapf(err, "forwarding to pod %q", pod.Name)
+		case <-ticker.C:
+			current, err := s.getPod(ctx, r.Namespace, pod.Name)
+			if err == nil && !isPodReady(*current) {
+				err = errors.New("pod is not ready")
+			}
+			if err != nil {
+				// wait for the forwarder to release the local ports so they
+				// can be used for the next pod.
+				cancel()
+				<-done
+				return true, errors.Wrapf(err, "pod %q", pod.Name)
+			}
+		}
+	}
+}
+
+func sharedLocalPort(a, b Profile) (uint16, bool) {
+	for _, pa := range a.Ports {
+		for _, pb := range b.Ports {
+			if pa.Local == pb.Local {
+				return pa.Local, true
+			}
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,357 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	clusterFake "github.com/vmware/octant/internal/cluster/fake"
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+)
+
+type memoryProfileStore struct {
+	profiles []Profile
+}
+
+var _ ProfileStore = (*memoryProfileStore)(nil)
+
+func (s *memoryProfileStore) Load() ([]Profile, error) {
+	return s.profiles, nil
+}
+
+func (s *memoryProfileStore) Save(profiles []Profile) error {
+	s.profiles = profiles
+	return nil
+}
+
+// fakePortForwarder forwards until it is stopped and records the pods it
+// forwarded to.
+type fakePortForwarder struct {
+	mu   sync.Mutex
+	pods []string
+}
+
+func (f *fakePortForwarder) ForwardPorts(method string, u *url.URL, opts Options) error {
+	f.mu.Lock()
+	f.pods = append(f.pods, path.Base(path.Dir(u.Path)))
+	f.mu.Unlock()
+
+	var ports []ForwardedPort
+	for _, spec := range opts.Ports {
+		parts := strings.Split(spec, ":")
+		local, _ := strconv.Atoi(parts[0])
+		remote, _ := strconv.Atoi(parts[1])
+		ports = append(ports, ForwardedPort{Local: uint16(local), Remote: uint16(remote)})
+	}
+	opts.PortsChannel <- ports
+
+	<-opts.StopChannel
+	return nil
+}
+
+func (f *fakePortForwarder) forwardedPods() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.pods...)
+}
+
+// fakeCluster holds the pods of a deployment and can change them while a
+// profile is forwarding.
+type fakeCluster struct {
+	mu   sync.Mutex
+	pods map[string]*corev1.Pod
+}
+
+func (c *fakeCluster) setPod(pod *corev1.Pod) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pods[pod.Name] = pod
+}
+
+func (c *fakeCluster) list(t *testing.T) []*unstructured.Unstructured {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var list []*unstructured.Unstructured
+	for _, pod := range c.pods {
+		list = append(list, testutil.ToUnstructured(t, pod))
+	}
+	return list
+}
+
+func (c *fakeCluster) get(t *testing.T, name string) *unstructured.Unstructured {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pod, ok := c.pods[name]
+	if !ok {
+		return nil
+	}
+	return testutil.ToUnstructured(t, pod)
+}
+
+func waitFor(t *testing.T, description string, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", description)
+}
+
+func TestService_SaveProfile_retargets(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.CreateDeployment("web")
+	deployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "web"},
+	}
+
+	cluster := &fakeCluster{pods: map[string]*corev1.Pod{}}
+	cluster.setPod(createReadyPod("web-a", true, map[string]string{"app": "web"}))
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key store.Key) (*unstructured.Unstructured, error) {
+			if key.Kind == "Deployment" {
+				return testutil.ToUnstructured(t, deployment), nil
+			}
+			return cluster.get(t, key.Name), nil
+		}).
+		AnyTimes()
+	o.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key store.Key) ([]*unstructured.Unstructured, error) {
+			return cluster.list(t), nil
+		}).
+		AnyTimes()
+
+	restClient, err := rest.NewRESTClient(
+		&url.URL{Scheme: "https", Host: "localhost"}, "/api/v1",
+		rest.ContentConfig{GroupVersion: &corev1.SchemeGroupVersion, NegotiatedSerializer: scheme.Codecs}, 0, 0, nil, nil)
+	require.NoError(t, err)
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().RESTClient().Return(restClient, nil).AnyTimes()
+	clusterClient.EXPECT().RESTConfig().Return(&rest.Config{}).AnyTimes()
+
+	forwarder := &fakePortForwarder{}
+	profiles := &memoryProfileStore{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svc := New(ctx, ServiceOptions{
+		ClusterClient: clusterClient,
+		ObjectStore:   o,
+		PortForwarder: forwarder,
+		Profiles:      profiles,
+		ContextName:   "cluster-a",
+	}, log.NopLogger())
+	svc.retryInterval = 10 * time.Millisecond
+	svc.checkInterval = 10 * time.Millisecond
+	defer svc.Stop()
+
+	profile := Profile{
+		Name:       "web",
+		Namespace:  "namespace",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Target:     "web",
+		Ports:      []PortForwardPortSpec{{Local: 8080, Remote: 80}},
+	}
+
+	require.NoError(t, svc.SaveProfile(profile))
+	profile.Context = "cluster-a"
+	assert.Equal(t, []Profile{profile}, profiles.profiles)
+	assert.Equal(t, []Profile{profile}, svc.Profiles())
+
+	state := func() State {
+		list := svc.List()
+		require.Len(t, list, 1)
+		return list[0]
+	}
+
+	waitFor(t, "forwarding to web-a", func() bool {
+		s := state()
+		return s.Status == StatusForwarding && s.Pod.Name == "web-a"
+	})
+	assert.Equal(t, "web", state().Profile)
+	assert.Equal(t, "web", state().Target.Name)
+	assert.Equal(t, []ForwardedPort{{Local: 8080, Remote: 80}}, state().Ports)
+
+	// the pod goes away and a new one becomes ready later.
+	cluster.setPod(createReadyPod("web-a", false, map[string]string{"app": "web"}))
+
+	waitFor(t, "web-a to be abandoned", func() bool {
+		return state().Status == StatusFailed
+	})
+
+	cluster.setPod(createReadyPod("web-b", true, map[string]string{"app": "web"}))
+
+	waitFor(t, "forwarding to web-b", func() bool {
+		s := state()
+		return s.Status == StatusForwarding && s.Pod.Name == "web-b"
+	})
+	assert.Empty(t, state().Message)
+	assert.Equal(t, []string{"web-a", "web-b"}, forwarder.forwardedPods())
+
+	require.NoError(t, svc.DeleteProfile("web"))
+	assert.Empty(t, svc.List())
+	assert.Empty(t, profiles.profiles)
+}
+
+func TestService_SaveProfile_conflicting_local_port(t *testing.T) {
+	profiles := &memoryProfileStore{
+		profiles: []Profile{
+			{
+				Name:       "db",
+				Context:    "cluster-a",
+				Namespace:  "default",
+				APIVersion: "v1",
+				Kind:       "Service",
+				Target:     "db",
+				Ports:      []PortForwardPortSpec{{Local: 8080, Remote: 5432}},
+			},
+		},
+	}
+
+	svc := New(context.Background(), ServiceOptions{Profiles: profiles, ContextName: "cluster-a"}, log.NopLogger())
+	defer svc.Stop()
+	svc.profiles = profiles.profiles
+
+	err := svc.SaveProfile(Profile{
+		Name:       "web",
+		Namespace:  "default",
+		APIVersion: "v1",
+		Kind:       "Service",
+		Target:     "web",
+		Ports:      []PortForwardPortSpec{{Local: 8080, Remote: 80}},
+	})
+	require.Error(t, err)
+	assert.Len(t, profiles.profiles, 1)
+}
+
+func TestService_SaveProfile_no_context(t *testing.T) {
+	profiles := &memoryProfileStore{}
+
+	svc := New(context.Background(), ServiceOptions{Profiles: profiles}, log.NopLogger())
+	defer svc.Stop()
+
+	err := svc.SaveProfile(Profile{
+		Name:       "web",
+		Namespace:  "default",
+		APIVersion: "v1",
+		Kind:       "Service",
+		Target:     "web",
+		Ports:      []PortForwardPortSpec{{Local: 8080, Remote: 80}},
+	})
+	require.Error(t, err)
+	assert.Empty(t, profiles.profiles)
+}
+
+func TestService_RestoreProfiles_active_context(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	profile := func(name, contextName string, local uint16) Profile {
+		return Profile{
+			Name:       name,
+			Context:    contextName,
+			Namespace:  "default",
+			APIVersion: "v1",
+			Kind:       "Service",
+			Target:     "web",
+			Ports:      []PortForwardPortSpec{{Local: local, Remote: 80}},
+		}
+	}
+
+	profiles := &memoryProfileStore{
+		profiles: []Profile{
+			profile("a", "cluster-a", 8080),
+			profile("b", "cluster-b", 8080),
+			profile("legacy", "", 8081),
+		},
+	}
+
+	svc := New(context.Background(), ServiceOptions{
+		ObjectStore: o,
+		Profiles:    profiles,
+		ContextName: "cluster-a",
+	}, log.NopLogger())
+	svc.retryInterval = time.Hour
+	defer svc.Stop()
+
+	started := func() []string {
+		var names []string
+		for _, state := range svc.List() {
+			names = append(names, state.Profile)
+		}
+		return names
+	}
+
+	require.NoError(t, svc.RestoreProfiles())
+	assert.Equal(t, []string{"a"}, started())
+	assert.Len(t, svc.Profiles(), 3)
+
+	clusterB := clusterFake.NewMockClientInterface(controller)
+	svc.UseContext("cluster-b", clusterB)
+	assert.Equal(t, []string{"b"}, started())
+	assert.Equal(t, clusterB, svc.currentClusterClient())
+
+	// rotated credentials for the same context keep the profiles running.
+	rotated := clusterFake.NewMockClientInterface(controller)
+	svc.UseContext("cluster-b", rotated)
+	assert.Equal(t, []string{"b"}, started())
+	assert.Equal(t, rotated, svc.currentClusterClient())
+
+	svc.UseContext("cluster-c", clusterFake.NewMockClientInterface(controller))
+	assert.Empty(t, started())
+}
+
+func TestService_SaveProfile_unavailable(t *testing.T) {
+	svc := New(context.Background(), ServiceOptions{}, log.NopLogger())
+	defer svc.Stop()
+
+	require.Error(t, svc.SaveProfile(Profile{Name: "web"}))
+	require.Error(t, svc.DeleteProfile("web"))
+	require.NoError(t, svc.RestoreProfiles())
+}
+
+func TestService_DeleteProfile_not_found(t *testing.T) {
+	svc := New(context.Background(), ServiceOptions{Profiles: &memoryProfileStore{}}, log.NopLogger())
+	defer svc.Stop()
+
+	require.Error(t, svc.DeleteProfile("web"))
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware/octant/pkg/store"
+)
+
+// targetKinds are the kinds which can be port forwarded to. Kinds other
+// than pods are resolved to one of their ready pods.
+var targetKinds = map[string]bool{
+	"Pod":         true,
+	"Service":     true,
+	"Deployment":  true,
+	"StatefulSet": true,
+	"DaemonSet":   true,
+	"ReplicaSet":  true,
+}
+
+// podSelector returns the selector for the pods of a service or workload.
+// The label set narrows the pods listed from the store and the selector is
+// matched against them, since workloads can select pods with expressions.
+func podSelector(ctx context.Context, o store.Store, r CreateRequest) (labels.Set, labels.Selector, error) {
+	key := store.Key{
+		Namespace:  r.Namespace,
+		APIVersion: r.APIVersion,
+		Kind:       r.Kind,
+		Name:       r.Name,
+	}
+
+	object, err := o.Get(ctx, key)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "get %s", key)
+	}
+	if object == nil {
+		return nil, nil, errors.Errorf("%s %q not found", r.Kind, r.Name)
+	}
+
+	if r.Kind == "Service" {
+		m, _, err := unstructured.NestedStringMap(object.Object, "spec", "selector")
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "read selector of service %q", r.Name)
+		}
+		if len(m) == 0 {
+			return nil, nil, errors.Errorf("service %q does not have a selector", r.Name)
+		}
+
+		set := labels.Set(m)
+		return set, set.AsSelector(), nil
+	}
+
+	m, _, err := unstructured.NestedMap(object.Object, "spec", "selector")
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "read selector of %s %q", r.Kind, r.Name)
+	}
+
+	var labelSelector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &labelSelector); err != nil {
+		return nil, nil, errors.Wrapf(err, "convert selector of %s %q", r.Kind, r.Name)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "parse selector of %s %q", r.Kind, r.Name)
+	}
+	if selector.Empty() {
+		return nil, nil, errors.Errorf("%s %q does not have a selector", r.Kind, r.Name)
+	}
+
+	return labels.Set(labelSelector.MatchLabels), selector, nil
+}
+
+// readyPods lists the ready pods of a service or workload, sorted by name.
+func readyPods(ctx context.Context, o store.Store, r CreateRequest) ([]corev1.Pod, error) {
+	set, selector, err := podSelector(ctx, o, r)
+	if err != nil {
+		return nil, err
+	}
+
+	key := store.Key{
+		Namespace:  r.Namespace,
+		APIVersion: "v1",
+		Kind:       "Pod",
+	}
+	if len(set) > 0 {
+		key.Selector = &set
+	}
+
+	objects, err := o.List(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods for %s %q", r.Kind, r.Name)
+	}
+
+	var pods []corev1.Pod
+	for _, object := range objects {
+		if !selector.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+
+		var pod corev1.Pod
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, &pod); err != nil {
+			return nil, errors.Wrapf(err, "convert pod %q", object.GetName())
+		}
+
+		if isPodReady(pod) {
+			pods = append(pods, pod)
+		}
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	return pods, nil
+}
+
+// isPodReady returns true if a pod is running, ready, and not being deleted.
+func isPodReady(pod corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// remotePorts translates the ports of a request to ports on a pod. Ports of
+// a service are service ports and are forwarded to their target ports, the
+// same way kubectl does.
+func remotePorts(ctx context.Context, o store.Store, r CreateRequest, pod corev1.Pod) ([]PortForwardPortSpec, error) {
+	ports := make([]PortForwardPortSpec, len(r.Ports))
+	copy(ports, r.Ports)
+
+	if r.Kind != "Service" {
+		return ports, nil
+	}
+
+	key := store.Key{
+		Namespace:  r.Namespace,
+		APIVersion: "v1",
+		Kind:       "Service",
+		Name:       r.Name,
+	}
+
+	var service corev1.Service
+	if err := store.GetAs(ctx, o, key, &service); err != nil {
+		return nil, errors.Wrapf(err, "get service %q", r.Name)
+	}
+
+	for i := range ports {
+		targetPort, err := serviceTargetPort(service, pod, ports[i].Remote)
+		if err != nil {
+			return nil, err
+		}
+		ports[i].Remote = targetPort
+	}
+
+	return ports, nil
+}
+
+func serviceTargetPort(service corev1.Service, pod corev1.Pod, port uint16) (uint16, error) {
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Port != int32(port) {
+			continue
+		}
+
+		switch {
+		case servicePort.TargetPort.Type == intstr.String:
+			name := servicePort.TargetPort.StrVal
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == name {
+						return uint16(containerPort.ContainerPort), nil
+					}
+				}
+			}
+			return 0, errors.Errorf("pod %q does not have a port named %q", pod.Name, name)
+		case servicePort.TargetPort.IntVal != 0:
+			return uint16(servicePort.TargetPort.IntVal), nil
+		default:
+			return port, nil
+		}
+	}
+
+	return 0, errors.Errorf("service %q does not have port %d", service.Name, port)
+}
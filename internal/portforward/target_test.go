@@ -0,0 +1,181 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+)
+
+func createReadyPod(name string, ready bool, podLabels map[string]string) *corev1.Pod {
+	pod := testutil.CreatePod(name)
+	pod.Labels = podLabels
+	pod.Status.Phase = corev1.PodRunning
+
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: status},
+	}
+
+	return pod
+}
+
+func Test_readyPods(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.CreateDeployment("web")
+	deployment.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "web"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "track", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"canary"}},
+		},
+	}
+
+	pods := testutil.ToUnstructuredList(t,
+		createReadyPod("web-b", true, map[string]string{"app": "web"}),
+		createReadyPod("web-a", true, map[string]string{"app": "web"}),
+		createReadyPod("web-canary", true, map[string]string{"app": "web", "track": "canary"}),
+		createReadyPod("web-starting", false, map[string]string{"app": "web"}),
+	)
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().
+		Get(gomock.Any(), store.Key{Namespace: "namespace", APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}).
+		Return(testutil.ToUnstructured(t, deployment), nil)
+
+	selector := labels.Set{"app": "web"}
+	o.EXPECT().
+		List(gomock.Any(), store.Key{Namespace: "namespace", APIVersion: "v1", Kind: "Pod", Selector: &selector}).
+		Return(pods, nil)
+
+	r := CreateRequest{
+		Namespace:  "namespace",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "web",
+	}
+
+	got, err := readyPods(context.Background(), o, r)
+	require.NoError(t, err)
+
+	var names []string
+	for _, pod := range got {
+		names = append(names, pod.Name)
+	}
+	assert.Equal(t, []string{"web-a", "web-b"}, names)
+}
+
+func Test_readyPods_service_without_selector(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	service := testutil.CreateService("external")
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(testutil.ToUnstructured(t, service), nil)
+
+	r := CreateRequest{
+		Namespace:  "namespace",
+		APIVersion: "v1",
+		Kind:       "Service",
+		Name:       "external",
+	}
+
+	_, err := readyPods(context.Background(), o, r)
+	require.Error(t, err)
+}
+
+func Test_readyPods_not_found(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return((*unstructured.Unstructured)(nil), nil)
+
+	r := CreateRequest{
+		Namespace:  "namespace",
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Name:       "db",
+	}
+
+	_, err := readyPods(context.Background(), o, r)
+	require.Error(t, err)
+}
+
+func Test_isPodReady(t *testing.T) {
+	deleted := createReadyPod("deleted", true, nil)
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+
+	pending := createReadyPod("pending", true, nil)
+	pending.Status.Phase = corev1.PodPending
+
+	assert.True(t, isPodReady(*createReadyPod("ready", true, nil)))
+	assert.False(t, isPodReady(*createReadyPod("not-ready", false, nil)))
+	assert.False(t, isPodReady(*deleted))
+	assert.False(t, isPodReady(*pending))
+	assert.False(t, isPodReady(*testutil.CreatePod("no-conditions")))
+}
+
+func Test_serviceTargetPort(t *testing.T) {
+	service := *testutil.CreateService("web")
+	service.Spec.Ports = []corev1.ServicePort{
+		{Port: 80, TargetPort: intstr.FromString("http")},
+		{Port: 443, TargetPort: intstr.FromInt(8443)},
+		{Port: 9090},
+	}
+
+	pod := *testutil.CreatePod("web")
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name:  "web",
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+		},
+	}
+
+	tests := []struct {
+		port     uint16
+		expected uint16
+		isErr    bool
+	}{
+		{port: 80, expected: 8080},
+		{port: 443, expected: 8443},
+		{port: 9090, expected: 9090},
+		{port: 22, isErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := serviceTargetPort(service, pod, test.port)
+		if test.isErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, got)
+	}
+}
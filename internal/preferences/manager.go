@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package preferences
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+// DefaultWatchInterval is how often the configuration file is checked for
+// changes.
+const DefaultWatchInterval = 2 * time.Second
+
+// Interface gets and updates preferences.
+type Interface interface {
+	// Preferences returns the current preferences.
+	Preferences() Preferences
+	// Update validates and saves preferences.
+	Update(p Preferences) error
+}
+
+// UpdateFunc is called with preferences after they change.
+type UpdateFunc func(p Preferences)
+
+// Manager manages preferences stored in a file. It reloads the file when
+// it changes, so edits are picked up without restarting Octant.
+type Manager struct {
+	fs     afero.Fs
+	path   string
+	logger log.Logger
+
+	mu       sync.RWMutex
+	current  Preferences
+	modTime  time.Time
+	onUpdate []UpdateFunc
+}
+
+var _ Interface = (*Manager)(nil)
+
+// NewManager creates an instance of Manager for FileName in dir and loads
+// it. It returns an error if the file is invalid. A missing file uses the
+// default preferences.
+func NewManager(fs afero.Fs, dir string, logger log.Logger) (*Manager, error) {
+	m := &Manager{
+		fs:     fs,
+		path:   filepath.Join(dir, FileName),
+		logger: logger.With("component", "preferences"),
+	}
+
+	p, modTime, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	m.current = p
+	m.modTime = modTime
+
+	return m, nil
+}
+
+// Path returns the path of the configuration file.
+func (m *Manager) Path() string {
+	return m.path
+}
+
+// Preferences returns a copy of the current preferences.
+func (m *Manager) Preferences() Preferences {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.current.DeepCopy()
+}
+
+// Update validates preferences, saves them and notifies listeners.
+func (m *Manager) Update(p Preferences) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(&p)
+	if err != nil {
+		return errors.Wrap(err, "encode preferences")
+	}
+
+	if err := m.fs.MkdirAll(filepath.Dir(m.path), 0700); err != nil {
+		return errors.Wrapf(err, "create %s", filepath.Dir(m.path))
+	}
+
+	if err := afero.WriteFile(m.fs, m.path, data, 0600); err != nil {
+		return errors.Wrapf(err, "write %s", m.path)
+	}
+
+	m.set(p, m.statModTime())
+
+	return nil
+}
+
+// RegisterOnUpdate registers a function which is called when preferences
+// change.
+func (m *Manager) RegisterOnUpdate(fn UpdateFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.onUpdate = append(m.onUpdate, fn)
+}
+
+// Watch checks the file for changes every interval until the context is
+// cancelled. An invalid file is logged and the current preferences are
+// kept.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Reload(); err != nil {
+				m.logger.WithErr(err).Errorf("reload configuration, keeping previous configuration")
+			}
+		}
+	}
+}
+
+// Reload reloads the file if it changed since it was last loaded.
+func (m *Manager) Reload() error {
+	m.mu.RLock()
+	previous := m.modTime
+	m.mu.RUnlock()
+
+	if m.statModTime().Equal(previous) {
+		return nil
+	}
+
+	p, modTime, err := m.load()
+	if err != nil {
+		// don't report the same invalid file again.
+		m.mu.Lock()
+		m.modTime = modTime
+		m.mu.Unlock()
+		return err
+	}
+
+	m.logger.With("path", m.path).Infof("reloaded configuration")
+	m.set(p, modTime)
+
+	return nil
+}
+
+func (m *Manager) set(p Preferences, modTime time.Time) {
+	m.mu.Lock()
+	m.current = p
+	m.modTime = modTime
+	listeners := make([]UpdateFunc, len(m.onUpdate))
+	copy(listeners, m.onUpdate)
+	m.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(p.DeepCopy())
+	}
+}
+
+// load loads and validates the file.
+func (m *Manager) load() (Preferences, time.Time, error) {
+	modTime := m.statModTime()
+
+	data, err := afero.ReadFile(m.fs, m.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Default(), modTime, nil
+		}
+		return Preferences{}, modTime, errors.Wrapf(err, "read %s", m.path)
+	}
+
+	var p Preferences
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return Preferences{}, modTime, errors.Wrapf(err, "parse %s", m.path)
+	}
+
+	if err := p.Validate(); err != nil {
+		return Preferences{}, modTime, errors.Wrapf(err, "load %s", m.path)
+	}
+
+	return p, modTime, nil
+}
+
+// statModTime returns the modification time of the file, or the zero time
+// if it doesn't exist.
+func (m *Manager) statModTime() time.Time {
+	fi, err := m.fs.Stat(m.path)
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package preferences
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/log"
+)
+
+const dir = "/home/user/.config/octant"
+
+func writeConfig(t *testing.T, fs afero.Fs, data string, modTime time.Time) {
+	path := filepath.Join(dir, FileName)
+	require.NoError(t, afero.WriteFile(fs, path, []byte(data), 0600))
+	require.NoError(t, fs.Chtimes(path, modTime, modTime))
+}
+
+func TestNewManager(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		expected  Preferences
+		expectErr string
+	}{
+		{
+			name:     "missing file",
+			expected: Default(),
+		},
+		{
+			name: "valid file",
+			data: "version: 1\ndefaultNamespace: web\ntheme: dark\n",
+			expected: Preferences{
+				Version:          1,
+				DefaultNamespace: "web",
+				Theme:            ThemeDark,
+			},
+		},
+		{
+			name:      "unknown field",
+			data:      "version: 1\nthem: dark\n",
+			expectErr: "parse /home/user/.config/octant/config.yaml",
+		},
+		{
+			name:      "invalid file",
+			data:      "version: 1\ntheme: blue\n",
+			expectErr: `theme: unknown theme "blue"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			if tc.data != "" {
+				writeConfig(t, fs, tc.data, time.Unix(1, 0))
+			}
+
+			m, err := NewManager(fs, dir, log.NopLogger())
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, m.Preferences())
+		})
+	}
+}
+
+func TestManager_Update(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	m, err := NewManager(fs, dir, log.NopLogger())
+	require.NoError(t, err)
+
+	var got []Preferences
+	m.RegisterOnUpdate(func(p Preferences) {
+		got = append(got, p)
+	})
+
+	invalid := Default()
+	invalid.Theme = "blue"
+	require.Error(t, m.Update(invalid))
+	assert.Empty(t, got)
+
+	p := Default()
+	p.Theme = ThemeDark
+	p.PluginDirs = []string{"/opt/plugins"}
+	require.NoError(t, m.Update(p))
+
+	assert.Equal(t, p, m.Preferences())
+	assert.Equal(t, []Preferences{p}, got)
+
+	reloaded, err := NewManager(fs, dir, log.NopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, p, reloaded.Preferences())
+}
+
+func TestManager_Reload(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeConfig(t, fs, "version: 1\n", time.Unix(1, 0))
+
+	m, err := NewManager(fs, dir, log.NopLogger())
+	require.NoError(t, err)
+
+	var got []Preferences
+	m.RegisterOnUpdate(func(p Preferences) {
+		got = append(got, p)
+	})
+
+	// unchanged files aren't reloaded.
+	require.NoError(t, m.Reload())
+	assert.Empty(t, got)
+
+	writeConfig(t, fs, "version: 1\ntheme: dark\n", time.Unix(2, 0))
+	require.NoError(t, m.Reload())
+	assert.Equal(t, ThemeDark, m.Preferences().Theme)
+	require.Len(t, got, 1)
+
+	// invalid files keep the previous preferences and are reported once.
+	writeConfig(t, fs, "version: 3\n", time.Unix(3, 0))
+	require.Error(t, m.Reload())
+	require.NoError(t, m.Reload())
+	assert.Equal(t, ThemeDark, m.Preferences().Theme)
+	require.Len(t, got, 1)
+
+	require.NoError(t, fs.Remove(filepath.Join(dir, FileName)))
+	require.NoError(t, m.Reload())
+	assert.Equal(t, Default(), m.Preferences())
+	require.Len(t, got, 2)
+}
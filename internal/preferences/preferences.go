@@ -0,0 +1,295 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package preferences loads, validates and saves Octant's configuration
+// file, config.yaml in Octant's configuration directory.
+package preferences
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/vmware/octant/pkg/navigation"
+)
+
+const (
+	// FileName is the name of the configuration file.
+	FileName = "config.yaml"
+
+	// CurrentVersion is the version of the configuration file this version
+	// of Octant reads and writes.
+	CurrentVersion = 1
+
+	// DefaultRefresh is how often views are refreshed unless configured.
+	DefaultRefresh = 5 * time.Second
+
+	// MinRefresh is the shortest refresh interval which can be configured.
+	MinRefresh = time.Second
+)
+
+// Themes are the themes the frontend can use.
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+var themes = []string{ThemeLight, ThemeDark}
+
+// Modules are the names of the built in modules which can be enabled.
+var Modules = []string{"overview", "cluster-overview", "configuration", "local"}
+
+// Preferences is the content of the configuration file.
+type Preferences struct {
+	// Version is the version of the file.
+	Version int `json:"version"`
+	// DefaultContext is the kube config context used when none is given on
+	// the command line. The kube config's current context is used if it is
+	// blank.
+	DefaultContext string `json:"defaultContext,omitempty"`
+	// DefaultNamespace is the namespace shown when none is given on the
+	// command line.
+	DefaultNamespace string `json:"defaultNamespace,omitempty"`
+	// Contexts are preferences for kube config contexts by name.
+	Contexts map[string]ContextPreferences `json:"contexts,omitempty"`
+	// Refresh is how often views are refreshed.
+	Refresh Refresh `json:"refresh,omitempty"`
+	// Modules are the built in modules to enable. Every module is enabled
+	// if it is empty.
+	Modules []string `json:"modules,omitempty"`
+	// PluginDirs are directories plugins are loaded from in addition to
+	// the default plugin directory.
+	PluginDirs []string `json:"pluginDirs,omitempty"`
+	// Theme is the frontend theme: light or dark.
+	Theme string `json:"theme,omitempty"`
+	// HiddenNavigation are the titles or paths of navigation sections to
+	// hide.
+	HiddenNavigation []string `json:"hiddenNavigation,omitempty"`
+}
+
+// ContextPreferences are preferences for a kube config context.
+type ContextPreferences struct {
+	// Namespaces are the namespaces listed for the context instead of the
+	// namespaces in the cluster. It is useful if the user can't list
+	// namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// Refresh configures refresh intervals. Blank intervals use DefaultRefresh.
+type Refresh struct {
+	Content    metav1.Duration `json:"content,omitempty"`
+	Navigation metav1.Duration `json:"navigation,omitempty"`
+	Namespaces metav1.Duration `json:"namespaces,omitempty"`
+}
+
+// ContentInterval returns how often content is refreshed.
+func (r Refresh) ContentInterval() time.Duration {
+	return orDefault(r.Content.Duration)
+}
+
+// NavigationInterval returns how often navigation is refreshed.
+func (r Refresh) NavigationInterval() time.Duration {
+	return orDefault(r.Navigation.Duration)
+}
+
+// NamespacesInterval returns how often namespaces are refreshed.
+func (r Refresh) NamespacesInterval() time.Duration {
+	return orDefault(r.Namespaces.Duration)
+}
+
+func orDefault(d time.Duration) time.Duration {
+	if d == 0 {
+		return DefaultRefresh
+	}
+	return d
+}
+
+// Default returns the preferences used when there is no configuration file.
+func Default() Preferences {
+	return Preferences{
+		Version: CurrentVersion,
+		Theme:   ThemeLight,
+	}
+}
+
+// ModuleEnabled returns true if the built in module is enabled.
+func (p Preferences) ModuleEnabled(name string) bool {
+	return len(p.Modules) == 0 || containsString(p.Modules, name)
+}
+
+// ContextNamespaces returns the namespaces configured for a context, or
+// nil if there are none.
+func (p Preferences) ContextNamespaces(contextName string) []string {
+	return p.Contexts[contextName].Namespaces
+}
+
+// NavigationHidden returns true if the navigation section with the title
+// and path is hidden. Titles are case insensitive.
+func (p Preferences) NavigationHidden(title, path string) bool {
+	for _, hidden := range p.HiddenNavigation {
+		if strings.EqualFold(hidden, title) || hidden == path {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterNavigation removes hidden sections, and hidden children of the
+// sections which remain.
+func (p Preferences) FilterNavigation(sections []navigation.Navigation) []navigation.Navigation {
+	if len(p.HiddenNavigation) == 0 {
+		return sections
+	}
+
+	var filtered []navigation.Navigation
+	for _, section := range sections {
+		if p.NavigationHidden(section.Title, section.Path) {
+			continue
+		}
+		section.Children = p.FilterNavigation(section.Children)
+		filtered = append(filtered, section)
+	}
+
+	return filtered
+}
+
+// DeepCopy returns a copy of the preferences which shares no maps or
+// slices with the original.
+func (p Preferences) DeepCopy() Preferences {
+	out := p
+	out.Modules = copyStrings(p.Modules)
+	out.PluginDirs = copyStrings(p.PluginDirs)
+	out.HiddenNavigation = copyStrings(p.HiddenNavigation)
+
+	if p.Contexts != nil {
+		out.Contexts = make(map[string]ContextPreferences, len(p.Contexts))
+		for name, cp := range p.Contexts {
+			out.Contexts[name] = ContextPreferences{Namespaces: copyStrings(cp.Namespaces)}
+		}
+	}
+
+	return out
+}
+
+// ValidationError lists the problems with preferences.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(e.Problems, "; "))
+}
+
+func (e *ValidationError) addf(format string, args ...interface{}) {
+	e.Problems = append(e.Problems, fmt.Sprintf(format, args...))
+}
+
+// Validate validates preferences. Every problem is reported in a
+// *ValidationError.
+func (p Preferences) Validate() error {
+	ve := &ValidationError{}
+
+	switch {
+	case p.Version == 0:
+		ve.addf("version: is required, set it to %d", CurrentVersion)
+	case p.Version != CurrentVersion:
+		ve.addf("version: %d is not supported, this version of Octant reads version %d", p.Version, CurrentVersion)
+	}
+
+	if p.DefaultNamespace != "" {
+		validateNamespace(ve, "defaultNamespace", p.DefaultNamespace)
+	}
+
+	var contextNames []string
+	for name := range p.Contexts {
+		contextNames = append(contextNames, name)
+	}
+	sort.Strings(contextNames)
+
+	for _, name := range contextNames {
+		if strings.TrimSpace(name) == "" {
+			ve.addf("contexts: context name is blank")
+			continue
+		}
+		for i, namespace := range p.Contexts[name].Namespaces {
+			validateNamespace(ve, fmt.Sprintf("contexts[%s].namespaces[%d]", name, i), namespace)
+		}
+	}
+
+	for _, r := range []struct {
+		field    string
+		duration time.Duration
+	}{
+		{field: "refresh.content", duration: p.Refresh.Content.Duration},
+		{field: "refresh.navigation", duration: p.Refresh.Navigation.Duration},
+		{field: "refresh.namespaces", duration: p.Refresh.Namespaces.Duration},
+	} {
+		if r.duration != 0 && r.duration < MinRefresh {
+			ve.addf("%s: %s is shorter than the minimum of %s", r.field, r.duration, MinRefresh)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, name := range p.Modules {
+		switch {
+		case !containsString(Modules, name):
+			ve.addf("modules[%d]: unknown module %q, expected one of %s", i, name, strings.Join(Modules, ", "))
+		case seen[name]:
+			ve.addf("modules[%d]: %q is listed more than once", i, name)
+		}
+		seen[name] = true
+	}
+
+	for i, dir := range p.PluginDirs {
+		if !filepath.IsAbs(dir) {
+			ve.addf("pluginDirs[%d]: %q is not an absolute path", i, dir)
+		}
+	}
+
+	if p.Theme != "" && !containsString(themes, p.Theme) {
+		ve.addf("theme: unknown theme %q, expected one of %s", p.Theme, strings.Join(themes, ", "))
+	}
+
+	for i, hidden := range p.HiddenNavigation {
+		if strings.TrimSpace(hidden) == "" {
+			ve.addf("hiddenNavigation[%d]: is blank", i)
+		}
+	}
+
+	if len(ve.Problems) > 0 {
+		return ve
+	}
+
+	return nil
+}
+
+func validateNamespace(ve *ValidationError, field, namespace string) {
+	for _, msg := range validation.IsDNS1123Label(namespace) {
+		ve.addf("%s: %q is not a valid namespace: %s", field, namespace, msg)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func copyStrings(in []string) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, len(in))
+	copy(out, in)
+	return out
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package preferences
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/octant/pkg/navigation"
+)
+
+func TestPreferences_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(p *Preferences)
+		problems []string
+	}{
+		{
+			name:   "default",
+			modify: func(p *Preferences) {},
+		},
+		{
+			name: "everything",
+			modify: func(p *Preferences) {
+				p.DefaultContext = "dev"
+				p.DefaultNamespace = "web"
+				p.Contexts = map[string]ContextPreferences{
+					"dev": {Namespaces: []string{"web", "api"}},
+				}
+				p.Refresh.Content = metav1.Duration{Duration: 10 * time.Second}
+				p.Modules = []string{"overview", "configuration"}
+				p.PluginDirs = []string{"/opt/octant/plugins"}
+				p.Theme = ThemeDark
+				p.HiddenNavigation = []string{"Custom Resources"}
+			},
+		},
+		{
+			name: "missing version",
+			modify: func(p *Preferences) {
+				p.Version = 0
+			},
+			problems: []string{"version: is required, set it to 1"},
+		},
+		{
+			name: "future version",
+			modify: func(p *Preferences) {
+				p.Version = 2
+			},
+			problems: []string{"version: 2 is not supported, this version of Octant reads version 1"},
+		},
+		{
+			name: "every problem is reported",
+			modify: func(p *Preferences) {
+				p.DefaultNamespace = "Web"
+				p.Contexts = map[string]ContextPreferences{
+					"dev": {Namespaces: []string{"ok", "not_ok"}},
+				}
+				p.Refresh.Navigation = metav1.Duration{Duration: 10 * time.Millisecond}
+				p.Modules = []string{"overview", "overview", "unknown"}
+				p.PluginDirs = []string{"plugins"}
+				p.Theme = "blue"
+				p.HiddenNavigation = []string{" "}
+			},
+			problems: []string{
+				`defaultNamespace: "Web" is not a valid namespace`,
+				`contexts[dev].namespaces[1]: "not_ok" is not a valid namespace`,
+				"refresh.navigation: 10ms is shorter than the minimum of 1s",
+				`modules[1]: "overview" is listed more than once`,
+				`modules[2]: unknown module "unknown"`,
+				`pluginDirs[0]: "plugins" is not an absolute path`,
+				`theme: unknown theme "blue", expected one of light, dark`,
+				"hiddenNavigation[0]: is blank",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := Default()
+			tc.modify(&p)
+
+			err := p.Validate()
+			if len(tc.problems) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			ve, ok := err.(*ValidationError)
+			require.True(t, ok)
+			require.Len(t, ve.Problems, len(tc.problems))
+			for i := range tc.problems {
+				assert.Contains(t, ve.Problems[i], tc.problems[i])
+			}
+		})
+	}
+}
+
+func TestPreferences_helpers(t *testing.T) {
+	p := Default()
+	assert.True(t, p.ModuleEnabled("overview"))
+	assert.Equal(t, DefaultRefresh, p.Refresh.ContentInterval())
+	assert.Nil(t, p.ContextNamespaces("dev"))
+	assert.False(t, p.NavigationHidden("Overview", "/content/overview"))
+
+	p.Modules = []string{"configuration"}
+	p.Refresh.Content = metav1.Duration{Duration: time.Minute}
+	p.Contexts = map[string]ContextPreferences{"dev": {Namespaces: []string{"web"}}}
+	p.HiddenNavigation = []string{"overview", "/content/cluster-overview"}
+
+	assert.False(t, p.ModuleEnabled("overview"))
+	assert.True(t, p.ModuleEnabled("configuration"))
+	assert.Equal(t, time.Minute, p.Refresh.ContentInterval())
+	assert.Equal(t, DefaultRefresh, p.Refresh.NavigationInterval())
+	assert.Equal(t, []string{"web"}, p.ContextNamespaces("dev"))
+	assert.True(t, p.NavigationHidden("Overview", "/content/overview"))
+	assert.True(t, p.NavigationHidden("Cluster Overview", "/content/cluster-overview"))
+
+	c := p.DeepCopy()
+	c.Modules[0] = "overview"
+	c.Contexts["dev"].Namespaces[0] = "api"
+	assert.Equal(t, []string{"configuration"}, p.Modules)
+	assert.Equal(t, []string{"web"}, p.ContextNamespaces("dev"))
+}
+
+func TestPreferences_FilterNavigation(t *testing.T) {
+	sections := []navigation.Navigation{
+		{
+			Title: "Overview",
+			Path:  "/content/overview",
+			Children: []navigation.Navigation{
+				{Title: "Workloads", Path: "/content/overview/workloads"},
+				{Title: "Custom Resources", Path: "/content/overview/custom-resources"},
+			},
+		},
+		{Title: "Cluster Overview", Path: "/content/cluster-overview"},
+	}
+
+	p := Default()
+	assert.Equal(t, sections, p.FilterNavigation(sections))
+
+	p.HiddenNavigation = []string{"custom resources", "/content/cluster-overview"}
+	expected := []navigation.Navigation{
+		{
+			Title: "Overview",
+			Path:  "/content/overview",
+			Children: []navigation.Navigation{
+				{Title: "Workloads", Path: "/content/overview/workloads"},
+			},
+		},
+	}
+	assert.Equal(t, expected, p.FilterNavigation(sections))
+	assert.Len(t, sections[0].Children, 2)
+}
@@ -7,16 +7,20 @@ package queryer
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 	"golang.org/x/sync/errgroup"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,10 +44,18 @@ import (
 
 type Queryer interface {
 	Children(ctx context.Context, object metav1.Object) ([]runtime.Object, error)
+	ConfigMapsForPod(ctx context.Context, pod *corev1.Pod) ([]*corev1.ConfigMap, error)
 	Events(ctx context.Context, object metav1.Object) ([]*corev1.Event, error)
+	HorizontalPodAutoscalersForObject(ctx context.Context, object runtime.Object) ([]*autoscalingv1.HorizontalPodAutoscaler, error)
 	IngressesForService(ctx context.Context, service *corev1.Service) ([]*extv1beta1.Ingress, error)
+	NetworkPoliciesForPod(ctx context.Context, pod *corev1.Pod) ([]*networkingv1.NetworkPolicy, error)
 	OwnerReference(ctx context.Context, namespace string, ownerReference metav1.OwnerReference) (runtime.Object, error)
+	PersistentVolumeClaimsForPod(ctx context.Context, pod *corev1.Pod) ([]*corev1.PersistentVolumeClaim, error)
+	PodsForNetworkPolicy(ctx context.Context, networkPolicy *networkingv1.NetworkPolicy) ([]*corev1.Pod, error)
+	PodsForObject(ctx context.Context, object runtime.Object) ([]*corev1.Pod, error)
 	PodsForService(ctx context.Context, service *corev1.Service) ([]*corev1.Pod, error)
+	ScaleTarget(ctx context.Context, hpa *autoscalingv1.HorizontalPodAutoscaler) (runtime.Object, error)
+	SecretsForPod(ctx context.Context, pod *corev1.Pod) ([]*corev1.Secret, error)
 	ServicesForIngress(ctx context.Context, ingress *extv1beta1.Ingress) ([]*corev1.Service, error)
 	ServicesForPod(ctx context.Context, pod *corev1.Pod) ([]*corev1.Service, error)
 	ServiceAccountForPod(ctx context.Context, pod *corev1.Pod) (*corev1.ServiceAccount, error)
@@ -412,6 +424,42 @@ func (osq *ObjectStoreQueryer) PodsForService(ctx context.Context, service *core
 	return pods, nil
 }
 
+// PodsForObject returns the pods selected by a workload or a service. Results
+// are not cached, so it can be polled to find pods as they are created.
+func (osq *ObjectStoreQueryer) PodsForObject(ctx context.Context, object runtime.Object) ([]*corev1.Pod, error) {
+	if object == nil {
+		return nil, errors.New("object is nil")
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := osq.getSelector(object)
+	if err != nil {
+		return nil, err
+	}
+
+	// an empty selector would select every pod in the namespace
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return nil, nil
+	}
+
+	key := store.Key{
+		Namespace:  accessor.GetNamespace(),
+		APIVersion: "v1",
+		Kind:       "Pod",
+	}
+
+	pods, err := osq.loadPods(ctx, key, selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching pods for %s", accessor.GetName())
+	}
+
+	return pods, nil
+}
+
 func (osq *ObjectStoreQueryer) loadPods(ctx context.Context, key store.Key, labelSelector *metav1.LabelSelector) ([]*corev1.Pod, error) {
 	objects, err := osq.objectStore.List(ctx, key)
 	if err != nil {
@@ -559,6 +607,346 @@ func (osq *ObjectStoreQueryer) ServiceAccountForPod(ctx context.Context, pod *co
 
 }
 
+// ConfigMapsForPod returns the config maps a pod mounts or references in
+// its environment. Config maps which don't exist are skipped.
+func (osq *ObjectStoreQueryer) ConfigMapsForPod(ctx context.Context, pod *corev1.Pod) ([]*corev1.ConfigMap, error) {
+	if pod == nil {
+		return nil, errors.New("pod is nil")
+	}
+
+	var configMaps []*corev1.ConfigMap
+	for _, name := range referencesForPod(pod).configMaps {
+		key := store.Key{
+			Namespace:  pod.Namespace,
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Name:       name,
+		}
+
+		configMap := &corev1.ConfigMap{}
+		found, err := osq.getObject(ctx, key, configMap)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			configMaps = append(configMaps, configMap)
+		}
+	}
+
+	return configMaps, nil
+}
+
+// SecretsForPod returns the secrets a pod mounts, references in its
+// environment or uses to pull images. Secrets which don't exist are skipped.
+func (osq *ObjectStoreQueryer) SecretsForPod(ctx context.Context, pod *corev1.Pod) ([]*corev1.Secret, error) {
+	if pod == nil {
+		return nil, errors.New("pod is nil")
+	}
+
+	var secrets []*corev1.Secret
+	for _, name := range referencesForPod(pod).secrets {
+		key := store.Key{
+			Namespace:  pod.Namespace,
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Name:       name,
+		}
+
+		secret := &corev1.Secret{}
+		found, err := osq.getObject(ctx, key, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	return secrets, nil
+}
+
+// PersistentVolumeClaimsForPod returns the persistent volume claims a pod
+// mounts. Claims which don't exist are skipped.
+func (osq *ObjectStoreQueryer) PersistentVolumeClaimsForPod(ctx context.Context, pod *corev1.Pod) ([]*corev1.PersistentVolumeClaim, error) {
+	if pod == nil {
+		return nil, errors.New("pod is nil")
+	}
+
+	var claims []*corev1.PersistentVolumeClaim
+	for _, name := range referencesForPod(pod).persistentVolumeClaims {
+		key := store.Key{
+			Namespace:  pod.Namespace,
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+			Name:       name,
+		}
+
+		claim := &corev1.PersistentVolumeClaim{}
+		found, err := osq.getObject(ctx, key, claim)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			claims = append(claims, claim)
+		}
+	}
+
+	return claims, nil
+}
+
+// HorizontalPodAutoscalersForObject returns the horizontal pod autoscalers
+// which scale an object.
+func (osq *ObjectStoreQueryer) HorizontalPodAutoscalersForObject(ctx context.Context, object runtime.Object) ([]*autoscalingv1.HorizontalPodAutoscaler, error) {
+	if object == nil {
+		return nil, errors.New("object is nil")
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, err
+	}
+
+	key := store.Key{
+		Namespace:  accessor.GetNamespace(),
+		APIVersion: "autoscaling/v1",
+		Kind:       "HorizontalPodAutoscaler",
+	}
+
+	ul, err := osq.objectStore.List(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving horizontal pod autoscalers")
+	}
+
+	kind := object.GetObjectKind().GroupVersionKind().Kind
+
+	var results []*autoscalingv1.HorizontalPodAutoscaler
+	for _, u := range ul {
+		hpa := &autoscalingv1.HorizontalPodAutoscaler{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, hpa); err != nil {
+			return nil, errors.Wrap(err, "converting unstructured horizontal pod autoscaler")
+		}
+
+		// the target's API group isn't compared since workloads can be
+		// served by more than one group, e.g. extensions and apps.
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind == kind && ref.Name == accessor.GetName() {
+			results = append(results, hpa)
+		}
+	}
+
+	return results, nil
+}
+
+// ScaleTarget returns the object a horizontal pod autoscaler scales. It
+// returns nil if the object doesn't exist.
+func (osq *ObjectStoreQueryer) ScaleTarget(ctx context.Context, hpa *autoscalingv1.HorizontalPodAutoscaler) (runtime.Object, error) {
+	if hpa == nil {
+		return nil, errors.New("horizontal pod autoscaler is nil")
+	}
+
+	ref := hpa.Spec.ScaleTargetRef
+	key := store.Key{
+		Namespace:  hpa.Namespace,
+		APIVersion: ref.APIVersion,
+		Kind:       ref.Kind,
+		Name:       ref.Name,
+	}
+
+	u, err := osq.objectStore.Get(ctx, key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get scale target %s %s", ref.Kind, ref.Name)
+	}
+
+	if u == nil {
+		return nil, nil
+	}
+
+	return u, nil
+}
+
+// NetworkPoliciesForPod returns the network policies which select a pod.
+func (osq *ObjectStoreQueryer) NetworkPoliciesForPod(ctx context.Context, pod *corev1.Pod) ([]*networkingv1.NetworkPolicy, error) {
+	if pod == nil {
+		return nil, errors.New("pod is nil")
+	}
+
+	key := store.Key{
+		Namespace:  pod.Namespace,
+		APIVersion: "networking.k8s.io/v1",
+		Kind:       "NetworkPolicy",
+	}
+
+	ul, err := osq.objectStore.List(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving network policies")
+	}
+
+	var results []*networkingv1.NetworkPolicy
+	for _, u := range ul {
+		networkPolicy := &networkingv1.NetworkPolicy{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, networkPolicy); err != nil {
+			return nil, errors.Wrap(err, "converting unstructured network policy")
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&networkPolicy.Spec.PodSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pod selector for network policy %s", networkPolicy.Name)
+		}
+
+		// an empty pod selector selects every pod in the namespace
+		if selector.Matches(kLabels.Set(pod.Labels)) {
+			results = append(results, networkPolicy)
+		}
+	}
+
+	return results, nil
+}
+
+// PodsForNetworkPolicy returns the pods a network policy selects.
+func (osq *ObjectStoreQueryer) PodsForNetworkPolicy(ctx context.Context, networkPolicy *networkingv1.NetworkPolicy) ([]*corev1.Pod, error) {
+	if networkPolicy == nil {
+		return nil, errors.New("network policy is nil")
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&networkPolicy.Spec.PodSelector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pod selector for network policy %s", networkPolicy.Name)
+	}
+
+	key := store.Key{
+		Namespace:  networkPolicy.Namespace,
+		APIVersion: "v1",
+		Kind:       "Pod",
+	}
+
+	ul, err := osq.objectStore.List(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "retrieving pods")
+	}
+
+	var pods []*corev1.Pod
+	for _, u := range ul {
+		if !selector.Matches(kLabels.Set(u.GetLabels())) {
+			continue
+		}
+
+		pod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, pod); err != nil {
+			return nil, errors.Wrap(err, "converting unstructured pod")
+		}
+
+		pods = append(pods, pod)
+	}
+
+	return pods, nil
+}
+
+// getObject loads an object from the store into a typed object. It returns
+// false if the object doesn't exist.
+func (osq *ObjectStoreQueryer) getObject(ctx context.Context, key store.Key, object interface{}) (bool, error) {
+	u, err := osq.objectStore.Get(ctx, key)
+	if err != nil {
+		return false, errors.Wrapf(err, "get %s %s", key.Kind, key.Name)
+	}
+
+	if u == nil {
+		return false, nil
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, object); err != nil {
+		return false, errors.Wrapf(err, "converting unstructured %s", key.Kind)
+	}
+
+	return true, nil
+}
+
+// podReferences are the names of objects a pod references.
+type podReferences struct {
+	configMaps             []string
+	secrets                []string
+	persistentVolumeClaims []string
+}
+
+// referencesForPod returns the config maps, secrets and persistent volume
+// claims a pod references in its volumes, its containers' environments and
+// its image pull secrets. Names are sorted and unique.
+func referencesForPod(pod *corev1.Pod) podReferences {
+	configMaps := make(map[string]bool)
+	secrets := make(map[string]bool)
+	claims := make(map[string]bool)
+
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			configMaps[volume.ConfigMap.Name] = true
+		case volume.Secret != nil:
+			secrets[volume.Secret.SecretName] = true
+		case volume.PersistentVolumeClaim != nil:
+			claims[volume.PersistentVolumeClaim.ClaimName] = true
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					configMaps[source.ConfigMap.Name] = true
+				}
+				if source.Secret != nil {
+					secrets[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+
+	containers := append([]corev1.Container{}, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				configMaps[ref.Name] = true
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				secrets[ref.Name] = true
+			}
+		}
+
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				configMaps[envFrom.ConfigMapRef.Name] = true
+			}
+			if envFrom.SecretRef != nil {
+				secrets[envFrom.SecretRef.Name] = true
+			}
+		}
+	}
+
+	for _, imagePullSecret := range pod.Spec.ImagePullSecrets {
+		secrets[imagePullSecret.Name] = true
+	}
+
+	return podReferences{
+		configMaps:             sortedNames(configMaps),
+		secrets:                sortedNames(secrets),
+		persistentVolumeClaims: sortedNames(claims),
+	}
+}
+
+func sortedNames(m map[string]bool) []string {
+	var names []string
+	for name := range m {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
 func (osq *ObjectStoreQueryer) getSelector(object runtime.Object) (*metav1.LabelSelector, error) {
 	switch t := object.(type) {
 	case *appsv1.DaemonSet:
@@ -567,6 +955,8 @@ func (osq *ObjectStoreQueryer) getSelector(object runtime.Object) (*metav1.Label
 		return t.Spec.Selector, nil
 	case *batchv1beta1.CronJob:
 		return nil, nil
+	case *batchv1.Job:
+		return t.Spec.Selector, nil
 	case *corev1.ReplicationController:
 		selector := &metav1.LabelSelector{
 			MatchLabels: t.Spec.Selector,
@@ -589,6 +979,8 @@ func (osq *ObjectStoreQueryer) getSelector(object runtime.Object) (*metav1.Label
 		return t.Spec.Selector, nil
 	case *batch.CronJob:
 		return nil, nil
+	case *batch.Job:
+		return t.Spec.Selector, nil
 	case *core.ReplicationController:
 		selector := &metav1.LabelSelector{
 			MatchLabels: t.Spec.Selector,
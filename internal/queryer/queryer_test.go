@@ -16,9 +16,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -454,6 +456,111 @@ func TestCacheQueryer_OwnerReference(t *testing.T) {
 	}
 }
 
+func TestCacheQueryer_PodsForObject(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"one"}},
+				},
+			},
+		},
+	}
+
+	pod1 := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod1",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "one"},
+		},
+	}
+
+	pod2 := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod2",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "two"},
+		},
+	}
+
+	key := store.Key{
+		Namespace:  "default",
+		APIVersion: "v1",
+		Kind:       "Pod",
+	}
+
+	cases := []struct {
+		name     string
+		object   runtime.Object
+		setup    func(t *testing.T, o *storeFake.MockStore)
+		expected []*corev1.Pod
+		isErr    bool
+	}{
+		{
+			name:   "deployment",
+			object: deployment,
+			setup: func(t *testing.T, o *storeFake.MockStore) {
+				o.EXPECT().
+					List(gomock.Any(), gomock.Eq(key)).
+					Return(testutil.ToUnstructuredList(t, pod1, pod2), nil).
+					Times(2)
+			},
+			expected: []*corev1.Pod{pod1},
+		},
+		{
+			name: "service without selector",
+			object: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "default"},
+			},
+		},
+		{
+			name:   "unsupported object",
+			object: &corev1.ConfigMap{},
+			isErr:  true,
+		},
+		{
+			name:  "object is nil",
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			o := storeFake.NewMockStore(controller)
+			discovery := queryerFake.NewMockDiscoveryInterface(controller)
+
+			if tc.setup != nil {
+				tc.setup(t, o)
+			}
+
+			oq := New(o, discovery)
+
+			ctx := context.Background()
+			got, err := oq.PodsForObject(ctx, tc.object)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+
+			if tc.expected != nil {
+				// results are not cached
+				got, err = oq.PodsForObject(ctx, tc.object)
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, got)
+			}
+		})
+	}
+}
+
 func TestCacheQueryer_PodsForService(t *testing.T) {
 	service := &corev1.Service{
 		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
@@ -858,6 +965,243 @@ func TestObjectStoreQueryer_ServiceAccountForPod(t *testing.T) {
 	require.Equal(t, serviceAccount, got)
 }
 
+func TestObjectStoreQueryer_ConfigMapsForPod(t *testing.T) {
+	configMap := testutil.CreateConfigMap("config-map")
+
+	pod := testutil.CreatePod("pod")
+	pod.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+				},
+			},
+		},
+	}
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name: "container",
+			EnvFrom: []corev1.EnvFromSource{
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}}},
+			},
+		},
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	o := storeFake.NewMockStore(controller)
+	key, err := store.KeyFromObject(configMap)
+	require.NoError(t, err)
+	o.EXPECT().
+		Get(gomock.Any(), key).
+		Return(testutil.ToUnstructured(t, configMap), nil)
+	o.EXPECT().
+		Get(gomock.Any(), store.Key{Namespace: pod.Namespace, APIVersion: "v1", Kind: "ConfigMap", Name: "missing"}).
+		Return(nil, nil)
+
+	discovery := queryerFake.NewMockDiscoveryInterface(controller)
+
+	q := New(o, discovery)
+
+	ctx := context.Background()
+	got, err := q.ConfigMapsForPod(ctx, pod)
+	require.NoError(t, err)
+
+	require.Equal(t, []*corev1.ConfigMap{configMap}, got)
+}
+
+func TestObjectStoreQueryer_SecretsForPod(t *testing.T) {
+	envSecret := testutil.CreateSecret("env")
+	pullSecret := testutil.CreateSecret("pull")
+
+	pod := testutil.CreatePod("pod")
+	pod.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: pullSecret.Name}}
+	pod.Spec.InitContainers = []corev1.Container{
+		{
+			Name: "init",
+			Env: []corev1.EnvVar{
+				{
+					Name: "PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: envSecret.Name},
+							Key:                  "password",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	o := storeFake.NewMockStore(controller)
+	for _, secret := range []*corev1.Secret{envSecret, pullSecret} {
+		key, err := store.KeyFromObject(secret)
+		require.NoError(t, err)
+		o.EXPECT().
+			Get(gomock.Any(), key).
+			Return(testutil.ToUnstructured(t, secret), nil)
+	}
+
+	discovery := queryerFake.NewMockDiscoveryInterface(controller)
+
+	q := New(o, discovery)
+
+	ctx := context.Background()
+	got, err := q.SecretsForPod(ctx, pod)
+	require.NoError(t, err)
+
+	require.Equal(t, []*corev1.Secret{envSecret, pullSecret}, got)
+}
+
+func TestObjectStoreQueryer_PersistentVolumeClaimsForPod(t *testing.T) {
+	claim := testutil.CreatePersistentVolumeClaim("claim")
+
+	pod := testutil.CreatePod("pod")
+	pod.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim.Name},
+			},
+		},
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	o := storeFake.NewMockStore(controller)
+	key, err := store.KeyFromObject(claim)
+	require.NoError(t, err)
+	o.EXPECT().
+		Get(gomock.Any(), key).
+		Return(testutil.ToUnstructured(t, claim), nil)
+
+	discovery := queryerFake.NewMockDiscoveryInterface(controller)
+
+	q := New(o, discovery)
+
+	ctx := context.Background()
+	got, err := q.PersistentVolumeClaimsForPod(ctx, pod)
+	require.NoError(t, err)
+
+	require.Equal(t, []*corev1.PersistentVolumeClaim{claim}, got)
+}
+
+func TestObjectStoreQueryer_HorizontalPodAutoscalersForObject(t *testing.T) {
+	deployment := testutil.CreateDeployment("deployment")
+
+	hpa1 := testutil.CreateHorizontalPodAutoscaler("hpa1")
+	hpa1.Spec.ScaleTargetRef = autoscalingv1.CrossVersionObjectReference{
+		APIVersion: "extensions/v1beta1",
+		Kind:       "Deployment",
+		Name:       deployment.Name,
+	}
+
+	hpa2 := testutil.CreateHorizontalPodAutoscaler("hpa2")
+	hpa2.Spec.ScaleTargetRef = autoscalingv1.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Name:       deployment.Name,
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	o := storeFake.NewMockStore(controller)
+	key := store.Key{
+		Namespace:  deployment.Namespace,
+		APIVersion: "autoscaling/v1",
+		Kind:       "HorizontalPodAutoscaler",
+	}
+	o.EXPECT().
+		List(gomock.Any(), key).
+		Return(testutil.ToUnstructuredList(t, hpa1, hpa2), nil)
+
+	discovery := queryerFake.NewMockDiscoveryInterface(controller)
+
+	q := New(o, discovery)
+
+	ctx := context.Background()
+	got, err := q.HorizontalPodAutoscalersForObject(ctx, deployment)
+	require.NoError(t, err)
+
+	require.Equal(t, []*autoscalingv1.HorizontalPodAutoscaler{hpa1}, got)
+}
+
+func TestObjectStoreQueryer_ScaleTarget(t *testing.T) {
+	deployment := testutil.CreateDeployment("deployment")
+
+	hpa := testutil.CreateHorizontalPodAutoscaler("hpa")
+	hpa.Spec.ScaleTargetRef = autoscalingv1.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       deployment.Name,
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	o := storeFake.NewMockStore(controller)
+	key, err := store.KeyFromObject(deployment)
+	require.NoError(t, err)
+	o.EXPECT().
+		Get(gomock.Any(), key).
+		Return(testutil.ToUnstructured(t, deployment), nil)
+
+	discovery := queryerFake.NewMockDiscoveryInterface(controller)
+
+	q := New(o, discovery)
+
+	ctx := context.Background()
+	got, err := q.ScaleTarget(ctx, hpa)
+	require.NoError(t, err)
+
+	require.Equal(t, testutil.ToUnstructured(t, deployment), got)
+}
+
+func TestObjectStoreQueryer_NetworkPolicies(t *testing.T) {
+	pod1 := testutil.CreatePod("pod1")
+	pod1.Labels = map[string]string{"app": "one"}
+	pod2 := testutil.CreatePod("pod2")
+	pod2.Labels = map[string]string{"app": "two"}
+
+	selectOne := testutil.CreateNetworkPolicy("select-one")
+	selectOne.Spec.PodSelector = metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "one"},
+	}
+	selectAll := testutil.CreateNetworkPolicy("select-all")
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().
+		List(gomock.Any(), store.Key{Namespace: testutil.DefaultNamespace, APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"}).
+		Return(testutil.ToUnstructuredList(t, selectOne, selectAll), nil)
+	o.EXPECT().
+		List(gomock.Any(), store.Key{Namespace: testutil.DefaultNamespace, APIVersion: "v1", Kind: "Pod"}).
+		Return(testutil.ToUnstructuredList(t, pod1, pod2), nil)
+
+	discovery := queryerFake.NewMockDiscoveryInterface(controller)
+
+	q := New(o, discovery)
+
+	ctx := context.Background()
+
+	networkPolicies, err := q.NetworkPoliciesForPod(ctx, pod2)
+	require.NoError(t, err)
+	require.Equal(t, []*networkingv1.NetworkPolicy{selectAll}, networkPolicies)
+
+	pods, err := q.PodsForNetworkPolicy(ctx, selectOne)
+	require.NoError(t, err)
+	require.Equal(t, []*corev1.Pod{pod1}, pods)
+}
+
 func TestCacheQueryer_getSelector(t *testing.T) {
 	selector := &metav1.LabelSelector{
 		MatchLabels: map[string]string{"foo": "bar"},
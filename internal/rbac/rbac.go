@@ -0,0 +1,439 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rbac analyzes role bindings to find the subjects they bind and
+// the permissions those subjects are granted.
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware/octant/pkg/store"
+)
+
+const apiVersion = "rbac.authorization.k8s.io/v1"
+
+// Subject is a user, group or service account which appears in a binding.
+// Only service accounts have a namespace.
+type Subject struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// String returns the subject as kind/name or kind/namespace/name.
+func (s Subject) String() string {
+	if s.Namespace == "" {
+		return fmt.Sprintf("%s/%s", s.Kind, s.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", s.Kind, s.Namespace, s.Name)
+}
+
+// implicitGroups returns the groups a subject belongs to which can be known
+// without asking the authenticator. Only service accounts have them.
+func (s Subject) implicitGroups() []Subject {
+	if s.Kind != rbacv1.ServiceAccountKind {
+		return nil
+	}
+
+	return []Subject{
+		{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts"},
+		{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:" + s.Namespace},
+		{Kind: rbacv1.GroupKind, Name: "system:authenticated"},
+	}
+}
+
+// Grant is a binding and the role it binds.
+type Grant struct {
+	BindingKind      string
+	BindingName      string
+	BindingNamespace string
+	RoleKind         string
+	RoleName         string
+}
+
+// RoleNamespace returns the namespace of the granted role. Cluster roles
+// don't have a namespace.
+func (g Grant) RoleNamespace() string {
+	if g.RoleKind == "ClusterRole" {
+		return ""
+	}
+	return g.BindingNamespace
+}
+
+// Rule is an effective permission for a subject. An empty namespace means
+// the rule applies to all namespaces and to cluster scoped resources.
+type Rule struct {
+	Namespace      string
+	APIGroup       string
+	Resource       string
+	ResourceNames  []string
+	NonResourceURL string
+	Verbs          []string
+	Grants         []Grant
+}
+
+type binding struct {
+	grant    Grant
+	subjects []Subject
+}
+
+// Analysis is a snapshot of the bindings and roles in a cluster.
+type Analysis struct {
+	bindings     []binding
+	roles        map[string][]rbacv1.PolicyRule
+	clusterRoles map[string][]rbacv1.PolicyRule
+}
+
+// Load loads the bindings and roles in all namespaces from the object store.
+func Load(ctx context.Context, objectStore store.Store) (*Analysis, error) {
+	if objectStore == nil {
+		return nil, errors.New("object store is nil")
+	}
+
+	a := &Analysis{
+		roles:        make(map[string][]rbacv1.PolicyRule),
+		clusterRoles: make(map[string][]rbacv1.PolicyRule),
+	}
+
+	var clusterRoles []rbacv1.ClusterRole
+	if err := list(ctx, objectStore, "ClusterRole", func(object runtime.Object) {
+		clusterRoles = append(clusterRoles, *object.(*rbacv1.ClusterRole))
+	}, &rbacv1.ClusterRole{}); err != nil {
+		return nil, err
+	}
+	for _, clusterRole := range clusterRoles {
+		a.clusterRoles[clusterRole.Name] = clusterRole.Rules
+	}
+
+	var roles []rbacv1.Role
+	if err := list(ctx, objectStore, "Role", func(object runtime.Object) {
+		roles = append(roles, *object.(*rbacv1.Role))
+	}, &rbacv1.Role{}); err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		a.roles[roleKey(role.Namespace, role.Name)] = role.Rules
+	}
+
+	if err := list(ctx, objectStore, "ClusterRoleBinding", func(object runtime.Object) {
+		crb := object.(*rbacv1.ClusterRoleBinding)
+		a.addBinding(Grant{
+			BindingKind: "ClusterRoleBinding",
+			BindingName: crb.Name,
+			RoleKind:    crb.RoleRef.Kind,
+			RoleName:    crb.RoleRef.Name,
+		}, crb.Subjects, "")
+	}, &rbacv1.ClusterRoleBinding{}); err != nil {
+		return nil, err
+	}
+
+	if err := list(ctx, objectStore, "RoleBinding", func(object runtime.Object) {
+		rb := object.(*rbacv1.RoleBinding)
+		a.addBinding(Grant{
+			BindingKind:      "RoleBinding",
+			BindingName:      rb.Name,
+			BindingNamespace: rb.Namespace,
+			RoleKind:         rb.RoleRef.Kind,
+			RoleName:         rb.RoleRef.Name,
+		}, rb.Subjects, rb.Namespace)
+	}, &rbacv1.RoleBinding{}); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// list lists objects of a kind in all namespaces. A new instance of
+// prototype is created for each object.
+func list(ctx context.Context, objectStore store.Store, kind string, fn func(runtime.Object), prototype runtime.Object) error {
+	key := store.Key{APIVersion: apiVersion, Kind: kind}
+
+	objects, err := objectStore.List(ctx, key)
+	if err != nil {
+		return errors.Wrapf(err, "list %s", kind)
+	}
+
+	for _, u := range objects {
+		object := prototype.DeepCopyObject()
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, object); err != nil {
+			return errors.Wrapf(err, "convert %s %s", kind, u.GetName())
+		}
+		fn(object)
+	}
+
+	return nil
+}
+
+func (a *Analysis) addBinding(grant Grant, subjects []rbacv1.Subject, namespace string) {
+	b := binding{grant: grant}
+	for _, s := range subjects {
+		subject := Subject{Kind: s.Kind, Name: s.Name}
+		if s.Kind == rbacv1.ServiceAccountKind {
+			subject.Namespace = s.Namespace
+			// role bindings may omit the service account namespace.
+			if subject.Namespace == "" {
+				subject.Namespace = namespace
+			}
+		}
+		b.subjects = append(b.subjects, subject)
+	}
+
+	a.bindings = append(a.bindings, b)
+}
+
+func roleKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// Subjects returns every subject which appears in a binding, sorted by kind,
+// namespace and name.
+func (a *Analysis) Subjects() []Subject {
+	seen := make(map[Subject]bool)
+	var subjects []Subject
+
+	for _, b := range a.bindings {
+		for _, subject := range b.subjects {
+			if seen[subject] {
+				continue
+			}
+			seen[subject] = true
+			subjects = append(subjects, subject)
+		}
+	}
+
+	sortSubjects(subjects)
+	return subjects
+}
+
+// Grants returns the bindings which bind a subject, including bindings for
+// the subject's implicit groups.
+func (a *Analysis) Grants(subject Subject) []Grant {
+	return a.grants(append([]Subject{subject}, subject.implicitGroups()...))
+}
+
+// grants returns the bindings which bind any of the subjects.
+func (a *Analysis) grants(subjects []Subject) []Grant {
+	matches := make(map[Subject]bool)
+	for _, subject := range subjects {
+		matches[subject] = true
+	}
+
+	var grants []Grant
+	for _, b := range a.bindings {
+		for _, s := range b.subjects {
+			if matches[s] {
+				grants = append(grants, b.grant)
+				break
+			}
+		}
+	}
+
+	sortGrants(grants)
+	return grants
+}
+
+// rules returns the policy rules a grant grants.
+func (a *Analysis) rules(grant Grant) []rbacv1.PolicyRule {
+	if grant.RoleKind == "ClusterRole" {
+		return a.clusterRoles[grant.RoleName]
+	}
+	return a.roles[roleKey(grant.BindingNamespace, grant.RoleName)]
+}
+
+type ruleKey struct {
+	namespace      string
+	apiGroup       string
+	resource       string
+	resourceNames  string
+	nonResourceURL string
+}
+
+// Rules returns the effective rules for a subject. Rules from all bindings
+// are merged by namespace, API group, resource and resource names, and the
+// verbs and grants are combined.
+func (a *Analysis) Rules(subject Subject) []Rule {
+	return a.mergeRules(a.Grants(subject))
+}
+
+func (a *Analysis) mergeRules(subjectGrants []Grant) []Rule {
+	merged := make(map[ruleKey]*Rule)
+	verbs := make(map[ruleKey]map[string]bool)
+	grants := make(map[ruleKey]map[Grant]bool)
+
+	add := func(key ruleKey, rule Rule, ruleVerbs []string, grant Grant) {
+		if _, ok := merged[key]; !ok {
+			merged[key] = &rule
+			verbs[key] = make(map[string]bool)
+			grants[key] = make(map[Grant]bool)
+		}
+		for _, verb := range ruleVerbs {
+			verbs[key][verb] = true
+		}
+		grants[key][grant] = true
+	}
+
+	for _, grant := range subjectGrants {
+		// a cluster role bound with a role binding only applies in the
+		// binding's namespace.
+		namespace := grant.BindingNamespace
+
+		for _, policyRule := range a.rules(grant) {
+			var resourceNames []string
+			if len(policyRule.ResourceNames) > 0 {
+				resourceNames = append(resourceNames, policyRule.ResourceNames...)
+				sort.Strings(resourceNames)
+			}
+
+			for _, apiGroup := range policyRule.APIGroups {
+				for _, resource := range policyRule.Resources {
+					key := ruleKey{
+						namespace:     namespace,
+						apiGroup:      apiGroup,
+						resource:      resource,
+						resourceNames: strings.Join(resourceNames, ","),
+					}
+					rule := Rule{
+						Namespace:     namespace,
+						APIGroup:      apiGroup,
+						Resource:      resource,
+						ResourceNames: resourceNames,
+					}
+					add(key, rule, policyRule.Verbs, grant)
+				}
+			}
+
+			for _, url := range policyRule.NonResourceURLs {
+				key := ruleKey{namespace: namespace, nonResourceURL: url}
+				add(key, Rule{Namespace: namespace, NonResourceURL: url}, policyRule.Verbs, grant)
+			}
+		}
+	}
+
+	var rules []Rule
+	for key, rule := range merged {
+		for verb := range verbs[key] {
+			rule.Verbs = append(rule.Verbs, verb)
+		}
+		sort.Strings(rule.Verbs)
+
+		for grant := range grants[key] {
+			rule.Grants = append(rule.Grants, grant)
+		}
+		sortGrants(rule.Grants)
+
+		rules = append(rules, *rule)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.NonResourceURL != b.NonResourceURL {
+			return a.NonResourceURL < b.NonResourceURL
+		}
+		if a.APIGroup != b.APIGroup {
+			return a.APIGroup < b.APIGroup
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return strings.Join(a.ResourceNames, ",") < strings.Join(b.ResourceNames, ",")
+	})
+
+	return rules
+}
+
+// Access is a subject which is allowed to perform an action and the rule
+// which allows it.
+type Access struct {
+	Subject Subject
+	Rule    Rule
+}
+
+// WhoCan returns the subjects which can perform a verb on a resource in a
+// namespace. The resource can include its API group, e.g.
+// deployments.apps. An empty namespace only matches cluster wide rules.
+// Implicit groups are not expanded since the groups are listed as subjects
+// of their own.
+func (a *Analysis) WhoCan(verb, resource, namespace string) []Access {
+	resourceName, apiGroup := parseResource(resource)
+
+	var accesses []Access
+	for _, subject := range a.Subjects() {
+		for _, rule := range a.mergeRules(a.grants([]Subject{subject})) {
+			if rule.NonResourceURL != "" {
+				continue
+			}
+			if rule.Namespace != "" && rule.Namespace != namespace {
+				continue
+			}
+			if !matches(rule.Verbs, verb) || !matches([]string{rule.Resource}, resourceName) {
+				continue
+			}
+			if apiGroup != "*" && !matches([]string{rule.APIGroup}, apiGroup) {
+				continue
+			}
+
+			accesses = append(accesses, Access{Subject: subject, Rule: rule})
+		}
+	}
+
+	return accesses
+}
+
+// parseResource splits resource.group into its resource and group. Core
+// resources have an empty group. A resource without a group matches rules
+// in any group, so "*" is returned in that case.
+func parseResource(s string) (string, string) {
+	parts := strings.SplitN(s, ".", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], "*"
+}
+
+func matches(values []string, s string) bool {
+	for _, value := range values {
+		if value == "*" || value == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortSubjects(subjects []Subject) {
+	sort.Slice(subjects, func(i, j int) bool {
+		a, b := subjects[i], subjects[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+func sortGrants(grants []Grant) {
+	sort.Slice(grants, func(i, j int) bool {
+		a, b := grants[i], grants[j]
+		if a.BindingKind != b.BindingKind {
+			return a.BindingKind < b.BindingKind
+		}
+		if a.BindingNamespace != b.BindingNamespace {
+			return a.BindingNamespace < b.BindingNamespace
+		}
+		return a.BindingName < b.BindingName
+	})
+}
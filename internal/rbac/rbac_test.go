@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storefake "github.com/vmware/octant/pkg/store/fake"
+)
+
+func loadAnalysis(t *testing.T, controller *gomock.Controller) *Analysis {
+	clusterRole := testutil.CreateClusterRole("crontab-admin")
+	clusterRole.Rules = append(clusterRole.Rules, rbacv1.PolicyRule{
+		NonResourceURLs: []string{"/healthz"},
+		Verbs:           []string{"get"},
+	})
+
+	viewer := testutil.CreateClusterRole("viewer")
+	viewer.Rules = []rbacv1.PolicyRule{
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get", "list"}},
+	}
+
+	role := testutil.CreateRole("pod-reader")
+
+	crb := testutil.CreateClusterRoleBinding("crontab-admins", "crontab-admin", []rbacv1.Subject{
+		*testutil.CreateRoleBindingSubject("User", "alice", ""),
+		*testutil.CreateRoleBindingSubject("Group", "system:serviceaccounts:namespace", ""),
+	})
+	crb.RoleRef.Kind = "ClusterRole"
+
+	rb := testutil.CreateRoleBinding("pod-readers", "pod-reader", []rbacv1.Subject{
+		*testutil.CreateRoleBindingSubject("User", "alice", ""),
+		*testutil.CreateRoleBindingSubject("ServiceAccount", "default", ""),
+	})
+
+	viewerBinding := testutil.CreateRoleBinding("viewers", "viewer", []rbacv1.Subject{
+		*testutil.CreateRoleBindingSubject("User", "bob", ""),
+	})
+	viewerBinding.RoleRef.Kind = "ClusterRole"
+
+	objectStore := storefake.NewMockStore(controller)
+
+	objectStore.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: apiVersion, Kind: "ClusterRole"}).
+		Return(testutil.ToUnstructuredList(t, clusterRole, viewer), nil)
+	objectStore.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: apiVersion, Kind: "Role"}).
+		Return(testutil.ToUnstructuredList(t, role), nil)
+	objectStore.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: apiVersion, Kind: "ClusterRoleBinding"}).
+		Return(testutil.ToUnstructuredList(t, crb), nil)
+	objectStore.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: apiVersion, Kind: "RoleBinding"}).
+		Return(testutil.ToUnstructuredList(t, rb, viewerBinding), nil)
+
+	a, err := Load(context.Background(), objectStore)
+	require.NoError(t, err)
+
+	return a
+}
+
+func TestAnalysis_Subjects(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	a := loadAnalysis(t, controller)
+
+	expected := []Subject{
+		{Kind: "Group", Name: "system:serviceaccounts:namespace"},
+		{Kind: "ServiceAccount", Name: "default", Namespace: "namespace"},
+		{Kind: "User", Name: "alice"},
+		{Kind: "User", Name: "bob"},
+	}
+	assert.Equal(t, expected, a.Subjects())
+}
+
+func TestAnalysis_Rules(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	a := loadAnalysis(t, controller)
+
+	crontabGrant := Grant{BindingKind: "ClusterRoleBinding", BindingName: "crontab-admins", RoleKind: "ClusterRole", RoleName: "crontab-admin"}
+	podGrant := Grant{BindingKind: "RoleBinding", BindingName: "pod-readers", BindingNamespace: "namespace", RoleKind: "Role", RoleName: "pod-reader"}
+
+	cases := []struct {
+		name     string
+		subject  Subject
+		expected []Rule
+	}{
+		{
+			name:    "user",
+			subject: Subject{Kind: "User", Name: "alice"},
+			expected: []Rule{
+				{
+					APIGroup: "stable.example.com", Resource: "crontabs",
+					Verbs:  []string{"create", "delete", "get", "list", "patch", "update", "watch"},
+					Grants: []Grant{crontabGrant},
+				},
+				{NonResourceURL: "/healthz", Verbs: []string{"get"}, Grants: []Grant{crontabGrant}},
+				{Namespace: "namespace", Resource: "pods", Verbs: []string{"get", "list", "watch"}, Grants: []Grant{podGrant}},
+			},
+		},
+		{
+			name:    "service account with implicit group",
+			subject: Subject{Kind: "ServiceAccount", Name: "default", Namespace: "namespace"},
+			expected: []Rule{
+				{
+					APIGroup: "stable.example.com", Resource: "crontabs",
+					Verbs:  []string{"create", "delete", "get", "list", "patch", "update", "watch"},
+					Grants: []Grant{crontabGrant},
+				},
+				{NonResourceURL: "/healthz", Verbs: []string{"get"}, Grants: []Grant{crontabGrant}},
+				{Namespace: "namespace", Resource: "pods", Verbs: []string{"get", "list", "watch"}, Grants: []Grant{podGrant}},
+			},
+		},
+		{
+			name:    "cluster role bound in a namespace",
+			subject: Subject{Kind: "User", Name: "bob"},
+			expected: []Rule{
+				{
+					Namespace: "namespace", APIGroup: "*", Resource: "*",
+					Verbs: []string{"get", "list"},
+					Grants: []Grant{{
+						BindingKind: "RoleBinding", BindingName: "viewers", BindingNamespace: "namespace",
+						RoleKind: "ClusterRole", RoleName: "viewer",
+					}},
+				},
+			},
+		},
+		{
+			name:    "unknown subject",
+			subject: Subject{Kind: "User", Name: "carol"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, a.Rules(tc.subject))
+		})
+	}
+}
+
+func TestAnalysis_WhoCan(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	a := loadAnalysis(t, controller)
+
+	subjects := func(accesses []Access) []string {
+		var got []string
+		for _, access := range accesses {
+			got = append(got, access.Subject.String())
+		}
+		return got
+	}
+
+	cases := []struct {
+		name      string
+		verb      string
+		resource  string
+		namespace string
+		expected  []string
+	}{
+		{
+			name:      "namespaced resource",
+			verb:      "list",
+			resource:  "pods",
+			namespace: "namespace",
+			expected:  []string{"ServiceAccount/namespace/default", "User/alice", "User/bob"},
+		},
+		{
+			name:      "other namespace",
+			verb:      "list",
+			resource:  "pods",
+			namespace: "other",
+		},
+		{
+			name:     "resource with group",
+			verb:     "delete",
+			resource: "crontabs.stable.example.com",
+			expected: []string{"Group/system:serviceaccounts:namespace", "User/alice"},
+		},
+		{
+			name:     "resource in other group",
+			verb:     "delete",
+			resource: "crontabs.apps",
+		},
+		{
+			name:      "wildcard resources",
+			verb:      "get",
+			resource:  "secrets",
+			namespace: "namespace",
+			expected:  []string{"User/bob"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, subjects(a.WhoCan(tc.verb, tc.resource, tc.namespace)))
+		})
+	}
+}
+
+func TestLoad_nil_store(t *testing.T) {
+	_, err := Load(context.Background(), nil)
+	require.Error(t, err)
+}
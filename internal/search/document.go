@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Owner is an owner of a document.
+type Owner struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+func (o Owner) String() string {
+	return fmt.Sprintf("%s/%s", o.Kind, o.Name)
+}
+
+// Document is the searchable part of an object.
+type Document struct {
+	Namespace   string            `json:"namespace,omitempty"`
+	APIVersion  string            `json:"apiVersion"`
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name"`
+	UID         string            `json:"uid,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Owners      []Owner           `json:"owners,omitempty"`
+	Images      []string          `json:"images,omitempty"`
+	Node        string            `json:"node,omitempty"`
+}
+
+// lastAppliedConfigAnnotation is set by kubectl apply to the whole object
+// that was applied, which can include secret data.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// podSpecPaths are the fields which contain a pod spec in the kinds Octant
+// shows.
+var podSpecPaths = [][]string{
+	{"spec"},
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// NewDocument creates a document for an object. Images are read from any
+// pod spec in the object, and the node from a pod's spec.nodeName.
+func NewDocument(object *unstructured.Unstructured) Document {
+	d := Document{
+		Namespace:   object.GetNamespace(),
+		APIVersion:  object.GetAPIVersion(),
+		Kind:        object.GetKind(),
+		Name:        object.GetName(),
+		UID:         string(object.GetUID()),
+		Labels:      object.GetLabels(),
+		Annotations: indexedAnnotations(object),
+	}
+
+	for _, ref := range object.GetOwnerReferences() {
+		d.Owners = append(d.Owners, Owner{Kind: ref.Kind, Name: ref.Name})
+	}
+
+	seen := make(map[string]bool)
+	for _, podSpecPath := range podSpecPaths {
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _, _ := unstructured.NestedSlice(object.Object, append(podSpecPath, field)...)
+			for _, container := range containers {
+				m, ok := container.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				image, _, _ := unstructured.NestedString(m, "image")
+				if image != "" && !seen[image] {
+					seen[image] = true
+					d.Images = append(d.Images, image)
+				}
+			}
+		}
+	}
+
+	d.Node, _, _ = unstructured.NestedString(object.Object, "spec", "nodeName")
+
+	return d
+}
+
+// indexedAnnotations returns the annotations of an object which are indexed.
+// Secret annotations aren't indexed since tools store credentials in them,
+// and the last applied configuration is never indexed.
+func indexedAnnotations(object *unstructured.Unstructured) map[string]string {
+	if object.GetKind() == "Secret" {
+		return nil
+	}
+
+	annotations := make(map[string]string)
+	for k, v := range object.GetAnnotations() {
+		if k == lastAppliedConfigAnnotation {
+			continue
+		}
+		annotations[k] = v
+	}
+
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	return annotations
+}
+
+// key returns the index key for the document. The UID is used when the
+// object has one, so a recreated object doesn't share a key with the
+// object it replaced.
+func (d Document) key() string {
+	if d.UID != "" {
+		return d.UID
+	}
+
+	return strings.Join([]string{d.Namespace, d.APIVersion, d.Kind, d.Name}, "/")
+}
+
+// imageName returns the name of an image without its registry, tag or
+// digest, e.g. nginx for docker.io/library/nginx:1.17.
+func imageName(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+
+	if i := strings.Index(image, ":"); i >= 0 {
+		image = image[:i]
+	}
+
+	return image
+}
@@ -0,0 +1,157 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package search
+
+import (
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kcache "k8s.io/client-go/tools/cache"
+)
+
+// DefaultLimit is the number of results returned when no limit is given.
+const DefaultLimit = 50
+
+// Result identifies an object which matched a query. It doesn't include the
+// rest of the object's document, so labels and annotations aren't exposed
+// through search results.
+type Result struct {
+	Namespace  string `json:"namespace,omitempty"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Score      int    `json:"score"`
+}
+
+// Searcher searches objects.
+type Searcher interface {
+	Search(query Query, limit int) []Result
+}
+
+// Index is an in-memory index of documents. It is kept up to date by
+// adding it as an event handler to informers.
+type Index struct {
+	mu        sync.RWMutex
+	documents map[string]Document
+}
+
+var _ Searcher = (*Index)(nil)
+var _ kcache.ResourceEventHandler = (*Index)(nil)
+
+// NewIndex creates an instance of Index.
+func NewIndex() *Index {
+	return &Index{
+		documents: make(map[string]Document),
+	}
+}
+
+// OnAdd adds an object to the index.
+func (i *Index) OnAdd(obj interface{}) {
+	if object, ok := obj.(*unstructured.Unstructured); ok {
+		i.Add(object)
+	}
+}
+
+// OnUpdate replaces an object in the index.
+func (i *Index) OnUpdate(_, newObj interface{}) {
+	i.OnAdd(newObj)
+}
+
+// OnDelete removes an object from the index. The last known state of
+// objects deleted while the informer was disconnected is used as well.
+func (i *Index) OnDelete(obj interface{}) {
+	if tombstone, ok := obj.(kcache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	if object, ok := obj.(*unstructured.Unstructured); ok {
+		i.Delete(object)
+	}
+}
+
+// Add adds or replaces an object.
+func (i *Index) Add(object *unstructured.Unstructured) {
+	d := NewDocument(object)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.documents[d.key()] = d
+}
+
+// Delete removes an object.
+func (i *Index) Delete(object *unstructured.Unstructured) {
+	d := NewDocument(object)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.documents, d.key())
+}
+
+// Reset removes all objects.
+func (i *Index) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.documents = make(map[string]Document)
+}
+
+// Len returns the number of objects in the index.
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.documents)
+}
+
+// Search returns up to limit documents which match the query, best match
+// first. Ties are ordered by kind, namespace and name.
+func (i *Index) Search(query Query, limit int) []Result {
+	if query.IsEmpty() {
+		return nil
+	}
+
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+
+	i.mu.RLock()
+	var results []Result
+	for _, d := range i.documents {
+		if score := query.Score(d); score > 0 {
+			results = append(results, Result{
+				Namespace:  d.Namespace,
+				APIVersion: d.APIVersion,
+				Kind:       d.Kind,
+				Name:       d.Name,
+				Score:      score,
+			})
+		}
+	}
+	i.mu.RUnlock()
+
+	sort.Slice(results, func(a, b int) bool {
+		ra, rb := results[a], results[b]
+		if ra.Score != rb.Score {
+			return ra.Score > rb.Score
+		}
+		if ra.Kind != rb.Kind {
+			return ra.Kind < rb.Kind
+		}
+		if ra.Namespace != rb.Namespace {
+			return ra.Namespace < rb.Namespace
+		}
+		return ra.Name < rb.Name
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
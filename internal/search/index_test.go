@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kcache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware/octant/internal/testutil"
+)
+
+func TestNewDocument(t *testing.T) {
+	deployment := testutil.CreateDeployment("web")
+	deployment.UID = types.UID("uid")
+	deployment.Labels = map[string]string{"app": "web"}
+	deployment.Annotations = map[string]string{
+		"team":                      "payments",
+		lastAppliedConfigAnnotation: `{"kind":"Deployment"}`,
+	}
+	deployment.Spec.Template.Spec.InitContainers = []corev1.Container{{Name: "init", Image: "busybox"}}
+	deployment.Spec.Template.Spec.Containers = []corev1.Container{
+		{Name: "web", Image: "nginx"},
+		{Name: "sidecar", Image: "busybox"},
+	}
+
+	pod := testutil.CreatePod("web-123")
+	pod.Spec.NodeName = "worker-1"
+	pod.OwnerReferences = testutil.ToOwnerReferences(t, deployment)
+
+	d := NewDocument(testutil.ToUnstructured(t, deployment))
+	assert.Equal(t, "namespace", d.Namespace)
+	assert.Equal(t, "apps/v1", d.APIVersion)
+	assert.Equal(t, "Deployment", d.Kind)
+	assert.Equal(t, "uid", d.UID)
+	assert.Equal(t, map[string]string{"app": "web"}, d.Labels)
+	assert.Equal(t, map[string]string{"team": "payments"}, d.Annotations)
+	assert.Equal(t, []string{"busybox", "nginx"}, d.Images)
+
+	d = NewDocument(testutil.ToUnstructured(t, pod))
+	assert.Equal(t, "worker-1", d.Node)
+	assert.Equal(t, []Owner{{Kind: "Deployment", Name: "web"}}, d.Owners)
+
+	secret := testutil.CreateSecret("token")
+	secret.Annotations = map[string]string{"token": "s3cr3t"}
+
+	d = NewDocument(testutil.ToUnstructured(t, secret))
+	assert.Equal(t, "token", d.Name)
+	assert.Empty(t, d.Annotations)
+}
+
+func TestIndex(t *testing.T) {
+	index := NewIndex()
+
+	web := testutil.CreatePod("web")
+	web.UID = types.UID("web")
+	webAPI := testutil.CreatePod("web-api")
+	webAPI.UID = types.UID("web-api")
+	service := testutil.CreateService("web")
+	service.UID = types.UID("service")
+	other := testutil.CreatePod("api")
+	other.UID = types.UID("api")
+	other.Labels = map[string]string{"app": "web"}
+
+	for _, object := range testutil.ToUnstructuredList(t, web, webAPI, service, other) {
+		index.OnAdd(object)
+	}
+	require.Equal(t, 4, index.Len())
+
+	q, err := ParseQuery("web")
+	require.NoError(t, err)
+
+	names := func(results []Result) []string {
+		var got []string
+		for _, result := range results {
+			got = append(got, result.Kind+"/"+result.Name)
+		}
+		return got
+	}
+
+	assert.Equal(t, []string{"Pod/web", "Service/web", "Pod/web-api", "Pod/api"}, names(index.Search(q, 0)))
+	assert.Equal(t, []string{"Pod/web", "Service/web"}, names(index.Search(q, 2)))
+
+	updated := webAPI.DeepCopy()
+	updated.Labels = map[string]string{"app": "db"}
+	index.OnUpdate(testutil.ToUnstructured(t, webAPI), testutil.ToUnstructured(t, updated))
+	require.Equal(t, 4, index.Len())
+
+	index.OnDelete(testutil.ToUnstructured(t, web))
+	index.OnDelete(kcache.DeletedFinalStateUnknown{Key: "namespace/web", Obj: testutil.ToUnstructured(t, service)})
+	assert.Equal(t, []string{"Pod/web-api", "Pod/api"}, names(index.Search(q, 0)))
+
+	assert.Empty(t, index.Search(Query{}, 0))
+
+	index.Reset()
+	assert.Equal(t, 0, index.Len())
+}
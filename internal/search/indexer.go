@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package search
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware/octant/internal/log"
+	"github.com/vmware/octant/pkg/store"
+)
+
+// indexedKind is a kind which is indexed.
+type indexedKind struct {
+	apiVersion    string
+	kind          string
+	clusterScoped bool
+}
+
+// indexedKinds are the kinds Octant describes. Events aren't indexed since
+// there are many of them and they aren't useful search results.
+var indexedKinds = []indexedKind{
+	{apiVersion: "v1", kind: "Pod"},
+	{apiVersion: "v1", kind: "Service"},
+	{apiVersion: "v1", kind: "ConfigMap"},
+	{apiVersion: "v1", kind: "Secret"},
+	{apiVersion: "v1", kind: "ServiceAccount"},
+	{apiVersion: "v1", kind: "PersistentVolumeClaim"},
+	{apiVersion: "v1", kind: "ReplicationController"},
+	{apiVersion: "apps/v1", kind: "Deployment"},
+	{apiVersion: "apps/v1", kind: "StatefulSet"},
+	{apiVersion: "apps/v1", kind: "DaemonSet"},
+	{apiVersion: "apps/v1", kind: "ReplicaSet"},
+	{apiVersion: "batch/v1", kind: "Job"},
+	{apiVersion: "batch/v1beta1", kind: "CronJob"},
+	{apiVersion: "extensions/v1beta1", kind: "Ingress"},
+	{apiVersion: "rbac.authorization.k8s.io/v1", kind: "Role"},
+	{apiVersion: "rbac.authorization.k8s.io/v1", kind: "RoleBinding"},
+	{apiVersion: "v1", kind: "Node", clusterScoped: true},
+	{apiVersion: "v1", kind: "Namespace", clusterScoped: true},
+	{apiVersion: "v1", kind: "PersistentVolume", clusterScoped: true},
+	{apiVersion: "rbac.authorization.k8s.io/v1", kind: "ClusterRole", clusterScoped: true},
+	{apiVersion: "rbac.authorization.k8s.io/v1", kind: "ClusterRoleBinding", clusterScoped: true},
+}
+
+// NamespacesFunc lists namespaces.
+type NamespacesFunc func() ([]string, error)
+
+// Indexer keeps an index up to date with the objects in an object store.
+type Indexer struct {
+	index      *Index
+	namespaces NamespacesFunc
+
+	mu          sync.Mutex
+	objectStore store.Store
+}
+
+var _ Searcher = (*Indexer)(nil)
+
+// NewIndexer creates an instance of Indexer. namespaces is used to watch
+// each namespace when the user can't watch a kind across the cluster.
+func NewIndexer(objectStore store.Store, namespaces NamespacesFunc) (*Indexer, error) {
+	if objectStore == nil {
+		return nil, errors.New("object store is nil")
+	}
+
+	if namespaces == nil {
+		return nil, errors.New("namespaces func is nil")
+	}
+
+	return &Indexer{
+		index:       NewIndex(),
+		namespaces:  namespaces,
+		objectStore: objectStore,
+	}, nil
+}
+
+// Start watches the object store. When the object store is updated after
+// a context switch, the index is rebuilt from the new cluster.
+func (x *Indexer) Start(ctx context.Context) {
+	x.watch(ctx)
+
+	x.objectStore.RegisterOnUpdate(func(newObjectStore store.Store) {
+		x.mu.Lock()
+		x.objectStore = newObjectStore
+		x.mu.Unlock()
+
+		x.index.Reset()
+		x.watch(ctx)
+	})
+}
+
+// Search searches the index.
+func (x *Indexer) Search(query Query, limit int) []Result {
+	return x.index.Search(query, limit)
+}
+
+// Len returns the number of indexed objects.
+func (x *Indexer) Len() int {
+	return x.index.Len()
+}
+
+// watch adds the index as a handler for each indexed kind. Kinds are
+// watched across the cluster if possible, otherwise in each namespace the
+// user can watch them in.
+func (x *Indexer) watch(ctx context.Context) {
+	logger := log.From(ctx).With("component", "search-indexer")
+
+	x.mu.Lock()
+	objectStore := x.objectStore
+	x.mu.Unlock()
+
+	var namespaces []string
+	listedNamespaces := false
+
+	for _, ik := range indexedKinds {
+		key := store.Key{APIVersion: ik.apiVersion, Kind: ik.kind}
+
+		if err := objectStore.HasAccess(ctx, key, "watch"); err == nil {
+			if err := objectStore.Watch(ctx, key, x.index); err != nil {
+				logger.With("kind", ik.kind).Warnf("watching for search: %v", err)
+			}
+			continue
+		}
+
+		if ik.clusterScoped {
+			continue
+		}
+
+		if !listedNamespaces {
+			var err error
+			if namespaces, err = x.namespaces(); err != nil {
+				logger.Warnf("listing namespaces for search: %v", err)
+			}
+			listedNamespaces = true
+		}
+
+		for _, namespace := range namespaces {
+			key.Namespace = namespace
+			if err := objectStore.HasAccess(ctx, key, "watch"); err != nil {
+				continue
+			}
+			if err := objectStore.Watch(ctx, key, x.index); err != nil {
+				logger.With("kind", ik.kind, "namespace", namespace).Warnf("watching for search: %v", err)
+			}
+		}
+	}
+}
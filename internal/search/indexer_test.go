@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware/octant/internal/testutil"
+	"github.com/vmware/octant/pkg/store"
+	storeFake "github.com/vmware/octant/pkg/store/fake"
+)
+
+func TestIndexer_Start(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	ctx := context.Background()
+	denied := errors.New("forbidden")
+
+	objectStore := storeFake.NewMockStore(controller)
+
+	// pods can be watched across the cluster, so they are watched once.
+	podKey := store.Key{APIVersion: "v1", Kind: "Pod"}
+	objectStore.EXPECT().HasAccess(gomock.Any(), podKey, "watch").Return(nil)
+	objectStore.EXPECT().Watch(gomock.Any(), podKey, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ store.Key, index *Index) error {
+			index.OnAdd(testutil.ToUnstructured(t, testutil.CreatePod("web")))
+			return nil
+		})
+
+	// services can only be watched in the default namespace.
+	serviceKey := store.Key{APIVersion: "v1", Kind: "Service"}
+	objectStore.EXPECT().HasAccess(gomock.Any(), serviceKey, "watch").Return(denied)
+	defaultServiceKey := store.Key{Namespace: "default", APIVersion: "v1", Kind: "Service"}
+	objectStore.EXPECT().HasAccess(gomock.Any(), defaultServiceKey, "watch").Return(nil)
+	objectStore.EXPECT().Watch(gomock.Any(), defaultServiceKey, gomock.Any()).Return(nil)
+	systemServiceKey := store.Key{Namespace: "kube-system", APIVersion: "v1", Kind: "Service"}
+	objectStore.EXPECT().HasAccess(gomock.Any(), systemServiceKey, "watch").Return(denied)
+
+	// everything else is denied.
+	objectStore.EXPECT().HasAccess(gomock.Any(), gomock.Any(), "watch").Return(denied).AnyTimes()
+
+	var onUpdate store.UpdateFn
+	objectStore.EXPECT().RegisterOnUpdate(gomock.Any()).
+		Do(func(fn store.UpdateFn) {
+			onUpdate = fn
+		})
+
+	namespaceCalls := 0
+	namespaces := func() ([]string, error) {
+		namespaceCalls++
+		return []string{"default", "kube-system"}, nil
+	}
+
+	indexer, err := NewIndexer(objectStore, namespaces)
+	require.NoError(t, err)
+
+	indexer.Start(ctx)
+	assert.Equal(t, 1, indexer.Len())
+	assert.Equal(t, 1, namespaceCalls)
+
+	q, err := ParseQuery("web")
+	require.NoError(t, err)
+	require.Len(t, indexer.Search(q, 0), 1)
+
+	// the index is rebuilt from the new object store after a context switch.
+	newObjectStore := storeFake.NewMockStore(controller)
+	newObjectStore.EXPECT().HasAccess(gomock.Any(), gomock.Any(), "watch").Return(denied).AnyTimes()
+
+	require.NotNil(t, onUpdate)
+	onUpdate(newObjectStore)
+	assert.Equal(t, 0, indexer.Len())
+	assert.Equal(t, 2, namespaceCalls)
+}
+
+func TestNewIndexer_requires_options(t *testing.T) {
+	_, err := NewIndexer(nil, func() ([]string, error) { return nil, nil })
+	assert.Error(t, err)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	_, err = NewIndexer(storeFake.NewMockStore(controller), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,248 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package search
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Fields which can qualify a term, e.g. label:app=web.
+const (
+	FieldLabel      = "label"
+	FieldAnnotation = "annotation"
+	FieldOwner      = "owner"
+	FieldImage      = "image"
+	FieldNode       = "node"
+	FieldKind       = "kind"
+	FieldNamespace  = "ns"
+)
+
+var fields = map[string]string{
+	FieldLabel:      FieldLabel,
+	FieldAnnotation: FieldAnnotation,
+	FieldOwner:      FieldOwner,
+	FieldImage:      FieldImage,
+	FieldNode:       FieldNode,
+	FieldKind:       FieldKind,
+	FieldNamespace:  FieldNamespace,
+	"namespace":     FieldNamespace,
+}
+
+// Term is a term in a query. Field is empty for free text.
+type Term struct {
+	Field string
+	Key   string
+	Value string
+}
+
+// Query is a parsed search query. An object matches a query if it matches
+// every term.
+type Query struct {
+	Terms []Term
+}
+
+// ParseQuery parses a query. Terms are separated by spaces. Free text
+// matches names by prefix, as well as images, owners, nodes, labels and
+// annotations. Qualified terms only match their field:
+//
+//	label:app label:app=web annotation:key[=value] owner:[Kind/]name
+//	image:nginx node:worker-1 kind:Pod ns:default
+//
+// Terms with an unknown qualifier are treated as free text.
+func ParseQuery(s string) (Query, error) {
+	var q Query
+
+	for _, token := range strings.Fields(s) {
+		parts := strings.SplitN(token, ":", 2)
+		field, ok := fields[strings.ToLower(parts[0])]
+		if len(parts) != 2 || !ok {
+			q.Terms = append(q.Terms, Term{Value: strings.ToLower(token)})
+			continue
+		}
+
+		value := parts[1]
+		if value == "" {
+			return Query{}, errors.Errorf("%s: value is blank", token)
+		}
+
+		term := Term{Field: field, Value: value}
+
+		switch field {
+		case FieldLabel, FieldAnnotation:
+			kv := strings.SplitN(value, "=", 2)
+			term.Key = kv[0]
+			term.Value = ""
+			if len(kv) == 2 {
+				term.Value = kv[1]
+			}
+			if term.Key == "" {
+				return Query{}, errors.Errorf("%s: key is blank", token)
+			}
+		case FieldOwner:
+			if i := strings.Index(value, "/"); i >= 0 {
+				term.Key = value[:i]
+				term.Value = value[i+1:]
+			}
+		}
+
+		q.Terms = append(q.Terms, term)
+	}
+
+	return q, nil
+}
+
+// IsEmpty returns true if the query has no terms.
+func (q Query) IsEmpty() bool {
+	return len(q.Terms) == 0
+}
+
+// Score returns how well a document matches the query. It returns zero if
+// the document doesn't match every term.
+func (q Query) Score(d Document) int {
+	if q.IsEmpty() {
+		return 0
+	}
+
+	total := 0
+	for _, term := range q.Terms {
+		score := term.score(d)
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+
+	return total
+}
+
+// Scores for the parts of a document a term matched. Names are preferred
+// over everything else.
+const (
+	scoreName          = 100
+	scoreNamePrefix    = 60
+	scoreNameSegment   = 30
+	scoreImage         = 25
+	scoreOwner         = 20
+	scoreNode          = 15
+	scoreLabel         = 10
+	scoreAnnotation    = 5
+	scoreQualification = 1
+)
+
+func (t Term) score(d Document) int {
+	switch t.Field {
+	case FieldLabel:
+		return matchKeyValue(d.Labels, t.Key, t.Value, scoreLabel)
+	case FieldAnnotation:
+		return matchKeyValue(d.Annotations, t.Key, t.Value, scoreAnnotation)
+	case FieldOwner:
+		for _, owner := range d.Owners {
+			if t.Key != "" && !strings.EqualFold(owner.Kind, t.Key) {
+				continue
+			}
+			if hasPrefixFold(owner.Name, t.Value) {
+				return scoreOwner
+			}
+		}
+		return 0
+	case FieldImage:
+		return matchImages(d.Images, t.Value)
+	case FieldNode:
+		if d.Node != "" && hasPrefixFold(d.Node, t.Value) {
+			return scoreNode
+		}
+		return 0
+	case FieldKind:
+		if strings.EqualFold(d.Kind, t.Value) {
+			return scoreQualification
+		}
+		return 0
+	case FieldNamespace:
+		if d.Namespace == t.Value {
+			return scoreQualification
+		}
+		return 0
+	}
+
+	return t.scoreText(d)
+}
+
+// scoreText scores free text against the document. The best match wins.
+func (t Term) scoreText(d Document) int {
+	name := strings.ToLower(d.Name)
+
+	switch {
+	case name == t.Value:
+		return scoreName
+	case strings.HasPrefix(name, t.Value):
+		return scoreNamePrefix
+	}
+
+	segments := strings.FieldsFunc(name, isNameSeparator)
+	for i := 1; i < len(segments); i++ {
+		if strings.HasPrefix(segments[i], t.Value) {
+			return scoreNameSegment
+		}
+	}
+
+	if score := matchImages(d.Images, t.Value); score > 0 {
+		return score
+	}
+
+	for _, owner := range d.Owners {
+		if hasPrefixFold(owner.Name, t.Value) {
+			return scoreOwner
+		}
+	}
+
+	if d.Node != "" && hasPrefixFold(d.Node, t.Value) {
+		return scoreNode
+	}
+
+	for k, v := range d.Labels {
+		if strings.EqualFold(k, t.Value) || strings.EqualFold(v, t.Value) {
+			return scoreLabel
+		}
+	}
+
+	for k, v := range d.Annotations {
+		if strings.EqualFold(k, t.Value) || strings.EqualFold(v, t.Value) {
+			return scoreAnnotation
+		}
+	}
+
+	return 0
+}
+
+func matchKeyValue(m map[string]string, key, value string, score int) int {
+	v, ok := m[key]
+	if !ok || (value != "" && v != value) {
+		return 0
+	}
+
+	return score
+}
+
+// matchImages matches images by their full reference or by their name.
+func matchImages(images []string, value string) int {
+	for _, image := range images {
+		if hasPrefixFold(image, value) || hasPrefixFold(imageName(image), value) {
+			return scoreImage
+		}
+	}
+
+	return 0
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func isNameSeparator(r rune) bool {
+	return r == '-' || r == '.' || r == '_'
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected Query
+		isErr    bool
+	}{
+		{
+			name:     "free text",
+			query:    "Web  API",
+			expected: Query{Terms: []Term{{Value: "web"}, {Value: "api"}}},
+		},
+		{
+			name:  "qualifiers",
+			query: "label:app=web annotation:owner image:nginx:1.17 node:worker-1 kind:Pod ns:default",
+			expected: Query{Terms: []Term{
+				{Field: FieldLabel, Key: "app", Value: "web"},
+				{Field: FieldAnnotation, Key: "owner"},
+				{Field: FieldImage, Value: "nginx:1.17"},
+				{Field: FieldNode, Value: "worker-1"},
+				{Field: FieldKind, Value: "Pod"},
+				{Field: FieldNamespace, Value: "default"},
+			}},
+		},
+		{
+			name:  "owner with kind",
+			query: "owner:ReplicaSet/web-123 owner:web",
+			expected: Query{Terms: []Term{
+				{Field: FieldOwner, Key: "ReplicaSet", Value: "web-123"},
+				{Field: FieldOwner, Value: "web"},
+			}},
+		},
+		{
+			name:     "namespace alias",
+			query:    "namespace:kube-system",
+			expected: Query{Terms: []Term{{Field: FieldNamespace, Value: "kube-system"}}},
+		},
+		{
+			name:     "unknown qualifier",
+			query:    "foo:bar",
+			expected: Query{Terms: []Term{{Value: "foo:bar"}}},
+		},
+		{
+			name:  "blank value",
+			query: "label:",
+			isErr: true,
+		},
+		{
+			name:  "blank key",
+			query: "label:=web",
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseQuery(test.query)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestQuery_Score(t *testing.T) {
+	d := Document{
+		Namespace:   "default",
+		APIVersion:  "v1",
+		Kind:        "Pod",
+		Name:        "web-api-5d8f",
+		Labels:      map[string]string{"app": "web", "tier": "frontend"},
+		Annotations: map[string]string{"team": "payments"},
+		Owners:      []Owner{{Kind: "ReplicaSet", Name: "web-api"}},
+		Images:      []string{"docker.io/library/nginx:1.17"},
+		Node:        "worker-1",
+	}
+
+	tests := []struct {
+		query    string
+		expected int
+	}{
+		{query: "web-api-5d8f", expected: scoreName},
+		{query: "web", expected: scoreNamePrefix},
+		{query: "api", expected: scoreNameSegment},
+		{query: "nginx", expected: scoreImage},
+		{query: "worker", expected: scoreNode},
+		{query: "frontend", expected: scoreLabel},
+		{query: "payments", expected: scoreAnnotation},
+		{query: "missing", expected: 0},
+		{query: "label:app=web", expected: scoreLabel},
+		{query: "label:app=db", expected: 0},
+		{query: "label:tier", expected: scoreLabel},
+		{query: "annotation:team=payments", expected: scoreAnnotation},
+		{query: "owner:replicaset/web", expected: scoreOwner},
+		{query: "owner:Deployment/web", expected: 0},
+		{query: "image:docker.io/library/nginx", expected: scoreImage},
+		{query: "image:redis", expected: 0},
+		{query: "node:worker-1", expected: scoreNode},
+		{query: "web kind:pod ns:default", expected: scoreNamePrefix + 2*scoreQualification},
+		{query: "web ns:kube-system", expected: 0},
+		{query: "", expected: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			q, err := ParseQuery(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, q.Score(d))
+		})
+	}
+}
+
+func Test_imageName(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                        "nginx",
+		"nginx:1.17":                   "nginx",
+		"docker.io/library/nginx:1.17": "nginx",
+		"localhost:5000/app@sha256:ab": "app",
+	}
+
+	for image, expected := range tests {
+		assert.Equal(t, expected, imageName(image), image)
+	}
+}
@@ -7,8 +7,10 @@ package testutil
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	extv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -126,6 +128,14 @@ func CreateEvent(name string) *corev1.Event {
 	}
 }
 
+// CreateHorizontalPodAutoscaler creates a horizontal pod autoscaler.
+func CreateHorizontalPodAutoscaler(name string) *autoscalingv1.HorizontalPodAutoscaler {
+	return &autoscalingv1.HorizontalPodAutoscaler{
+		TypeMeta:   genTypeMeta(gvk.HorizontalPodAutoscalerGVK),
+		ObjectMeta: genObjectMeta(name, true),
+	}
+}
+
 // CreateIngress creates an ingress
 func CreateIngress(name string) *extv1beta1.Ingress {
 	return &extv1beta1.Ingress{
@@ -147,6 +157,14 @@ func CreateJob(name string) *batchv1.Job {
 	}
 }
 
+// CreateNetworkPolicy creates a network policy.
+func CreateNetworkPolicy(name string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta:   genTypeMeta(gvk.NetworkPolicyGVK),
+		ObjectMeta: genObjectMeta(name, true),
+	}
+}
+
 // CreatePod creates a pod
 func CreatePod(name string) *corev1.Pod {
 	return &corev1.Pod{
@@ -44,6 +44,8 @@ type Key struct {
 	Kind       string
 	Name       string
 	Selector   *labels.Set
+	// Subresource is only used when checking access, e.g. "exec" for pods/exec.
+	Subresource string
 }
 
 func (k Key) String() string {
@@ -64,6 +66,10 @@ func (k Key) String() string {
 		sb.WriteString(fmt.Sprintf(", Selector=%q", k.Selector.String()))
 	}
 
+	if k.Subresource != "" {
+		sb.WriteString(fmt.Sprintf(", Subresource=%q", k.Subresource))
+	}
+
 	sb.WriteString("]")
 
 	return sb.String()
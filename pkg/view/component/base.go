@@ -27,9 +27,12 @@ const (
 	typeQuadrant           = "quadrant"
 	typeResourceViewer     = "resourceViewer"
 	typeSelectors          = "selectors"
+	typeSparkline          = "sparkline"
 	typeSummary            = "summary"
 	typeTable              = "table"
+	typeTerminal           = "terminal"
 	typeText               = "text"
+	typeTimeline           = "timeline"
 	typeTimestamp          = "timestamp"
 	typeYAML               = "yaml"
 )
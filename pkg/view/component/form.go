@@ -406,7 +406,8 @@ func (ff *FormFieldSelect) UnmarshalJSON(data []byte) error {
 type FormFieldTextarea struct {
 	*baseFormField
 
-	value string
+	value  string
+	upload bool
 }
 
 func NewFormFieldTextarea(label, name, value string) *FormFieldTextarea {
@@ -418,8 +419,20 @@ func NewFormFieldTextarea(label, name, value string) *FormFieldTextarea {
 
 var _ FormField = (*FormFieldTextarea)(nil)
 
+// SetUpload sets whether the textarea can be filled from a file the user
+// uploads.
+func (ff *FormFieldTextarea) SetUpload(upload bool) {
+	ff.upload = upload
+}
+
 func (ff *FormFieldTextarea) Configuration() map[string]interface{} {
-	return map[string]interface{}{}
+	if !ff.upload {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"upload": true,
+	}
 }
 
 func (ff *FormFieldTextarea) Value() interface{} {
@@ -432,11 +445,13 @@ func (ff *FormFieldTextarea) MarshalJSON() ([]byte, error) {
 
 func (ff *FormFieldTextarea) UnmarshalJSON(data []byte) error {
 	x := struct {
-		Label         string                 `json:"label"`
-		Name          string                 `json:"name"`
-		Type          string                 `json:"type"`
-		Configuration map[string]interface{} `json:"configuration"`
-		Value         string                 `json:"value"`
+		Label         string `json:"label"`
+		Name          string `json:"name"`
+		Type          string `json:"type"`
+		Configuration struct {
+			Upload bool `json:"upload"`
+		} `json:"configuration"`
+		Value string `json:"value"`
 	}{}
 
 	if err := json.Unmarshal(data, &x); err != nil {
@@ -445,6 +460,7 @@ func (ff *FormFieldTextarea) UnmarshalJSON(data []byte) error {
 
 	ff.baseFormField = newBaseFormField(x.Label, x.Name, x.Type)
 	ff.value = x.Value
+	ff.upload = x.Configuration.Upload
 
 	return nil
 }
@@ -117,6 +117,21 @@ func TestFormFieldTextarea_UnmarshalJSON(t *testing.T) {
 	assertFormFieldEqual(t, expected, &got)
 }
 
+func TestFormFieldTextarea_upload(t *testing.T) {
+	expected := NewFormFieldTextarea("label", "name", "")
+	expected.SetUpload(true)
+	assert.Equal(t, map[string]interface{}{"upload": true}, expected.Configuration())
+
+	data, err := json.Marshal(&expected)
+	require.NoError(t, err)
+
+	var got FormFieldTextarea
+
+	require.NoError(t, json.Unmarshal(data, &got))
+
+	assertFormFieldEqual(t, expected, &got)
+}
+
 func TestFormFieldHidden_UnmarshalJSON(t *testing.T) {
 	expected := NewFormFieldHidden("label", "name")
 
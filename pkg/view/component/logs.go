@@ -13,6 +13,9 @@ type LogsConfig struct {
 	Namespace  string   `json:"namespace,omitempty"`
 	Name       string   `json:"name,omitempty"`
 	Containers []string `json:"containers,omitempty"`
+	// Kind is set when logs are aggregated from the pods of a workload or
+	// service, e.g. "deployment". It is empty for a pod.
+	Kind string `json:"kind,omitempty"`
 }
 
 type Logs struct {
@@ -12,6 +12,9 @@ type PortForwardState struct {
 	IsForwarded   bool   `json:"isForwarded,omitempty"`
 	Port          int    `json:"port,omitempty"`
 	ID            string `json:"id,omitempty"`
+	// ProxyURL is the path the forwarded port can be opened at through
+	// the dash server.
+	ProxyURL string `json:"proxyURL,omitempty"`
 }
 
 // Port is a component for a port
@@ -17,8 +17,9 @@ type AdjList map[string][]Edge
 
 // Edge represents a directed edge in a graph
 type Edge struct {
-	Node string   `json:"node"`
-	Type EdgeType `json:"edge"`
+	Node  string   `json:"node"`
+	Type  EdgeType `json:"edge"`
+	Label string   `json:"label,omitempty"`
 }
 
 // Add adds a directed edge to the adjacency list
@@ -60,6 +61,7 @@ type Nodes map[string]Node
 // IsNetwork is a hint to the layout engine.
 type Node struct {
 	Name       string      `json:"name,omitempty"`
+	Namespace  string      `json:"namespace,omitempty"`
 	APIVersion string      `json:"apiVersion,omitempty"`
 	Kind       string      `json:"kind,omitempty"`
 	Status     NodeStatus  `json:"status,omitempty"`
@@ -93,6 +95,11 @@ func NewResourceViewer(title string) *ResourceViewer {
 }
 
 func (rv *ResourceViewer) AddEdge(nodeID, childID string, edgeType EdgeType) error {
+	return rv.AddLabeledEdge(nodeID, childID, edgeType, "")
+}
+
+// AddLabeledEdge adds an edge with a label describing how the nodes are related.
+func (rv *ResourceViewer) AddLabeledEdge(nodeID, childID string, edgeType EdgeType, label string) error {
 	if _, ok := rv.Config.Nodes[childID]; !ok {
 		var nodeIDs []string
 		for k := range rv.Config.Nodes {
@@ -103,8 +110,9 @@ func (rv *ResourceViewer) AddEdge(nodeID, childID string, edgeType EdgeType) err
 	}
 
 	edge := Edge{
-		Node: childID,
-		Type: edgeType,
+		Node:  childID,
+		Type:  edgeType,
+		Label: label,
 	}
 	rv.Config.Edges[nodeID] = append(rv.Config.Edges[nodeID], edge)
 
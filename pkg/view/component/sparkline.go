@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import "encoding/json"
+
+// Sparkline is a component representing a small chart of recent values.
+type Sparkline struct {
+	base
+	Config SparklineConfig `json:"config"`
+}
+
+var _ Component = (*Sparkline)(nil)
+
+// SparklineConfig is the contents of Sparkline.
+type SparklineConfig struct {
+	// Label is the text displayed next to the chart, usually the current value.
+	Label string `json:"label"`
+	// Values are the values in the chart ordered from oldest to newest.
+	Values []float64 `json:"values"`
+}
+
+// NewSparkline creates a sparkline component.
+func NewSparkline(label string, values []float64) *Sparkline {
+	if values == nil {
+		values = []float64{}
+	}
+
+	return &Sparkline{
+		base: newBase(typeSparkline, nil),
+		Config: SparklineConfig{
+			Label:  label,
+			Values: values,
+		},
+	}
+}
+
+type sparklineMarshal Sparkline
+
+// MarshalJSON implements json.Marshaler.
+func (s *Sparkline) MarshalJSON() ([]byte, error) {
+	m := sparklineMarshal(*s)
+	m.Metadata.Type = typeSparkline
+	return json.Marshal(&m)
+}
+
+// LessThan returns true if this component's latest value is less than the argument supplied.
+func (s *Sparkline) LessThan(i interface{}) bool {
+	v, ok := i.(*Sparkline)
+	if !ok {
+		return false
+	}
+
+	return lastValue(s.Config.Values) < lastValue(v.Config.Values)
+}
+
+func lastValue(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	return values[len(values)-1]
+}
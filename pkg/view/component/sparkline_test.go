@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Sparkline_Marshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Component
+		expected string
+	}{
+		{
+			name:  "general",
+			input: NewSparkline("150m", []float64{100, 150}),
+			expected: `
+            {
+                "metadata": {
+                  "type": "sparkline"
+                },
+                "config": {
+                  "label": "150m",
+                  "values": [100, 150]
+                }
+            }
+`,
+		},
+		{
+			name:  "no values",
+			input: NewSparkline("", nil),
+			expected: `
+            {
+                "metadata": {
+                  "type": "sparkline"
+                },
+                "config": {
+                  "label": "",
+                  "values": []
+                }
+            }
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := json.Marshal(tc.input)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+}
+
+func Test_Sparkline_LessThan(t *testing.T) {
+	s := NewSparkline("", []float64{10, 5})
+
+	assert.True(t, s.LessThan(NewSparkline("", []float64{1, 6})))
+	assert.False(t, s.LessThan(NewSparkline("", []float64{4})))
+	assert.False(t, s.LessThan(NewText("text")))
+}
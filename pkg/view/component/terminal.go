@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import "encoding/json"
+
+// Terminal is a component for an interactive shell in a pod's container.
+type Terminal struct {
+	base
+	Config TerminalConfig `json:"config"`
+}
+
+var _ Component = (*Terminal)(nil)
+
+// TerminalConfig is the contents of Terminal.
+type TerminalConfig struct {
+	// Namespace is the namespace of the pod.
+	Namespace string `json:"namespace"`
+	// Name is the name of the pod.
+	Name string `json:"name"`
+	// Containers are the containers a terminal can be opened in.
+	Containers []string `json:"containers"`
+	// Shells are the commands which can be used to start the terminal.
+	Shells []string `json:"shells"`
+}
+
+// NewTerminal creates a terminal component.
+func NewTerminal(namespace, name string, containers, shells []string) *Terminal {
+	if containers == nil {
+		containers = []string{}
+	}
+
+	if shells == nil {
+		shells = []string{}
+	}
+
+	return &Terminal{
+		base: newBase(typeTerminal, TitleFromString("Terminal")),
+		Config: TerminalConfig{
+			Namespace:  namespace,
+			Name:       name,
+			Containers: containers,
+			Shells:     shells,
+		},
+	}
+}
+
+type terminalMarshal Terminal
+
+// MarshalJSON implements json.Marshaler.
+func (t *Terminal) MarshalJSON() ([]byte, error) {
+	m := terminalMarshal(*t)
+	m.Metadata.Type = typeTerminal
+	return json.Marshal(&m)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Terminal_Marshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Component
+		expected string
+	}{
+		{
+			name:  "general",
+			input: NewTerminal("default", "pod", []string{"nginx"}, []string{"/bin/sh"}),
+			expected: `
+            {
+                "metadata": {
+                  "type": "terminal",
+                  "title": [{"metadata": {"type": "text"}, "config": {"value": "Terminal"}}]
+                },
+                "config": {
+                  "namespace": "default",
+                  "name": "pod",
+                  "containers": ["nginx"],
+                  "shells": ["/bin/sh"]
+                }
+            }
+`,
+		},
+		{
+			name:  "no containers",
+			input: NewTerminal("default", "pod", nil, nil),
+			expected: `
+            {
+                "metadata": {
+                  "type": "terminal",
+                  "title": [{"metadata": {"type": "text"}, "config": {"value": "Terminal"}}]
+                },
+                "config": {
+                  "namespace": "default",
+                  "name": "pod",
+                  "containers": [],
+                  "shells": []
+                }
+            }
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := json.Marshal(tc.input)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Timeline is a component for events in chronological order.
+type Timeline struct {
+	base
+	Config TimelineConfig `json:"config"`
+}
+
+var _ Component = (*Timeline)(nil)
+
+// TimelineConfig is the contents of Timeline.
+type TimelineConfig struct {
+	// Events are the events in chronological order.
+	Events []TimelineEvent `json:"events"`
+	// Types are the distinct event types which can be filtered.
+	Types []string `json:"types"`
+	// Reasons are the distinct event reasons which can be filtered.
+	Reasons []string `json:"reasons"`
+}
+
+// TimelineEvent is an event in a timeline.
+type TimelineEvent struct {
+	// Kind is the kind of the object the event is for.
+	Kind string `json:"kind"`
+	// Name is the name of the object the event is for.
+	Name string `json:"name"`
+	// Path is the path of the object the event is for, if it can be linked.
+	Path string `json:"path,omitempty"`
+	// Type is the event type, e.g. Normal or Warning.
+	Type string `json:"type"`
+	// Reason is the event reason.
+	Reason string `json:"reason"`
+	// Message is the event message.
+	Message string `json:"message"`
+	// Source is the component which reported the event.
+	Source string `json:"source,omitempty"`
+	// Count is the number of times the event occurred.
+	Count int32 `json:"count"`
+	// FirstSeen is when the event first occurred as a unix timestamp.
+	FirstSeen int64 `json:"firstSeen"`
+	// LastSeen is when the event last occurred as a unix timestamp.
+	LastSeen int64 `json:"lastSeen"`
+}
+
+// NewTimeline creates a timeline component. The types and reasons which can
+// be filtered are collected from the events.
+func NewTimeline(title string, events []TimelineEvent) *Timeline {
+	if events == nil {
+		events = []TimelineEvent{}
+	}
+
+	typeSet := make(map[string]bool)
+	reasonSet := make(map[string]bool)
+	for _, event := range events {
+		typeSet[event.Type] = true
+		reasonSet[event.Reason] = true
+	}
+
+	return &Timeline{
+		base: newBase(typeTimeline, TitleFromString(title)),
+		Config: TimelineConfig{
+			Events:  events,
+			Types:   sortedKeys(typeSet),
+			Reasons: sortedKeys(reasonSet),
+		},
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := []string{}
+	for k := range m {
+		if k != "" {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+type timelineMarshal Timeline
+
+// MarshalJSON implements json.Marshaler.
+func (t *Timeline) MarshalJSON() ([]byte, error) {
+	m := timelineMarshal(*t)
+	m.Metadata.Type = typeTimeline
+	return json.Marshal(&m)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Timeline_Marshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    Component
+		expected string
+	}{
+		{
+			name: "general",
+			input: NewTimeline("Events", []TimelineEvent{
+				{Kind: "Pod", Name: "pod", Type: "Warning", Reason: "BackOff", Message: "back-off", Count: 2, FirstSeen: 1, LastSeen: 2},
+				{Kind: "ReplicaSet", Name: "rs", Type: "Normal", Reason: "SuccessfulCreate", Message: "created", Count: 1, FirstSeen: 3, LastSeen: 3},
+				{Kind: "Pod", Name: "pod", Type: "Warning", Reason: "BackOff", Message: "back-off again", Count: 1, FirstSeen: 4, LastSeen: 4},
+			}),
+			expected: `
+            {
+                "metadata": {
+                  "type": "timeline",
+                  "title": [{"metadata": {"type": "text"}, "config": {"value": "Events"}}]
+                },
+                "config": {
+                  "events": [
+                    {"kind": "Pod", "name": "pod", "type": "Warning", "reason": "BackOff", "message": "back-off", "count": 2, "firstSeen": 1, "lastSeen": 2},
+                    {"kind": "ReplicaSet", "name": "rs", "type": "Normal", "reason": "SuccessfulCreate", "message": "created", "count": 1, "firstSeen": 3, "lastSeen": 3},
+                    {"kind": "Pod", "name": "pod", "type": "Warning", "reason": "BackOff", "message": "back-off again", "count": 1, "firstSeen": 4, "lastSeen": 4}
+                  ],
+                  "types": ["Normal", "Warning"],
+                  "reasons": ["BackOff", "SuccessfulCreate"]
+                }
+            }
+`,
+		},
+		{
+			name:  "no events",
+			input: NewTimeline("Events", nil),
+			expected: `
+            {
+                "metadata": {
+                  "type": "timeline",
+                  "title": [{"metadata": {"type": "text"}, "config": {"value": "Events"}}]
+                },
+                "config": {
+                  "events": [],
+                  "types": [],
+                  "reasons": []
+                }
+            }
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := json.Marshal(tc.input)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+}
@@ -232,6 +232,15 @@ func Test_unmarshal(t *testing.T) {
 				base: newBase(typeSelectors, nil),
 			},
 		},
+		{
+			name:       "sparkline",
+			configFile: "config_sparkline.json",
+			objectType: "sparkline",
+			expected: &Sparkline{
+				Config: SparklineConfig{Label: "150m", Values: []float64{100, 150}},
+				base:   newBase(typeSparkline, nil),
+			},
+		},
 		{
 			name:       "summary",
 			configFile: "config_summary.json",
@@ -316,6 +325,20 @@ func Test_unmarshal(t *testing.T) {
 				base: newBase(typeTable, nil),
 			},
 		},
+		{
+			name:       "terminal",
+			configFile: "config_terminal.json",
+			objectType: "terminal",
+			expected: &Terminal{
+				Config: TerminalConfig{
+					Namespace:  "default",
+					Name:       "pod",
+					Containers: []string{"nginx"},
+					Shells:     []string{"/bin/sh"},
+				},
+				base: newBase(typeTerminal, nil),
+			},
+		},
 		{
 			name:       "text",
 			configFile: "config_text.json",
@@ -325,6 +348,32 @@ func Test_unmarshal(t *testing.T) {
 				base:   newBase(typeText, nil),
 			},
 		},
+		{
+			name:       "timeline",
+			configFile: "config_timeline.json",
+			objectType: "timeline",
+			expected: &Timeline{
+				Config: TimelineConfig{
+					Events: []TimelineEvent{
+						{
+							Kind:      "Pod",
+							Name:      "pod",
+							Path:      "/content/overview/namespace/default/workloads/pods/pod",
+							Type:      "Warning",
+							Reason:    "BackOff",
+							Message:   "Back-off restarting failed container",
+							Source:    "kubelet",
+							Count:     3,
+							FirstSeen: 1548198349,
+							LastSeen:  1548198409,
+						},
+					},
+					Types:   []string{"Warning"},
+					Reasons: []string{"BackOff"},
+				},
+				base: newBase(typeTimeline, nil),
+			},
+		},
 		{
 			name:       "timestamp",
 			configFile: "config_timestamp.json",
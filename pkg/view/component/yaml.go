@@ -16,8 +16,20 @@ import (
 	k8sJSON "k8s.io/apimachinery/pkg/runtime/serializer/json"
 )
 
+// YAMLField documents a field found in YAML data.
+type YAMLField struct {
+	// Path is the dotted path to the field. Array items are denoted
+	// with `[]` and map values with `*`.
+	Path        string `json:"path"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+}
+
 type YAMLConfig struct {
-	Data string `json:"data,omitempty"`
+	Data   string      `json:"data,omitempty"`
+	Fields []YAMLField `json:"fields,omitempty"`
 }
 
 type YAML struct {
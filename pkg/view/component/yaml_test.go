@@ -33,6 +33,25 @@ func Test_YAML_Marshal(t *testing.T) {
 			},
 			expectedPath: "yaml1.json",
 		},
+		{
+			name: "with fields",
+			input: &YAML{
+				Config: YAMLConfig{
+					Data: "---\nfoo: bar",
+					Fields: []YAMLField{
+						{
+							Path:        "foo",
+							Type:        "string",
+							Description: "Foo is deprecated.",
+							Required:    true,
+							Deprecated:  true,
+						},
+					},
+				},
+				base: newBase(typeYAML, nil),
+			},
+			expectedPath: "yaml_fields.json",
+		},
 	}
 
 	for _, tc := range cases {
@@ -12,6 +12,8 @@ import { NamespaceComponent } from './components/namespace/namespace.component';
 import { PageNotFoundComponent } from './components/page-not-found/page-not-found.component';
 import { InputFilterComponent } from './components/input-filter/input-filter.component';
 import { NotifierComponent } from './components/notifier/notifier.component';
+import { ObjectSearchComponent } from './components/object-search/object-search.component';
+import { FilterExpressionComponent } from './components/filter-expression/filter-expression.component';
 import { NavigationComponent } from './components/navigation/navigation.component';
 import { ContextSelectorComponent } from './modules/overview/components/context-selector/context-selector.component';
 import { DefaultPipe } from './modules/overview/pipes/default.pipe';
@@ -32,6 +34,8 @@ describe('AppComponent', () => {
         NamespaceComponent,
         PageNotFoundComponent,
         InputFilterComponent,
+        ObjectSearchComponent,
+        FilterExpressionComponent,
         NotifierComponent,
         NavigationComponent,
         ContextSelectorComponent,
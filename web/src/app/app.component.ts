@@ -4,7 +4,9 @@
 
 import { Component, ElementRef, OnInit, ViewChild } from '@angular/core';
 import { ContentStreamService } from './services/content-stream/content-stream.service';
+import { ThemeService } from './services/theme/theme.service';
 import { Navigation } from './models/navigation';
+import { Preferences } from './models/preferences';
 
 @Component({
   selector: 'app-root',
@@ -16,11 +18,22 @@ export class AppComponent implements OnInit {
   navigation: Navigation;
   previousUrl: string;
 
-  constructor(private contentStreamService: ContentStreamService) {}
+  constructor(
+    private contentStreamService: ContentStreamService,
+    private themeService: ThemeService
+  ) {}
 
   ngOnInit(): void {
     this.contentStreamService.navigation.subscribe((navigation: Navigation) => {
       this.navigation = navigation;
     });
+
+    this.contentStreamService.preferences.subscribe(
+      (preferences: Preferences) => {
+        if (preferences) {
+          this.themeService.apply(preferences.theme);
+        }
+      }
+    );
   }
 }
@@ -17,6 +17,8 @@ import { InputFilterComponent } from './components/input-filter/input-filter.com
 import { NamespaceComponent } from './components/namespace/namespace.component';
 import { NavigationComponent } from './components/navigation/navigation.component';
 import { NotifierComponent } from './components/notifier/notifier.component';
+import { ObjectSearchComponent } from './components/object-search/object-search.component';
+import { FilterExpressionComponent } from './components/filter-expression/filter-expression.component';
 import { PageNotFoundComponent } from './components/page-not-found/page-not-found.component';
 import { OverviewModule } from './modules/overview/overview.module';
 import { MarkdownModule, MarkedOptions } from 'ngx-markdown';
@@ -34,6 +36,8 @@ export class UnstripTrailingSlashLocation extends Location {
     NamespaceComponent,
     PageNotFoundComponent,
     InputFilterComponent,
+    ObjectSearchComponent,
+    FilterExpressionComponent,
     NotifierComponent,
     NavigationComponent,
   ],
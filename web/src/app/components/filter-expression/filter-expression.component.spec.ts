@@ -0,0 +1,121 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { async, ComponentFixture, TestBed } from '@angular/core/testing';
+import { FormsModule } from '@angular/forms';
+import { HttpErrorResponse } from '@angular/common/http';
+import { By } from '@angular/platform-browser';
+import { BehaviorSubject, of, throwError } from 'rxjs';
+import { FilterExpressionComponent } from './filter-expression.component';
+import {
+  FilterExpressionService,
+  SavedFilter,
+} from 'src/app/services/filter-expression/filter-expression.service';
+
+describe('FilterExpressionComponent', () => {
+  let component: FilterExpressionComponent;
+  let fixture: ComponentFixture<FilterExpressionComponent>;
+  let filterExpressionStub;
+
+  beforeEach(async(() => {
+    filterExpressionStub = {
+      expression: new BehaviorSubject<string>(''),
+      saved: new BehaviorSubject<SavedFilter[]>([]),
+      validate: jasmine.createSpy('validate'),
+      apply: jasmine.createSpy('apply'),
+      clear: jasmine.createSpy('clear'),
+      save: jasmine.createSpy('save'),
+      remove: jasmine.createSpy('remove'),
+    };
+
+    TestBed.configureTestingModule({
+      imports: [FormsModule],
+      declarations: [FilterExpressionComponent],
+      providers: [
+        { provide: FilterExpressionService, useValue: filterExpressionStub },
+      ],
+    }).compileComponents();
+  }));
+
+  beforeEach(() => {
+    fixture = TestBed.createComponent(FilterExpressionComponent);
+    component = fixture.componentInstance;
+    fixture.detectChanges();
+  });
+
+  it('should create', () => {
+    expect(component).toBeDefined();
+  });
+
+  it('should show the expression from the URL', () => {
+    filterExpressionStub.expression.next('age>1h');
+    expect(component.inputValue).toBe('age>1h');
+    expect(component.applied).toBe('age>1h');
+  });
+
+  it('should apply a valid expression', () => {
+    filterExpressionStub.validate.and.returnValue(
+      of({ expression: 'age>1h' })
+    );
+    component.inputValue = ' age>1h ';
+    component.onEnter();
+    expect(filterExpressionStub.validate).toHaveBeenCalledWith('age>1h');
+    expect(filterExpressionStub.apply).toHaveBeenCalledWith('age>1h');
+    expect(component.error).toBe('');
+  });
+
+  it('should show the error for an invalid expression', () => {
+    filterExpressionStub.validate.and.returnValue(
+      throwError(
+        new HttpErrorResponse({
+          status: 400,
+          error: { error: { code: 400, message: 'unknown status "bad"' } },
+        })
+      )
+    );
+    component.inputValue = 'status=bad';
+    component.onEnter();
+    expect(filterExpressionStub.apply).not.toHaveBeenCalled();
+    expect(component.error).toBe('unknown status "bad"');
+
+    fixture.detectChanges();
+    const errorElement: HTMLElement = fixture.debugElement.query(
+      By.css('.filter-expression-error')
+    ).nativeElement;
+    expect(errorElement.textContent).toMatch(/unknown status/);
+  });
+
+  it('should clear the expression when the input is blank', () => {
+    component.inputValue = ' ';
+    component.onEnter();
+    expect(filterExpressionStub.validate).not.toHaveBeenCalled();
+    expect(filterExpressionStub.clear).toHaveBeenCalled();
+  });
+
+  it('should list, apply and save filters', () => {
+    filterExpressionStub.saved.next([
+      { name: 'old', expression: 'age>7d' },
+      { name: 'failed', expression: 'status=error' },
+    ]);
+    component.showSaved = true;
+    fixture.detectChanges();
+
+    const items = fixture.debugElement.queryAll(
+      By.css('.filter-expression-saved-text')
+    );
+    expect(items.length).toBe(2);
+
+    items[1].triggerEventHandler('click', null);
+    expect(filterExpressionStub.apply).toHaveBeenCalledWith('status=error');
+
+    filterExpressionStub.expression.next('status=error');
+    component.saveName = 'errors';
+    component.save();
+    expect(filterExpressionStub.save).toHaveBeenCalledWith(
+      'errors',
+      'status=error'
+    );
+    expect(component.saveName).toBe('');
+  });
+});
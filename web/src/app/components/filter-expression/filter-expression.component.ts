@@ -0,0 +1,99 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { Component, ElementRef, HostListener, OnInit } from '@angular/core';
+import { HttpErrorResponse } from '@angular/common/http';
+import {
+  FilterExpressionService,
+  SavedFilter,
+} from '../../services/filter-expression/filter-expression.service';
+
+@Component({
+  selector: 'app-filter-expression',
+  templateUrl: './filter-expression.component.html',
+  styleUrls: ['./filter-expression.component.scss'],
+})
+export class FilterExpressionComponent implements OnInit {
+  inputValue = '';
+  saveName = '';
+  error = '';
+  showSaved = false;
+  applied = '';
+  saved: SavedFilter[] = [];
+
+  constructor(
+    private eRef: ElementRef,
+    private filterExpressionService: FilterExpressionService
+  ) {}
+
+  ngOnInit() {
+    this.filterExpressionService.expression.subscribe(expression => {
+      this.applied = expression;
+      this.inputValue = expression;
+    });
+    this.filterExpressionService.saved.subscribe(saved => {
+      this.saved = saved;
+    });
+  }
+
+  @HostListener('document:click', ['$event'])
+  outsideClick(event) {
+    if (!this.eRef.nativeElement.contains(event.target)) {
+      this.showSaved = false;
+    }
+  }
+
+  toggleSaved() {
+    this.showSaved = !this.showSaved;
+  }
+
+  identifySaved(index: number, item: SavedFilter): string {
+    return item.name;
+  }
+
+  onEnter() {
+    const expression = this.inputValue.trim();
+    if (expression === '') {
+      this.clear();
+      return;
+    }
+
+    this.filterExpressionService.validate(expression).subscribe(
+      resp => {
+        this.error = '';
+        this.filterExpressionService.apply(resp.expression);
+      },
+      (err: HttpErrorResponse) => {
+        this.error =
+          err.error && err.error.error
+            ? err.error.error.message
+            : 'Invalid filter expression';
+      }
+    );
+  }
+
+  apply(filter: SavedFilter) {
+    this.error = '';
+    this.showSaved = false;
+    this.filterExpressionService.apply(filter.expression);
+  }
+
+  save() {
+    const name = this.saveName.trim();
+    if (name === '' || this.applied === '') {
+      return;
+    }
+    this.filterExpressionService.save(name, this.applied);
+    this.saveName = '';
+  }
+
+  remove(filter: SavedFilter) {
+    this.filterExpressionService.remove(filter.name);
+  }
+
+  clear() {
+    this.error = '';
+    this.filterExpressionService.clear();
+  }
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { async, ComponentFixture, TestBed } from '@angular/core/testing';
+import { FormsModule } from '@angular/forms';
+import { Router } from '@angular/router';
+import { ObjectSearchComponent } from './object-search.component';
+
+describe('ObjectSearchComponent', () => {
+  let component: ObjectSearchComponent;
+  let fixture: ComponentFixture<ObjectSearchComponent>;
+  const routerSpy = jasmine.createSpyObj('Router', ['navigate']);
+
+  beforeEach(async(() => {
+    TestBed.configureTestingModule({
+      imports: [FormsModule],
+      declarations: [ObjectSearchComponent],
+      providers: [{ provide: Router, useValue: routerSpy }],
+    }).compileComponents();
+  }));
+
+  beforeEach(() => {
+    routerSpy.navigate.calls.reset();
+    fixture = TestBed.createComponent(ObjectSearchComponent);
+    component = fixture.componentInstance;
+    fixture.detectChanges();
+  });
+
+  it('should create', () => {
+    expect(component).toBeDefined();
+  });
+
+  it('should navigate to the search results', () => {
+    component.inputValue = ' label:app=web ';
+    component.onEnter();
+    expect(routerSpy.navigate).toHaveBeenCalledWith([
+      'content',
+      'cluster-overview',
+      'search',
+      'label:app=web',
+    ]);
+  });
+
+  it('should navigate to the search usage without a query', () => {
+    component.inputValue = '';
+    component.onEnter();
+    expect(routerSpy.navigate).toHaveBeenCalledWith([
+      'content',
+      'cluster-overview',
+      'search',
+    ]);
+  });
+});
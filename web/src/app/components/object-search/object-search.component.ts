@@ -0,0 +1,29 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { Component } from '@angular/core';
+import { Router } from '@angular/router';
+
+export const searchContentPath = ['content', 'cluster-overview', 'search'];
+
+@Component({
+  selector: 'app-object-search',
+  templateUrl: './object-search.component.html',
+  styleUrls: ['./object-search.component.scss'],
+})
+export class ObjectSearchComponent {
+  inputValue = '';
+
+  constructor(private router: Router) {}
+
+  onEnter() {
+    const query = this.inputValue.trim();
+    if (query === '') {
+      this.router.navigate(searchContentPath);
+      return;
+    }
+
+    this.router.navigate([...searchContentPath, query]);
+  }
+}
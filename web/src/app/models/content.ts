@@ -161,10 +161,12 @@ export interface QuadrantView extends View {
 export interface Edge {
   node: string;
   edge: string;
+  label?: string;
 }
 
 export interface Node {
   name: string;
+  namespace?: string;
   apiVersion: string;
   kind: string;
   status: string;
@@ -233,6 +235,13 @@ export interface TableColumn {
   accessor: string;
 }
 
+export interface SparklineView extends View {
+  config: {
+    label: string;
+    values: number[];
+  };
+}
+
 export interface TextView extends View {
   config: {
     value: string;
@@ -246,9 +255,18 @@ export interface TimestampView extends View {
   };
 }
 
+export interface YAMLField {
+  path: string;
+  type?: string;
+  description?: string;
+  required?: boolean;
+  deprecated?: boolean;
+}
+
 export interface YAMLView extends View {
   config: {
     data: string;
+    fields?: YAMLField[];
   };
 }
 
@@ -257,12 +275,47 @@ export interface LogsView extends View {
     namespace: string;
     name: string;
     containers: string[];
+    kind?: string;
+  };
+}
+
+export interface TerminalView extends View {
+  config: {
+    namespace: string;
+    name: string;
+    containers: string[];
+    shells: string[];
+  };
+}
+
+export interface TimelineEvent {
+  kind: string;
+  name: string;
+  path?: string;
+  type: string;
+  reason: string;
+  message: string;
+  source?: string;
+  count: number;
+  firstSeen: number;
+  lastSeen: number;
+}
+
+export interface TimelineView extends View {
+  config: {
+    events: TimelineEvent[];
+    types: string[];
+    reasons: string[];
   };
 }
 
 export interface LogEntry {
-  timestamp: string; // TODO: should be Date
+  timestamp?: string; // TODO: should be Date
   message: string;
+  level?: string;
+  fields?: { [key: string]: string };
+  pod?: string;
+  container?: string;
 }
 
 export interface LogResponse {
@@ -282,6 +335,7 @@ export interface Port extends View {
       isForwarded: boolean;
       isForwardable: boolean;
       port: number;
+      proxyURL: string;
     }>;
   };
 }
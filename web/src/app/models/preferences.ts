@@ -0,0 +1,24 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+export interface ContextPreferences {
+  namespaces?: string[];
+}
+
+export interface Refresh {
+  content?: string;
+  navigation?: string;
+  namespaces?: string;
+}
+
+export interface Preferences {
+  version: number;
+  defaultContext?: string;
+  defaultNamespace?: string;
+  contexts?: { [name: string]: ContextPreferences };
+  refresh?: Refresh;
+  modules?: string[];
+  pluginDirs?: string[];
+  theme?: string;
+  hiddenNavigation?: string[];
+}
@@ -33,4 +33,29 @@ describe('FormComponent', () => {
   it('should create', () => {
     expect(component).toBeTruthy();
   });
+
+  it('should fill a textarea from an uploaded file', done => {
+    const field = {
+      configuration: { upload: true },
+      label: 'Snippet',
+      name: 'snippet',
+      type: 'textarea',
+      value: '',
+    };
+    component.form = { fields: [field] };
+    component.ngOnInit();
+
+    const file = new Blob(['kind: Config'], { type: 'text/plain' });
+    const event = ({ target: { files: [file] } } as unknown) as Event;
+    const read = Promise.resolve('kind: Config');
+    spyOn(component, 'readFile').and.returnValue(read);
+
+    component.onFileSelected(field, event);
+
+    expect(component.readFile).toHaveBeenCalledWith(file);
+    read.then(() => {
+      expect(component.formGroup.get('snippet').value).toEqual('kind: Config');
+      done();
+    });
+  });
 });
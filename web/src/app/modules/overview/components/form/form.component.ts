@@ -62,4 +62,24 @@ export class FormComponent implements OnInit {
   fieldChoices(field: ActionField) {
     return field.configuration.choices as Choice[];
   }
+
+  onFileSelected(field: ActionField, event: Event) {
+    const files = (event.target as HTMLInputElement).files;
+    if (!files || files.length === 0) {
+      return;
+    }
+
+    this.readFile(files[0]).then(text => {
+      this.formGroup.get(field.name).setValue(text);
+    });
+  }
+
+  readFile(file: Blob): Promise<string> {
+    return new Promise((resolve, reject) => {
+      const reader = new FileReader();
+      reader.onload = () => resolve(reader.result as string);
+      reader.onerror = () => reject(reader.error);
+      reader.readAsText(file);
+    });
+  }
 }
@@ -1,16 +1,9 @@
 // Copyright (c) 2019 VMware, Inc. All Rights Reserved.
 // SPDX-License-Identifier: Apache-2.0
 //
-import { HttpClient } from '@angular/common/http';
 import { HttpTestingController } from '@angular/common/http/testing';
 import { DebugElement } from '@angular/core';
-import {
-  async,
-  ComponentFixture,
-  discardPeriodicTasks,
-  fakeAsync,
-  TestBed,
-} from '@angular/core/testing';
+import { async, ComponentFixture, TestBed } from '@angular/core/testing';
 import { By } from '@angular/platform-browser';
 import _ from 'lodash';
 import { LogEntry, LogsView } from 'src/app/models/content';
@@ -36,6 +29,16 @@ function createTestLogsView(containers: string[]): LogsView {
   };
 }
 
+function createFakeEventSource() {
+  const listeners: { [type: string]: (event: MessageEvent) => void } = {};
+  return {
+    listeners,
+    addEventListener: (type: string, listener: (event: MessageEvent) => void) =>
+      (listeners[type] = listener),
+    close: jasmine.createSpy('close'),
+  };
+}
+
 function createRandomLogEntry(): LogEntry {
   return {
     timestamp: '2019-05-06T18:59:06.554540433Z',
@@ -47,7 +50,6 @@ describe('LogsComponent <-> PodsLogsService', () => {
   let component: LogsComponent;
   let fixture: ComponentFixture<LogsComponent>;
   let service: PodLogsService;
-  let httpClient: HttpClient;
   let httpTestingController: HttpTestingController;
 
   beforeEach(async(() => {
@@ -62,7 +64,6 @@ describe('LogsComponent <-> PodsLogsService', () => {
     component = fixture.componentInstance;
     fixture.detectChanges();
     service = TestBed.get(PodLogsService);
-    httpClient = TestBed.get(HttpClient);
     httpTestingController = TestBed.get(HttpTestingController);
   });
 
@@ -91,7 +92,17 @@ describe('LogsComponent <-> PodsLogsService', () => {
     );
   });
 
-  it('should create new streams when choosing between containers', fakeAsync(() => {
+  it('should create new streams when choosing between containers', () => {
+    const eventSources = [];
+    const createEventSource = spyOn(
+      service,
+      'createEventSource'
+    ).and.callFake(() => {
+      const eventSource = createFakeEventSource();
+      eventSources.push(eventSource);
+      return eventSource;
+    });
+
     expect(component.selectedContainer).toBe('');
     expect(component.containerLogs.length).toBe(0);
     expect(component.shouldDisplayTimestamp).toBe(true);
@@ -120,19 +131,25 @@ describe('LogsComponent <-> PodsLogsService', () => {
     selectElement.dispatchEvent(new Event('change'));
 
     expect(component.selectedContainer).toBe('containerB');
-
-    let req = httpTestingController.expectOne(
-      `${API_BASE}/api/v1/content/overview/namespace/default/logs/pod/cartpod/container/containerB`
-    );
-
-    expect(req.request.method).toBe('GET');
-
-    let testLogEntries: LogEntry[] = [
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageA' },
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageB' },
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageC' },
-    ];
-    req.flush({ entries: testLogEntries });
+    expect(createEventSource).toHaveBeenCalledWith(
+      `${API_BASE}/api/v1/content/overview/namespace/default/logs/pod/cartpod/container/containerB/stream?follow=true&tailLines=100`
+    );
+
+    eventSources[0].listeners.logs({
+      data: JSON.stringify({
+        entries: [
+          { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageA' },
+          { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageB' },
+        ],
+      }),
+    } as MessageEvent);
+    eventSources[0].listeners.logs({
+      data: JSON.stringify({
+        entries: [
+          { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageC' },
+        ],
+      }),
+    } as MessageEvent);
     fixture.detectChanges();
 
     expect(component.containerLogs).toEqual([
@@ -160,48 +177,138 @@ describe('LogsComponent <-> PodsLogsService', () => {
 
     expect(component.selectedContainer).toBe('containerC');
     expect(component.containerLogs).toEqual([]);
+    expect(eventSources[0].close).toHaveBeenCalled();
+    expect(createEventSource).toHaveBeenCalledWith(
+      `${API_BASE}/api/v1/content/overview/namespace/default/logs/pod/cartpod/container/containerC/stream?follow=true&tailLines=100`
+    );
+
+    eventSources[1].listeners.logs({
+      data: JSON.stringify({
+        entries: [
+          { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageD' },
+        ],
+      }),
+    } as MessageEvent);
+    fixture.detectChanges();
 
-    req = httpTestingController.expectOne(
-      `${API_BASE}/api/v1/content/overview/namespace/default/logs/pod/cartpod/container/containerC`
+    logEntriesDebugElement = fixture.debugElement.queryAll(
+      By.css('.container-log')
     );
+    expect(logEntriesDebugElement.length).toBe(1);
+    expect(logEntriesDebugElement[0].nativeElement.textContent).toMatch(
+      /messageD/
+    );
+  });
 
-    expect(req.request.method).toBe('GET');
-
-    testLogEntries = [
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageD' },
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageE' },
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageF' },
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageG' },
-    ];
-    req.flush({ entries: testLogEntries });
+  it('should stream aggregated logs for workloads', () => {
+    const eventSource = createFakeEventSource();
+    const createEventSource = spyOn(
+      service,
+      'createEventSource'
+    ).and.returnValue(eventSource);
+
+    component.view = createTestLogsView(['containerA']);
+    component.view.config.name = 'cart';
+    component.view.config.kind = 'deployment';
+    component.ngOnInit();
     fixture.detectChanges();
 
-    expect(component.containerLogs).toEqual([
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageD' },
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageE' },
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageF' },
-      { timestamp: '2019-05-06T18:59:06.554540433Z', message: 'messageG' },
-    ]);
+    expect(component.selectedContainer).toBe('');
+    expect(createEventSource).toHaveBeenCalledWith(
+      `${API_BASE}/api/v1/content/overview/namespace/default/logs/deployment/cart/stream?follow=true&tailLines=100`
+    );
 
-    logEntriesDebugElement = fixture.debugElement.queryAll(
+    const selectOptionsDebugElements: DebugElement[] = fixture.debugElement.queryAll(
+      By.css('.container-select select > option')
+    );
+    expect(selectOptionsDebugElements.length).toBe(2);
+    expect(selectOptionsDebugElements[0].nativeElement.value).toBe('');
+
+    eventSource.listeners.logs({
+      data: JSON.stringify({
+        entries: [
+          {
+            timestamp: '2019-05-06T18:59:06.554540433Z',
+            message: 'messageA',
+            pod: 'cart-1',
+            container: 'containerA',
+          },
+        ],
+      }),
+    } as MessageEvent);
+    fixture.detectChanges();
+
+    const logEntriesDebugElement: DebugElement[] = fixture.debugElement.queryAll(
       By.css('.container-log')
     );
-    expect(logEntriesDebugElement.length).toBe(4);
+    expect(logEntriesDebugElement.length).toBe(1);
     expect(logEntriesDebugElement[0].nativeElement.textContent).toMatch(
-      /messageD/
+      /cart-1\/containerA\s+messageA/
     );
-    expect(logEntriesDebugElement[1].nativeElement.textContent).toMatch(
-      /messageE/
+
+    component.onContainerChange('containerA');
+
+    expect(eventSource.close).toHaveBeenCalled();
+    expect(createEventSource).toHaveBeenCalledWith(
+      `${API_BASE}/api/v1/content/overview/namespace/default/logs/deployment/cart/stream?follow=true&tailLines=100&container=containerA`
     );
-    expect(logEntriesDebugElement[2].nativeElement.textContent).toMatch(
-      /messageF/
+  });
+
+  it('should pass the query to the stream and download', () => {
+    const createEventSource = spyOn(
+      service,
+      'createEventSource'
+    ).and.callFake(() => createFakeEventSource());
+
+    component.view = createTestLogsView(['containerA']);
+    component.ngOnInit();
+    fixture.detectChanges();
+
+    expect(createEventSource).toHaveBeenCalledWith(
+      `${API_BASE}/api/v1/content/overview/namespace/default/logs/pod/cartpod/container/containerA/stream?follow=true&tailLines=100`
+    );
+
+    component.onFilterChange('GET /');
+    component.onLevelChange('warning', true);
+    component.onLevelChange('error', true);
+    component.onTimeRangeChange('', '2019-05-06T19:00');
+    fixture.detectChanges();
+
+    const untilTime = encodeURIComponent(
+      new Date('2019-05-06T19:00').toISOString()
     );
-    expect(logEntriesDebugElement[3].nativeElement.textContent).toMatch(
-      /messageG/
+    expect(createEventSource).toHaveBeenCalledWith(
+      `${API_BASE}/api/v1/content/overview/namespace/default/logs/pod/cartpod/container/containerA/stream?follow=false&filter=GET%20%2F&level=warning%2Cerror&untilTime=${untilTime}`
     );
 
-    discardPeriodicTasks();
-  }));
+    const download: HTMLAnchorElement = fixture.debugElement.query(
+      By.css('.log-download')
+    ).nativeElement;
+    expect(download.getAttribute('href')).toBe(
+      `${API_BASE}/api/v1/content/overview/namespace/default/logs/pod/cartpod/container/containerA/download?format=text&filter=GET%20%2F&level=warning%2Cerror&untilTime=${untilTime}`
+    );
+  });
+
+  it('should show levels and fields of structured entries', () => {
+    component.containerLogs = [
+      {
+        timestamp: '2019-05-06T18:59:06.554540433Z',
+        message: 'disk almost full',
+        level: 'warning',
+        fields: { used: '0.9' },
+      },
+    ];
+    fixture.detectChanges();
+
+    expect(
+      fixture.debugElement.query(By.css('.container-log-level')).nativeElement
+        .textContent
+    ).toMatch(/warning/);
+    expect(
+      fixture.debugElement.query(By.css('.container-log-fields')).nativeElement
+        .textContent
+    ).toMatch(/used="0.9"/);
+  });
 
   it('should allow user to toggle displaying timestamps', () => {
     component.shouldDisplayTimestamp = true;
@@ -13,8 +13,10 @@ import {
 } from '@angular/core';
 import { LogsView, LogEntry } from 'src/app/models/content';
 import {
+  LogQuery,
+  logLevels,
+  LogStream,
   PodLogsService,
-  PodLogsStreamer,
 } from 'src/app/services/pod-logs/pod-logs.service';
 
 @Component({
@@ -23,7 +25,7 @@ import {
   styleUrls: ['./logs.component.scss'],
 })
 export class LogsComponent implements OnInit, OnDestroy, AfterViewChecked {
-  private logStream: PodLogsStreamer;
+  private logStream: LogStream;
   private scrollToBottom = false;
 
   @Input() view: LogsView;
@@ -33,11 +35,15 @@ export class LogsComponent implements OnInit, OnDestroy, AfterViewChecked {
   selectedContainer = '';
   shouldDisplayTimestamp = true;
 
+  levels = logLevels;
+  query: LogQuery = {};
+
   constructor(private podLogsService: PodLogsService) {}
 
   ngOnInit() {
     if (this.view) {
       if (
+        !this.isAggregated() &&
         this.view.config.containers &&
         this.view.config.containers.length > 0
       ) {
@@ -49,36 +55,135 @@ export class LogsComponent implements OnInit, OnDestroy, AfterViewChecked {
 
   onContainerChange(containerSelection: string): void {
     this.selectedContainer = containerSelection;
-    if (this.logStream) {
-      this.containerLogs = [];
-      this.logStream.close();
-      this.logStream = null;
+    this.restartStream();
+  }
+
+  onFilterChange(filter: string): void {
+    this.query = { ...this.query, filter };
+    this.restartStream();
+  }
+
+  onRegexChange(regex: string): void {
+    this.query = { ...this.query, regex };
+    this.restartStream();
+  }
+
+  onLevelChange(level: string, selected: boolean): void {
+    const levels = (this.query.levels || []).filter(l => l !== level);
+    if (selected) {
+      levels.push(level);
     }
-    this.startStream();
+    this.query = { ...this.query, levels };
+    this.restartStream();
+  }
+
+  isLevelSelected(level: string): boolean {
+    return !!this.query.levels && this.query.levels.includes(level);
+  }
+
+  /**
+   * onTimeRangeChange sets the time range from datetime-local inputs. An
+   * empty value leaves that end of the range open.
+   */
+  onTimeRangeChange(since: string, until: string): void {
+    this.query = {
+      ...this.query,
+      sinceTime: toRFC3339(since),
+      untilTime: toRFC3339(until),
+    };
+    this.restartStream();
+  }
+
+  /**
+   * downloadUrl returns the URL the selected container's log is downloaded
+   * from with the current query. Aggregated logs can't be downloaded.
+   */
+  downloadUrl(): string {
+    if (this.isAggregated() || !this.view || !this.selectedContainer) {
+      return '';
+    }
+
+    const { namespace, name } = this.view.config;
+    return this.podLogsService.downloadUrl(
+      namespace,
+      name,
+      this.selectedContainer,
+      this.query
+    );
+  }
+
+  hasLevels(): boolean {
+    return this.containerLogs.some(entry => !!entry.level);
+  }
+
+  hasFields(): boolean {
+    return this.containerLogs.some(
+      entry => !!entry.fields && Object.keys(entry.fields).length > 0
+    );
+  }
+
+  formatFields(entry: LogEntry): string {
+    if (!entry.fields) {
+      return '';
+    }
+
+    return Object.keys(entry.fields)
+      .sort()
+      .map(key => `${key}=${JSON.stringify(entry.fields[key])}`)
+      .join(' ');
   }
 
   toggleTimestampDisplay(): void {
     this.shouldDisplayTimestamp = !this.shouldDisplayTimestamp;
   }
 
+  /**
+   * isAggregated returns true if the logs are aggregated from the pods of
+   * a workload or service.
+   */
+  isAggregated(): boolean {
+    return !!(this.view && this.view.config.kind);
+  }
+
   startStream() {
-    const namespace = this.view.config.namespace;
-    const pod = this.view.config.name;
+    const { namespace, name, kind } = this.view.config;
     const container = this.selectedContainer;
-    if (namespace && pod && container) {
+
+    if (kind) {
+      this.logStream = this.podLogsService.createAggregatedStream(
+        namespace,
+        kind,
+        name,
+        container,
+        this.query
+      );
+    } else if (namespace && name && container) {
       this.logStream = this.podLogsService.createStream(
         namespace,
-        pod,
-        container
+        name,
+        container,
+        this.query
       );
-      this.logStream.logEntries.subscribe((entries: LogEntry[]) => {
-        this.containerLogs = entries;
-      });
+    } else {
+      return;
+    }
+
+    this.logStream.logEntries.subscribe((entries: LogEntry[]) => {
+      this.containerLogs = entries;
+    });
+  }
+
+  private restartStream() {
+    if (this.logStream) {
+      this.containerLogs = [];
+      this.logStream.close();
+      this.logStream = null;
     }
+    this.startStream();
   }
 
   identifyLog(index: number, item: LogEntry) {
-    return `${item.timestamp}-${item.message}`;
+    return `${item.pod}-${item.container}-${item.timestamp}-${item.message}`;
   }
 
   // Note(marlon): to determine if we should continue tailing
@@ -112,3 +217,17 @@ export class LogsComponent implements OnInit, OnDestroy, AfterViewChecked {
     }
   }
 }
+
+// toRFC3339 converts a datetime-local input value to an RFC3339 time.
+function toRFC3339(value: string): string {
+  if (!value) {
+    return '';
+  }
+
+  const date = new Date(value);
+  if (isNaN(date.getTime())) {
+    return '';
+  }
+
+  return date.toISOString();
+}
@@ -108,8 +108,11 @@ export class PortsComponent implements OnChanges, OnDestroy {
     if (!port.config.state.isForwarded) {
       return;
     }
-    const localhostUrl = `http://localhost:${port.config.state.port}`;
-    window.open(localhostUrl, '_blank');
+    // the proxy is preferred since it goes through the dash server.
+    const url =
+      port.config.state.proxyURL ||
+      `http://localhost:${port.config.state.port}`;
+    window.open(url, '_blank');
   }
 
   ngOnDestroy() {
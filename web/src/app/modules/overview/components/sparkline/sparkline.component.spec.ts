@@ -0,0 +1,53 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { SimpleChange } from '@angular/core';
+import { async, ComponentFixture, TestBed } from '@angular/core/testing';
+import { SparklineView } from 'src/app/models/content';
+
+import { OverviewModule } from '../../overview.module';
+import { SparklineComponent } from './sparkline.component';
+
+describe('SparklineComponent', () => {
+  let component: SparklineComponent;
+  let fixture: ComponentFixture<SparklineComponent>;
+
+  beforeEach(async(() => {
+    TestBed.configureTestingModule({
+      imports: [OverviewModule],
+    }).compileComponents();
+  }));
+
+  beforeEach(() => {
+    fixture = TestBed.createComponent(SparklineComponent);
+    component = fixture.componentInstance;
+    fixture.detectChanges();
+  });
+
+  it('should create', () => {
+    expect(component).toBeTruthy();
+  });
+
+  it('renders the label and a line for the values', () => {
+    const view: SparklineView = {
+      metadata: { type: 'sparkline' },
+      config: { label: '20m', values: [10, 30, 20] },
+    };
+    component.view = view;
+    component.ngOnChanges({
+      view: new SimpleChange(undefined, view, true),
+    });
+    fixture.detectChanges();
+
+    const element: HTMLElement = fixture.nativeElement;
+    expect(element.querySelector('.sparkline-label').textContent).toBe('20m');
+    expect(element.querySelector('polyline').getAttribute('points')).toBe(
+      '0.0,16.0 30.0,0.0 60.0,8.0'
+    );
+  });
+
+  it('omits the chart with fewer than two values', () => {
+    expect(component.toPoints([5])).toBe('');
+  });
+});
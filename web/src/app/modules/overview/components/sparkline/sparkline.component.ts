@@ -0,0 +1,57 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { Component, Input, OnChanges, SimpleChanges } from '@angular/core';
+import { SparklineView } from 'src/app/models/content';
+
+@Component({
+  selector: 'app-view-sparkline',
+  templateUrl: './sparkline.component.html',
+  styleUrls: ['./sparkline.component.scss'],
+})
+export class SparklineComponent implements OnChanges {
+  @Input() view: SparklineView;
+
+  readonly width = 60;
+  readonly height = 16;
+
+  label: string;
+  points: string;
+
+  constructor() {}
+
+  ngOnChanges(changes: SimpleChanges): void {
+    if (changes.view.currentValue) {
+      const view = changes.view.currentValue as SparklineView;
+
+      this.label = view.config.label;
+      this.points = this.toPoints(view.config.values || []);
+    }
+  }
+
+  /**
+   * toPoints scales values to the chart's size and returns them as SVG
+   * polyline points. The chart is empty with fewer than two values.
+   *
+   * @param values values ordered from oldest to newest
+   */
+  toPoints(values: number[]): string {
+    if (values.length < 2) {
+      return '';
+    }
+
+    const min = Math.min(...values);
+    const max = Math.max(...values);
+    const range = max - min || 1;
+    const step = this.width / (values.length - 1);
+
+    return values
+      .map((value, i) => {
+        const x = i * step;
+        const y = this.height - ((value - min) / range) * this.height;
+        return `${x.toFixed(1)},${y.toFixed(1)}`;
+      })
+      .join(' ');
+  }
+}
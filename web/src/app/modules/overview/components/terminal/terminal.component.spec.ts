@@ -0,0 +1,86 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { async, ComponentFixture, TestBed } from '@angular/core/testing';
+import { Subject } from 'rxjs';
+import { TerminalService } from 'src/app/services/terminal/terminal.service';
+
+import { OverviewModule } from '../../overview.module';
+import { TerminalComponent } from './terminal.component';
+
+describe('TerminalComponent', () => {
+  let component: TerminalComponent;
+  let fixture: ComponentFixture<TerminalComponent>;
+  let session: jasmine.SpyObj<any>;
+  let terminalService: jasmine.SpyObj<TerminalService>;
+
+  beforeEach(async(() => {
+    session = jasmine.createSpyObj('TerminalSession', ['send', 'close']);
+    session.output = new Subject<string>();
+    session.closed = new Subject<void>();
+    terminalService = jasmine.createSpyObj('TerminalService', [
+      'createSession',
+    ]);
+    terminalService.createSession.and.returnValue(session);
+
+    TestBed.configureTestingModule({
+      imports: [OverviewModule],
+      providers: [{ provide: TerminalService, useValue: terminalService }],
+    }).compileComponents();
+  }));
+
+  beforeEach(() => {
+    fixture = TestBed.createComponent(TerminalComponent);
+    component = fixture.componentInstance;
+    component.view = {
+      metadata: { type: 'terminal' },
+      config: {
+        namespace: 'default',
+        name: 'pod',
+        containers: ['nginx', 'sidecar'],
+        shells: ['/bin/bash', '/bin/sh'],
+      },
+    };
+    fixture.detectChanges();
+  });
+
+  it('connects to the first container and shell', () => {
+    expect(terminalService.createSession).toHaveBeenCalledWith(
+      'default',
+      'pod',
+      'nginx',
+      '/bin/bash',
+      jasmine.any(Object)
+    );
+  });
+
+  it('reconnects when the container changes', () => {
+    component.onContainerChange('sidecar');
+
+    expect(session.close).toHaveBeenCalled();
+    expect(terminalService.createSession).toHaveBeenCalledWith(
+      'default',
+      'pod',
+      'sidecar',
+      '/bin/bash',
+      jasmine.any(Object)
+    );
+  });
+
+  it('sends key presses', () => {
+    component.onKeyDown(new KeyboardEvent('keydown', { key: 'l' }));
+    component.onKeyDown(new KeyboardEvent('keydown', { key: 'Enter' }));
+    component.onKeyDown(
+      new KeyboardEvent('keydown', { key: 'c', ctrlKey: true })
+    );
+
+    expect(session.send.calls.allArgs()).toEqual([['l'], ['\r'], ['\x03']]);
+  });
+
+  it('writes output without escape sequences', () => {
+    session.output.next('\x1b[1;32m$\x1b[0m lsx\b \b\r\nbin\r\n');
+
+    expect(component.output).toBe('$ ls\nbin\n');
+  });
+});
@@ -0,0 +1,219 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import {
+  AfterViewChecked,
+  Component,
+  ElementRef,
+  Input,
+  OnDestroy,
+  OnInit,
+  ViewChild,
+} from '@angular/core';
+import { TerminalView } from 'src/app/models/content';
+import {
+  TerminalService,
+  TerminalSession,
+} from 'src/app/services/terminal/terminal.service';
+
+// escapeSequence matches ANSI CSI and OSC sequences, which set colors,
+// titles and cursor positions this terminal doesn't render.
+const escapeSequence = /\x1b\[[0-9;?]*[ -\/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)|\x1b[()][0-9A-Za-z]|\x1b[=>]/g;
+
+// keySequences are the sequences sent for keys which aren't characters.
+const keySequences: { [key: string]: string } = {
+  Enter: '\r',
+  Backspace: '\x7f',
+  Tab: '\t',
+  Escape: '\x1b',
+  ArrowUp: '\x1b[A',
+  ArrowDown: '\x1b[B',
+  ArrowRight: '\x1b[C',
+  ArrowLeft: '\x1b[D',
+  Home: '\x1b[H',
+  End: '\x1b[F',
+  Delete: '\x1b[3~',
+};
+
+// maxOutputLength is how much output is kept.
+const maxOutputLength = 100000;
+
+@Component({
+  selector: 'app-view-terminal',
+  templateUrl: './terminal.component.html',
+  styleUrls: ['./terminal.component.scss'],
+})
+export class TerminalComponent implements OnInit, OnDestroy, AfterViewChecked {
+  private session: TerminalSession;
+  private scrollToBottom = false;
+
+  @Input() view: TerminalView;
+  @ViewChild('screen') screen: ElementRef;
+
+  selectedContainer = '';
+  selectedShell = '';
+  output = '';
+  connected = false;
+
+  constructor(private terminalService: TerminalService) {}
+
+  ngOnInit() {
+    if (this.view) {
+      const { containers, shells } = this.view.config;
+      if (containers && containers.length > 0) {
+        this.selectedContainer = containers[0];
+      }
+      if (shells && shells.length > 0) {
+        this.selectedShell = shells[0];
+      }
+      this.connect();
+    }
+  }
+
+  onContainerChange(container: string): void {
+    this.selectedContainer = container;
+    this.connect();
+  }
+
+  onShellChange(shell: string): void {
+    this.selectedShell = shell;
+    this.connect();
+  }
+
+  connect(): void {
+    this.disconnect();
+    this.output = '';
+
+    const { namespace, name } = this.view.config;
+    if (!namespace || !name || !this.selectedContainer || !this.selectedShell) {
+      return;
+    }
+
+    this.session = this.terminalService.createSession(
+      namespace,
+      name,
+      this.selectedContainer,
+      this.selectedShell,
+      this.size()
+    );
+    this.connected = true;
+    this.session.output.subscribe(data => this.write(data));
+    this.session.closed.subscribe(() => (this.connected = false));
+  }
+
+  onKeyDown(event: KeyboardEvent): void {
+    if (!this.session) {
+      return;
+    }
+
+    const data = this.keyData(event);
+    if (data) {
+      event.preventDefault();
+      this.session.send(data);
+    }
+  }
+
+  onPaste(event: ClipboardEvent): void {
+    if (this.session) {
+      event.preventDefault();
+      this.session.send(event.clipboardData.getData('text'));
+    }
+  }
+
+  /**
+   * keyData returns the input for a key press, or an empty string if the
+   * key doesn't send input.
+   *
+   * @param event key press
+   */
+  keyData(event: KeyboardEvent): string {
+    if (event.ctrlKey && event.key.length === 1) {
+      const code = event.key.toUpperCase().charCodeAt(0);
+      if (code >= 64 && code <= 95) {
+        return String.fromCharCode(code - 64);
+      }
+      return '';
+    }
+
+    if (event.metaKey || event.altKey) {
+      return '';
+    }
+
+    if (keySequences[event.key]) {
+      return keySequences[event.key];
+    }
+
+    return event.key.length === 1 ? event.key : '';
+  }
+
+  /**
+   * write appends terminal output. Escape sequences are removed and
+   * backspaces and carriage returns are applied to the current line.
+   *
+   * @param data output from the terminal
+   */
+  write(data: string): void {
+    let output = this.output;
+    const text = data.replace(escapeSequence, '').replace(/\r\n/g, '\n');
+
+    for (const ch of text) {
+      switch (ch) {
+        case '\b':
+          if (output.length > 0 && !output.endsWith('\n')) {
+            output = output.slice(0, -1);
+          }
+          break;
+        case '\r':
+          output = output.slice(0, output.lastIndexOf('\n') + 1);
+          break;
+        case '\x07':
+          break;
+        default:
+          output += ch;
+      }
+    }
+
+    if (output.length > maxOutputLength) {
+      output = output.slice(output.length - maxOutputLength);
+    }
+
+    this.output = output;
+    this.scrollToBottom = true;
+  }
+
+  ngAfterViewChecked() {
+    if (this.scrollToBottom && this.screen) {
+      const { nativeElement } = this.screen;
+      nativeElement.scrollTop = nativeElement.scrollHeight;
+      this.scrollToBottom = false;
+    }
+  }
+
+  ngOnDestroy(): void {
+    this.disconnect();
+  }
+
+  private disconnect(): void {
+    if (this.session) {
+      this.session.close();
+      this.session = null;
+    }
+    this.connected = false;
+  }
+
+  /**
+   * size estimates how many columns and rows fit on the screen.
+   */
+  private size() {
+    const charWidth = 7.2;
+    const lineHeight = 16;
+    const element: HTMLElement = this.screen && this.screen.nativeElement;
+    const width = element && element.clientWidth ? element.clientWidth : 800;
+
+    return {
+      cols: Math.max(20, Math.floor((width - 16) / charWidth)),
+      rows: 24,
+    };
+  }
+}
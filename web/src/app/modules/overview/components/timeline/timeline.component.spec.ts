@@ -0,0 +1,79 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { SimpleChange } from '@angular/core';
+import { async, ComponentFixture, TestBed } from '@angular/core/testing';
+import { RouterTestingModule } from '@angular/router/testing';
+import { TimelineView } from 'src/app/models/content';
+
+import { OverviewModule } from '../../overview.module';
+import { TimelineComponent } from './timeline.component';
+
+describe('TimelineComponent', () => {
+  let component: TimelineComponent;
+  let fixture: ComponentFixture<TimelineComponent>;
+
+  const view: TimelineView = {
+    metadata: { type: 'timeline' },
+    config: {
+      events: [
+        {
+          kind: 'Pod',
+          name: 'nginx',
+          path: '/overview/namespace/default/workloads/pods/nginx',
+          type: 'Normal',
+          reason: 'Pulled',
+          message: 'Container image pulled',
+          count: 1,
+          firstSeen: 1562061600,
+          lastSeen: 1562061600,
+        },
+        {
+          kind: 'Pod',
+          name: 'nginx',
+          type: 'Warning',
+          reason: 'BackOff',
+          message: 'Back-off restarting failed container',
+          count: 3,
+          firstSeen: 1562061700,
+          lastSeen: 1562061900,
+        },
+      ],
+      types: ['Normal', 'Warning'],
+      reasons: ['BackOff', 'Pulled'],
+    },
+  };
+
+  beforeEach(async(() => {
+    TestBed.configureTestingModule({
+      imports: [OverviewModule, RouterTestingModule],
+    }).compileComponents();
+  }));
+
+  beforeEach(() => {
+    fixture = TestBed.createComponent(TimelineComponent);
+    component = fixture.componentInstance;
+    component.view = view;
+    component.ngOnChanges({
+      view: new SimpleChange(undefined, view, true),
+    });
+    fixture.detectChanges();
+  });
+
+  it('renders every event', () => {
+    const events = fixture.nativeElement.querySelectorAll('.timeline-event');
+    expect(events.length).toBe(2);
+    expect(events[0].textContent).toMatch(/Pulled/);
+    expect(events[1].textContent).toMatch(/Back-off restarting/);
+  });
+
+  it('filters events by type and reason', () => {
+    component.onTypeChange('Warning');
+    expect(component.events.map(e => e.reason)).toEqual(['BackOff']);
+
+    component.onTypeChange('');
+    component.onReasonChange('Pulled');
+    expect(component.events.map(e => e.reason)).toEqual(['Pulled']);
+  });
+});
@@ -0,0 +1,75 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { Component, Input, OnChanges, SimpleChanges } from '@angular/core';
+import moment from 'moment';
+import { TimelineEvent, TimelineView } from 'src/app/models/content';
+import { ViewService } from '../../services/view/view.service';
+
+@Component({
+  selector: 'app-view-timeline',
+  templateUrl: './timeline.component.html',
+  styleUrls: ['./timeline.component.scss'],
+})
+export class TimelineComponent implements OnChanges {
+  @Input() view: TimelineView;
+
+  title: string;
+  types: string[] = [];
+  reasons: string[] = [];
+  selectedType = '';
+  selectedReason = '';
+  events: TimelineEvent[] = [];
+
+  constructor(private viewService: ViewService) {}
+
+  ngOnChanges(changes: SimpleChanges): void {
+    if (changes.view.currentValue) {
+      const view = changes.view.currentValue as TimelineView;
+
+      this.title = this.viewService.viewTitleAsText(view);
+      this.types = view.config.types || [];
+      this.reasons = view.config.reasons || [];
+
+      if (!this.types.includes(this.selectedType)) {
+        this.selectedType = '';
+      }
+      if (!this.reasons.includes(this.selectedReason)) {
+        this.selectedReason = '';
+      }
+
+      this.filter();
+    }
+  }
+
+  onTypeChange(type: string): void {
+    this.selectedType = type;
+    this.filter();
+  }
+
+  onReasonChange(reason: string): void {
+    this.selectedReason = reason;
+    this.filter();
+  }
+
+  /**
+   * filter selects the events matching the selected type and reason.
+   */
+  filter(): void {
+    const events = (this.view && this.view.config.events) || [];
+    this.events = events.filter(
+      event =>
+        (!this.selectedType || event.type === this.selectedType) &&
+        (!this.selectedReason || event.reason === this.selectedReason)
+    );
+  }
+
+  formatTime(timestamp: number): string {
+    return moment(timestamp * 1000).format('lll');
+  }
+
+  identifyEvent(index: number, item: TimelineEvent) {
+    return `${item.kind}-${item.name}-${item.reason}-${item.firstSeen}`;
+  }
+}
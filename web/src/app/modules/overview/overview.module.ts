@@ -42,10 +42,13 @@ import { PortsComponent } from './components/ports/ports.component';
 import { QuadrantComponent } from './components/quadrant/quadrant.component';
 import { ResourceViewerComponent } from './components/resource-viewer/resource-viewer.component';
 import { SelectorsComponent } from './components/selectors/selectors.component';
+import { SparklineComponent } from './components/sparkline/sparkline.component';
 import { SummaryComponent } from './components/summary/summary.component';
 import { TableComponent } from './components/table/table.component';
 import { TabsComponent } from './components/tabs/tabs.component';
+import { TerminalComponent } from './components/terminal/terminal.component';
 import { TextComponent } from './components/text/text.component';
+import { TimelineComponent } from './components/timeline/timeline.component';
 import { TimestampComponent } from './components/timestamp/timestamp.component';
 import { YamlComponent } from './components/yaml/yaml.component';
 import { OverviewComponent } from './overview.component';
@@ -73,10 +76,13 @@ export function hljsLanguages() {
     QuadrantComponent,
     ResourceViewerComponent,
     SelectorsComponent,
+    SparklineComponent,
     SummaryComponent,
     TableComponent,
     TabsComponent,
+    TerminalComponent,
     TextComponent,
+    TimelineComponent,
     TimestampComponent,
     YamlComponent,
     OverviewComponent,
@@ -14,6 +14,9 @@ import {
   NotifierService,
   NotifierSignalType,
 } from '../notifier/notifier.service';
+import {
+  FilterExpressionService,
+} from '../filter-expression/filter-expression.service';
 import getAPIBase from '../common/getAPIBase';
 import { ContentResponse } from '../../models/content';
 import { Navigation } from '../../models/navigation';
@@ -37,6 +40,7 @@ describe('ContentStreamService', () => {
     eventSourceStubs: Array<{ url: string; eventSourceStub: EventSourceStub }>;
   };
   let labelFilterService;
+  let filterExpressionService;
   let notifierService;
 
   beforeEach(() => {
@@ -44,6 +48,10 @@ describe('ContentStreamService', () => {
       filters: new BehaviorSubject<Filter[]>([]),
     };
 
+    const filterExpressionStub: Partial<FilterExpressionService> = {
+      expression: new BehaviorSubject<string>(''),
+    };
+
     const eventSourceServiceStub = {
       eventSourceStubs: [],
       createEventSource(url: string) {
@@ -56,6 +64,7 @@ describe('ContentStreamService', () => {
     TestBed.configureTestingModule({
       providers: [
         { provide: LabelFilterService, useValue: labelFilterStub },
+        { provide: FilterExpressionService, useValue: filterExpressionStub },
         { provide: NotifierService, useFactory: notifierServiceStubFactory },
         { provide: EventSourceService, useValue: eventSourceServiceStub },
       ],
@@ -64,6 +73,7 @@ describe('ContentStreamService', () => {
     contentStreamService = TestBed.get(ContentStreamService);
     eventSourceService = TestBed.get(EventSourceService);
     labelFilterService = TestBed.get(LabelFilterService);
+    filterExpressionService = TestBed.get(FilterExpressionService);
     notifierService = TestBed.get(NotifierService);
   });
 
@@ -152,6 +162,39 @@ describe('ContentStreamService', () => {
     );
   });
 
+  it('should stream content after setting a filter expression', () => {
+    const { eventSourceStubs } = eventSourceService;
+
+    contentStreamService.openStream('namespace/default/overview');
+    expect(eventSourceStubs.length).toBe(1);
+
+    filterExpressionService.expression.next('status.phase!=Running');
+
+    expect(eventSourceStubs.length).toBe(2);
+    expect(eventSourceStubs[1].url).toBe(
+      `${API_BASE}/api/v1/content/namespace/default/overview/?poll=5&where=status.phase!%3DRunning`
+    );
+  });
+
+  it('should stream preferences', () => {
+    const { eventSourceStubs } = eventSourceService;
+
+    contentStreamService.openStream('namespace/default/overview');
+    expect(contentStreamService.preferences.getValue()).toBeNull();
+
+    const { eventSourceStub } = eventSourceStubs[0];
+    eventSourceStub.queueMessage(
+      'preferences',
+      JSON.stringify({ version: 1, theme: 'dark' })
+    );
+    eventSourceStub.flush();
+
+    expect(contentStreamService.preferences.getValue()).toEqual({
+      version: 1,
+      theme: 'dark',
+    });
+  });
+
   it('should notify error signal if error is streamed in', () => {
     const { eventSourceStubs } = eventSourceService;
     const { notifierSessionStub } = notifierService;
@@ -9,6 +9,7 @@ import getAPIBase from '../common/getAPIBase';
 import { ContentResponse } from '../../models/content';
 import { Namespaces } from '../../models/namespace';
 import { Navigation } from '../../models/navigation';
+import { Preferences } from '../../models/preferences';
 import {
   Filter,
   LabelFilterService,
@@ -19,6 +20,9 @@ import {
   NotifierSignalType,
 } from '../notifier/notifier.service';
 import { EventSourceService } from './event-source.service';
+import {
+  FilterExpressionService,
+} from '../filter-expression/filter-expression.service';
 import _ from 'lodash';
 
 export interface ContextDescription {
@@ -57,6 +61,7 @@ export class ContentStreamService {
   namespaces = new BehaviorSubject<string[]>([]);
   navigation = new BehaviorSubject<Navigation>(emptyNavigation);
   kubeContext = new BehaviorSubject<KubeContextResponse>(emptyKubeContext);
+  preferences = new BehaviorSubject<Preferences>(null);
 
   private eventSource: EventSource;
   private notifierSession: NotifierSession;
@@ -66,9 +71,13 @@ export class ContentStreamService {
     private notifierService: NotifierService,
     private location: Location,
     private eventSourceService: EventSourceService,
-    private labelFilterService: LabelFilterService
+    private labelFilterService: LabelFilterService,
+    private filterExpressionService: FilterExpressionService
   ) {
     this.labelFilterService.filters.subscribe(() => this.restartStream());
+    this.filterExpressionService.expression.subscribe(() =>
+      this.restartStream()
+    );
     this.notifierSession = this.notifierService.createSession();
   }
 
@@ -89,6 +98,10 @@ export class ContentStreamService {
       this.handleObjectNotFoundEvent
     );
     this.eventSource.addEventListener('kubeConfig', this.handleKubeConfigEvent);
+    this.eventSource.addEventListener(
+      'preferences',
+      this.handlePreferencesEvent
+    );
   }
 
   closeStream() {
@@ -132,6 +145,11 @@ export class ContentStreamService {
     this.kubeContext.next(data);
   };
 
+  private handlePreferencesEvent = (message: MessageEvent) => {
+    const data = JSON.parse(message.data) as Preferences;
+    this.preferences.next(data);
+  };
+
   private handleErrorEvent = () => {
     this.notifierSession.pushSignal(
       NotifierSignalType.ERROR,
@@ -159,6 +177,11 @@ export class ContentStreamService {
       filterQuery = `&${filterQuery}`;
     }
 
+    const where = this.filterExpressionService.expression.getValue();
+    if (where) {
+      filterQuery += `&where=${encodeURIComponent(where)}`;
+    }
+
     if (_.last(path) !== '/') {
       path += '/';
     }
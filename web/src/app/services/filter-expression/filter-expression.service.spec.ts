@@ -0,0 +1,83 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { TestBed, fakeAsync, tick } from '@angular/core/testing';
+import { NgZone } from '@angular/core';
+import { Router } from '@angular/router';
+import { RouterTestingModule } from '@angular/router/testing';
+import {
+  HttpClientTestingModule,
+  HttpTestingController,
+} from '@angular/common/http/testing';
+import {
+  FilterExpressionService,
+  savedFiltersKey,
+} from './filter-expression.service';
+import getAPIBase from '../common/getAPIBase';
+
+describe('FilterExpressionService', () => {
+  const API_BASE = getAPIBase();
+  let service: FilterExpressionService;
+  let router: Router;
+  let ngZone: NgZone;
+  let httpTestingController: HttpTestingController;
+
+  beforeEach(() => {
+    localStorage.removeItem(savedFiltersKey);
+    TestBed.configureTestingModule({
+      imports: [RouterTestingModule, HttpClientTestingModule],
+    });
+    service = TestBed.get(FilterExpressionService);
+    router = TestBed.get(Router);
+    ngZone = TestBed.get(NgZone);
+    httpTestingController = TestBed.get(HttpTestingController);
+  });
+
+  afterEach(() => {
+    httpTestingController.verify();
+    localStorage.removeItem(savedFiltersKey);
+  });
+
+  it('should be created with no expression', () => {
+    expect(service).toBeTruthy();
+    expect(service.expression.getValue()).toEqual('');
+    expect(service.saved.getValue()).toEqual([]);
+  });
+
+  it('should apply an expression and trigger router', fakeAsync(() => {
+    ngZone.run(() => {
+      service.apply(' age>1h ');
+      tick();
+      expect(service.expression.getValue()).toEqual('age>1h');
+      expect(router.url).toMatch(/\?where=age%3E1h$/i);
+    });
+  }));
+
+  it('should validate an expression', () => {
+    service.validate('age>1h').subscribe(resp => {
+      expect(resp.expression).toEqual('age>1h');
+    });
+
+    const req = httpTestingController.expectOne(
+      `${API_BASE}/api/v1/filter?where=age%3E1h`
+    );
+    expect(req.request.method).toEqual('GET');
+    req.flush({ expression: 'age>1h' });
+  });
+
+  it('should save and remove filters', () => {
+    service.save('old pods', 'age>7d');
+    service.save('failed', 'status.phase=Failed');
+    expect(service.saved.getValue()).toEqual([
+      { name: 'failed', expression: 'status.phase=Failed' },
+      { name: 'old pods', expression: 'age>7d' },
+    ]);
+    expect(JSON.parse(localStorage.getItem(savedFiltersKey)).length).toBe(2);
+
+    service.remove('failed');
+    expect(service.saved.getValue()).toEqual([
+      { name: 'old pods', expression: 'age>7d' },
+    ]);
+  });
+});
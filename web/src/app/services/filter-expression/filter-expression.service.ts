@@ -0,0 +1,97 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { Injectable } from '@angular/core';
+import { HttpClient } from '@angular/common/http';
+import { ActivatedRoute, NavigationEnd, Router } from '@angular/router';
+import { BehaviorSubject, Observable } from 'rxjs';
+import _ from 'lodash';
+import getAPIBase from '../common/getAPIBase';
+
+const API_BASE = getAPIBase();
+
+export const savedFiltersKey = 'octant.savedFilters';
+
+export interface SavedFilter {
+  name: string;
+  expression: string;
+}
+
+export interface FilterValidation {
+  expression: string;
+}
+
+@Injectable({
+  providedIn: 'root',
+})
+export class FilterExpressionService {
+  public expression = new BehaviorSubject<string>('');
+  public saved = new BehaviorSubject<SavedFilter[]>(this.loadSaved());
+  private activatedRoute: ActivatedRoute;
+
+  constructor(private router: Router, private http: HttpClient) {
+    this.router.events.subscribe(event => {
+      if (event instanceof NavigationEnd) {
+        this.activatedRoute = this.router.routerState.root;
+        this.router.navigate([], {
+          relativeTo: this.activatedRoute,
+          replaceUrl: true,
+          queryParams: { where: this.expression.getValue() || null },
+          queryParamsHandling: 'merge',
+        });
+      }
+    });
+
+    this.router.routerState.root.queryParamMap.subscribe(paramMap => {
+      const where = paramMap.get('where') || '';
+      if (where !== this.expression.getValue()) {
+        this.expression.next(where);
+      }
+    });
+  }
+
+  validate(expression: string): Observable<FilterValidation> {
+    return this.http.get<FilterValidation>(`${API_BASE}/api/v1/filter`, {
+      params: { where: expression },
+    });
+  }
+
+  apply(expression: string): void {
+    const trimmed = expression.trim();
+    this.expression.next(trimmed);
+    this.router.navigate([], {
+      relativeTo: this.activatedRoute,
+      queryParams: { where: trimmed || null },
+      queryParamsHandling: 'merge',
+    });
+  }
+
+  clear(): void {
+    this.apply('');
+  }
+
+  save(name: string, expression: string): void {
+    const current = _.reject(this.saved.getValue(), { name });
+    current.push({ name, expression });
+    this.publishSaved(_.sortBy(current, 'name'));
+  }
+
+  remove(name: string): void {
+    this.publishSaved(_.reject(this.saved.getValue(), { name }));
+  }
+
+  private loadSaved(): SavedFilter[] {
+    try {
+      const raw = localStorage.getItem(savedFiltersKey);
+      return raw ? JSON.parse(raw) : [];
+    } catch (e) {
+      return [];
+    }
+  }
+
+  private publishSaved(list: SavedFilter[]): void {
+    localStorage.setItem(savedFiltersKey, JSON.stringify(list));
+    this.saved.next(list);
+  }
+}
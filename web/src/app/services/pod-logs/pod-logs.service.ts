@@ -3,62 +3,246 @@
 //
 
 import { Injectable } from '@angular/core';
-import { HttpClient } from '@angular/common/http';
 import { BehaviorSubject } from 'rxjs';
 import { LogEntry, LogResponse } from 'src/app/models/content';
 import getAPIBase from '../common/getAPIBase';
 
 const API_BASE = getAPIBase();
 
-export class PodLogsStreamer {
+// streamTailLines is how many lines of each container's log are shown when
+// a stream starts.
+const streamTailLines = 100;
+
+// maxStreamEntries is how many entries a stream keeps.
+const maxStreamEntries = 1000;
+
+// logLevels are the levels structured log entries are normalized to.
+export const logLevels = [
+  'trace',
+  'debug',
+  'info',
+  'warning',
+  'error',
+  'fatal',
+];
+
+// LogQuery selects the log entries which are streamed or downloaded. Times
+// are RFC3339.
+export interface LogQuery {
+  filter?: string;
+  regex?: string;
+  levels?: string[];
+  sinceTime?: string;
+  untilTime?: string;
+}
+
+// isFiltered returns true if a query selects entries.
+export function isFiltered(query: LogQuery): boolean {
+  if (!query) {
+    return false;
+  }
+
+  return !!(
+    query.filter ||
+    query.regex ||
+    (query.levels && query.levels.length > 0) ||
+    query.sinceTime ||
+    query.untilTime
+  );
+}
+
+// logQueryParams converts a query to the query parameters the log
+// endpoints accept.
+export function logQueryParams(query: LogQuery): string[] {
+  const params: string[] = [];
+  if (!query) {
+    return params;
+  }
+
+  if (query.filter) {
+    params.push(`filter=${encodeURIComponent(query.filter)}`);
+  }
+  if (query.regex) {
+    params.push(`regex=${encodeURIComponent(query.regex)}`);
+  }
+  if (query.levels && query.levels.length > 0) {
+    params.push(`level=${encodeURIComponent(query.levels.join(','))}`);
+  }
+  if (query.sinceTime) {
+    params.push(`sinceTime=${encodeURIComponent(query.sinceTime)}`);
+  }
+  if (query.untilTime) {
+    params.push(`untilTime=${encodeURIComponent(query.untilTime)}`);
+  }
+
+  return params;
+}
+
+// streamParams are the parameters which start a stream. Filtered streams
+// search the whole log instead of its last lines, and a stream with an end
+// time doesn't follow the log past it.
+function streamParams(query: LogQuery): string[] {
+  const params = [`follow=${!(query && query.untilTime)}`];
+  if (!isFiltered(query)) {
+    params.push(`tailLines=${streamTailLines}`);
+  }
+  return params;
+}
+
+export interface LogStream {
+  logEntries: BehaviorSubject<LogEntry[]>;
+  close(): void;
+}
+
+// LogEventStreamer follows a log stream's server sent events. Entries
+// arrive in batches and the newest entries are kept.
+export class LogEventStreamer implements LogStream {
   public logEntries: BehaviorSubject<LogEntry[]>;
-  private intervalID: number;
+  private eventSource: EventSource;
 
   constructor(
-    private namespace: string,
-    private pod: string,
-    private container: string,
-    private http: HttpClient
+    private url: string,
+    private createEventSource: (url: string) => EventSource
   ) {}
 
-  private poll() {
-    this.http.get(this.logsUrl()).subscribe((res: LogResponse) => {
-      this.logEntries.next(res.entries);
-    });
-  }
-
   public start(): void {
     this.logEntries = new BehaviorSubject([]);
-    this.poll();
-    this.intervalID = window.setInterval(() => this.poll(), 5000);
+    this.eventSource = this.createEventSource(this.url);
+    this.eventSource.addEventListener('logs', (event: MessageEvent) => {
+      const res = JSON.parse(event.data) as LogResponse;
+      const entries = this.logEntries.getValue().concat(res.entries || []);
+      this.logEntries.next(entries.slice(-maxStreamEntries));
+    });
+    this.eventSource.addEventListener('end', () => this.eventSource.close());
   }
 
   public close(): void {
+    this.eventSource.close();
     this.logEntries.unsubscribe();
-    clearInterval(this.intervalID);
   }
+}
+
+@Injectable({
+  providedIn: 'root',
+})
+export class PodLogsService {
+  /**
+   * createStream follows the log of a pod's container.
+   *
+   * @param namespace namespace of the pod
+   * @param pod name of the pod
+   * @param container container name
+   * @param query optional query which selects entries
+   */
+  public createStream(
+    namespace,
+    pod,
+    container: string,
+    query?: LogQuery
+  ): LogEventStreamer {
+    const streamer = new LogEventStreamer(
+      this.streamUrl(namespace, pod, container, query),
+      url => this.createEventSource(url)
+    );
+    streamer.start();
+    return streamer;
+  }
+
+  /**
+   * createAggregatedStream follows the logs of the pods of a workload or
+   * service. All containers are included if container is empty.
+   *
+   * @param namespace namespace of the object
+   * @param kind lowercase kind of the object, e.g. deployment
+   * @param name name of the object
+   * @param container optional container name
+   * @param query optional query which selects entries
+   */
+  public createAggregatedStream(
+    namespace,
+    kind,
+    name,
+    container: string,
+    query?: LogQuery
+  ): LogEventStreamer {
+    const streamer = new LogEventStreamer(
+      this.aggregatedStreamUrl(namespace, kind, name, container, query),
+      url => this.createEventSource(url)
+    );
+    streamer.start();
+    return streamer;
+  }
+
+  public createEventSource(url: string): EventSource {
+    return new EventSource(url);
+  }
+
+  /**
+   * downloadUrl returns the URL a pod's container log is downloaded from.
+   * Only entries which match the query are included.
+   *
+   * @param namespace namespace of the pod
+   * @param pod name of the pod
+   * @param container container name
+   * @param query optional query which selects entries
+   * @param format text or ndjson
+   */
+  public downloadUrl(
+    namespace,
+    pod,
+    container: string,
+    query?: LogQuery,
+    format = 'text'
+  ): string {
+    const params = [`format=${format}`, ...logQueryParams(query)];
 
-  private logsUrl(): string {
+    return [
+      this.containerUrl(namespace, pod, container),
+      `download?${params.join('&')}`,
+    ].join('/');
+  }
+
+  private containerUrl(namespace, pod, container: string) {
     return [
       API_BASE,
       'api/v1/content/overview',
-      `namespace/${this.namespace}`,
+      `namespace/${namespace}`,
       'logs',
-      `pod/${this.pod}`,
-      `container/${this.container}`,
+      `pod/${pod}`,
+      `container/${container}`,
     ].join('/');
   }
-}
 
-@Injectable({
-  providedIn: 'root',
-})
-export class PodLogsService {
-  constructor(private http: HttpClient) {}
+  private streamUrl(namespace, pod, container: string, query: LogQuery) {
+    const params = [...streamParams(query), ...logQueryParams(query)];
+
+    return [
+      this.containerUrl(namespace, pod, container),
+      `stream?${params.join('&')}`,
+    ].join('/');
+  }
+
+  private aggregatedStreamUrl(
+    namespace,
+    kind,
+    name,
+    container: string,
+    query: LogQuery
+  ) {
+    const params = streamParams(query);
+    if (container) {
+      params.push(`container=${encodeURIComponent(container)}`);
+    }
+    params.push(...logQueryParams(query));
 
-  public createStream(namespace, pod, container: string): PodLogsStreamer {
-    const pls = new PodLogsStreamer(namespace, pod, container, this.http);
-    pls.start();
-    return pls;
+    return [
+      API_BASE,
+      'api/v1/content/overview',
+      `namespace/${namespace}`,
+      'logs',
+      kind,
+      name,
+      `stream?${params.join('&')}`,
+    ].join('/');
   }
 }
@@ -0,0 +1,29 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { TestBed } from '@angular/core/testing';
+import getAPIBase from '../common/getAPIBase';
+
+import { TerminalService, TerminalSession } from './terminal.service';
+
+describe('TerminalService', () => {
+  beforeEach(() => TestBed.configureTestingModule({}));
+
+  it('should be created', () => {
+    const service: TerminalService = TestBed.get(TerminalService);
+    expect(service).toBeTruthy();
+  });
+
+  it('connects to the container terminal over a WebSocket', () => {
+    const session = new TerminalSession('default', 'pod', 'nginx', '/bin/sh', {
+      cols: 80,
+      rows: 24,
+    });
+    const base = getAPIBase().replace(/^http/, 'ws');
+
+    expect(session.terminalUrl()).toBe(
+      `${base}/api/v1/content/overview/namespace/default/terminal/pod/pod/container/nginx?shell=%2Fbin%2Fsh`
+    );
+  });
+});
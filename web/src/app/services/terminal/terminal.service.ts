@@ -0,0 +1,104 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { Injectable } from '@angular/core';
+import { Subject } from 'rxjs';
+import getAPIBase from '../common/getAPIBase';
+
+const API_BASE = getAPIBase();
+
+export interface TerminalSize {
+  cols: number;
+  rows: number;
+}
+
+export class TerminalSession {
+  public output = new Subject<string>();
+  public closed = new Subject<void>();
+  private socket: WebSocket;
+  private decoder = new TextDecoder();
+
+  constructor(
+    private namespace: string,
+    private pod: string,
+    private container: string,
+    private shell: string,
+    private size: TerminalSize
+  ) {}
+
+  public start(): void {
+    this.socket = new WebSocket(this.terminalUrl());
+    this.socket.binaryType = 'arraybuffer';
+    this.socket.onopen = () => this.resize(this.size);
+    this.socket.onmessage = (event: MessageEvent) => {
+      const data =
+        typeof event.data === 'string'
+          ? event.data
+          : this.decoder.decode(new Uint8Array(event.data), { stream: true });
+      this.output.next(data);
+    };
+    this.socket.onclose = () => this.closed.next();
+  }
+
+  public send(data: string): void {
+    this.sendMessage({ type: 'stdin', data });
+  }
+
+  public resize(size: TerminalSize): void {
+    this.size = size;
+    this.sendMessage({ type: 'resize', cols: size.cols, rows: size.rows });
+  }
+
+  public close(): void {
+    if (this.socket) {
+      this.socket.close();
+      this.socket = null;
+    }
+    this.output.complete();
+    this.closed.complete();
+  }
+
+  terminalUrl(): string {
+    const base = API_BASE.replace(/^http/, 'ws');
+    return [
+      base,
+      'api/v1/content/overview',
+      `namespace/${this.namespace}`,
+      'terminal',
+      `pod/${this.pod}`,
+      `container/${this.container}?shell=${encodeURIComponent(this.shell)}`,
+    ].join('/');
+  }
+
+  private sendMessage(message: object): void {
+    if (this.socket && this.socket.readyState === WebSocket.OPEN) {
+      this.socket.send(JSON.stringify(message));
+    }
+  }
+}
+
+@Injectable({
+  providedIn: 'root',
+})
+export class TerminalService {
+  constructor() {}
+
+  public createSession(
+    namespace: string,
+    pod: string,
+    container: string,
+    shell: string,
+    size: TerminalSize
+  ): TerminalSession {
+    const session = new TerminalSession(
+      namespace,
+      pod,
+      container,
+      shell,
+      size
+    );
+    session.start();
+    return session;
+  }
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { TestBed } from '@angular/core/testing';
+import { darkThemeId, ThemeService } from './theme.service';
+
+describe('ThemeService', () => {
+  let service: ThemeService;
+
+  beforeEach(() => {
+    TestBed.configureTestingModule({});
+    service = TestBed.get(ThemeService);
+  });
+
+  afterEach(() => {
+    service.apply('light');
+  });
+
+  it('should add the dark theme once', () => {
+    service.apply('dark');
+    service.apply('dark');
+    expect(document.querySelectorAll(`#${darkThemeId}`).length).toBe(1);
+  });
+
+  it('should remove the dark theme', () => {
+    service.apply('dark');
+    service.apply('light');
+    expect(document.getElementById(darkThemeId)).toBeNull();
+  });
+});
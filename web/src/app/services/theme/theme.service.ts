@@ -0,0 +1,37 @@
+// Copyright (c) 2019 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { Inject, Injectable } from '@angular/core';
+import { DOCUMENT } from '@angular/common';
+
+export const darkThemeId = 'dark-theme';
+
+// darkThemeHref is the lazy style bundle built from Clarity's dark theme.
+const darkThemeHref = 'dark-theme.css';
+
+@Injectable({
+  providedIn: 'root',
+})
+export class ThemeService {
+  constructor(@Inject(DOCUMENT) private document: Document) {}
+
+  apply(theme: string): void {
+    const link = this.document.getElementById(darkThemeId);
+
+    if (theme === 'dark') {
+      if (!link) {
+        const element = this.document.createElement('link');
+        element.id = darkThemeId;
+        element.rel = 'stylesheet';
+        element.href = darkThemeHref;
+        this.document.head.appendChild(element);
+      }
+      return;
+    }
+
+    if (link) {
+      link.remove();
+    }
+  }
+}